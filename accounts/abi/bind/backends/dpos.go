@@ -0,0 +1,297 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backends
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/misc"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/eth/filters"
+	"github.com/hypnosisfoundation/go-hypnosis/ethdb"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+const (
+	dposSimulatedEpoch    = 20         // Epoch length of the simulated DPoS chain, short for tests to cross epochs
+	dposSimulatedGasLimit = 30_000_000 // Block gas limit of the simulated DPoS chain
+)
+
+var errNoSealer = errors.New("no validator key allowed to seal the block")
+
+// dposSealer seals the blocks of a simulated DPoS chain with the validator keys.
+type dposSealer struct {
+	engine *dpos.Dpos
+	keys   map[common.Address]*ecdsa.PrivateKey
+	order  []common.Address // Validator addresses in the order of the keys
+	shift  int64            // Time shift of the simulated clock in nanoseconds, accessed atomically
+}
+
+// DposSimulatedGenesis returns the genesis of a simulated DPoS chain following the
+// mainnet rules, with the system contracts of dpos.SystemContracts merged into the
+// given allocation and the given genesis validator, funded to stake its initial
+// deposit at block 1.
+func DposSimulatedGenesis(alloc core.GenesisAlloc, validator common.Address) *core.Genesis {
+	config := *params.MainnetChainConfig
+	config.ChainID = big.NewInt(1337)
+	config.Dpos = &params.DposConfig{
+		Epoch:                 dposSimulatedEpoch,
+		EnableDevVerification: params.MainnetChainConfig.Dpos.EnableDevVerification,
+	}
+	// Merge the system contracts with the requested allocation
+	genesisAlloc := dpos.SystemContracts()
+	for addr, account := range alloc {
+		genesisAlloc[addr] = account
+	}
+	account := genesisAlloc[validator]
+	if account.Balance == nil {
+		account.Balance = new(big.Int)
+	}
+	account.Balance = new(big.Int).Add(account.Balance, systemcontract.InitDeposit)
+	genesisAlloc[validator] = account
+
+	extra := make([]byte, 32+common.AddressLength+crypto.SignatureLength)
+	copy(extra[32:], validator.Bytes())
+
+	return &core.Genesis{Config: &config, GasLimit: dposSimulatedGasLimit, ExtraData: extra, Alloc: genesisAlloc}
+}
+
+// NewDposSimulatedBackendWithDatabase creates a new binding backend based on the
+// given database using a simulated DPoS blockchain for testing purposes. The chain
+// is the one of DposSimulatedGenesis, sealed with the given validator keys: the
+// first one is the genesis validator, the others seal blocks once they are elected.
+// A random genesis validator key is generated if none is given.
+// A simulated backend always uses chainID 1337.
+func NewDposSimulatedBackendWithDatabase(database ethdb.Database, alloc core.GenesisAlloc, validators ...*ecdsa.PrivateKey) *SimulatedBackend {
+	if len(validators) == 0 {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			panic(err)
+		}
+		validators = []*ecdsa.PrivateKey{key}
+	}
+	sealer := &dposSealer{keys: make(map[common.Address]*ecdsa.PrivateKey)}
+	for _, key := range validators {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		sealer.keys[addr] = key
+		sealer.order = append(sealer.order, addr)
+	}
+	genesis := DposSimulatedGenesis(alloc, sealer.order[0])
+	genesis.MustCommit(database)
+
+	sealer.engine = dpos.New(genesis.Config, database)
+	sealer.engine.SetClock(sealer.now)
+	blockchain, err := core.NewBlockChain(database, nil, genesis.Config, sealer.engine, vm.Config{}, nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	sealer.engine.SetStateFn(blockchain.StateAt)
+	sealer.engine.SetChain(blockchain)
+
+	backend := &SimulatedBackend{
+		database:   database,
+		blockchain: blockchain,
+		config:     genesis.Config,
+		events:     filters.NewEventSystem(&filterBackend{database, blockchain}, false),
+		dpos:       sealer,
+	}
+	backend.rollback(blockchain.CurrentBlock())
+	return backend
+}
+
+// NewDposSimulatedBackend creates a new binding backend using a simulated DPoS
+// blockchain for testing purposes, see NewDposSimulatedBackendWithDatabase.
+// A simulated backend always uses chainID 1337.
+func NewDposSimulatedBackend(alloc core.GenesisAlloc, validators ...*ecdsa.PrivateKey) *SimulatedBackend {
+	return NewDposSimulatedBackendWithDatabase(rawdb.NewMemoryDatabase(), alloc, validators...)
+}
+
+// now returns the time of the simulated clock.
+func (s *dposSealer) now() time.Time {
+	return time.Now().Add(time.Duration(atomic.LoadInt64(&s.shift)))
+}
+
+// adjustTime shifts the simulated clock.
+func (s *dposSealer) adjustTime(adjustment time.Duration) {
+	atomic.AddInt64(&s.shift, int64(adjustment))
+}
+
+// snapshot returns the validators snapshot after the given block.
+func (s *dposSealer) snapshot(chain *core.BlockChain, parent *types.Block) (*dpos.Snapshot, error) {
+	return s.engine.APIs(chain)[0].Service.(*dpos.API).GetSnapshotAtHash(parent.Hash())
+}
+
+// authorize picks the key sealing the block after the given parent, the same way
+// the dpos chain maker does, and authorizes the engine with it.
+func (s *dposSealer) authorize(chain *core.BlockChain, parent *types.Block) error {
+	snap, err := s.snapshot(chain, parent)
+	if err != nil {
+		return err
+	}
+	addr := dpos.NextSealer(snap, parent.NumberU64()+1, func(addr common.Address) bool {
+		_, ok := s.keys[addr]
+		return ok
+	})
+	if addr == (common.Address{}) {
+		return errNoSealer
+	}
+	s.sign(addr)
+	return nil
+}
+
+// sign authorizes the engine to seal blocks and sign system transactions with the
+// key of the given validator.
+func (s *dposSealer) sign(addr common.Address) {
+	key := s.keys[addr]
+	s.engine.Authorize(addr, func(account accounts.Account, mimeType string, message []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(message), key)
+	}, func(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+		return types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+	})
+}
+
+// generate creates a sealed block with the given transactions on top of the
+// parent, the same way a validator would, and returns it along with its state.
+func (s *dposSealer) generate(chain *core.BlockChain, parent *types.Block, txs []*types.Transaction) (*types.Block, *state.StateDB, error) {
+	if err := s.authorize(chain, parent); err != nil {
+		return nil, nil, err
+	}
+	config := chain.Config()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		GasLimit:   parent.GasLimit(),
+	}
+	if config.IsLondon(header.Number) {
+		header.BaseFee = misc.CalcBaseFee(config, parent.Header())
+	}
+	if err := s.engine.Prepare(chain, header); err != nil {
+		return nil, nil, err
+	}
+	statedb, err := chain.StateAt(parent.Root())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.engine.PreHandle(chain, header, statedb); err != nil {
+		return nil, nil, err
+	}
+	var (
+		extraValidator = s.engine.CreateEvmExtraValidator(header, statedb)
+		signer         = types.MakeSigner(config, header.Number)
+		gasPool        = new(core.GasPool).AddGas(header.GasLimit)
+		receipts       []*types.Receipt
+	)
+	for i, tx := range txs {
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.engine.ValidateTx(sender, tx, header, statedb); err != nil {
+			return nil, nil, err
+		}
+		statedb.Prepare(tx.Hash(), i)
+		receipt, err := core.ApplyTransaction(config, chain, &header.Coinbase, gasPool, statedb, header, tx, &header.GasUsed, vm.Config{}, extraValidator)
+		if err != nil {
+			return nil, nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	block, _, err := s.engine.FinalizeAndAssemble(chain, header, statedb, txs, nil, receipts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := statedb.Commit(config.IsEIP158(header.Number)); err != nil {
+		return nil, nil, err
+	}
+	// Seal the block as the engine would, without waiting for its time
+	header = block.Header()
+	if err := dpos.SealHeader(header, s.keys[header.Coinbase]); err != nil {
+		return nil, nil, err
+	}
+	block = block.WithSeal(header)
+
+	pending, err := state.New(block.Root(), chain.StateCache(), nil)
+	return block, pending, err
+}
+
+// userTxs returns the transactions of the block, without the system transactions
+// the engine adds when sealing it.
+func (s *dposSealer) userTxs(config *params.ChainConfig, block *types.Block) []*types.Transaction {
+	signer := types.MakeSigner(config, block.Number())
+	var txs []*types.Transaction
+	for _, tx := range block.Transactions() {
+		sender, err := types.Sender(signer, tx)
+		if err == nil {
+			if sys, _ := s.engine.IsSysTransaction(sender, tx, block.Header()); sys {
+				continue
+			}
+		}
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+// AdvanceEpoch commits blocks until the first block of the next epoch, which
+// elects the validators of the epoch, and returns its number.
+func (b *SimulatedBackend) AdvanceEpoch() uint64 {
+	if b.dpos == nil {
+		panic("not a dpos simulated backend")
+	}
+	for {
+		b.Commit()
+		if number := b.blockchain.CurrentBlock().NumberU64(); number%b.config.Dpos.Epoch == 0 {
+			return number
+		}
+	}
+}
+
+// Validators returns the validators sealing the blocks after the current one.
+func (b *SimulatedBackend) Validators() ([]common.Address, error) {
+	if b.dpos == nil {
+		return nil, errors.New("not a dpos simulated backend")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snap, err := b.dpos.snapshot(b.blockchain, b.blockchain.CurrentBlock())
+	if err != nil {
+		return nil, err
+	}
+	validators := make([]common.Address, 0, len(snap.Validators))
+	for addr := range snap.Validators {
+		validators = append(validators, addr)
+	}
+	sort.Slice(validators, func(i, j int) bool {
+		return bytes.Compare(validators[i][:], validators[j][:]) < 0
+	})
+	return validators, nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backends
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
+	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi/bind"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+const nodeVotesVoteABI = `[{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"vote","outputs":[],"stateMutability":"payable","type":"function"}]`
+
+func TestDposSimulatedBackend(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		validator, _ = crypto.GenerateKey()
+		valAddr      = crypto.PubkeyToAddress(validator.PublicKey)
+		voter, _     = crypto.GenerateKey()
+		auth, _      = bind.NewKeyedTransactorWithChainID(voter, big.NewInt(1337))
+	)
+	sim := NewDposSimulatedBackend(core.GenesisAlloc{auth.From: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))}}, validator)
+	defer sim.Close()
+
+	// The system contracts are deployed and initialized at block 1
+	sim.Commit()
+	if code, err := sim.CodeAt(ctx, systemcontract.ValidatorsContractAddr, nil); err != nil || len(code) == 0 {
+		t.Fatalf("validators contract missing: %v", err)
+	}
+	// Vote for the genesis validator through a binding
+	parsed, err := abi.JSON(strings.NewReader(nodeVotesVoteABI))
+	if err != nil {
+		t.Fatalf("failed to parse abi: %v", err)
+	}
+	votes := bind.NewBoundContract(systemcontract.NodeVotesContractAddr, parsed, sim, sim, sim)
+	auth.Value = big.NewInt(params.Ether)
+	tx, err := votes.Transact(auth, "vote", valAddr)
+	if err != nil {
+		t.Fatalf("failed to vote: %v", err)
+	}
+	sim.Commit()
+	receipt, err := sim.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatalf("failed to retrieve receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Errorf("vote failed")
+	}
+	head, _ := sim.HeaderByNumber(ctx, nil)
+	if head.Coinbase != valAddr {
+		t.Errorf("block sealer mismatch: have %x, want %x", head.Coinbase, valAddr)
+	}
+	// The fees are distributed by the engine at the end of the block
+	if balance, _ := sim.BalanceAt(ctx, consensus.FeeRecoder, nil); balance.Sign() != 0 {
+		t.Errorf("fees not distributed: %v", balance)
+	}
+	// Cross an epoch, which elects the validators again
+	if number := sim.AdvanceEpoch(); number != dposSimulatedEpoch {
+		t.Errorf("epoch block mismatch: have %d, want %d", number, dposSimulatedEpoch)
+	}
+	validators, err := sim.Validators()
+	if err != nil {
+		t.Fatalf("failed to retrieve validators: %v", err)
+	}
+	if !reflect.DeepEqual(validators, []common.Address{valAddr}) {
+		t.Errorf("validators mismatch: have %v", validators)
+	}
+	// Shifting the clock moves the next blocks forward in time
+	parent, _ := sim.HeaderByNumber(ctx, nil)
+	if err := sim.AdjustTime(time.Hour); err != nil {
+		t.Fatalf("failed to adjust time: %v", err)
+	}
+	sim.Commit()
+	head, _ = sim.HeaderByNumber(ctx, nil)
+	if head.Time < parent.Time+uint64(time.Hour.Seconds()) {
+		t.Errorf("block time not adjusted: have %d, parent %d", head.Time, parent.Time)
+	}
+}
+
+// Tests that a validator key is generated when none is given.
+func TestDposSimulatedBackendNoValidator(t *testing.T) {
+	sim := NewDposSimulatedBackend(nil)
+	defer sim.Close()
+
+	sim.Commit()
+	validators, err := sim.Validators()
+	if err != nil {
+		t.Fatalf("failed to retrieve validators: %v", err)
+	}
+	if head := sim.Blockchain().CurrentHeader(); len(validators) != 1 || head.Coinbase != validators[0] {
+		t.Errorf("validators mismatch: have %v, sealer %x", validators, head.Coinbase)
+	}
+}
+
+// addressListAccount returns the AddressList contract account with the given
+// storage on top of its genesis one.
+func addressListAccount(storage map[common.Hash]common.Hash) core.GenesisAccount {
+	account := dpos.SystemContracts()[systemcontract.AddressListContractAddr]
+	account.Storage = storage
+	return account
+}
+
+// arrayElementSlot returns the slot of the given word of the dynamic array stored at slot.
+func arrayElementSlot(slot common.Hash, word uint64) common.Hash {
+	base := crypto.Keccak256Hash(slot.Bytes()).Big()
+	return common.BigToHash(base.Add(base, new(big.Int).SetUint64(word)))
+}
+
+// Tests that the transactions of blacklisted accounts are rejected, and that the
+// internal calls to blacklisted accounts are denied.
+func TestDposSimulatedBackendBlacklist(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		key, _      = crypto.GenerateKey()
+		addr        = crypto.PubkeyToAddress(key.PublicKey)
+		blackFrom   = common.HexToAddress("0xb1")
+		blackTo     = common.HexToAddress("0xb2")
+		forwarder   = common.HexToAddress("0xf0")
+		blackKey, _ = crypto.GenerateKey()
+		blackAddr   = crypto.PubkeyToAddress(blackKey.PublicKey)
+		funds       = new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))
+	)
+	// The forwarder sends the received value to the address given as calldata
+	code := common.FromHex("6000600060006000346000355af100")
+	sim := NewDposSimulatedBackend(core.GenesisAlloc{
+		addr:      {Balance: funds},
+		blackAddr: {Balance: funds},
+		forwarder: {Balance: new(big.Int), Code: code},
+		systemcontract.AddressListContractAddr: addressListAccount(map[common.Hash]common.Hash{
+			systemcontract.BlacksFromPosition:                      common.BigToHash(big.NewInt(2)),
+			arrayElementSlot(systemcontract.BlacksFromPosition, 0): blackAddr.Hash(),
+			arrayElementSlot(systemcontract.BlacksFromPosition, 1): blackFrom.Hash(),
+			systemcontract.BlacksToPosition:                        common.BigToHash(big.NewInt(1)),
+			arrayElementSlot(systemcontract.BlacksToPosition, 0):   blackTo.Hash(),
+		}),
+	})
+	defer sim.Close()
+	sim.Commit()
+
+	signer := types.LatestSignerForChainID(big.NewInt(1337))
+	send := func(key *ecdsa.PrivateKey, to common.Address, data []byte) (*types.Transaction, error) {
+		from := crypto.PubkeyToAddress(key.PublicKey)
+		nonce, _ := sim.PendingNonceAt(ctx, from)
+		head, _ := sim.HeaderByNumber(ctx, nil)
+		tx := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1337),
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: new(big.Int).Add(head.BaseFee, big.NewInt(params.GWei)),
+			Gas:       100000,
+			To:        &to,
+			Value:     big.NewInt(1),
+			Data:      data,
+		})
+		return tx, sim.SendTransaction(ctx, tx)
+	}
+	// Direct transactions from and to blacklisted accounts are rejected
+	if _, err := send(blackKey, addr, nil); !errors.Is(err, types.ErrAddressDenied) {
+		t.Errorf("blacklisted sender error mismatch: have %v, want %v", err, types.ErrAddressDenied)
+	}
+	if _, err := send(key, blackTo, nil); !errors.Is(err, types.ErrAddressDenied) {
+		t.Errorf("blacklisted recipient error mismatch: have %v, want %v", err, types.ErrAddressDenied)
+	}
+	if _, err := send(key, blackFrom, nil); err != nil {
+		t.Errorf("failed to send to a sender-only blacklisted account: %v", err)
+	}
+	// Internal calls to blacklisted accounts are denied by the extra validator
+	for _, to := range []common.Address{blackTo, blackFrom} {
+		if _, err := send(key, forwarder, to.Hash().Bytes()); err != nil {
+			t.Fatalf("failed to call the forwarder: %v", err)
+		}
+	}
+	sim.Commit()
+	if balance, _ := sim.BalanceAt(ctx, blackTo, nil); balance.Sign() != 0 {
+		t.Errorf("value forwarded to a blacklisted account: %v", balance)
+	}
+	if balance, _ := sim.BalanceAt(ctx, blackFrom, nil); balance.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("sender-only blacklisted balance mismatch: have %v, want 2", balance)
+	}
+}
+
+// Tests that only developers can create contracts once the developer verification
+// is enabled.
+func TestDposSimulatedBackendDeveloperVerification(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		devKey, _  = crypto.GenerateKey()
+		devAddr    = crypto.PubkeyToAddress(devKey.PublicKey)
+		userKey, _ = crypto.GenerateKey()
+		userAddr   = crypto.PubkeyToAddress(userKey.PublicKey)
+		funds      = new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))
+	)
+	// The verification flag is packed in the first slot, the developers mapping
+	// follows the Solidity layout
+	enabled := common.Hash{}
+	enabled[common.HashLength-2] = 0x01
+	devSlot := make([]byte, common.HashLength)
+	devSlot[common.HashLength-1] = systemcontract.DevMappingPosition
+
+	sim := NewDposSimulatedBackend(core.GenesisAlloc{
+		devAddr:  {Balance: funds},
+		userAddr: {Balance: funds},
+		systemcontract.AddressListContractAddr: addressListAccount(map[common.Hash]common.Hash{
+			{}: enabled,
+			crypto.Keccak256Hash(devAddr.Hash().Bytes(), devSlot): common.BigToHash(common.Big1),
+		}),
+	})
+	defer sim.Close()
+	sim.Commit()
+
+	// An empty contract is enough to exercise the creation guard
+	for i, tt := range []struct {
+		key *ecdsa.PrivateKey
+		err error
+	}{
+		{devKey, nil},
+		{userKey, core.ErrUnauthorizedDeveloper},
+	} {
+		from := crypto.PubkeyToAddress(tt.key.PublicKey)
+		nonce, _ := sim.PendingNonceAt(ctx, from)
+		tx := types.MustSignNewTx(tt.key, types.LatestSignerForChainID(big.NewInt(1337)), &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: big.NewInt(10 * params.GWei),
+			Gas:      100000,
+		})
+		if err := sim.SendTransaction(ctx, tx); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
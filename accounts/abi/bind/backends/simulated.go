@@ -70,6 +70,7 @@ type SimulatedBackend struct {
 	events *filters.EventSystem // Event system for filtering log events live
 
 	config *params.ChainConfig
+	dpos   *dposSealer // Sealer of the blocks on a simulated DPoS chain, nil for ethash
 }
 
 // NewSimulatedBackendWithDatabase creates a new binding backend based on the given database
@@ -126,6 +127,14 @@ func (b *SimulatedBackend) Rollback() {
 }
 
 func (b *SimulatedBackend) rollback(parent *types.Block) {
+	if b.dpos != nil {
+		block, statedb, err := b.dpos.generate(b.blockchain, parent, nil)
+		if err != nil {
+			panic(err) // This cannot happen unless the simulator is wrong, fail in that case
+		}
+		b.pendingBlock, b.pendingState = block, statedb
+		return
+	}
 	blocks, _ := core.GenerateChain(b.config, parent, ethash.NewFaker(), b.database, 1, func(int, *core.BlockGen) {})
 
 	b.pendingBlock = blocks[0]
@@ -654,6 +663,17 @@ func (b *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transa
 		panic(fmt.Errorf("invalid transaction nonce: got %d, want %d", tx.Nonce(), nonce))
 	}
 	// Include tx in chain
+	if b.dpos != nil {
+		// Transactions the consensus rules reject, e.g. from blacklisted senders,
+		// are reported instead
+		txs := append(b.dpos.userTxs(b.config, b.pendingBlock), tx)
+		block, statedb, err := b.dpos.generate(b.blockchain, block, txs)
+		if err != nil {
+			return err
+		}
+		b.pendingBlock, b.pendingState = block, statedb
+		return nil
+	}
 	blocks, _ := core.GenerateChain(b.config, block, ethash.NewFaker(), b.database, 1, func(number int, block *core.BlockGen) {
 		for _, tx := range b.pendingBlock.Transactions() {
 			block.AddTxWithChain(b.blockchain, tx)
@@ -768,9 +788,23 @@ func (b *SimulatedBackend) AdjustTime(adjustment time.Duration) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if len(b.pendingBlock.Transactions()) != 0 {
+	txs := b.pendingBlock.Transactions()
+	if b.dpos != nil {
+		txs = b.dpos.userTxs(b.config, b.pendingBlock)
+	}
+	if len(txs) != 0 {
 		return errors.New("Could not adjust time on non-empty block")
 	}
+	if b.dpos != nil {
+		// The engine checks the blocks against the shifted clock too
+		b.dpos.adjustTime(adjustment)
+		block, statedb, err := b.dpos.generate(b.blockchain, b.blockchain.CurrentBlock(), nil)
+		if err != nil {
+			return err
+		}
+		b.pendingBlock, b.pendingState = block, statedb
+		return nil
+	}
 
 	blocks, _ := core.GenerateChain(b.config, b.blockchain.CurrentBlock(), ethash.NewFaker(), b.database, 1, func(number int, block *core.BlockGen) {
 		block.OffsetTime(int64(adjustment.Seconds()))
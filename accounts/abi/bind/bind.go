@@ -41,6 +41,7 @@ const (
 	LangGo Lang = iota
 	LangJava
 	LangObjC
+	LangVMCaller
 )
 
 // Bind generates a Go wrapper around a contract ABI. This wrapper isn't meant
@@ -228,7 +229,7 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 		return "", err
 	}
 	// For Go bindings pass the code through gofmt to clean it up
-	if lang == LangGo {
+	if lang == LangGo || lang == LangVMCaller {
 		code, err := format.Source(buffer.Bytes())
 		if err != nil {
 			return "", fmt.Errorf("%v\n%s", err, buffer)
@@ -242,8 +243,9 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 // bindType is a set of type binders that convert Solidity types to some supported
 // programming language types.
 var bindType = map[Lang]func(kind abi.Type, structs map[string]*tmplStruct) string{
-	LangGo:   bindTypeGo,
-	LangJava: bindTypeJava,
+	LangGo:       bindTypeGo,
+	LangJava:     bindTypeJava,
+	LangVMCaller: bindTypeGo,
 }
 
 // bindBasicTypeGo converts basic solidity types(except array, slice and tuple) to Go ones.
@@ -364,8 +366,9 @@ func bindTypeJava(kind abi.Type, structs map[string]*tmplStruct) string {
 // bindTopicType is a set of type binders that convert Solidity types to some
 // supported programming language topic types.
 var bindTopicType = map[Lang]func(kind abi.Type, structs map[string]*tmplStruct) string{
-	LangGo:   bindTopicTypeGo,
-	LangJava: bindTopicTypeJava,
+	LangGo:       bindTopicTypeGo,
+	LangJava:     bindTopicTypeJava,
+	LangVMCaller: bindTopicTypeGo,
 }
 
 // bindTopicTypeGo converts a Solidity topic type to a Go one. It is almost the same
@@ -405,8 +408,9 @@ func bindTopicTypeJava(kind abi.Type, structs map[string]*tmplStruct) string {
 // bindStructType is a set of type binders that convert Solidity tuple types to some supported
 // programming language struct definition.
 var bindStructType = map[Lang]func(kind abi.Type, structs map[string]*tmplStruct) string{
-	LangGo:   bindStructTypeGo,
-	LangJava: bindStructTypeJava,
+	LangGo:       bindStructTypeGo,
+	LangJava:     bindStructTypeJava,
+	LangVMCaller: bindStructTypeGo,
 }
 
 // bindStructTypeGo converts a Solidity tuple type to a Go one and records the mapping
@@ -488,8 +492,9 @@ func bindStructTypeJava(kind abi.Type, structs map[string]*tmplStruct) string {
 // namedType is a set of functions that transform language specific types to
 // named versions that may be used inside method names.
 var namedType = map[Lang]func(string, abi.Type) string{
-	LangGo:   func(string, abi.Type) string { panic("this shouldn't be needed") },
-	LangJava: namedTypeJava,
+	LangGo:       func(string, abi.Type) string { panic("this shouldn't be needed") },
+	LangJava:     namedTypeJava,
+	LangVMCaller: func(string, abi.Type) string { panic("this shouldn't be needed") },
 }
 
 // namedTypeJava converts some primitive data types to named variants that can
@@ -530,8 +535,9 @@ func alias(aliases map[string]string, n string) string {
 // methodNormalizer is a name transformer that modifies Solidity method names to
 // conform to target language naming conventions.
 var methodNormalizer = map[Lang]func(string) string{
-	LangGo:   abi.ToCamelCase,
-	LangJava: decapitalise,
+	LangGo:       abi.ToCamelCase,
+	LangJava:     decapitalise,
+	LangVMCaller: abi.ToCamelCase,
 }
 
 // capitalise makes a camel-case string which starts with an upper case character.
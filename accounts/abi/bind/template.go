@@ -75,8 +75,9 @@ type tmplStruct struct {
 // tmplSource is language to template mapping containing all the supported
 // programming languages the package can generate to.
 var tmplSource = map[Lang]string{
-	LangGo:   tmplSourceGo,
-	LangJava: tmplSourceJava,
+	LangGo:       tmplSourceGo,
+	LangJava:     tmplSourceJava,
+	LangVMCaller: tmplSourceVMCaller,
 }
 
 // tmplSourceGo is the Go source template that the generated Go contract binding
@@ -706,3 +707,88 @@ import java.util.*;
 }
 {{end}}
 `
+
+// tmplSourceVMCaller is the Go source template that the generated read-only system
+// contract callers are built from. Calls are executed directly against a state
+// database through the vmcaller package instead of a remote backend.
+const tmplSourceVMCaller = `
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+import (
+	"math/big"
+	"strings"
+	"errors"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = common.Big1
+)
+
+{{$structs := .Structs}}
+{{range $structs}}
+	// {{.Name}} is an auto generated low-level Go binding around an user-defined struct.
+	type {{.Name}} struct {
+	{{range $field := .Fields}}
+	{{$field.Name}} {{$field.Type}}{{end}}
+	}
+{{end}}
+
+{{range $contract := .Contracts}}
+	// {{.Type}}ABI is the input ABI used to generate the binding from.
+	const {{.Type}}ABI = "{{.InputABI}}"
+
+	// {{.Type}}Caller is an auto generated read-only Go binding around a system contract,
+	// executing calls directly against the state of a block.
+	type {{.Type}}Caller struct {
+		abi     abi.ABI        // Parsed contract ABI to pack calls and unpack results with
+		address common.Address // Address the contract is deployed at
+	}
+
+	// New{{.Type}}Caller creates a new read-only instance of {{.Type}}, bound to a specific deployed contract.
+	func New{{.Type}}Caller(address common.Address) (*{{.Type}}Caller, error) {
+		parsed, err := abi.JSON(strings.NewReader({{.Type}}ABI))
+		if err != nil {
+			return nil, err
+		}
+		return &{{.Type}}Caller{abi: parsed, address: address}, nil
+	}
+
+	{{range .Calls}}
+		// {{.Normalized.Name}} is a free data retrieval call binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Caller) {{.Normalized.Name}}(ctx *vmcaller.CallContext {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) ({{if .Structured}}struct{ {{range .Normalized.Outputs}}{{.Name}} {{bindtype .Type $structs}};{{end}} },{{else}}{{range .Normalized.Outputs}}{{bindtype .Type $structs}},{{end}}{{end}} error) {
+			out, err := ctx.Call(_{{$contract.Type}}.abi, _{{$contract.Type}}.address, "{{.Original.Name}}" {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+			{{if .Structured}}
+			outstruct := new(struct{ {{range .Normalized.Outputs}} {{.Name}} {{bindtype .Type $structs}}; {{end}} })
+			if err != nil {
+				return *outstruct, err
+			}
+			{{range $i, $t := .Normalized.Outputs}}
+			outstruct.{{.Name}} = *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}
+
+			return *outstruct, err
+			{{else}}
+			if err != nil {
+				return {{range $i, $_ := .Normalized.Outputs}}*new({{bindtype .Type $structs}}), {{end}} err
+			}
+			{{range $i, $t := .Normalized.Outputs}}
+			out{{$i}} := *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}
+
+			return {{range $i, $t := .Normalized.Outputs}}out{{$i}}, {{end}} err
+			{{end}}
+		}
+	{{end}}
+{{end}}
+`
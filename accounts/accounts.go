@@ -40,6 +40,7 @@ const (
 	MimetypeTypedData         = "data/typed"
 	MimetypeClique            = "application/x-clique-header"
 	MimetypeDpos              = "application/x-dpos-header"
+	MimetypeDposAttestation   = "application/x-dpos-attestation"
 	MimetypeTextPlain         = "text/plain"
 )
 
@@ -167,7 +167,7 @@ func (api *ExternalSigner) SignData(account accounts.Account, mimeType string, d
 		return nil, err
 	}
 	// If V is on 27/28-form, convert to 0/1 for Clique/Dpos
-	if (mimeType == accounts.MimetypeClique || mimeType == accounts.MimetypeDpos) && (res[64] == 27 || res[64] == 28) {
+	if (mimeType == accounts.MimetypeClique || mimeType == accounts.MimetypeDpos || mimeType == accounts.MimetypeDposAttestation) && (res[64] == 27 || res[64] == 28) {
 		res[64] -= 27 // Transform V from 27/28 to 0/1 for Clique/Dpos use
 	}
 	return res, nil
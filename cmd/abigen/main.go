@@ -91,7 +91,7 @@ var (
 	}
 	langFlag = cli.StringFlag{
 		Name:  "lang",
-		Usage: "Destination language for the bindings (go, java, objc)",
+		Usage: "Destination language for the bindings (go, java, objc, vmcaller)",
 		Value: "go",
 	}
 	aliasFlag = cli.StringFlag{
@@ -135,6 +135,8 @@ func abigen(c *cli.Context) error {
 	case "objc":
 		lang = bind.LangObjC
 		utils.Fatalf("Objc binding generation is uncompleted")
+	case "vmcaller":
+		lang = bind.LangVMCaller
 	default:
 		utils.Fatalf("Unsupported destination language \"%s\" (--lang)", c.GlobalString(langFlag.Name))
 	}
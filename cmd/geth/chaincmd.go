@@ -23,7 +23,6 @@ import (
 	"os"
 	"runtime"
 	"strconv"
-	"strings"
 	"sync/atomic"
 	"time"
 
@@ -197,16 +196,21 @@ func initGenesis(ctx *cli.Context) error {
 		utils.Fatalf("invalid genesis file: %v", err)
 	}
 
-	ga := new(core.GenesisAlloc)
-	err = json.NewDecoder(strings.NewReader(dpos.GenesisAlloc)).Decode(ga)
-	if err != nil {
-		panic(err)
-	}
+	// Allocate the system contracts a dpos genesis lacks, keeping the given accounts
+	if genesis.Config != nil && genesis.Config.Dpos != nil {
+		alloc := dpos.SystemContracts()
+		for addr, account := range genesis.Alloc {
+			alloc[addr] = account
+		}
+		if added := len(alloc) - len(genesis.Alloc); added > 0 {
+			log.Warn("Allocated missing dpos system contracts", "count", added)
+		}
+		genesis.Alloc = alloc
 
-	for i, v := range genesis.Alloc {
-		(*ga)[i] = v
+		if err := dpos.VerifyGenesis(genesis); err != nil {
+			utils.Fatalf("Invalid dpos genesis: %v", err)
+		}
 	}
-	genesis.Alloc = *ga
 
 	// Open and initialise both full and light databases
 	stack, _ := makeConfigNode(ctx)
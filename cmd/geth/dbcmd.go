@@ -17,7 +17,10 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -27,10 +30,13 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/cmd/utils"
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/common/hexutil"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
 	"github.com/hypnosisfoundation/go-hypnosis/console/prompt"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
 	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
 	"github.com/hypnosisfoundation/go-hypnosis/ethdb"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
+	"github.com/hypnosisfoundation/go-hypnosis/rlp"
 	"github.com/hypnosisfoundation/go-hypnosis/trie"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -62,6 +68,7 @@ Remove blockchain and state databases`,
 			dbPutCmd,
 			dbGetSlotsCmd,
 			dbDumpFreezerIndex,
+			dbDposSnapshotsCmd,
 		},
 	}
 	dbInspectCmd = cli.Command{
@@ -171,6 +178,50 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		},
 		Description: "This command displays information about the freezer index.",
 	}
+	dbDposSnapshotsCmd = cli.Command{
+		Name:  "dpos-snapshots",
+		Usage: "Manage the dpos consensus snapshots",
+		Subcommands: []cli.Command{
+			{
+				Action: utils.MigrateFlags(dposSnapshotsList),
+				Name:   "list",
+				Usage:  "List the stored dpos snapshots",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.SyncModeFlag,
+					utils.MainnetFlag,
+					utils.TestnetFlag,
+				},
+				Description: "This command lists the dpos snapshots stored in the database.",
+			},
+			{
+				Action: utils.MigrateFlags(dposSnapshotsVerify),
+				Name:   "verify",
+				Usage:  "Verify the stored dpos snapshots against the headers",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.SyncModeFlag,
+					utils.MainnetFlag,
+					utils.TestnetFlag,
+				},
+				Description: `This command regenerates the dpos snapshots from the canonical headers
+and compares them with the stored ones.`,
+			},
+			{
+				Action: utils.MigrateFlags(dposSnapshotsRebuild),
+				Name:   "rebuild",
+				Usage:  "Rebuild the dpos snapshots from the headers",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.SyncModeFlag,
+					utils.MainnetFlag,
+					utils.TestnetFlag,
+				},
+				Description: `This command deletes the stored dpos snapshots and regenerates them from
+the canonical headers. Snapshots of blocks moved into the freezer are not kept.`,
+			},
+		},
+	}
 )
 
 func removeDB(ctx *cli.Context) error {
@@ -483,3 +534,142 @@ func freezerInspect(ctx *cli.Context) error {
 	}
 	return nil
 }
+
+// openDposHeaderChain opens the canonical header chain of a dpos database along
+// with a consensus engine to regenerate its snapshots.
+func openDposHeaderChain(db ethdb.Database) (*core.HeaderChain, *dpos.Dpos, error) {
+	config := rawdb.ReadChainConfig(db, rawdb.ReadCanonicalHash(db, 0))
+	if config == nil {
+		return nil, nil, errors.New("missing chain config")
+	}
+	if config.Dpos == nil {
+		return nil, nil, errors.New("not a dpos chain")
+	}
+	engine := dpos.New(config, db)
+	chain, err := core.NewHeaderChain(db, config, engine, func() bool { return false })
+	if err != nil {
+		return nil, nil, err
+	}
+	return chain, engine, nil
+}
+
+// dposSnapshotsList lists the dpos snapshots stored in the database
+func dposSnapshotsList(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	refs := rawdb.ReadDposSnapshotRefs(db, math.MaxUint64)
+	for _, ref := range refs {
+		snap := new(dpos.Snapshot)
+		if err := rlp.DecodeBytes(rawdb.ReadDposSnapshot(db, ref.Number, ref.Hash), snap); err != nil {
+			fmt.Printf("%-10d %x corrupted: %v\n", ref.Number, ref.Hash, err)
+			continue
+		}
+		canonical := rawdb.ReadCanonicalHash(db, ref.Number) == ref.Hash
+		fmt.Printf("%-10d %x canonical=%-5t validators=%d checkpoint=%x\n", ref.Number, ref.Hash, canonical, len(snap.Validators), snap.Checkpoint)
+	}
+	fmt.Printf("%d snapshots, %d in the legacy format\n", len(refs), len(rawdb.ReadLegacyDposSnapshotHashes(db)))
+	return nil
+}
+
+// dposSnapshotsVerify compares the stored dpos snapshots with the ones regenerated
+// from the headers
+func dposSnapshotsVerify(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	chain, engine, err := openDposHeaderChain(db)
+	if err != nil {
+		return err
+	}
+	var (
+		start    = time.Now()
+		verified int
+		bad      int
+	)
+	err = engine.ReplaySnapshots(chain, chain.CurrentHeader().Number.Uint64(), func(snap *dpos.Snapshot) error {
+		stored := rawdb.ReadDposSnapshot(db, snap.Number, snap.Hash)
+		if len(stored) == 0 {
+			return nil
+		}
+		blob, err := rlp.EncodeToBytes(snap)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(stored, blob) {
+			log.Error("Snapshot differs from the headers", "number", snap.Number, "hash", snap.Hash)
+			bad++
+		}
+		verified++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, ref := range rawdb.ReadDposSnapshotRefs(db, math.MaxUint64) {
+		if rawdb.ReadCanonicalHash(db, ref.Number) != ref.Hash {
+			log.Warn("Snapshot of a non-canonical block", "number", ref.Number, "hash", ref.Hash)
+		}
+	}
+	log.Info("Verified dpos snapshots", "verified", verified, "bad", bad, "elapsed", common.PrettyDuration(time.Since(start)))
+	if bad > 0 {
+		return fmt.Errorf("%d bad snapshots, run rebuild to regenerate them", bad)
+	}
+	return nil
+}
+
+// dposSnapshotsRebuild replaces the stored dpos snapshots by the ones regenerated
+// from the headers
+func dposSnapshotsRebuild(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	chain, engine, err := openDposHeaderChain(db)
+	if err != nil {
+		return err
+	}
+	// Regenerate all the snapshots before touching the stored ones, so a failed
+	// replay leaves the database as it was
+	var (
+		start = time.Now()
+		refs  []rawdb.DposSnapshotRef
+		blobs [][]byte
+	)
+	err = engine.ReplaySnapshots(chain, chain.CurrentHeader().Number.Uint64(), func(snap *dpos.Snapshot) error {
+		blob, err := rlp.EncodeToBytes(snap)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, rawdb.DposSnapshotRef{Number: snap.Number, Hash: snap.Hash})
+		blobs = append(blobs, blob)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	batch := db.NewBatch()
+	for _, ref := range rawdb.ReadDposSnapshotRefs(db, math.MaxUint64) {
+		rawdb.DeleteDposSnapshot(batch, ref.Number, ref.Hash)
+	}
+	for _, hash := range rawdb.ReadLegacyDposSnapshotHashes(db) {
+		rawdb.DeleteLegacyDposSnapshot(batch, hash)
+	}
+	for i, ref := range refs {
+		rawdb.WriteDposSnapshot(batch, ref.Number, ref.Hash, blobs[i])
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	pruned := dpos.PruneSnapshots(db)
+	log.Info("Rebuilt dpos snapshots", "stored", len(refs)-pruned, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
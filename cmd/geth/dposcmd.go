@@ -0,0 +1,232 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/cmd/utils"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/common/math"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	dposValidatorFlag = cli.StringSliceFlag{
+		Name:  "validator",
+		Usage: "Validator sealing the chain as address[:balance] in ether, staking its initial deposit out of its balance (default balance = initial deposit)",
+	}
+	dposAllocFlag = cli.StringFlag{
+		Name:  "alloc",
+		Usage: "JSON file of the accounts pre-funded at genesis, as {address: {balance}}",
+	}
+	dposChainIDFlag = cli.Uint64Flag{
+		Name:  "chainid",
+		Usage: "Chain id of the network",
+		Value: params.MainnetChainConfig.ChainID.Uint64(),
+	}
+	dposPeriodFlag = cli.Uint64Flag{
+		Name:  "period",
+		Usage: "Block period in seconds",
+		Value: params.MainnetChainConfig.Dpos.Period,
+	}
+	dposEpochFlag = cli.Uint64Flag{
+		Name:  "epoch",
+		Usage: "Epoch length in blocks, electing the validators",
+		Value: params.MainnetChainConfig.Dpos.Epoch,
+	}
+	dposTimestampFlag = cli.Uint64Flag{
+		Name:  "timestamp",
+		Usage: "Timestamp of the genesis block (default = now)",
+	}
+	dposGasLimitFlag = cli.Uint64Flag{
+		Name:  "gaslimit",
+		Usage: "Gas limit of the genesis block (default = mainnet gas limit)",
+	}
+	dposForkFlag = cli.StringSliceFlag{
+		Name:  "fork",
+		Usage: "Fork block as chain config name=block, e.g. doubleSignSlashBlock=100000",
+	}
+	dposOutputFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the genesis to (default = stdout)",
+	}
+
+	dposCommand = cli.Command{
+		Name:     "dpos",
+		Usage:    "A set of commands for dpos chains",
+		Category: "MISCELLANEOUS COMMANDS",
+		Subcommands: []cli.Command{
+			{
+				Name:     "genesis",
+				Usage:    "Build a dpos genesis file",
+				Action:   utils.MigrateFlags(buildDposGenesis),
+				Category: "MISCELLANEOUS COMMANDS",
+				Flags: []cli.Flag{
+					dposValidatorFlag,
+					dposAllocFlag,
+					dposChainIDFlag,
+					dposPeriodFlag,
+					dposEpochFlag,
+					dposTimestampFlag,
+					dposGasLimitFlag,
+					dposForkFlag,
+					dposOutputFlag,
+				},
+				Description: `
+geth dpos genesis --validator <address>[:balance] [flags]
+builds the genesis of a dpos chain following the mainnet rules, with the system
+contracts and the validator funded with its balance. The validator is listed in
+the extra-data and seals the chain, staking its initial deposit out of its
+balance when the system contracts are initialized at block 1. Only a single
+validator is supported, as the system contracts are initialized with one. The
+built-in migrated balances are registered in the Migrate contract at block 1 and
+claimed from it. The genesis is verified before being written.
+`,
+				Subcommands: []cli.Command{
+					{
+						Name:      "verify",
+						Usage:     "Verify a dpos genesis file",
+						ArgsUsage: "<genesisPath>",
+						Action:    utils.MigrateFlags(verifyDposGenesis),
+						Category:  "MISCELLANEOUS COMMANDS",
+						Description: `
+geth dpos genesis verify <genesisPath>
+checks that the genesis can start a dpos chain: its forks are ordered, its
+extra-data lists a single validator able to stake its initial deposit, between
+the vanity and an empty seal, and it allocates all the system contracts.
+`,
+					},
+				},
+			},
+		},
+	}
+)
+
+// buildDposGenesis builds a dpos genesis from the command line flags.
+func buildDposGenesis(ctx *cli.Context) error {
+	spec := &dpos.GenesisSpec{
+		ChainID:   new(big.Int).SetUint64(ctx.Uint64(dposChainIDFlag.Name)),
+		Period:    ctx.Uint64(dposPeriodFlag.Name),
+		Epoch:     ctx.Uint64(dposEpochFlag.Name),
+		Timestamp: ctx.Uint64(dposTimestampFlag.Name),
+		GasLimit:  ctx.Uint64(dposGasLimitFlag.Name),
+		Forks:     make(map[string]*big.Int),
+	}
+	if !ctx.IsSet(dposTimestampFlag.Name) {
+		spec.Timestamp = uint64(time.Now().Unix())
+	}
+	// The system contracts are initialized with a single validator
+	args := ctx.StringSlice(dposValidatorFlag.Name)
+	if len(args) != 1 {
+		utils.Fatalf("Must supply a single genesis validator, have %d", len(args))
+	}
+	validator, err := parseGenesisValidator(args[0])
+	if err != nil {
+		utils.Fatalf("Invalid validator %q: %v", args[0], err)
+	}
+	spec.Validators = []dpos.GenesisValidator{validator}
+	if path := ctx.String(dposAllocFlag.Name); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			utils.Fatalf("Failed to read alloc: %v", err)
+		}
+		defer file.Close()
+		if err := json.NewDecoder(file).Decode(&spec.Alloc); err != nil {
+			utils.Fatalf("Invalid alloc file: %v", err)
+		}
+	}
+	for _, arg := range ctx.StringSlice(dposForkFlag.Name) {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			utils.Fatalf("Invalid fork %q, want name=block", arg)
+		}
+		block, ok := math.ParseBig256(parts[1])
+		if !ok {
+			utils.Fatalf("Invalid fork %q block", arg)
+		}
+		spec.Forks[parts[0]] = block
+	}
+	genesis, err := dpos.BuildGenesis(spec)
+	if err != nil {
+		utils.Fatalf("Failed to build genesis: %v", err)
+	}
+	out := os.Stdout
+	if path := ctx.String(dposOutputFlag.Name); path != "" {
+		if out, err = os.Create(path); err != nil {
+			utils.Fatalf("Failed to create genesis file: %v", err)
+		}
+		defer out.Close()
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(genesis); err != nil {
+		utils.Fatalf("Failed to write genesis: %v", err)
+	}
+	return nil
+}
+
+// parseGenesisValidator parses a validator given as address[:balance], with the
+// balance in ether defaulting to the initial deposit.
+func parseGenesisValidator(arg string) (dpos.GenesisValidator, error) {
+	parts := strings.SplitN(arg, ":", 2)
+	if !common.IsHexAddress(parts[0]) {
+		return dpos.GenesisValidator{}, errors.New("invalid address")
+	}
+	validator := dpos.GenesisValidator{Address: common.HexToAddress(parts[0]), Balance: systemcontract.InitDeposit}
+	if len(parts) == 2 {
+		balance, ok := math.ParseBig256(parts[1])
+		if !ok {
+			return dpos.GenesisValidator{}, errors.New("invalid balance")
+		}
+		validator.Balance = new(big.Int).Mul(balance, big.NewInt(params.Ether))
+	}
+	return validator, nil
+}
+
+// verifyDposGenesis verifies a dpos genesis file.
+func verifyDposGenesis(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if len(path) == 0 {
+		utils.Fatalf("Must supply path to genesis JSON file")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		utils.Fatalf("Failed to read genesis file: %v", err)
+	}
+	defer file.Close()
+
+	genesis := new(core.Genesis)
+	if err := json.NewDecoder(file).Decode(genesis); err != nil {
+		utils.Fatalf("Invalid genesis file: %v", err)
+	}
+	if err := dpos.VerifyGenesis(genesis); err != nil {
+		utils.Fatalf("Invalid dpos genesis: %v", err)
+	}
+	fmt.Printf("Valid dpos genesis: chain %v, period %d, epoch %d\n", genesis.Config.ChainID, genesis.Config.Dpos.Period, genesis.Config.Dpos.Epoch)
+	return nil
+}
@@ -17,13 +17,17 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
 	"github.com/davecgh/go-spew/spew"
 )
@@ -93,3 +97,59 @@ func TestParitySturebyConverter(t *testing.T) {
 		t.Fatalf("chainspec mismatch")
 	}
 }
+
+// Tests that the genesis wizard configures dpos networks and their forks.
+func TestDposGenesisWizard(t *testing.T) {
+	var (
+		validator = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		funded    = common.HexToAddress("0x0000000000000000000000000000000000001000")
+	)
+	w := &wizard{
+		network: "test",
+		conf:    config{path: filepath.Join(t.TempDir(), "test.json")},
+		in: bufio.NewReader(strings.NewReader(strings.Join([]string{
+			"3",                 // consensus engine
+			"3",                 // block period
+			"100",               // epoch length
+			validator.Hex()[2:], // validator
+			"no",                // developer verification
+			funded.Hex()[2:],    // pre-funded account
+			"",                  // no more pre-funded accounts
+			"no",                // precompile pre-funding
+			"1337",              // chain id
+		}, "\n") + "\n")),
+	}
+	w.makeGenesis()
+
+	genesis := w.conf.Genesis
+	if genesis == nil || genesis.Config.Dpos == nil {
+		t.Fatalf("dpos genesis not configured")
+	}
+	if dposConf := genesis.Config.Dpos; dposConf.Period != 3 || dposConf.Epoch != 100 || dposConf.EnableDevVerification {
+		t.Errorf("dpos config mismatch: %+v", dposConf)
+	}
+	if genesis.Config.ChainID.Uint64() != 1337 || genesis.Alloc[funded].Balance == nil {
+		t.Errorf("chain id %v or pre-funding mismatch", genesis.Config.ChainID)
+	}
+	if err := dpos.VerifyGenesis(genesis); err != nil {
+		t.Fatalf("invalid dpos genesis: %v", err)
+	}
+	// Modify the dpos forks, keeping the others
+	manage := func(london string, forks ...string) {
+		answers := append([]string{"1"}, make([]string, 9)...)
+		answers = append(answers, london)
+		answers = append(answers, forks...)
+		answers = append(answers, make([]string, 10-len(forks))...)
+		w.in = bufio.NewReader(strings.NewReader(strings.Join(answers, "\n") + "\n"))
+		w.manageGenesis()
+	}
+	manage("", "", "", "5")
+	if block := genesis.Config.DoubleSignSlashBlock; block == nil || block.Int64() != 5 {
+		t.Errorf("double-sign slashing fork mismatch: have %v, want 5", block)
+	}
+	// Misordered forks are discarded
+	manage("10", "", "", "7")
+	if block := genesis.Config.DoubleSignSlashBlock; block.Int64() != 5 || genesis.Config.LondonBlock.Sign() != 0 {
+		t.Errorf("misordered forks kept: double-sign slashing %v, london %v", block, genesis.Config.LondonBlock)
+	}
+}
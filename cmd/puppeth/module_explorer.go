@@ -26,6 +26,7 @@ import (
 	"strings"
 
 	"github.com/hypnosisfoundation/go-hypnosis/log"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
 )
 
 // explorerDockerfile is the Dockerfile required to run a block explorer.
@@ -78,7 +79,7 @@ services:
 // deployExplorer deploys a new block explorer container to a remote machine via
 // SSH, docker and docker-compose. If an instance with the specified network name
 // already exists there, it will be overwritten!
-func deployExplorer(client *sshClient, network string, bootnodes []string, config *explorerInfos, nocache bool, isClique bool) ([]byte, error) {
+func deployExplorer(client *sshClient, network string, bootnodes []string, config *explorerInfos, nocache bool, chainConfig *params.ChainConfig) ([]byte, error) {
 	// Generate the content to upload to the server
 	workdir := fmt.Sprintf("%d", rand.Int63())
 	files := make(map[string][]byte)
@@ -92,10 +93,6 @@ func deployExplorer(client *sshClient, network string, bootnodes []string, confi
 	})
 	files[filepath.Join(workdir, "Dockerfile")] = dockerfile.Bytes()
 
-	transformer := "base"
-	if isClique {
-		transformer = "clique"
-	}
 	composefile := new(bytes.Buffer)
 	template.Must(template.New("").Parse(explorerComposefile)).Execute(composefile, map[string]interface{}{
 		"Network":     network,
@@ -106,7 +103,7 @@ func deployExplorer(client *sshClient, network string, bootnodes []string, confi
 		"EthPort":     config.node.port,
 		"EthName":     config.node.ethstats[:strings.Index(config.node.ethstats, ":")],
 		"WebPort":     config.port,
-		"Transformer": transformer,
+		"Transformer": explorerTransformer(chainConfig),
 	})
 	files[filepath.Join(workdir, "docker-compose.yaml")] = composefile.Bytes()
 	files[filepath.Join(workdir, "genesis.json")] = config.node.genesis
@@ -124,6 +121,23 @@ func deployExplorer(client *sshClient, network string, bootnodes []string, confi
 	return nil, client.Stream(fmt.Sprintf("cd %s && docker-compose -p %s up -d --build --force-recreate --timeout 60", workdir, network))
 }
 
+// explorerTransformer returns the block transformer the explorer uses to resolve
+// the producers of the blocks.
+func explorerTransformer(config *params.ChainConfig) string {
+	switch {
+	case config.Clique != nil:
+		// Clique blocks carry votes in their coinbases, recover the signers from the seals
+		return "clique"
+	case config.Dpos != nil:
+		// Dpos blocks are verified to be sealed by their coinbases, report them as
+		// is: the clique transformer would hash the base fee into the sealed header,
+		// which dpos doesn't, and recover bogus signers after London
+		return "base"
+	default:
+		return "base"
+	}
+}
+
 // explorerInfos is returned from a block explorer status check to allow reporting
 // various configuration parameters.
 type explorerInfos struct {
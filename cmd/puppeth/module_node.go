@@ -28,6 +28,7 @@ import (
 
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
 )
 
 // nodeDockerfile is the Dockerfile required to run an Ethereum node.
@@ -191,20 +192,47 @@ func (info *nodeInfos) Report() map[string]string {
 			report["Miner account"] = info.etherbase
 		}
 		if info.keyJSON != "" {
-			// Clique proof-of-authority signer
+			// Clique proof-of-authority signer or dpos validator
 			var key struct {
 				Address string `json:"address"`
 			}
 			if err := json.Unmarshal([]byte(info.keyJSON), &key); err == nil {
-				report["Signer account"] = common.HexToAddress(key.Address).Hex()
+				label := "Signer account"
+				if info.engine() == "dpos" {
+					label = "Validator account"
+				}
+				report[label] = common.HexToAddress(key.Address).Hex()
 			} else {
 				log.Error("Failed to retrieve signer address", "err", err)
 			}
 		}
 	}
+	if engine := info.engine(); engine != "" {
+		report["Consensus engine"] = engine
+	}
 	return report
 }
 
+// engine returns the name of the consensus engine of the node's genesis, or an
+// empty string if it can't be determined.
+func (info *nodeInfos) engine() string {
+	var genesis struct {
+		Config *params.ChainConfig `json:"config"`
+	}
+	if err := json.Unmarshal(info.genesis, &genesis); err != nil || genesis.Config == nil {
+		return ""
+	}
+	switch {
+	case genesis.Config.Dpos != nil:
+		return "dpos"
+	case genesis.Config.Clique != nil:
+		return "clique"
+	case genesis.Config.Ethash != nil:
+		return "ethash"
+	}
+	return ""
+}
+
 // checkNode does a health-check against a boot or seal node server to verify
 // whether it's running, and if yes, whether it's responsive.
 func checkNode(client *sshClient, network string, boot bool) (*nodeInfos, error) {
@@ -30,6 +30,8 @@ import (
 	"time"
 
 	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
 	"github.com/hypnosisfoundation/go-hypnosis/params"
@@ -59,6 +61,7 @@ func (w *wizard) makeGenesis() {
 	fmt.Println("Which consensus engine to use? (default = clique)")
 	fmt.Println(" 1. Ethash - proof-of-work")
 	fmt.Println(" 2. Clique - proof-of-authority")
+	fmt.Println(" 3. Dpos   - delegated proof-of-stake")
 
 	choice := w.read()
 	switch {
@@ -105,6 +108,40 @@ func (w *wizard) makeGenesis() {
 			copy(genesis.ExtraData[32+i*common.AddressLength:], signer[:])
 		}
 
+	case choice == "3":
+		// In the case of dpos, configure the consensus parameters
+		fmt.Println()
+		fmt.Printf("How many seconds should blocks take? (default = %d)\n", params.MainnetChainConfig.Dpos.Period)
+		period := uint64(w.readDefaultInt(int(params.MainnetChainConfig.Dpos.Period)))
+
+		fmt.Println()
+		fmt.Printf("How many blocks should an epoch last? (default = %d)\n", params.MainnetChainConfig.Dpos.Epoch)
+		epoch := uint64(w.readDefaultInt(int(params.MainnetChainConfig.Dpos.Epoch)))
+
+		// We also need the validator sealing the chain, the system contracts are
+		// initialized with a single one
+		fmt.Println()
+		fmt.Println("Which account should be the validator? (mandatory, a single one seals from genesis)")
+
+		var validator *common.Address
+		for validator == nil {
+			validator = w.readAddress()
+		}
+		spec, err := dpos.BuildGenesis(&dpos.GenesisSpec{
+			Period:     period,
+			Epoch:      epoch,
+			Timestamp:  genesis.Timestamp,
+			Validators: []dpos.GenesisValidator{{Address: *validator, Balance: systemcontract.InitDeposit}},
+		})
+		if err != nil {
+			log.Crit("Invalid dpos genesis", "err", err)
+		}
+		genesis = spec
+
+		fmt.Println()
+		fmt.Println("Should the developer addresses of contract deployments be verified? (default = yes)")
+		genesis.Config.Dpos.EnableDevVerification = w.readDefaultYesNo(true)
+
 	default:
 		log.Crit("Invalid consensus engine choice", "choice", choice)
 	}
@@ -114,6 +151,10 @@ func (w *wizard) makeGenesis() {
 	for {
 		// Read the address of the account to fund
 		if address := w.readAddress(); address != nil {
+			if account, ok := genesis.Alloc[*address]; ok && len(account.Code) > 0 {
+				log.Error("Refusing to overwrite a system contract", "address", *address)
+				continue
+			}
 			genesis.Alloc[*address] = core.GenesisAccount{
 				Balance: new(big.Int).Lsh(big.NewInt(1), 256-7), // 2^256 / 128 (allow many pre-funds without balance overflows)
 			}
@@ -134,6 +175,9 @@ func (w *wizard) makeGenesis() {
 	fmt.Println("Specify your chain/network ID if you want an explicit one (default = random)")
 	genesis.Config.ChainID = new(big.Int).SetUint64(uint64(w.readDefaultInt(rand.Intn(65536))))
 
+	if err := verifyGenesis(genesis); err != nil {
+		log.Crit("Invalid genesis", "err", err)
+	}
 	// All done, store the genesis and flush to disk
 	log.Info("Configured new genesis block")
 
@@ -141,6 +185,15 @@ func (w *wizard) makeGenesis() {
 	w.conf.flush()
 }
 
+// verifyGenesis checks that a dpos genesis can start its chain, the genesis of
+// the other engines are accepted as is.
+func verifyGenesis(genesis *core.Genesis) error {
+	if genesis.Config == nil || genesis.Config.Dpos == nil {
+		return nil
+	}
+	return dpos.VerifyGenesis(genesis)
+}
+
 // importGenesis imports a Geth genesis spec into puppeth.
 func (w *wizard) importGenesis() {
 	// Request the genesis JSON spec URL from the user
@@ -201,6 +254,8 @@ func (w *wizard) manageGenesis() {
 	switch choice {
 	case "1":
 		// Fork rule updating requested, iterate over each fork
+		original := *w.conf.Genesis.Config
+
 		fmt.Println()
 		fmt.Printf("Which block should Homestead come into effect? (default = %v)\n", w.conf.Genesis.Config.HomesteadBlock)
 		w.conf.Genesis.Config.HomesteadBlock = w.readDefaultBigInt(w.conf.Genesis.Config.HomesteadBlock)
@@ -243,6 +298,34 @@ func (w *wizard) manageGenesis() {
 		fmt.Printf("Which block should London come into effect? (default = %v)\n", w.conf.Genesis.Config.LondonBlock)
 		w.conf.Genesis.Config.LondonBlock = w.readDefaultBigInt(w.conf.Genesis.Config.LondonBlock)
 
+		if config := w.conf.Genesis.Config; config.Dpos != nil {
+			// Dpos networks have their own forks too
+			for _, fork := range []struct {
+				name  string
+				block **big.Int
+			}{
+				{"RedCoast", &config.RedCoastBlock},
+				{"Sophon", &config.SophonBlock},
+				{"double-sign slashing", &config.DoubleSignSlashBlock},
+				{"the stake schedule", &config.StakeScheduleBlock},
+				{"the base fee policy", &config.BaseFeePolicyBlock},
+				{"the system contract upgrade", &config.ContractUpgradeBlock},
+				{"jailing", &config.JailBlock},
+				{"the governance actions", &config.GovernanceActionsBlock},
+				{"the governance timelock", &config.GovernanceTimelockBlock},
+				{"the event data rules", &config.EventDataRulesBlock},
+			} {
+				fmt.Println()
+				fmt.Printf("Which block should %s come into effect? (default = %v)\n", fork.name, *fork.block)
+				*fork.block = w.readDefaultBigInt(*fork.block)
+			}
+		}
+		if err := verifyGenesis(w.conf.Genesis); err != nil {
+			log.Error("Invalid chain configuration, discarding the changes", "err", err)
+			*w.conf.Genesis.Config = original
+			return
+		}
+
 		out, _ := json.MarshalIndent(w.conf.Genesis.Config, "", "  ")
 		fmt.Printf("Chain configuration updated:\n\n%s\n", out)
 
@@ -165,6 +165,8 @@ func (w *wizard) gatherStats(server string, pubkey []byte, client *sshClient) *s
 		g := new(core.Genesis)
 		if err := json.Unmarshal([]byte(genesis), g); err != nil {
 			log.Error("Failed to parse remote genesis", "err", err)
+		} else if err := verifyGenesis(g); err != nil {
+			log.Error("Invalid remote genesis", "err", err)
 		} else {
 			w.conf.Genesis = g
 		}
@@ -39,6 +39,7 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/common/fdlimit"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/clique"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/ethash"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
 	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
@@ -155,6 +156,10 @@ var (
 		Name:  "dev.period",
 		Usage: "Block period to use in developer mode (0 = mine only if transaction pending)",
 	}
+	DeveloperDposFlag = cli.BoolFlag{
+		Name:  "dev.dpos",
+		Usage: "Use a dPoS chain with the system contracts and the developer account as sole validator in developer mode",
+	}
 	IdentityFlag = cli.StringFlag{
 		Name:  "identity",
 		Usage: "Custom node name",
@@ -214,6 +219,10 @@ var (
 		Usage: "Number of recent blocks to maintain transactions index for (default = about one year, 0 = entire chain)",
 		Value: ethconfig.Defaults.TxLookupLimit,
 	}
+	DposActivityIndexFlag = cli.BoolFlag{
+		Name:  "dpos.activityindex",
+		Usage: "Index the validator activity of every dpos epoch to serve dpos_getValidatorActivity over long ranges",
+	}
 	LightKDFFlag = cli.BoolFlag{
 		Name:  "lightkdf",
 		Usage: "Reduce key-derivation RAM & CPU usage at some expense of KDF strength",
@@ -366,6 +375,11 @@ var (
 		Usage: "Maximum amount of time non-executable transaction are queued",
 		Value: ethconfig.Defaults.TxPool.Lifetime,
 	}
+	TxPoolScreenGasFlag = cli.Uint64Flag{
+		Name:  "txpool.screengas",
+		Usage: "Maximum gas of pending transactions screened against the consensus blacklist on each new head (0 = disabled)",
+		Value: ethconfig.Defaults.TxPool.ScreenGas,
+	}
 	// Performance tuning settings
 	CacheFlag = cli.IntFlag{
 		Name:  "cache",
@@ -456,6 +470,21 @@ var (
 		Name:  "miner.noverify",
 		Usage: "Disable remote sealing verification",
 	}
+	MinerPriorityGasFlag = cli.Uint64Flag{
+		Name:  "miner.prioritygas",
+		Usage: "Percentage of the block gas reserved for system contract transactions, withheld from others (0 = disabled)",
+		Value: ethconfig.Defaults.Miner.PriorityLane.GasPercent,
+	}
+	MinerPrioritySenderLimitFlag = cli.Uint64Flag{
+		Name:  "miner.prioritysenderlimit",
+		Usage: "Maximum reserved gas transactions of a sender within the priority window (0 = unlimited)",
+		Value: ethconfig.Defaults.Miner.PriorityLane.SenderLimit,
+	}
+	MinerPriorityWindowFlag = cli.Uint64Flag{
+		Name:  "miner.prioritywindow",
+		Usage: "Number of blocks the priority sender limit applies to",
+		Value: ethconfig.Defaults.Miner.PriorityLane.SenderWindow,
+	}
 	// Account settings
 	UnlockedAccountFlag = cli.StringFlag{
 		Name:  "unlock",
@@ -1318,6 +1347,9 @@ func setTxPool(ctx *cli.Context, cfg *core.TxPoolConfig) {
 	if ctx.GlobalIsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.GlobalDuration(TxPoolLifetimeFlag.Name)
 	}
+	if ctx.GlobalIsSet(TxPoolScreenGasFlag.Name) {
+		cfg.ScreenGas = ctx.GlobalUint64(TxPoolScreenGasFlag.Name)
+	}
 }
 
 func setEthash(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1367,6 +1399,15 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.GlobalIsSet(MinerNoVerfiyFlag.Name) {
 		cfg.Noverify = ctx.GlobalBool(MinerNoVerfiyFlag.Name)
 	}
+	if ctx.GlobalIsSet(MinerPriorityGasFlag.Name) {
+		cfg.PriorityLane.GasPercent = ctx.GlobalUint64(MinerPriorityGasFlag.Name)
+	}
+	if ctx.GlobalIsSet(MinerPrioritySenderLimitFlag.Name) {
+		cfg.PriorityLane.SenderLimit = ctx.GlobalUint64(MinerPrioritySenderLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(MinerPriorityWindowFlag.Name) {
+		cfg.PriorityLane.SenderWindow = ctx.GlobalUint64(MinerPriorityWindowFlag.Name)
+	}
 	if ctx.GlobalIsSet(LegacyMinerGasTargetFlag.Name) {
 		log.Warn("The generic --miner.gastarget flag is deprecated and will be removed in the future!")
 	}
@@ -1513,6 +1554,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.GlobalIsSet(TxLookupLimitFlag.Name) {
 		cfg.TxLookupLimit = ctx.GlobalUint64(TxLookupLimitFlag.Name)
 	}
+	if ctx.GlobalIsSet(DposActivityIndexFlag.Name) {
+		cfg.DposActivityIndex = ctx.GlobalBool(DposActivityIndexFlag.Name)
+	}
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheTrieFlag.Name) {
 		cfg.TrieCleanCache = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheTrieFlag.Name) / 100
 	}
@@ -1613,7 +1657,12 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		log.Info("Using developer account", "address", developer.Address)
 
 		// Create a new developer genesis block or reuse existing one
-		cfg.Genesis = core.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), developer.Address)
+		period := uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name))
+		if ctx.GlobalBool(DeveloperDposFlag.Name) {
+			cfg.Genesis = dpos.DeveloperGenesisBlock(period, developer.Address)
+		} else {
+			cfg.Genesis = core.DeveloperGenesisBlock(period, developer.Address)
+		}
 		if ctx.GlobalIsSet(DataDirFlag.Name) {
 			// Check if we have an already initialized chain and fall back to
 			// that if so. Otherwise we need to generate a new genesis spec.
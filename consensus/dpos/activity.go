@@ -0,0 +1,248 @@
+package dpos
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/ethdb"
+	"github.com/hypnosisfoundation/go-hypnosis/event"
+)
+
+const (
+	activityConfirms   = 64                     // Number of confirmations before an epoch is indexed
+	activityThrottling = 100 * time.Millisecond // Time to wait between indexing two epochs
+	maxActivityScan    = 32768                  // Maximum number of headers scanned for activity missing from the index
+)
+
+var (
+	activityPrefix      = []byte("dpos-activity-") // activityPrefix is the prefix of the activity index table
+	activityEpochPrefix = []byte("e")              // activityEpochPrefix + epoch (uint64 big endian) -> epoch activity
+
+	// errActivityRangeTooLarge is returned if an activity query needs to scan too
+	// many headers that are not indexed.
+	errActivityRangeTooLarge = errors.New("validator activity range too large, enable the activity index")
+)
+
+// ValidatorActivity is the sealing activity of a validator within an epoch.
+type ValidatorActivity struct {
+	Sealed    uint64 `json:"sealed"`    // Blocks sealed by the validator
+	InTurn    uint64 `json:"inTurn"`    // Blocks sealed in turn
+	OutOfTurn uint64 `json:"outOfTurn"` // Blocks sealed out of turn
+	Missed    uint64 `json:"missed"`    // In-turn slots sealed by another validator
+	Punished  uint64 `json:"punished"`  // Missed slots punished by the system rewards contract
+}
+
+// EpochActivity is the activity of the validators over the blocks of an epoch.
+type EpochActivity struct {
+	Epoch      uint64                                `json:"epoch"`
+	From       uint64                                `json:"from"` // First block covered
+	To         uint64                                `json:"to"`   // Last block covered
+	Validators map[common.Address]*ValidatorActivity `json:"validators"`
+}
+
+// newEpochActivity creates an empty activity record of the given blocks of an epoch.
+func newEpochActivity(epoch, from, to uint64) *EpochActivity {
+	return &EpochActivity{
+		Epoch:      epoch,
+		From:       from,
+		To:         to,
+		Validators: make(map[common.Address]*ValidatorActivity),
+	}
+}
+
+// validator returns the activity of a validator, creating it if needed.
+func (a *EpochActivity) validator(addr common.Address) *ValidatorActivity {
+	if a.Validators[addr] == nil {
+		a.Validators[addr] = new(ValidatorActivity)
+	}
+	return a.Validators[addr]
+}
+
+// activityKey = activityEpochPrefix + epoch (uint64 big endian)
+func activityKey(epoch uint64) []byte {
+	key := make([]byte, len(activityEpochPrefix)+8)
+	copy(key, activityEpochPrefix)
+	binary.BigEndian.PutUint64(key[len(activityEpochPrefix):], epoch)
+	return key
+}
+
+// readEpochActivity loads the activity record of an epoch from the index.
+func readEpochActivity(db ethdb.KeyValueReader, epoch uint64) (*EpochActivity, error) {
+	blob, err := db.Get(activityKey(epoch))
+	if err != nil {
+		return nil, err
+	}
+	activity := new(EpochActivity)
+	if err := json.Unmarshal(blob, activity); err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+// writeEpochActivity stores the activity record of an epoch into the index.
+func writeEpochActivity(db ethdb.KeyValueWriter, activity *EpochActivity) error {
+	blob, err := json.Marshal(activity)
+	if err != nil {
+		return err
+	}
+	return db.Put(activityKey(activity.Epoch), blob)
+}
+
+// recordActivity adds the sealing activity of a header to the record. An out-of-turn
+// header is a missed slot of the in-turn validator, which is punished unless it
+// signed recently, the same way as Finalize does.
+func (d *Dpos) recordActivity(chain consensus.ChainHeaderReader, activity *EpochActivity, header *types.Header) error {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return nil
+	}
+	sealer, err := d.Author(header)
+	if err != nil {
+		return err
+	}
+	sealed := activity.validator(sealer)
+	sealed.Sealed++
+	if header.Difficulty.Cmp(diffInTurn) == 0 {
+		sealed.InTurn++
+		return nil
+	}
+	sealed.OutOfTurn++
+
+	snap, err := d.snapshot(chain, number-1, header.ParentHash, nil)
+	if err != nil {
+		return err
+	}
+	inturn := snap.proposerSelector(number).Proposer(snap, number)
+	missed := activity.validator(inturn)
+	missed.Missed++
+	for _, recent := range snap.Recents {
+		if recent == inturn {
+			return nil
+		}
+	}
+	missed.Punished++
+	return nil
+}
+
+// activityIndexer implements core.ChainIndexerBackend, building up the validator
+// activity of every epoch of the canonical chain.
+type activityIndexer struct {
+	dpos     *Dpos
+	chain    consensus.ChainHeaderReader
+	db       ethdb.Database // Activity index table
+	activity *EpochActivity // Activity of the epoch being indexed
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new epoch record.
+func (b *activityIndexer) Reset(ctx context.Context, section uint64, prevHead common.Hash) error {
+	epoch := b.dpos.config.Epoch
+	b.activity = newEpochActivity(section, section*epoch, (section+1)*epoch-1)
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, adding the activity of a header
+// into the epoch record.
+func (b *activityIndexer) Process(ctx context.Context, header *types.Header) error {
+	return b.dpos.recordActivity(b.chain, b.activity, header)
+}
+
+// Commit implements core.ChainIndexerBackend, writing the epoch record out into
+// the database.
+func (b *activityIndexer) Commit() error {
+	return writeEpochActivity(b.db, b.activity)
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (b *activityIndexer) Prune(threshold uint64) error {
+	return nil
+}
+
+// ActivityChain is the chain the validator activity indexer follows.
+type ActivityChain interface {
+	consensus.ChainHeaderReader
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// StartActivityIndexer starts indexing the validator activity of every epoch of
+// the given chain in the background, so that activity queries over long ranges
+// don't have to scan the headers.
+func (d *Dpos) StartActivityIndexer(chain ActivityChain) {
+	table := rawdb.NewTable(d.db, string(activityPrefix))
+	backend := &activityIndexer{
+		dpos:  d,
+		chain: chain,
+		db:    table,
+	}
+	indexer := core.NewChainIndexer(d.db, table, backend, d.config.Epoch, activityConfirms, activityThrottling, "dposactivity")
+
+	d.lock.Lock()
+	d.activity = indexer
+	d.lock.Unlock()
+
+	indexer.Start(chain)
+}
+
+// validatorActivity returns the activity of the validators within the given blocks,
+// one record per epoch. Whole epochs are read from the index if available, the rest
+// is collected from the headers.
+func (d *Dpos) validatorActivity(chain consensus.ChainHeaderReader, from, to uint64) ([]*EpochActivity, error) {
+	d.lock.RLock()
+	indexer := d.activity
+	d.lock.RUnlock()
+
+	var (
+		indexed uint64
+		table   ethdb.Database
+	)
+	if indexer != nil {
+		indexed, _, _ = indexer.Sections()
+		table = rawdb.NewTable(d.db, string(activityPrefix))
+	}
+	var (
+		epoch   = d.config.Epoch
+		records []*EpochActivity
+		scanned uint64
+	)
+	for n := from - from%epoch; n <= to; n += epoch {
+		var (
+			number = n / epoch
+			first  = n
+			last   = n + epoch - 1
+		)
+		if first < from {
+			first = from
+		}
+		if last > to {
+			last = to
+		}
+		if first == n && last == n+epoch-1 && number < indexed {
+			if activity, err := readEpochActivity(table, number); err == nil {
+				records = append(records, activity)
+				continue
+			}
+		}
+		if scanned += last - first + 1; scanned > maxActivityScan {
+			return nil, errActivityRangeTooLarge
+		}
+		activity := newEpochActivity(number, first, last)
+		for i := first; i <= last; i++ {
+			header := chain.GetHeaderByNumber(i)
+			if header == nil {
+				return nil, errUnknownBlock
+			}
+			if err := d.recordActivity(chain, activity, header); err != nil {
+				return nil, err
+			}
+		}
+		records = append(records, activity)
+	}
+	return records, nil
+}
@@ -0,0 +1,130 @@
+package dpos
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+// newActivityTester creates a chain of n blocks sealed round-robin by three
+// validators, except for the given out-of-turn blocks sealed by the next one,
+// and an engine with an epoch of four blocks whose snapshots contain them.
+func newActivityTester(t *testing.T, n int, outOfTurn map[uint64]bool) (*Dpos, *testFinalityChain, []common.Address) {
+	keys := make([]*ecdsa.PrivateKey, 3)
+	byAddr := make(map[common.Address]*ecdsa.PrivateKey)
+	validators := make([]common.Address, len(keys))
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		validators[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+		byAddr[validators[i]] = keys[i]
+	}
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Epoch: 4}
+	engine := New(&config, rawdb.NewMemoryDatabase())
+
+	chain := &testFinalityChain{}
+	parent := common.Hash{}
+	for i := 0; i <= n; i++ {
+		number := uint64(i)
+		snap := newSnapshot(engine.chainConfig, engine.config, engine.signatures, number, common.Hash{}, validators, nil)
+		sealer, difficulty := snap.validators()[number%3], diffInTurn
+		if outOfTurn[number] {
+			sealer, difficulty = snap.validators()[(number+1)%3], diffNoTurn
+		}
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     new(big.Int).SetUint64(number),
+			Coinbase:   sealer,
+			Difficulty: difficulty,
+			Extra:      make([]byte, extraVanity+extraSeal),
+		}
+		sig, err := crypto.Sign(SealHash(header).Bytes(), byAddr[sealer])
+		if err != nil {
+			t.Fatalf("failed to seal header: %v", err)
+		}
+		copy(header.Extra[extraVanity:], sig)
+
+		chain.headers = append(chain.headers, header)
+		parent = header.Hash()
+
+		rawdb.WriteHeader(engine.db, header)
+		rawdb.WriteCanonicalHash(engine.db, header.Hash(), number)
+
+		snap.Hash = header.Hash()
+		engine.recents.Add(header.Hash(), snap)
+	}
+	// Return the validators in their round-robin order
+	sort.Sort(validatorsAscending(validators))
+	return engine, chain, validators
+}
+
+func TestValidatorActivity(t *testing.T) {
+	engine, chain, validators := newActivityTester(t, 9, map[uint64]bool{5: true, 7: true})
+
+	// Block 7 isn't punished, its in-turn validator signed recently
+	snap, _ := engine.recents.Get(chain.headers[6].Hash())
+	snap.(*Snapshot).Recents[6] = validators[1]
+
+	records, err := engine.validatorActivity(chain, 2, 9)
+	if err != nil {
+		t.Fatalf("failed to collect activity: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("epoch count mismatch: have %d, want 3", len(records))
+	}
+	for i, want := range [][3]uint64{{0, 2, 3}, {1, 4, 7}, {2, 8, 9}} {
+		if records[i].Epoch != want[0] || records[i].From != want[1] || records[i].To != want[2] {
+			t.Errorf("record %d: range mismatch: have %d %d-%d, want %d %d-%d", i, records[i].Epoch, records[i].From, records[i].To, want[0], want[1], want[2])
+		}
+	}
+	epoch := records[1].Validators
+	for i, want := range []ValidatorActivity{
+		{Sealed: 2, InTurn: 1, OutOfTurn: 1},
+		{Sealed: 1, InTurn: 1, Missed: 1},
+		{Sealed: 1, OutOfTurn: 1, Missed: 1, Punished: 1},
+	} {
+		if have := *epoch[validators[i]]; have != want {
+			t.Errorf("validator %d activity mismatch: have %+v, want %+v", i, have, want)
+		}
+	}
+}
+
+func TestValidatorActivityIndex(t *testing.T) {
+	engine, chain, _ := newActivityTester(t, 80, map[uint64]bool{5: true, 42: true})
+
+	scanned, err := engine.validatorActivity(chain, 1, 80)
+	if err != nil {
+		t.Fatalf("failed to scan activity: %v", err)
+	}
+	engine.StartActivityIndexer(chain)
+	defer engine.Close()
+
+	// 80 blocks with 64 confirmations index four epochs
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if sections, _, _ := engine.activity.Sections(); sections == 4 {
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("activity index not built")
+		}
+	}
+	if _, err := readEpochActivity(rawdb.NewTable(engine.db, string(activityPrefix)), 3); err != nil {
+		t.Fatalf("epoch missing from the index: %v", err)
+	}
+	indexed, err := engine.validatorActivity(chain, 1, 80)
+	if err != nil {
+		t.Fatalf("failed to collect indexed activity: %v", err)
+	}
+	if !reflect.DeepEqual(indexed, scanned) {
+		t.Errorf("indexed activity mismatch")
+	}
+}
@@ -0,0 +1,550 @@
+package dpos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/log"
+	"github.com/hypnosisfoundation/go-hypnosis/metrics"
+	"github.com/hypnosisfoundation/go-hypnosis/rlp"
+)
+
+var (
+	addressListReloadMeter = metrics.NewRegisteredMeter("dpos/addresslist/reload", nil) // Content reloaded through contract calls
+	addressListUpdateMeter = metrics.NewRegisteredMeter("dpos/addresslist/update", nil) // Content updated from the contract logs
+)
+
+// errAddressListMismatch is returned if the logs of the AddressList contract don't
+// apply to the content known before them.
+var errAddressListMismatch = errors.New("address list logs mismatch")
+
+// addressListRule is an event check rule as stored by the AddressList contract.
+type addressListRule struct {
+	Sig   common.Hash
+	Index *big.Int
+	Check uint8
+}
+
+// addressList is the content of the AddressList contract after a block. It's
+// immutable once built, as it is shared by the blocks not changing it.
+type addressList struct {
+	blacks map[common.Address]blacklistDirection
+	rules  []*addressListRule // In the order of the contract
+
+	decoded map[bool]map[common.Hash]*EventCheckRule // Rules decoded with and without the EventDataRules fork, protected by Dpos.rulesLock
+}
+
+// eventCheckRules returns the rules of the list, decoded as the fork dictates.
+// The caller must hold Dpos.rulesLock.
+func (l *addressList) eventCheckRules(dataRules bool) map[common.Hash]*EventCheckRule {
+	if rules, ok := l.decoded[dataRules]; ok {
+		return rules
+	}
+	rules := make(map[common.Hash]*EventCheckRule)
+	for _, r := range l.rules {
+		rule, exist := rules[r.Sig]
+		if !exist {
+			rule = &EventCheckRule{
+				EventSig: r.Sig,
+				Checks:   make(map[int]common.AddressCheckType),
+			}
+			rules[r.Sig] = rule
+		}
+		rule.addCheck(r.Index, common.AddressCheckType(r.Check), dataRules)
+	}
+	if l.decoded == nil {
+		l.decoded = make(map[bool]map[common.Hash]*EventCheckRule)
+	}
+	l.decoded[dataRules] = rules
+	return rules
+}
+
+// addressListRuleKey identifies an event check rule of the AddressList contract.
+type addressListRuleKey struct {
+	sig   common.Hash
+	index string // Check index, as a decimal string
+}
+
+// addressListChanges names the entries of the AddressList contract changed by the
+// logs of a block.
+type addressListChanges struct {
+	blacks map[common.Address]struct{}
+	rules  map[addressListRuleKey]struct{}
+}
+
+// apply returns the content of the list after the given logs of a block, emitted
+// by the AddressList contract on changes, along with the entries they name. The
+// logs mirror the bookkeeping of the contract, including the order of the rules.
+func (l *addressList) apply(d *Dpos, logs []*types.Log) (*addressList, *addressListChanges, error) {
+	alABI := d.abi[systemcontract.AddressListContractName]
+	var (
+		blacks  = l.blacks
+		rules   = l.rules
+		copied  = [2]bool{}
+		changes = &addressListChanges{
+			blacks: make(map[common.Address]struct{}),
+			rules:  make(map[addressListRuleKey]struct{}),
+		}
+	)
+	for _, evLog := range logs {
+		if evLog.Address != systemcontract.AddressListContractAddr || len(evLog.Topics) != 2 {
+			continue
+		}
+		event, err := alABI.EventByID(evLog.Topics[0])
+		if err != nil {
+			continue // Not a change of the list
+		}
+		args, err := alABI.Unpack(event.Name, evLog.Data)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch event.Name {
+		case "BlackAddrAdded", "BlackAddrRemoved":
+			if !copied[0] {
+				blacks = make(map[common.Address]blacklistDirection, len(l.blacks))
+				for addr, dir := range l.blacks {
+					blacks[addr] = dir
+				}
+				copied[0] = true
+			}
+			addr := common.BytesToAddress(evLog.Topics[1].Bytes())
+			if err := applyBlacklistLog(blacks, addr, blacklistDirection(args[0].(uint8)), event.Name == "BlackAddrAdded"); err != nil {
+				return nil, nil, err
+			}
+			changes.blacks[addr] = struct{}{}
+
+		case "RuleAdded", "RuleUpdated", "RuleRemoved":
+			if !copied[1] {
+				rules = make([]*addressListRule, len(l.rules))
+				copy(rules, l.rules)
+				copied[1] = true
+			}
+			rule := &addressListRule{Sig: evLog.Topics[1], Index: args[0].(*big.Int), Check: args[1].(uint8)}
+			if rules, err = applyRuleLog(rules, rule, event.Name); err != nil {
+				return nil, nil, err
+			}
+			changes.rules[rule.key()] = struct{}{}
+		}
+	}
+	return &addressList{blacks: blacks, rules: rules}, changes, nil
+}
+
+// key returns the identifier of the rule.
+func (r *addressListRule) key() addressListRuleKey {
+	return addressListRuleKey{sig: r.Sig, index: r.Index.String()}
+}
+
+// applyBlacklistLog applies an addition to or a removal from the blacklist. The
+// contract keeps the addresses denied as senders and as recipients in two lists,
+// removals are logged once per list.
+func applyBlacklistLog(blacks map[common.Address]blacklistDirection, addr common.Address, dir blacklistDirection, added bool) error {
+	cur, exist := blacks[addr]
+	switch {
+	case added && !exist:
+		blacks[addr] = dir
+	case added && cur != dir && cur != DirectionBoth && dir != DirectionBoth:
+		blacks[addr] = DirectionBoth
+	case !added && exist && cur == dir && dir != DirectionBoth:
+		delete(blacks, addr)
+	case !added && cur == DirectionBoth && dir != DirectionBoth:
+		blacks[addr] = DirectionFrom + DirectionTo - dir // the remaining one
+	default:
+		return fmt.Errorf("%w: %x direction %v added %v", errAddressListMismatch, addr, dir, added)
+	}
+	return nil
+}
+
+// applyRuleLog applies a change of the event check rules. Removed rules are
+// replaced by the last one, as the contract does.
+func applyRuleLog(rules []*addressListRule, rule *addressListRule, name string) ([]*addressListRule, error) {
+	pos := -1
+	for i, r := range rules {
+		if r.Sig == rule.Sig && r.Index.Cmp(rule.Index) == 0 {
+			pos = i
+			break
+		}
+	}
+	switch {
+	case name == "RuleAdded" && pos < 0:
+		return append(rules, rule), nil
+	case name == "RuleUpdated" && pos >= 0:
+		rules[pos] = rule
+		return rules, nil
+	case name == "RuleRemoved" && pos >= 0:
+		rules[pos] = rules[len(rules)-1]
+		return rules[:len(rules)-1], nil
+	}
+	return nil, fmt.Errorf("%w: %s %x index %v", errAddressListMismatch, name, rule.Sig, rule.Index)
+}
+
+// matches returns whether the list is the content of the contract after the given
+// changes, read back from its storage. Only the lengths of the arrays and the
+// positions of the changed entries are checked: every changed address must be
+// listed in the arrays of its directions only, and every changed rule must be at
+// its place in the rules, or nowhere if removed.
+func (l *addressList) matches(state consensus.StateReader, changes *addressListChanges) bool {
+	var froms, tos uint64
+	for _, dir := range l.blacks {
+		if dir != DirectionTo {
+			froms++
+		}
+		if dir != DirectionFrom {
+			tos++
+		}
+	}
+	get := func(slot common.Hash) common.Hash {
+		return state.GetState(systemcontract.AddressListContractAddr, slot)
+	}
+	length := func(slot common.Hash) uint64 {
+		return get(slot).Big().Uint64()
+	}
+	if froms != length(systemcontract.BlacksFromPosition) ||
+		tos != length(systemcontract.BlacksToPosition) ||
+		uint64(len(l.rules)) != length(systemcontract.RulesPosition) {
+		return false
+	}
+	// The maps of the contract store the index in the array plus one, zero if absent
+	for addr := range changes.blacks {
+		dir, listed := l.blacks[addr]
+		for _, array := range []struct {
+			slot, index common.Hash
+			size        uint64
+			exclude     blacklistDirection
+		}{
+			{systemcontract.BlacksFromPosition, mappingSlot(systemcontract.BlacksFromMapPosition, addr.Hash()), froms, DirectionTo},
+			{systemcontract.BlacksToPosition, mappingSlot(systemcontract.BlacksToMapPosition, addr.Hash()), tos, DirectionFrom},
+		} {
+			index := get(array.index).Big().Uint64()
+			if !listed || dir == array.exclude {
+				if index != 0 {
+					return false
+				}
+				continue
+			}
+			if index == 0 || index > array.size || common.BytesToAddress(get(arrayElementSlot(array.slot, index-1)).Bytes()) != addr {
+				return false
+			}
+		}
+	}
+	// A rule takes two slots, the event signature then the check index packed
+	// with the check type
+	positions := make(map[addressListRuleKey]int, len(l.rules))
+	for i, rule := range l.rules {
+		positions[rule.key()] = i
+	}
+	for key := range changes.rules {
+		index, _ := new(big.Int).SetString(key.index, 10)
+		stored := get(mappingSlot(mappingSlot(systemcontract.RulesMapPosition, key.sig), common.BigToHash(index))).Big().Uint64()
+
+		i, listed := positions[key]
+		if !listed {
+			if stored != 0 {
+				return false
+			}
+			continue
+		}
+		rule := l.rules[i]
+		packed := get(arrayElementSlot(systemcontract.RulesPosition, 2*uint64(i)+1))
+		if stored != uint64(i)+1 || get(arrayElementSlot(systemcontract.RulesPosition, 2*uint64(i))) != rule.Sig ||
+			new(big.Int).SetBytes(packed[16:]).Cmp(rule.Index) != 0 || packed[15] != rule.Check {
+			return false
+		}
+	}
+	return true
+}
+
+// mappingSlot returns the storage slot of the value of the key in a mapping whose
+// slot is given.
+func mappingSlot(slot common.Hash, key common.Hash) common.Hash {
+	return crypto.Keccak256Hash(key.Bytes(), slot.Bytes())
+}
+
+// arrayElementSlot returns the storage slot of the given word of the content of a
+// dynamic array, whose length is stored at slot.
+func arrayElementSlot(slot common.Hash, word uint64) common.Hash {
+	base := crypto.Keccak256Hash(slot.Bytes()).Big()
+	return common.BigToHash(base.Add(base, new(big.Int).SetUint64(word)))
+}
+
+// storedAddressList is the checkpoint of the AddressList contract content stored
+// in the database, after the last block changing it.
+type storedAddressList struct {
+	Number uint64
+	Hash   common.Hash
+	Blacks []storedBlack // Sorted by address
+	Rules  []*addressListRule
+}
+
+type storedBlack struct {
+	Address   common.Address
+	Direction uint8
+}
+
+// lastAddressListUpdate returns the last block changing the AddressList contract.
+func lastAddressListUpdate(state consensus.StateReader) uint64 {
+	if blacks, rules := lastBlacklistUpdatedNumber(state), lastRulesUpdatedNumber(state); blacks > rules {
+		return blacks
+	} else {
+		return rules
+	}
+}
+
+// getAddressList returns the content of the AddressList contract the given block
+// is validated against, i.e. after its parent. Once known, it's maintained from
+// the logs of the contract instead of being reloaded through contract calls.
+func (d *Dpos) getAddressList(header *types.Header, parentState *state.StateDB) (*addressList, error) {
+	if v, ok := d.addressLists.Get(header.ParentHash); ok {
+		return v.(*addressList), nil
+	}
+	d.alLock.Lock()
+	defer d.alLock.Unlock()
+
+	return d.addressListAfter(header.ParentHash, header.Number.Uint64()-1, parentState, true)
+}
+
+// addressListAfter returns the content of the AddressList contract after the given
+// block, whose post state is passed. The caller must hold Dpos.alLock.
+func (d *Dpos) addressListAfter(hash common.Hash, number uint64, postState *state.StateDB, recurse bool) (*addressList, error) {
+	if list := d.knownAddressList(hash); list != nil {
+		return list, nil
+	}
+	var (
+		updated = lastAddressListUpdate(postState)
+		list    *addressList
+	)
+	if updated < number {
+		// Not changed by the block, so the same as after the last block changing it
+		if ancestor, ok := d.ancestorHash(hash, number, updated); ok {
+			list = d.knownAddressList(ancestor)
+		}
+	} else if header := d.chain.GetHeader(hash, number); header != nil && number > 0 {
+		// Changed by the block, apply its logs to the content before it
+		prev := d.knownAddressList(header.ParentHash)
+		if prev == nil && recurse {
+			if parent := d.chain.GetHeader(header.ParentHash, number-1); parent != nil {
+				ctx, cancel := context.WithTimeout(context.Background(), stateRetrievalTimeout)
+				if parentState, err := d.stateAt(ctx, parent); err == nil {
+					prev, _ = d.addressListAfter(parent.Hash(), number-1, parentState, false)
+				}
+				cancel()
+			}
+		}
+		if receipts := rawdb.ReadRawReceipts(d.db, hash, number); prev != nil && receipts != nil {
+			var logs []*types.Log
+			for _, receipt := range receipts {
+				logs = append(logs, receipt.Logs...)
+			}
+			if next, changes, err := prev.apply(d, logs); err != nil {
+				log.Warn("Failed to apply address list logs", "number", number, "hash", hash, "err", err)
+			} else if !next.matches(postState, changes) {
+				log.Warn("Address list logs not matching the contract", "number", number, "hash", hash)
+			} else {
+				list = next
+				addressListUpdateMeter.Mark(1)
+			}
+		}
+	}
+	if list == nil {
+		var err error
+		if list, err = d.loadAddressList(hash, number, postState); err != nil {
+			return nil, err
+		}
+	}
+	d.addressLists.Add(hash, list)
+
+	// Checkpoint the content after the last canonical block changing it, which is
+	// all a restarted node needs
+	if ancestor, ok := d.ancestorHash(hash, number, updated); ok && rawdb.ReadCanonicalHash(d.db, updated) == ancestor {
+		d.storeAddressList(updated, ancestor, list)
+	}
+	return list, nil
+}
+
+// knownAddressList returns the content of the AddressList contract after the given
+// block if it's cached or checkpointed, nil otherwise.
+func (d *Dpos) knownAddressList(hash common.Hash) *addressList {
+	if v, ok := d.addressLists.Get(hash); ok {
+		return v.(*addressList)
+	}
+	blob := rawdb.ReadDposAddressList(d.db)
+	if _, stored, ok := checkpointHash(blob); !ok || stored != hash {
+		return nil
+	}
+	stored := new(storedAddressList)
+	if err := rlp.DecodeBytes(blob, stored); err != nil {
+		log.Warn("Failed to decode address list checkpoint", "err", err)
+		return nil
+	}
+	list := &addressList{
+		blacks: make(map[common.Address]blacklistDirection, len(stored.Blacks)),
+		rules:  stored.Rules,
+	}
+	for _, black := range stored.Blacks {
+		list.blacks[black.Address] = blacklistDirection(black.Direction)
+	}
+	d.addressLists.Add(hash, list)
+	return list
+}
+
+// storeAddressList checkpoints the content of the AddressList contract after the
+// given block, unless a later block is checkpointed already.
+func (d *Dpos) storeAddressList(number uint64, hash common.Hash, list *addressList) {
+	if last, lastHash, ok := checkpointHash(rawdb.ReadDposAddressList(d.db)); ok && (lastHash == hash || last > number) {
+		return
+	}
+	stored := &storedAddressList{Number: number, Hash: hash, Rules: list.rules}
+	for addr, dir := range list.blacks {
+		stored.Blacks = append(stored.Blacks, storedBlack{Address: addr, Direction: uint8(dir)})
+	}
+	sort.Slice(stored.Blacks, func(i, j int) bool {
+		return bytes.Compare(stored.Blacks[i].Address[:], stored.Blacks[j].Address[:]) < 0
+	})
+	blob, err := rlp.EncodeToBytes(stored)
+	if err != nil {
+		log.Error("Failed to encode address list checkpoint", "err", err)
+		return
+	}
+	rawdb.WriteDposAddressList(d.db, blob)
+}
+
+// checkpointHash returns the block of a stored checkpoint without decoding the
+// whole content, which is large for long lists.
+func checkpointHash(blob []byte) (uint64, common.Hash, bool) {
+	content, _, err := rlp.SplitList(blob)
+	if err != nil {
+		return 0, common.Hash{}, false
+	}
+	number, rest, err := rlp.SplitUint64(content)
+	if err != nil {
+		return 0, common.Hash{}, false
+	}
+	hash, _, err := rlp.SplitString(rest)
+	if err != nil || len(hash) != common.HashLength {
+		return 0, common.Hash{}, false
+	}
+	return number, common.BytesToHash(hash), true
+}
+
+// ancestorHash returns the hash of the ancestor of the given block at the target
+// number, looked up through the canonical chain, or the recent headers otherwise.
+func (d *Dpos) ancestorHash(hash common.Hash, number, target uint64) (common.Hash, bool) {
+	if target > number {
+		return common.Hash{}, false
+	}
+	if rawdb.ReadCanonicalHash(d.db, number) == hash {
+		ancestor := rawdb.ReadCanonicalHash(d.db, target)
+		return ancestor, ancestor != (common.Hash{})
+	}
+	for number-target <= inmemoryBlacklist {
+		if number == target {
+			return hash, true
+		}
+		header := d.chain.GetHeader(hash, number)
+		if header == nil {
+			break
+		}
+		hash, number = header.ParentHash, number-1
+	}
+	return common.Hash{}, false
+}
+
+// loadAddressList loads the content of the AddressList contract after the given
+// block through contract calls.
+func (d *Dpos) loadAddressList(hash common.Hash, number uint64, postState *state.StateDB) (*addressList, error) {
+	// The calls are made in the context of the child block
+	header := &types.Header{ParentHash: hash, Number: new(big.Int).SetUint64(number + 1), Difficulty: new(big.Int)}
+	blacks, err := d.loadBlacklist(header, postState)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := d.loadRules(header, postState)
+	if err != nil {
+		return nil, err
+	}
+	addressListReloadMeter.Mark(1)
+	return &addressList{blacks: blacks, rules: rules}, nil
+}
+
+// loadBlacklist loads the blacklist of the AddressList contract through contract calls.
+func (d *Dpos) loadBlacklist(header *types.Header, parentState *state.StateDB) (map[common.Address]blacklistDirection, error) {
+	alABI := d.abi[systemcontract.AddressListContractName]
+	get := func(method string) ([]common.Address, error) {
+		ret, err := d.commonCallContract(header, parentState, alABI, systemcontract.AddressListContractAddr, method, 1)
+		if err != nil {
+			log.Error(fmt.Sprintf("%s failed", method), "err", err)
+			return nil, err
+		}
+
+		blacks, ok := ret[0].([]common.Address)
+		if !ok {
+			return []common.Address{}, errors.New("invalid blacklist format")
+		}
+		return blacks, nil
+	}
+	froms, err := get("getBlacksFrom")
+	if err != nil {
+		return nil, err
+	}
+	tos, err := get("getBlacksTo")
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[common.Address]blacklistDirection)
+	for _, from := range froms {
+		m[from] = DirectionFrom
+	}
+	for _, to := range tos {
+		if _, exist := m[to]; exist {
+			m[to] = DirectionBoth
+		} else {
+			m[to] = DirectionTo
+		}
+	}
+	return m, nil
+}
+
+// loadRules loads the event check rules of the AddressList contract through
+// contract calls, in the order of the contract.
+func (d *Dpos) loadRules(header *types.Header, parentState *state.StateDB) ([]*addressListRule, error) {
+	alABI := d.abi[systemcontract.AddressListContractName]
+	method := "getRuleByIndex"
+	get := func(i uint32) (*addressListRule, error) {
+		ret, err := d.commonCallContract(header, parentState, alABI, systemcontract.AddressListContractAddr, method, 3, i)
+		if err != nil {
+			return nil, err
+		}
+		sig := ret[0].([32]byte)
+		idx := ret[1].(*big.Int)
+		ct := ret[2].(uint8)
+
+		return &addressListRule{Sig: sig, Index: idx, Check: ct}, nil
+	}
+
+	cnt, err := d.getEventCheckRulesLen(header, parentState)
+	if err != nil {
+		log.Error("getEventCheckRulesLen failed", "err", err)
+		return nil, err
+	}
+	rules := make([]*addressListRule, 0, cnt)
+	for i := 0; i < cnt; i++ {
+		rule, err := get(uint32(i))
+		if err != nil {
+			log.Error("getRuleByIndex failed", "index", i, "number", header.Number, "parentHash", header.ParentHash, "err", err)
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
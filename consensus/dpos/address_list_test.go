@@ -0,0 +1,244 @@
+package dpos
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+)
+
+func TestAddressListLogs(t *testing.T) {
+	var (
+		d     = &Dpos{abi: systemcontract.GetInteractiveABI()}
+		alABI = d.abi[systemcontract.AddressListContractName]
+		addr1 = common.HexToAddress("0x01")
+		addr2 = common.HexToAddress("0x02")
+		sig1  = common.HexToHash("0x11")
+		sig2  = common.HexToHash("0x12")
+	)
+	newLog := func(name string, topic common.Hash, args ...interface{}) *types.Log {
+		event := alABI.Events[name]
+		data, err := event.Inputs.NonIndexed().Pack(args...)
+		if err != nil {
+			t.Fatalf("failed to pack %s: %v", name, err)
+		}
+		return &types.Log{Address: systemcontract.AddressListContractAddr, Topics: []common.Hash{event.ID, topic}, Data: data}
+	}
+	black := func(name string, addr common.Address, dir blacklistDirection) *types.Log {
+		return newLog(name, addr.Hash(), uint8(dir))
+	}
+	rule := func(name string, sig common.Hash, idx int64, check common.AddressCheckType) *types.Log {
+		return newLog(name, sig, big.NewInt(idx), uint8(check))
+	}
+	prev := &addressList{blacks: map[common.Address]blacklistDirection{addr1: DirectionFrom}}
+	list, changes, err := prev.apply(d, []*types.Log{
+		black("BlackAddrAdded", addr1, DirectionTo),
+		black("BlackAddrAdded", addr2, DirectionBoth),
+		black("BlackAddrRemoved", addr2, DirectionFrom),
+		rule("RuleAdded", sig1, 1, common.CheckFrom),
+		rule("RuleAdded", sig1, 2, common.CheckTo),
+		rule("RuleAdded", sig2, 1, common.CheckBothInAny),
+		rule("RuleRemoved", sig1, 1, common.CheckFrom),
+		rule("RuleUpdated", sig1, 2, common.CheckBothInAny),
+		{Address: common.HexToAddress("0xdead"), Topics: []common.Hash{alABI.Events["RuleAdded"].ID, sig2}}, // Other contracts are ignored
+	})
+	if err != nil {
+		t.Fatalf("failed to apply logs: %v", err)
+	}
+	if len(prev.blacks) != 1 || prev.blacks[addr1] != DirectionFrom || len(prev.rules) != 0 {
+		t.Errorf("previous list modified: %v %v", prev.blacks, prev.rules)
+	}
+	if len(list.blacks) != 2 || list.blacks[addr1] != DirectionBoth || list.blacks[addr2] != DirectionTo {
+		t.Errorf("blacklist mismatch: %v", list.blacks)
+	}
+	// The removed rule is replaced by the last one, as the contract does
+	if len(list.rules) != 2 || list.rules[0].Sig != sig2 || list.rules[1].Sig != sig1 || list.rules[1].Check != uint8(common.CheckBothInAny) {
+		t.Errorf("rules mismatch: %+v %+v", list.rules[0], list.rules[1])
+	}
+	rules := list.eventCheckRules(false)
+	if len(rules) != 2 || rules[sig1].Checks[2] != common.CheckBothInAny || rules[sig2].Checks[1] != common.CheckBothInAny {
+		t.Errorf("decoded rules mismatch: %v", rules)
+	}
+	// Logs not matching the known content are rejected
+	for i, logs := range [][]*types.Log{
+		{black("BlackAddrAdded", addr1, DirectionFrom)},
+		{black("BlackAddrRemoved", addr2, DirectionFrom)},
+		{rule("RuleAdded", sig2, 1, common.CheckTo)},
+		{rule("RuleRemoved", sig2, 2, common.CheckTo)},
+	} {
+		if _, _, err := list.apply(d, logs); err == nil {
+			t.Errorf("test %d: mismatching logs applied", i)
+		}
+	}
+	// The changed entries are checked against the storage of the contract
+	newState := func() *state.StateDB {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		return statedb
+	}
+	statedb := newState()
+	writeAddressListStorage(statedb, []common.Address{addr1}, []common.Address{addr2, addr1}, list.rules)
+	if !list.matches(statedb, changes) {
+		t.Errorf("list not matching the contract")
+	}
+	changed := *list.rules[1]
+	changed.Check = uint8(common.CheckTo)
+	for i, tt := range []struct {
+		froms, tos []common.Address
+		rules      []*addressListRule
+	}{
+		{[]common.Address{addr2}, []common.Address{addr2, addr1}, list.rules},
+		{[]common.Address{addr1}, []common.Address{addr2, addr2}, list.rules},
+		{[]common.Address{addr1, addr2}, []common.Address{addr2, addr1}, list.rules},
+		{[]common.Address{addr1}, []common.Address{addr2, addr1}, []*addressListRule{list.rules[1], list.rules[0]}},
+		{[]common.Address{addr1}, []common.Address{addr2, addr1}, []*addressListRule{list.rules[0], &changed}},
+	} {
+		statedb := newState()
+		if writeAddressListStorage(statedb, tt.froms, tt.tos, tt.rules); list.matches(statedb, changes) {
+			t.Errorf("test %d: list matching a different contract content", i)
+		}
+	}
+	// Removed rules must be gone from the map of the contract too
+	statedb = newState()
+	writeAddressListStorage(statedb, []common.Address{addr1}, []common.Address{addr2, addr1}, list.rules)
+	statedb.SetState(systemcontract.AddressListContractAddr, mappingSlot(mappingSlot(systemcontract.RulesMapPosition, sig1), common.BigToHash(big.NewInt(1))), common.BigToHash(common.Big1))
+	if list.matches(statedb, changes) {
+		t.Errorf("list matching a contract keeping a removed rule")
+	}
+	// Entries not named by the logs are not read back, only the array lengths
+	statedb = newState()
+	writeAddressListStorage(statedb, []common.Address{addr2}, []common.Address{addr2, addr2}, []*addressListRule{list.rules[1], list.rules[0]})
+	if !list.matches(statedb, &addressListChanges{}) {
+		t.Errorf("unchanged entries read back")
+	}
+}
+
+// writeAddressListStorage lays out the given content in the storage of the
+// AddressList contract, as the contract would.
+func writeAddressListStorage(statedb *state.StateDB, froms, tos []common.Address, rules []*addressListRule) {
+	set := func(slot common.Hash, value common.Hash) {
+		statedb.SetState(systemcontract.AddressListContractAddr, slot, value)
+	}
+	for _, array := range []struct {
+		slot, index common.Hash
+		addrs       []common.Address
+	}{
+		{systemcontract.BlacksFromPosition, systemcontract.BlacksFromMapPosition, froms},
+		{systemcontract.BlacksToPosition, systemcontract.BlacksToMapPosition, tos},
+	} {
+		set(array.slot, common.BigToHash(big.NewInt(int64(len(array.addrs)))))
+		for i, addr := range array.addrs {
+			set(arrayElementSlot(array.slot, uint64(i)), addr.Hash())
+			set(mappingSlot(array.index, addr.Hash()), common.BigToHash(big.NewInt(int64(i+1))))
+		}
+	}
+	set(systemcontract.RulesPosition, common.BigToHash(big.NewInt(int64(len(rules)))))
+	for i, rule := range rules {
+		packed := common.BigToHash(rule.Index)
+		packed[15] = rule.Check
+		set(arrayElementSlot(systemcontract.RulesPosition, 2*uint64(i)), rule.Sig)
+		set(arrayElementSlot(systemcontract.RulesPosition, 2*uint64(i)+1), packed)
+		set(mappingSlot(mappingSlot(systemcontract.RulesMapPosition, rule.Sig), common.BigToHash(rule.Index)), common.BigToHash(big.NewInt(int64(i+1))))
+	}
+}
+
+// Tests that the storage layout read back by matches is the one of the contract.
+func TestAddressListStorageLayout(t *testing.T) {
+	maker, _ := newTestStateChainMaker(t, 1, 100)
+	genesis := maker.genesis()
+	statedb := maker.state(genesis)
+
+	var (
+		addr1 = common.HexToAddress("0x01")
+		addr2 = common.HexToAddress("0x02")
+		rules = []*addressListRule{
+			{Sig: common.HexToHash("0x11"), Index: big.NewInt(2), Check: uint8(common.CheckTo)},
+			{Sig: common.HexToHash("0x12"), Index: big.NewInt(1), Check: uint8(common.CheckBothInAny)},
+		}
+	)
+	writeAddressListStorage(statedb, []common.Address{addr1}, []common.Address{addr2, addr1}, rules)
+	list, err := maker.engine.loadAddressList(genesis.Hash(), 0, statedb)
+	if err != nil {
+		t.Fatalf("failed to load address list: %v", err)
+	}
+	if len(list.blacks) != 2 || list.blacks[addr1] != DirectionBoth || list.blacks[addr2] != DirectionTo || len(list.rules) != 2 {
+		t.Fatalf("loaded list mismatch: %v %v", list.blacks, list.rules)
+	}
+	for i, rule := range rules {
+		if have := list.rules[i]; have.Sig != rule.Sig || have.Index.Cmp(rule.Index) != 0 || have.Check != rule.Check {
+			t.Errorf("rule %d mismatch: have %+v, want %+v", i, have, rule)
+		}
+	}
+	changes := &addressListChanges{
+		blacks: map[common.Address]struct{}{addr1: {}, addr2: {}, common.HexToAddress("0x03"): {}},
+		rules:  map[addressListRuleKey]struct{}{rules[0].key(): {}, rules[1].key(): {}},
+	}
+	if !list.matches(statedb, changes) {
+		t.Errorf("loaded list not matching the contract storage")
+	}
+	// The contract finds the entries through the maps matches reads
+	header := &types.Header{ParentHash: genesis.Hash(), Number: big.NewInt(1), Difficulty: new(big.Int)}
+	lookups, _ := abi.JSON(strings.NewReader(`[
+		{"inputs":[{"name":"addr","type":"address"}],"name":"isBlackAddress","outputs":[{"name":"","type":"bool"},{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+		{"inputs":[{"name":"sig","type":"bytes32"},{"name":"index","type":"uint128"}],"name":"getRuleByKey","outputs":[{"name":"","type":"bytes32"},{"name":"","type":"uint128"},{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"}
+	]`))
+	for addr, want := range map[common.Address]blacklistDirection{addr1: DirectionBoth, addr2: DirectionTo} {
+		ret, err := maker.engine.commonCallContract(header, statedb, lookups, systemcontract.AddressListContractAddr, "isBlackAddress", 2, addr)
+		if err != nil {
+			t.Fatalf("failed to look %x up: %v", addr, err)
+		}
+		if !ret[0].(bool) || blacklistDirection(ret[1].(uint8)) != want {
+			t.Errorf("%x lookup mismatch: have %v, want %v", addr, ret, want)
+		}
+	}
+	for i, rule := range rules {
+		ret, err := maker.engine.commonCallContract(header, statedb, lookups, systemcontract.AddressListContractAddr, "getRuleByKey", 3, rule.Sig, rule.Index)
+		if err != nil {
+			t.Fatalf("failed to look rule %d up: %v", i, err)
+		}
+		if ret[0].([32]byte) != rule.Sig || ret[1].(*big.Int).Cmp(rule.Index) != 0 || ret[2].(uint8) != rule.Check {
+			t.Errorf("rule %d lookup mismatch: have %v", i, ret)
+		}
+	}
+}
+
+func TestAddressListCheckpoint(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	newEngine := func() *Dpos {
+		lists, _ := lru.New(inmemoryBlacklist)
+		return &Dpos{db: db, addressLists: lists}
+	}
+	var (
+		hash1 = common.HexToHash("0x01")
+		hash2 = common.HexToHash("0x02")
+		list  = &addressList{
+			blacks: map[common.Address]blacklistDirection{common.HexToAddress("0x01"): DirectionTo, common.HexToAddress("0x02"): DirectionBoth},
+			rules:  []*addressListRule{{Sig: common.HexToHash("0x11"), Index: big.NewInt(1), Check: uint8(common.CheckFrom)}},
+		}
+	)
+	d := newEngine()
+	d.storeAddressList(2, hash2, list)
+	d.storeAddressList(1, hash1, &addressList{}) // Older than the checkpoint
+
+	// A restarted engine finds the content without calling the contract
+	d = newEngine()
+	if d.knownAddressList(hash1) != nil {
+		t.Fatalf("stale checkpoint stored")
+	}
+	stored := d.knownAddressList(hash2)
+	if stored == nil {
+		t.Fatalf("checkpoint not found")
+	}
+	if len(stored.blacks) != 2 || stored.blacks[common.HexToAddress("0x02")] != DirectionBoth {
+		t.Errorf("checkpointed blacklist mismatch: %v", stored.blacks)
+	}
+	if len(stored.rules) != 1 || stored.rules[0].Sig != list.rules[0].Sig || stored.rules[0].Index.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("checkpointed rules mismatch: %v", stored.rules)
+	}
+}
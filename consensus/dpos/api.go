@@ -17,13 +17,18 @@
 package dpos
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/common/hexutil"
+	"github.com/hypnosisfoundation/go-hypnosis/common/math"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
 	"github.com/hypnosisfoundation/go-hypnosis/core/state"
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
 	"github.com/hypnosisfoundation/go-hypnosis/rpc"
 	"math/big"
 )
@@ -49,29 +54,49 @@ type ProposalInfo struct {
 	Status      uint8
 }
 
-func (api *API) GetHeaderAndState(number *rpc.BlockNumber) (*types.Header, *state.StateDB, error) {
-	var header *types.Header
-	if number == nil || *number == rpc.LatestBlockNumber {
-		header = api.chain.CurrentHeader()
-	} else {
-		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+// finalityHeaderReader is implemented by chains tracking the blocks justified and
+// finalized by the finality gadget.
+type finalityHeaderReader interface {
+	CurrentSafeBlock() *types.Header
+	CurrentFinalizedBlock() *types.Header
+}
+
+// headerByNumber retrieves the requested block (or the current one if none is
+// requested), resolving the safe and finalized tags to the blocks justified and
+// finalized by the finality gadget.
+func (api *API) headerByNumber(number *rpc.BlockNumber) *types.Header {
+	switch {
+	case number == nil || *number == rpc.LatestBlockNumber:
+		return api.chain.CurrentHeader()
+	case *number == rpc.SafeBlockNumber || *number == rpc.FinalizedBlockNumber:
+		chain, ok := api.chain.(finalityHeaderReader)
+		if !ok {
+			return nil
+		}
+		if *number == rpc.SafeBlockNumber {
+			return chain.CurrentSafeBlock()
+		}
+		return chain.CurrentFinalizedBlock()
+	case *number < 0:
+		return nil
+	default:
+		return api.chain.GetHeaderByNumber(uint64(number.Int64()))
 	}
+}
+
+func (api *API) GetHeaderAndState(ctx context.Context, number *rpc.BlockNumber) (*types.Header, *state.StateDB, error) {
+	header := api.headerByNumber(number)
 	if header == nil {
 		return nil, nil, errUnknownBlock
 	}
-	statedb, err := api.dpos.stateFn(header.Root)
+	statedb, err := api.dpos.stateAt(ctx, header)
 	return header, statedb, err
 }
 
 // GetSnapshot retrieves the state snapshot at a given block.
 func (api *API) GetSnapshot(number *rpc.BlockNumber) (*Snapshot, error) {
 	// Retrieve the requested block number (or current if none requested)
-	var header *types.Header
-	if number == nil || *number == rpc.LatestBlockNumber {
-		header = api.chain.CurrentHeader()
-	} else {
-		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
-	}
+	header := api.headerByNumber(number)
 	// Ensure we have an actually valid block and return its snapshot
 	if header == nil {
 		return nil, errUnknownBlock
@@ -88,15 +113,22 @@ func (api *API) GetSnapshotAtHash(hash common.Hash) (*Snapshot, error) {
 	return api.dpos.snapshot(api.chain, header.Number.Uint64(), header.Hash(), nil)
 }
 
+// GetLiveness retrieves the downtime records of the validators at the specified block.
+func (api *API) GetLiveness(number *rpc.BlockNumber) (map[common.Address]*Liveness, error) {
+	if api.dpos.config.Jail == nil {
+		return nil, errJailDisabled
+	}
+	snap, err := api.GetSnapshot(number)
+	if err != nil {
+		return nil, err
+	}
+	return snap.liveness(), nil
+}
+
 // GetValidators retrieves the list of authorized validators at the specified block.
 func (api *API) GetValidators(number *rpc.BlockNumber) ([]common.Address, error) {
 	// Retrieve the requested block number (or current if none requested)
-	var header *types.Header
-	if number == nil || *number == rpc.LatestBlockNumber {
-		header = api.chain.CurrentHeader()
-	} else {
-		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
-	}
+	header := api.headerByNumber(number)
 	// Ensure we have an actually valid block and return the validators from its snapshot
 	if header == nil {
 		return nil, errUnknownBlock
@@ -121,9 +153,9 @@ func (api *API) GetValidatorsAtHash(hash common.Hash) ([]common.Address, error)
 	return snap.validators(), nil
 }
 
-func (api *API) GetBaseInfos(number *rpc.BlockNumber) (map[string]interface{}, error) {
+func (api *API) GetBaseInfos(ctx context.Context, number *rpc.BlockNumber) (map[string]interface{}, error) {
 	base := systemcontract.NewBase()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return map[string]interface{}{}, err
 	}
@@ -135,9 +167,9 @@ func (api *API) GetBaseInfos(number *rpc.BlockNumber) (map[string]interface{}, e
 }
 
 // GetValidator return the validator of address
-func (api *API) GetValidator(addr common.Address, number *rpc.BlockNumber) (*systemcontract.Validator, error) {
+func (api *API) GetValidator(ctx context.Context, addr common.Address, number *rpc.BlockNumber) (*systemcontract.Validator, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return &systemcontract.Validator{}, err
 	}
@@ -149,9 +181,9 @@ func (api *API) GetValidator(addr common.Address, number *rpc.BlockNumber) (*sys
 }
 
 // GetTotalDeposit return total deposit
-func (api *API) GetTotalDeposit(number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) GetTotalDeposit(ctx context.Context, number *rpc.BlockNumber) (*big.Int, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -163,9 +195,9 @@ func (api *API) GetTotalDeposit(number *rpc.BlockNumber) (*big.Int, error) {
 }
 
 // GetTotalVotes return total votes
-func (api *API) GetTotalVotes(number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) GetTotalVotes(ctx context.Context, number *rpc.BlockNumber) (*big.Int, error) {
 	nodeVotes := systemcontract.NewNodeVotes()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -177,9 +209,9 @@ func (api *API) GetTotalVotes(number *rpc.BlockNumber) (*big.Int, error) {
 }
 
 // GetCurrentEpochValidators return current epoch validators
-func (api *API) GetCurrentEpochValidators(number *rpc.BlockNumber) ([]common.Address, error) {
+func (api *API) GetCurrentEpochValidators(ctx context.Context, number *rpc.BlockNumber) ([]common.Address, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []common.Address{}, err
 	}
@@ -191,9 +223,9 @@ func (api *API) GetCurrentEpochValidators(number *rpc.BlockNumber) ([]common.Add
 }
 
 // GetEffictiveValidators return all effictive validators
-func (api *API) GetEffictiveValidators(number *rpc.BlockNumber) ([]common.Address, error) {
+func (api *API) GetEffictiveValidators(ctx context.Context, number *rpc.BlockNumber) ([]common.Address, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []common.Address{}, err
 	}
@@ -229,9 +261,9 @@ func (api *API) GetEffictiveValidators(number *rpc.BlockNumber) ([]common.Addres
 }
 
 // GetInvalidValidators return all invalid validators
-func (api *API) GetInvalidValidators(number *rpc.BlockNumber) ([]common.Address, error) {
+func (api *API) GetInvalidValidators(ctx context.Context, number *rpc.BlockNumber) ([]common.Address, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []common.Address{}, err
 	}
@@ -267,9 +299,9 @@ func (api *API) GetInvalidValidators(number *rpc.BlockNumber) ([]common.Address,
 }
 
 // GetCancelQueueValidators return all canceling queue validators
-func (api *API) GetCancelQueueValidators(number *rpc.BlockNumber) ([]common.Address, error) {
+func (api *API) GetCancelQueueValidators(ctx context.Context, number *rpc.BlockNumber) ([]common.Address, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []common.Address{}, err
 	}
@@ -281,9 +313,9 @@ func (api *API) GetCancelQueueValidators(number *rpc.BlockNumber) ([]common.Addr
 }
 
 // GetValidatorVoters return the address voter
-func (api *API) GetValidatorVoters(addr common.Address, number *rpc.BlockNumber) ([]common.Address, error) {
+func (api *API) GetValidatorVoters(ctx context.Context, addr common.Address, number *rpc.BlockNumber) ([]common.Address, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []common.Address{}, err
 	}
@@ -319,9 +351,9 @@ func (api *API) GetValidatorVoters(addr common.Address, number *rpc.BlockNumber)
 }
 
 // EffictiveValsLength return effictive validators length
-func (api *API) EffictiveValsLength(number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) EffictiveValsLength(ctx context.Context, number *rpc.BlockNumber) (*big.Int, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -333,9 +365,9 @@ func (api *API) EffictiveValsLength(number *rpc.BlockNumber) (*big.Int, error) {
 }
 
 // InvalidValsLength return invalid validators length
-func (api *API) InvalidValsLength(number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) InvalidValsLength(ctx context.Context, number *rpc.BlockNumber) (*big.Int, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -347,9 +379,9 @@ func (api *API) InvalidValsLength(number *rpc.BlockNumber) (*big.Int, error) {
 }
 
 // CancelQueueValidatorsLength return cancel queue validators length
-func (api *API) CancelQueueValidatorsLength(number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) CancelQueueValidatorsLength(ctx context.Context, number *rpc.BlockNumber) (*big.Int, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -361,9 +393,9 @@ func (api *API) CancelQueueValidatorsLength(number *rpc.BlockNumber) (*big.Int,
 }
 
 // ValidatorVotersLength return the validator voters length
-func (api *API) ValidatorVotersLength(addr common.Address, number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) ValidatorVotersLength(ctx context.Context, addr common.Address, number *rpc.BlockNumber) (*big.Int, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -375,9 +407,9 @@ func (api *API) ValidatorVotersLength(addr common.Address, number *rpc.BlockNumb
 }
 
 // IsEffictiveValidator return the address is validator
-func (api *API) IsEffictiveValidator(addr common.Address, number *rpc.BlockNumber) (bool, error) {
+func (api *API) IsEffictiveValidator(ctx context.Context, addr common.Address, number *rpc.BlockNumber) (bool, error) {
 	validators := systemcontract.NewValidators()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return false, err
 	}
@@ -391,9 +423,9 @@ func (api *API) IsEffictiveValidator(addr common.Address, number *rpc.BlockNumbe
 // Proposals
 
 // GetAddressProposalSets return the address proposal id
-func (api *API) GetAddressProposalSets(addr common.Address, number *rpc.BlockNumber) ([]string, error) {
+func (api *API) GetAddressProposalSets(ctx context.Context, addr common.Address, number *rpc.BlockNumber) ([]string, error) {
 	proposals := systemcontract.NewProposals()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []string{}, err
 	}
@@ -433,9 +465,9 @@ func (api *API) GetAddressProposalSets(addr common.Address, number *rpc.BlockNum
 }
 
 // GetAllProposalSets return all proposals id
-func (api *API) GetAllProposalSets(number *rpc.BlockNumber) ([]string, error) {
+func (api *API) GetAllProposalSets(ctx context.Context, number *rpc.BlockNumber) ([]string, error) {
 	proposals := systemcontract.NewProposals()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []string{}, err
 	}
@@ -477,9 +509,9 @@ func (api *API) GetAllProposalSets(number *rpc.BlockNumber) ([]string, error) {
 }
 
 // GetAllProposals return all proposals
-func (api *API) GetAllProposals(number *rpc.BlockNumber) ([]ProposalInfo, error) {
+func (api *API) GetAllProposals(ctx context.Context, number *rpc.BlockNumber) ([]ProposalInfo, error) {
 	proposals := systemcontract.NewProposals()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []ProposalInfo{}, err
 	}
@@ -533,9 +565,9 @@ func (api *API) GetAllProposals(number *rpc.BlockNumber) ([]ProposalInfo, error)
 }
 
 // GetProposal return the proposal of id
-func (api *API) GetProposal(id string, number *rpc.BlockNumber) (*ProposalInfo, error) {
+func (api *API) GetProposal(ctx context.Context, id string, number *rpc.BlockNumber) (*ProposalInfo, error) {
 	proposals := systemcontract.NewProposals()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return &ProposalInfo{}, err
 	}
@@ -560,9 +592,9 @@ func (api *API) GetProposal(id string, number *rpc.BlockNumber) (*ProposalInfo,
 }
 
 // GetAddressProposals return the address proposals
-func (api *API) GetAddressProposals(addr common.Address, number *rpc.BlockNumber) ([]ProposalInfo, error) {
+func (api *API) GetAddressProposals(ctx context.Context, addr common.Address, number *rpc.BlockNumber) ([]ProposalInfo, error) {
 	proposals := systemcontract.NewProposals()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []ProposalInfo{}, err
 	}
@@ -615,9 +647,9 @@ func (api *API) GetAddressProposals(addr common.Address, number *rpc.BlockNumber
 }
 
 // GetProposalCount return all proposal count
-func (api *API) GetProposalCount(number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) GetProposalCount(ctx context.Context, number *rpc.BlockNumber) (*big.Int, error) {
 	proposals := systemcontract.NewProposals()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -629,9 +661,9 @@ func (api *API) GetProposalCount(number *rpc.BlockNumber) (*big.Int, error) {
 }
 
 // GetAddressProposalCount return the address proposal count
-func (api *API) GetAddressProposalCount(addr common.Address, number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) GetAddressProposalCount(ctx context.Context, addr common.Address, number *rpc.BlockNumber) (*big.Int, error) {
 	proposals := systemcontract.NewProposals()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -645,9 +677,9 @@ func (api *API) GetAddressProposalCount(addr common.Address, number *rpc.BlockNu
 // NodeVotes
 
 // PendingVoteReward return the voter vote the validator rewards
-func (api *API) PendingVoteReward(val common.Address, voter common.Address, number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) PendingVoteReward(ctx context.Context, val common.Address, voter common.Address, number *rpc.BlockNumber) (*big.Int, error) {
 	nodeVotes := systemcontract.NewNodeVotes()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -659,9 +691,9 @@ func (api *API) PendingVoteReward(val common.Address, voter common.Address, numb
 }
 
 // PendingVoteRedeem return the voter redeem validators voters
-func (api *API) PendingVoteRedeem(val common.Address, voter common.Address, number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) PendingVoteRedeem(ctx context.Context, val common.Address, voter common.Address, number *rpc.BlockNumber) (*big.Int, error) {
 	nodeVotes := systemcontract.NewNodeVotes()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -673,9 +705,9 @@ func (api *API) PendingVoteRedeem(val common.Address, voter common.Address, numb
 }
 
 // VoteListLength return the voter vote list length
-func (api *API) VoteListLength(addr common.Address, number *rpc.BlockNumber) (*big.Int, error) {
+func (api *API) VoteListLength(ctx context.Context, addr common.Address, number *rpc.BlockNumber) (*big.Int, error) {
 	nodeVotes := systemcontract.NewNodeVotes()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return big.NewInt(0), err
 	}
@@ -687,9 +719,9 @@ func (api *API) VoteListLength(addr common.Address, number *rpc.BlockNumber) (*b
 }
 
 // VotesRewardRedeemInfo votesRewardRedeemInfo
-func (api *API) VotesRewardRedeemInfo(val common.Address, voter common.Address, number *rpc.BlockNumber) (*systemcontract.VotesRewardRedeemInfo, error) {
+func (api *API) VotesRewardRedeemInfo(ctx context.Context, val common.Address, voter common.Address, number *rpc.BlockNumber) (*systemcontract.VotesRewardRedeemInfo, error) {
 	nodeVotes := systemcontract.NewNodeVotes()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return &systemcontract.VotesRewardRedeemInfo{}, err
 	}
@@ -702,9 +734,9 @@ func (api *API) VotesRewardRedeemInfo(val common.Address, voter common.Address,
 }
 
 // VotesRewardRedeemInfos nodevotes.VotesRewardRedeemInfos
-func (api *API) VotesRewardRedeemInfos(voter common.Address, number *rpc.BlockNumber) ([]systemcontract.VotesRewardRedeemInfo, error) {
+func (api *API) VotesRewardRedeemInfos(ctx context.Context, voter common.Address, number *rpc.BlockNumber) ([]systemcontract.VotesRewardRedeemInfo, error) {
 	nodeVotes := systemcontract.NewNodeVotes()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []systemcontract.VotesRewardRedeemInfo{}, err
 	}
@@ -753,9 +785,9 @@ type SysRewardsInfo struct {
 }
 
 // EpochInfo return the epoch info
-func (api *API) EpochInfo(epoch *big.Int, number *rpc.BlockNumber) (*systemcontract.EpochInfo, error) {
+func (api *API) EpochInfo(ctx context.Context, epoch *big.Int, number *rpc.BlockNumber) (*systemcontract.EpochInfo, error) {
 	systemRewards := systemcontract.NewSystemRewards()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return &systemcontract.EpochInfo{}, err
 	}
@@ -767,9 +799,9 @@ func (api *API) EpochInfo(epoch *big.Int, number *rpc.BlockNumber) (*systemcontr
 }
 
 // KickoutInfo return kickout addresses in epoch
-func (api *API) KickoutInfo(epoch *big.Int, number *rpc.BlockNumber) ([]common.Address, error) {
+func (api *API) KickoutInfo(ctx context.Context, epoch *big.Int, number *rpc.BlockNumber) ([]common.Address, error) {
 	systemRewards := systemcontract.NewSystemRewards()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return []common.Address{}, err
 	}
@@ -781,9 +813,9 @@ func (api *API) KickoutInfo(epoch *big.Int, number *rpc.BlockNumber) ([]common.A
 }
 
 // ValidatorRewardsInfo return the sys reward info
-func (api *API) ValidatorRewardsInfo(addr common.Address, number *rpc.BlockNumber) (*SysRewardsInfo, error) {
+func (api *API) ValidatorRewardsInfo(ctx context.Context, addr common.Address, number *rpc.BlockNumber) (*SysRewardsInfo, error) {
 	systemRewards := systemcontract.NewSystemRewards()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return &SysRewardsInfo{}, err
 	}
@@ -808,9 +840,9 @@ func (api *API) ValidatorRewardsInfo(addr common.Address, number *rpc.BlockNumbe
 }
 
 // ValidatorRewardInfoByEpoch return the address and the epoch reward info
-func (api *API) ValidatorRewardInfoByEpoch(addr common.Address, epoch *big.Int, number *rpc.BlockNumber) (*systemcontract.Reward, error) {
+func (api *API) ValidatorRewardInfoByEpoch(ctx context.Context, addr common.Address, epoch *big.Int, number *rpc.BlockNumber) (*systemcontract.Reward, error) {
 	systemRewards := systemcontract.NewSystemRewards()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return &systemcontract.Reward{}, err
 	}
@@ -822,9 +854,9 @@ func (api *API) ValidatorRewardInfoByEpoch(addr common.Address, epoch *big.Int,
 }
 
 // PendingValidatorReward return the address reward
-func (api *API) PendingValidatorReward(addr common.Address, number *rpc.BlockNumber) (map[string]*big.Int, error) {
+func (api *API) PendingValidatorReward(ctx context.Context, addr common.Address, number *rpc.BlockNumber) (map[string]*big.Int, error) {
 	systemRewards := systemcontract.NewSystemRewards()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return map[string]*big.Int{}, err
 	}
@@ -839,9 +871,9 @@ func (api *API) PendingValidatorReward(addr common.Address, number *rpc.BlockNum
 }
 
 // PunishInfo punishInfo function of systemRewards contract
-func (api *API) PunishInfo(addr common.Address, epoch *big.Int, number *rpc.BlockNumber) (*systemcontract.Punish, error) {
+func (api *API) PunishInfo(ctx context.Context, addr common.Address, epoch *big.Int, number *rpc.BlockNumber) (*systemcontract.Punish, error) {
 	systemRewards := systemcontract.NewSystemRewards()
-	header, statedb, err := api.GetHeaderAndState(number)
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
 	if err != nil {
 		return &systemcontract.Punish{}, err
 	}
@@ -853,6 +885,252 @@ func (api *API) PunishInfo(addr common.Address, epoch *big.Int, number *rpc.Bloc
 	return punish, nil
 }
 
+// SubmitAttestation adds an attestation signed by a validator to the finality gadget.
+func (api *API) SubmitAttestation(att Attestation) error {
+	return api.dpos.SubmitAttestation(&att)
+}
+
+// GetAttestations returns the latest attestation of each validator known by the
+// finality gadget.
+func (api *API) GetAttestations() (map[common.Address]*Attestation, error) {
+	api.dpos.lock.RLock()
+	f := api.dpos.finality
+	api.dpos.lock.RUnlock()
+
+	if f == nil {
+		return nil, errFinalityNotRunning
+	}
+	return f.latestAttestations(), nil
+}
+
+// Attestations creates a subscription that is triggered each time an attestation
+// is accepted by the finality gadget, allowing validators to relay them.
+func (api *API) Attestations(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		atts := make(chan *Attestation, finalityHeadChanSize)
+		sub, err := api.dpos.SubscribeAttestations(atts)
+		if err != nil {
+			return
+		}
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case att := <-atts:
+				notifier.Notify(rpcSub.ID, att)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// GetQueuedProposals returns the passed governance proposals waiting to be executed
+// at the given block, along with the first block each of them can be executed in.
+func (api *API) GetQueuedProposals(ctx context.Context, number *rpc.BlockNumber) ([]*QueuedProposal, error) {
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return api.dpos.queuedProposals(api.chain, header, statedb)
+}
+
+// ScreenAddress returns the blacklist and governance rules which would deny the
+// address in the block after the given one, and the topics of the logs in which
+// the event check rules would deny it.
+func (api *API) ScreenAddress(ctx context.Context, addr common.Address, number *rpc.BlockNumber) (*AddressScreening, error) {
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return api.dpos.screenAddress(header, statedb, addr)
+}
+
+// ScreenTx executes the signed transaction on top of the given block and returns
+// every blacklist, governance and event check rule it, its internal calls or its
+// logs would hit.
+func (api *API) ScreenTx(ctx context.Context, input hexutil.Bytes, number *rpc.BlockNumber) (*TxScreening, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return nil, err
+	}
+	header, statedb, err := api.GetHeaderAndState(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return api.dpos.screenTx(api.chain, header, statedb, tx)
+}
+
+// RewardDistributionInfo is the block reward split in force at a given block.
+type RewardDistributionInfo struct {
+	Block          *big.Int                 `json:"block"`
+	Recipients     []params.RewardRecipient `json:"recipients"`
+	Burn           uint64                   `json:"burn"`
+	ValidatorShare uint64                   `json:"validatorShare"`
+}
+
+// GetRewardDistribution returns the block reward split in force at the given block,
+// with all shares in basis points.
+func (api *API) GetRewardDistribution(number *rpc.BlockNumber) (*RewardDistributionInfo, error) {
+	header := api.headerByNumber(number)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	dist := api.dpos.rewardDistribution(header.Number)
+	return &RewardDistributionInfo{
+		Block:          dist.Block,
+		Recipients:     dist.Recipients,
+		Burn:           dist.Burn,
+		ValidatorShare: dist.ValidatorShare(),
+	}, nil
+}
+
+// FeeAccounting is the breakdown of the transaction fees paid in a block: the base
+// fees burnt, the priority fees tipped to the validators' reward pool, and the base
+// fees redistributed to the recipient of the base fee policy.
+type FeeAccounting struct {
+	Number        uint64          `json:"number"`
+	BaseFee       *big.Int        `json:"baseFee"`
+	Policy        string          `json:"policy"`
+	Burned        *big.Int        `json:"burned"`
+	Tipped        *big.Int        `json:"tipped"`
+	Redistributed *big.Int        `json:"redistributed"`
+	Recipient     *common.Address `json:"recipient,omitempty"`
+}
+
+// blockReceiptReader is implemented by chains keeping full blocks and their receipts.
+type blockReceiptReader interface {
+	GetBlock(hash common.Hash, number uint64) *types.Block
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
+
+// GetFeeAccounting breaks down the transaction fees paid in the given block according
+// to the base fee policy in force at the block.
+func (api *API) GetFeeAccounting(number *rpc.BlockNumber) (*FeeAccounting, error) {
+	header := api.headerByNumber(number)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	chain, ok := api.chain.(blockReceiptReader)
+	if !ok {
+		return nil, errors.New("fee accounting requires a chain with block bodies and receipts")
+	}
+	block := chain.GetBlock(header.Hash(), header.Number.Uint64())
+	receipts := chain.GetReceiptsByHash(header.Hash())
+	if block == nil || len(receipts) != len(block.Transactions()) {
+		return nil, errUnknownBlock
+	}
+	return feeAccounting(api.dpos.chainConfig, block, receipts), nil
+}
+
+// feeAccounting breaks down the fees paid by the transactions of a block.
+func feeAccounting(config *params.ChainConfig, block *types.Block, receipts types.Receipts) *FeeAccounting {
+	policy := config.BaseFeePolicyAt(block.Number())
+	accounting := &FeeAccounting{
+		Number:        block.NumberU64(),
+		BaseFee:       new(big.Int),
+		Policy:        policy.Mode,
+		Burned:        new(big.Int),
+		Tipped:        new(big.Int),
+		Redistributed: new(big.Int),
+		Recipient:     core.BaseFeeRecipient(config, block.Number()),
+	}
+	if block.BaseFee() != nil {
+		accounting.BaseFee.Set(block.BaseFee())
+	}
+	baseFees := new(big.Int)
+	for i, tx := range block.Transactions() {
+		// System transactions neither use gas nor pay any fees
+		price := tx.GasPrice()
+		if block.BaseFee() != nil {
+			price = math.BigMin(new(big.Int).Add(tx.GasTipCap(), block.BaseFee()), tx.GasFeeCap())
+		}
+		baseFee := math.BigMin(accounting.BaseFee, price)
+		gasUsed := new(big.Int).SetUint64(receipts[i].GasUsed)
+
+		baseFees.Add(baseFees, new(big.Int).Mul(baseFee, gasUsed))
+		accounting.Tipped.Add(accounting.Tipped, new(big.Int).Mul(new(big.Int).Sub(price, baseFee), gasUsed))
+	}
+	if accounting.Recipient == nil {
+		accounting.Burned = baseFees
+	} else {
+		accounting.Redistributed = baseFees
+	}
+	return accounting
+}
+
+// EvidenceInfo is a double-sign evidence known by the node, along with the block in
+// which its offender was slashed, zero if it wasn't slashed yet.
+type EvidenceInfo struct {
+	*EvidenceRecord
+	SlashedAt uint64 `json:"slashedAt"`
+}
+
+// SubmitDoubleSignEvidence queues a double-sign evidence detected elsewhere to be
+// included into the next block sealed by the local validator.
+func (api *API) SubmitDoubleSignEvidence(evidence DoubleSignEvidence) (common.Hash, error) {
+	if err := api.dpos.addEvidence(&evidence); err != nil {
+		return common.Hash{}, err
+	}
+	return evidence.Hash(), nil
+}
+
+// SubmitUnjail queues the signed unjail request of a jailed validator to be carried
+// into the next block sealed by the local validator.
+func (api *API) SubmitUnjail(input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.dpos.SubmitUnjail(tx); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// GetDoubleSignEvidences returns the double-sign evidences detected by the node or
+// included in imported blocks, with their slashing status at the given block.
+func (api *API) GetDoubleSignEvidences(ctx context.Context, number *rpc.BlockNumber) ([]*EvidenceInfo, error) {
+	_, statedb, err := api.GetHeaderAndState(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	records, err := loadEvidenceRecords(api.dpos.db)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*EvidenceInfo, 0, len(records))
+	for _, record := range records {
+		infos = append(infos, &EvidenceInfo{EvidenceRecord: record, SlashedAt: slashedAt(statedb, record.Offender, record.Number)})
+	}
+	return infos, nil
+}
+
+// GetDoubleSignEvidence returns a double-sign evidence by its hash, with its
+// slashing status at the given block.
+func (api *API) GetDoubleSignEvidence(ctx context.Context, hash common.Hash, number *rpc.BlockNumber) (*EvidenceInfo, error) {
+	_, statedb, err := api.GetHeaderAndState(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	record, err := loadEvidenceRecord(api.dpos.db, hash)
+	if err != nil {
+		return nil, fmt.Errorf("unknown double-sign evidence %x", hash)
+	}
+	return &EvidenceInfo{EvidenceRecord: record, SlashedAt: slashedAt(statedb, record.Offender, record.Number)}, nil
+}
+
 type status struct {
 	InturnPercent float64                `json:"inturnPercent"`
 	SigningStatus map[common.Address]int `json:"sealerActivity"`
@@ -908,3 +1186,38 @@ func (api *API) Status() (*status, error) {
 		NumBlocks:     numBlocks,
 	}, nil
 }
+
+// GetValidatorActivity returns the activity of the validators within the given
+// blocks, one record per epoch: the blocks sealed in and out of turn, the missed
+// in-turn slots and the punished ones. Long ranges require the activity index.
+func (api *API) GetValidatorActivity(from, to rpc.BlockNumber) ([]*EpochActivity, error) {
+	head := api.chain.CurrentHeader().Number.Uint64()
+	resolve := func(number rpc.BlockNumber) (uint64, error) {
+		if number == rpc.SafeBlockNumber || number == rpc.FinalizedBlockNumber {
+			header := api.headerByNumber(&number)
+			if header == nil {
+				return 0, errUnknownBlock
+			}
+			return header.Number.Uint64(), nil
+		}
+		if number < 0 {
+			return head, nil
+		}
+		if uint64(number) > head {
+			return 0, errUnknownBlock
+		}
+		return uint64(number), nil
+	}
+	first, err := resolve(from)
+	if err != nil {
+		return nil, err
+	}
+	last, err := resolve(to)
+	if err != nil {
+		return nil, err
+	}
+	if first > last {
+		return nil, fmt.Errorf("invalid block range %d-%d", first, last)
+	}
+	return api.dpos.validatorActivity(api.chain, first, last)
+}
@@ -0,0 +1,179 @@
+package dpos
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/misc"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+)
+
+// AddressScreening lists the rules which would deny an address.
+type AddressScreening struct {
+	Address common.Address `json:"address"`
+	Denied  bool           `json:"denied"`
+	Rules   []*DenyRule    `json:"rules"` // Every rule denying the address, including the event check rules
+}
+
+// TxScreening lists the rules which would deny a transaction, or its internal
+// calls and logs.
+type TxScreening struct {
+	Hash   common.Hash `json:"hash"`
+	Denied bool        `json:"denied"`
+	Rules  []*DenyRule `json:"rules"`           // Rules hit by the transaction, in execution order
+	Error  string      `json:"error,omitempty"` // Why the transaction couldn't be executed, if it couldn't
+}
+
+// screeningValidator is a blacklistValidator recording the rules of its denials.
+type screeningValidator struct {
+	*blacklistValidator
+	hits []*DenyRule
+}
+
+func (s *screeningValidator) IsAddressDenied(address common.Address, cType common.AddressCheckType) bool {
+	return s.record(s.addressDenyRule(address, cType))
+}
+
+func (s *screeningValidator) IsLogDenied(evLog *types.Log) bool {
+	return s.record(s.logDenyRule(evLog))
+}
+
+func (s *screeningValidator) record(hit *DenyRule) bool {
+	if hit == nil {
+		return false
+	}
+	s.hits = append(s.hits, hit)
+	return true
+}
+
+// newScreeningValidator returns a validator enforcing the same rules as the
+// producer of the given header would, and whether the rules also apply to the
+// internal calls and logs of the transactions.
+func (d *Dpos) newScreeningValidator(header *types.Header, parentState *state.StateDB) (*screeningValidator, bool, error) {
+	var (
+		validator = new(blacklistValidator)
+		sophon    = d.chainConfig.SophonBlock != nil && d.chainConfig.SophonBlock.Cmp(header.Number) < 0
+		err       error
+	)
+	if d.chainConfig.RedCoastBlock != nil && d.chainConfig.RedCoastBlock.Cmp(header.Number) < 0 {
+		if validator.blacks, err = d.getBlacklist(header, parentState); err != nil {
+			return nil, false, err
+		}
+	}
+	if sophon {
+		if validator.rules, err = d.getEventCheckRules(header, parentState); err != nil {
+			return nil, false, err
+		}
+	}
+	governance := d.chainConfig.IsGovernanceActions(header.Number)
+	if governance {
+		validator.frozen = parentState
+	}
+	return &screeningValidator{blacklistValidator: validator, hits: make([]*DenyRule, 0)}, sophon || governance, nil
+}
+
+// screenHeader returns the header of the block after parent, in which the
+// screened addresses and transactions are checked.
+func (d *Dpos) screenHeader(parent *types.Header) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Coinbase:   parent.Coinbase,
+		Difficulty: new(big.Int).Set(parent.Difficulty),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + d.config.Period,
+	}
+	if d.chainConfig.IsLondon(header.Number) {
+		header.BaseFee = misc.CalcBaseFee(d.chainConfig, parent)
+	}
+	return header
+}
+
+// screenAddress returns the rules which would deny the address in the block after
+// parent: directly as a sender or recipient, or as a topic of the logs matched by
+// the event check rules.
+func (d *Dpos) screenAddress(parent *types.Header, parentState *state.StateDB, addr common.Address) (*AddressScreening, error) {
+	validator, _, err := d.newScreeningValidator(d.screenHeader(parent), parentState)
+	if err != nil {
+		return nil, err
+	}
+	validator.IsAddressDenied(addr, common.CheckFrom)
+	validator.IsAddressDenied(addr, common.CheckTo)
+
+	sigs := make([]common.Hash, 0, len(validator.rules))
+	for sig := range validator.rules {
+		sigs = append(sigs, sig)
+	}
+	sort.Slice(sigs, func(i, j int) bool { return bytes.Compare(sigs[i][:], sigs[j][:]) < 0 })
+	for _, sig := range sigs {
+		rule := validator.rules[sig]
+		for _, idx := range sortedChecks(rule.Checks) {
+			if hit := validator.addressDenyRule(addr, rule.Checks[idx]); hit != nil {
+				sig, index := rule.EventSig, idx
+				hit.EventSig, hit.TopicIndex = &sig, &index
+				validator.record(hit)
+			}
+		}
+		for i, checks := range []map[int]common.AddressCheckType{rule.DataChecks, rule.ArrayChecks} {
+			for _, word := range sortedChecks(checks) {
+				if hit := validator.addressDenyRule(addr, checks[word]); hit != nil {
+					sig, offset := rule.EventSig, word
+					hit.EventSig, hit.DataWord, hit.Array = &sig, &offset, i == 1
+					validator.record(hit)
+				}
+			}
+		}
+	}
+	return &AddressScreening{Address: addr, Denied: len(validator.hits) > 0, Rules: validator.hits}, nil
+}
+
+// sortedChecks returns the positions of the checks of an event check rule in order.
+func sortedChecks(checks map[int]common.AddressCheckType) []int {
+	positions := make([]int, 0, len(checks))
+	for pos := range checks {
+		positions = append(positions, pos)
+	}
+	sort.Ints(positions)
+	return positions
+}
+
+// screenTx executes the transaction on a copy of the parent state, as the producer
+// of the next block would, and returns the rules it hits. Unlike the block producer,
+// it doesn't stop at the first denial.
+func (d *Dpos) screenTx(chain consensus.ChainHeaderReader, parent *types.Header, parentState *state.StateDB, tx *types.Transaction) (*TxScreening, error) {
+	header := d.screenHeader(parent)
+	sender, err := types.Sender(types.MakeSigner(d.chainConfig, header.Number), tx)
+	if err != nil {
+		return nil, err
+	}
+	statedb := parentState.Copy()
+	validator, evm, err := d.newScreeningValidator(header, statedb)
+	if err != nil {
+		return nil, err
+	}
+	screening := &TxScreening{Hash: tx.Hash()}
+
+	// The sender and recipient are checked before execution, by ValidateTx
+	validator.IsAddressDenied(sender, common.CheckFrom)
+	if to := tx.To(); to != nil {
+		validator.IsAddressDenied(*to, common.CheckTo)
+	}
+	if evm {
+		// Before Sophon, only the frozen accounts are checked during the execution
+		if d.chainConfig.SophonBlock == nil || d.chainConfig.SophonBlock.Cmp(header.Number) >= 0 {
+			validator.blacks = nil
+		}
+		gp := new(core.GasPool).AddGas(header.GasLimit)
+		if _, err := core.ApplyTransaction(d.chainConfig, newChainContext(chain, d), &header.Coinbase, gp, statedb, header, tx, new(uint64), vm.Config{NoBaseFee: true}, validator); err != nil {
+			screening.Error = err.Error()
+		}
+	}
+	screening.Denied, screening.Rules = len(validator.hits) > 0, validator.hits
+	return screening, nil
+}
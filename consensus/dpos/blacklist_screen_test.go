@@ -0,0 +1,61 @@
+package dpos
+
+import (
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+)
+
+func TestDenyRules(t *testing.T) {
+	var (
+		sender   = common.HexToAddress("0x01")
+		receiver = common.HexToAddress("0x02")
+		frozen   = common.HexToAddress("0x03")
+		token    = common.HexToAddress("0x1000")
+		transfer = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	setFrozen(statedb, frozen, true)
+
+	validator := &screeningValidator{blacklistValidator: &blacklistValidator{
+		blacks: map[common.Address]blacklistDirection{sender: DirectionFrom, receiver: DirectionTo},
+		rules: map[common.Hash]*EventCheckRule{
+			transfer: {EventSig: transfer, Checks: map[int]common.AddressCheckType{1: common.CheckFrom, 2: common.CheckTo}},
+		},
+		frozen: statedb,
+	}}
+	// Directions not blacklisted are not recorded
+	if validator.IsAddressDenied(sender, common.CheckTo) || validator.IsAddressDenied(receiver, common.CheckFrom) || len(validator.hits) != 0 {
+		t.Fatalf("address denied in a direction not blacklisted")
+	}
+	if !validator.IsAddressDenied(sender, common.CheckFrom) || !validator.IsAddressDenied(frozen, common.CheckTo) {
+		t.Fatalf("blacklisted addresses not denied")
+	}
+	// A transfer to the blacklisted receiver is denied by its third topic
+	evLog := &types.Log{Address: token, Topics: []common.Hash{transfer, common.HexToHash("0x04"), receiver.Hash()}}
+	if !validator.IsLogDenied(evLog) {
+		t.Fatalf("log not denied")
+	}
+	if validator.IsLogDenied(&types.Log{Address: token, Topics: []common.Hash{transfer, receiver.Hash(), sender.Hash()}}) {
+		t.Fatalf("log denied for addresses blacklisted in other directions")
+	}
+	if len(validator.hits) != 3 {
+		t.Fatalf("recorded rule count mismatch: have %d, want 3", len(validator.hits))
+	}
+	if hit := validator.hits[0]; hit.Address != sender || hit.Check != "from" || hit.Direction != "from" || hit.Frozen {
+		t.Errorf("blacklist rule mismatch: %+v", hit)
+	}
+	if hit := validator.hits[1]; hit.Address != frozen || hit.Check != "to" || hit.Direction != "" || !hit.Frozen {
+		t.Errorf("frozen rule mismatch: %+v", hit)
+	}
+	hit := validator.hits[2]
+	if hit.Address != receiver || hit.Direction != "to" || hit.EventSig == nil || *hit.EventSig != transfer {
+		t.Errorf("event rule mismatch: %+v", hit)
+	}
+	if hit.TopicIndex == nil || *hit.TopicIndex != 2 || hit.Contract == nil || *hit.Contract != token {
+		t.Errorf("event rule topic mismatch: %+v", hit)
+	}
+}
@@ -1,59 +1,226 @@
 package dpos
 
 import (
+	"math"
+	"math/big"
+
 	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
 )
 
+// Bits of the index of an event check rule read from the AddressList contract.
+// Before the EventDataRules fork the index is a topic index. After it, the low 64
+// bits are a position, and the bits above them tell where the address is found.
+const (
+	ruleIndexData  = 64 // The position is the offset, in 32 bytes words, of an address in the log data
+	ruleIndexArray = 65 // With ruleIndexData, the data word is the ABI offset of an address array instead
+)
+
 type EventCheckRule struct {
-	EventSig common.Hash
-	Checks   map[int]common.AddressCheckType
+	EventSig    common.Hash
+	Checks      map[int]common.AddressCheckType // Checks of the log topics, by topic index
+	DataChecks  map[int]common.AddressCheckType // Checks of the addresses in the log data, by word offset
+	ArrayChecks map[int]common.AddressCheckType // Checks of the address arrays in the log data, by word offset of their ABI offset
+}
+
+// addCheck adds the check of a rule read from the AddressList contract, decoding
+// its index as the given fork dictates. Unknown encodings are skipped, as every
+// node skips them alike.
+func (r *EventCheckRule) addCheck(index *big.Int, checkType common.AddressCheckType, dataRules bool) {
+	if !dataRules {
+		r.Checks[int(index.Uint64())] = checkType
+		return
+	}
+	position := new(big.Int).SetUint64(index.Uint64()) // low 64 bits
+	flags := new(big.Int).Rsh(index, ruleIndexData)
+	if !position.IsInt64() || position.Int64() > math.MaxInt32 || flags.BitLen() > ruleIndexArray-ruleIndexData+1 {
+		log.Warn("Skipping event check rule with unknown index", "sig", r.EventSig, "index", index)
+		return
+	}
+	pos := int(position.Int64())
+	switch {
+	case index.Bit(ruleIndexArray) == 1 && index.Bit(ruleIndexData) == 1:
+		if r.ArrayChecks == nil {
+			r.ArrayChecks = make(map[int]common.AddressCheckType)
+		}
+		r.ArrayChecks[pos] = checkType
+	case index.Bit(ruleIndexData) == 1:
+		if r.DataChecks == nil {
+			r.DataChecks = make(map[int]common.AddressCheckType)
+		}
+		r.DataChecks[pos] = checkType
+	case index.Bit(ruleIndexArray) == 1:
+		log.Warn("Skipping event check rule with unknown index", "sig", r.EventSig, "index", index)
+	default:
+		r.Checks[pos] = checkType
+	}
+}
+
+// dataAddress returns the address ABI encoded in the given 32 bytes word of data.
+func dataAddress(data []byte, word int) (common.Address, bool) {
+	start := uint64(word) * common.HashLength
+	if start+common.HashLength > uint64(len(data)) {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(data[start : start+common.HashLength]), true
+}
+
+// dataAddresses returns the address array ABI encoded in data, whose offset is in
+// the given 32 bytes word of data.
+func dataAddresses(data []byte, word int) ([]common.Address, bool) {
+	start := uint64(word) * common.HashLength
+	if start+common.HashLength > uint64(len(data)) {
+		return nil, false
+	}
+	offset := new(big.Int).SetBytes(data[start : start+common.HashLength])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(data))-common.HashLength {
+		return nil, false
+	}
+	elems := data[offset.Uint64()+common.HashLength:]
+	length := new(big.Int).SetBytes(data[offset.Uint64() : offset.Uint64()+common.HashLength])
+	if !length.IsUint64() || length.Uint64() > uint64(len(elems))/common.HashLength {
+		return nil, false
+	}
+	addrs := make([]common.Address, length.Uint64())
+	for i := range addrs {
+		addrs[i] = common.BytesToAddress(elems[i*common.HashLength : (i+1)*common.HashLength])
+	}
+	return addrs, true
+}
+
+// DenyRule explains which rule denies an address: its governance freeze, its
+// blacklisted direction, or an event check rule matching a topic of a log.
+type DenyRule struct {
+	Address    common.Address  `json:"address"`
+	Check      string          `json:"check"`                // Direction the address is checked in: from, to or any
+	Frozen     bool            `json:"frozen,omitempty"`     // Whether the address is frozen by governance
+	Direction  string          `json:"direction,omitempty"`  // Blacklisted direction of the address
+	EventSig   *common.Hash    `json:"eventSig,omitempty"`   // Signature of the event check rule
+	TopicIndex *int            `json:"topicIndex,omitempty"` // Index of the log topic holding the address
+	DataWord   *int            `json:"dataWord,omitempty"`   // Offset in words of the log data holding the address, or the offset of its array
+	Array      bool            `json:"array,omitempty"`      // Whether the address is in an array of the log data
+	ArrayIndex *int            `json:"arrayIndex,omitempty"` // Index of the address in its array
+	Contract   *common.Address `json:"contract,omitempty"`   // Contract emitting the denied log
+}
+
+// checkTypeName returns the name of an address check type used by DenyRule.
+func checkTypeName(cType common.AddressCheckType) string {
+	switch cType {
+	case common.CheckFrom:
+		return "from"
+	case common.CheckTo:
+		return "to"
+	case common.CheckBothInAny:
+		return "any"
+	}
+	return "none"
 }
 
 type blacklistValidator struct {
 	blacks map[common.Address]blacklistDirection
 	rules  map[common.Hash]*EventCheckRule
+	frozen consensus.StateReader // State recording the frozen accounts, nil before the GovernanceActions fork
+}
+
+func (b *blacklistValidator) IsAddressDenied(address common.Address, cType common.AddressCheckType) bool {
+	return b.addressDenyRule(address, cType) != nil
 }
 
-func (b *blacklistValidator) IsAddressDenied(address common.Address, cType common.AddressCheckType) (hit bool) {
+func (b *blacklistValidator) IsLogDenied(evLog *types.Log) bool {
+	return b.logDenyRule(evLog) != nil
+}
+
+// addressDenyRule returns the rule denying the address in the given direction, or
+// nil if it isn't denied.
+func (b *blacklistValidator) addressDenyRule(address common.Address, cType common.AddressCheckType) *DenyRule {
+	// Frozen accounts are denied in both directions
+	if b.frozen != nil && isFrozen(b.frozen, address) {
+		log.Trace("Hit frozen account", "addr", address.String(), "checkType", cType)
+		return &DenyRule{Address: address, Check: checkTypeName(cType), Frozen: true}
+	}
 	d, exist := b.blacks[address]
-	if exist {
-		switch cType {
-		case common.CheckFrom:
-			hit = d != DirectionTo // equals to : d == DirectionFrom || d == DirectionBoth
-		case common.CheckTo:
-			hit = d != DirectionFrom
-		case common.CheckBothInAny:
-			hit = true
-		default:
-			log.Warn("blacklist, unsupported AddressCheckType", "type", cType)
-			// Unsupported value, not denied by default
-			hit = false
-		}
+	if !exist {
+		return nil
 	}
-	if hit {
-		log.Trace("Hit blacklist", "addr", address.String(), "direction", d, "checkType", cType)
+	var hit bool
+	switch cType {
+	case common.CheckFrom:
+		hit = d != DirectionTo // equals to : d == DirectionFrom || d == DirectionBoth
+	case common.CheckTo:
+		hit = d != DirectionFrom
+	case common.CheckBothInAny:
+		hit = true
+	default:
+		log.Warn("blacklist, unsupported AddressCheckType", "type", cType)
+		// Unsupported value, not denied by default
+		hit = false
 	}
-	return
+	if !hit {
+		return nil
+	}
+	log.Trace("Hit blacklist", "addr", address.String(), "direction", d, "checkType", cType)
+	return &DenyRule{Address: address, Check: checkTypeName(cType), Direction: d.String()}
 }
 
-func (b *blacklistValidator) IsLogDenied(evLog *types.Log) bool {
-	if nil == evLog || len(evLog.Topics) <= 1 {
-		return false
+// logDenyRule returns the rule denying the log, or nil if it isn't denied.
+func (b *blacklistValidator) logDenyRule(evLog *types.Log) *DenyRule {
+	if nil == evLog || len(evLog.Topics) == 0 {
+		return nil
 	}
-	if rule, exist := b.rules[evLog.Topics[0]]; exist {
+	rule, exist := b.rules[evLog.Topics[0]]
+	if !exist {
+		return nil
+	}
+	deny := func(addr common.Address, checkType common.AddressCheckType) *DenyRule {
+		hit := b.addressDenyRule(addr, checkType)
+		if hit != nil {
+			sig, contract := rule.EventSig, evLog.Address
+			hit.EventSig, hit.Contract = &sig, &contract
+		}
+		return hit
+	}
+	if len(evLog.Topics) > 1 {
 		for idx, checkType := range rule.Checks {
 			// do a basic check
 			if idx >= len(evLog.Topics) {
 				log.Error("check index in rule out to range", "sig", rule.EventSig.String(), "checkIdx", idx, "topicsLen", len(evLog.Topics))
 				continue
 			}
-			addr := common.BytesToAddress(evLog.Topics[idx].Bytes())
-			if b.IsAddressDenied(addr, checkType) {
-				return true
+			if hit := deny(common.BytesToAddress(evLog.Topics[idx].Bytes()), checkType); hit != nil {
+				index := idx
+				hit.TopicIndex = &index
+				return hit
+			}
+		}
+	}
+	// Logs of other events sharing the signature may not match the layout
+	for word, checkType := range rule.DataChecks {
+		addr, ok := dataAddress(evLog.Data, word)
+		if !ok {
+			log.Trace("check word in rule out of range", "sig", rule.EventSig.String(), "word", word, "dataLen", len(evLog.Data))
+			continue
+		}
+		if hit := deny(addr, checkType); hit != nil {
+			offset := word
+			hit.DataWord = &offset
+			return hit
+		}
+	}
+	for word, checkType := range rule.ArrayChecks {
+		addrs, ok := dataAddresses(evLog.Data, word)
+		if !ok {
+			log.Trace("check array in rule not decodable", "sig", rule.EventSig.String(), "word", word, "dataLen", len(evLog.Data))
+			continue
+		}
+		for i, addr := range addrs {
+			if hit := deny(addr, checkType); hit != nil {
+				offset, index := word, i
+				hit.DataWord, hit.Array, hit.ArrayIndex = &offset, true, &index
+				return hit
 			}
 		}
 	}
-	return false
+	return nil
 }
@@ -0,0 +1,108 @@
+package dpos
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+)
+
+func TestEventCheckRuleEncoding(t *testing.T) {
+	var (
+		dataFlag  = new(big.Int).Lsh(common.Big1, ruleIndexData)
+		arrayFlag = new(big.Int).Lsh(common.Big1, ruleIndexArray)
+		index     = func(pos int64, flags ...*big.Int) *big.Int {
+			idx := big.NewInt(pos)
+			for _, flag := range flags {
+				idx.Or(idx, flag)
+			}
+			return idx
+		}
+	)
+	newRule := func() *EventCheckRule {
+		return &EventCheckRule{Checks: make(map[int]common.AddressCheckType)}
+	}
+	// Before the fork, only the low 64 bits are used, as topic index
+	rule := newRule()
+	rule.addCheck(index(2, dataFlag), common.CheckTo, false)
+	if rule.Checks[2] != common.CheckTo || len(rule.DataChecks) != 0 {
+		t.Fatalf("legacy rule mismatch: %+v", rule)
+	}
+	rule = newRule()
+	rule.addCheck(index(1), common.CheckFrom, true)
+	rule.addCheck(index(3, dataFlag), common.CheckTo, true)
+	rule.addCheck(index(4, dataFlag, arrayFlag), common.CheckBothInAny, true)
+	rule.addCheck(index(5, arrayFlag), common.CheckTo, true)
+	rule.addCheck(index(6, new(big.Int).Lsh(common.Big1, ruleIndexArray+1)), common.CheckTo, true)
+	if len(rule.Checks) != 1 || rule.Checks[1] != common.CheckFrom {
+		t.Errorf("topic checks mismatch: %v", rule.Checks)
+	}
+	if len(rule.DataChecks) != 1 || rule.DataChecks[3] != common.CheckTo {
+		t.Errorf("data checks mismatch: %v", rule.DataChecks)
+	}
+	if len(rule.ArrayChecks) != 1 || rule.ArrayChecks[4] != common.CheckBothInAny {
+		t.Errorf("array checks mismatch: %v", rule.ArrayChecks)
+	}
+}
+
+func TestLogDataRules(t *testing.T) {
+	var (
+		denied = common.HexToAddress("0xdead")
+		other  = common.HexToAddress("0xbeef")
+		sig    = common.HexToHash("0x01")
+	)
+	validator := &blacklistValidator{
+		blacks: map[common.Address]blacklistDirection{denied: DirectionBoth},
+		rules: map[common.Hash]*EventCheckRule{
+			sig: {
+				EventSig:    sig,
+				Checks:      map[int]common.AddressCheckType{},
+				DataChecks:  map[int]common.AddressCheckType{1: common.CheckTo},
+				ArrayChecks: map[int]common.AddressCheckType{2: common.CheckFrom},
+			},
+		},
+	}
+	// words: amount, to, offset of the array, array length, array items
+	encode := func(to common.Address, offset int64, items ...common.Address) []byte {
+		data := append(common.BigToHash(big.NewInt(100)).Bytes(), to.Hash().Bytes()...)
+		data = append(data, common.BigToHash(big.NewInt(offset)).Bytes()...)
+		data = append(data, common.BigToHash(big.NewInt(int64(len(items)))).Bytes()...)
+		for _, item := range items {
+			data = append(data, item.Hash().Bytes()...)
+		}
+		return data
+	}
+	for i, tt := range []struct {
+		data   []byte
+		denied bool
+		word   int
+		index  int
+	}{
+		{data: encode(other, 96, other, other)},
+		{data: encode(denied, 96, other), denied: true, word: 1},
+		{data: encode(other, 96, other, denied), denied: true, word: 2, index: 1},
+		{data: encode(other, 96, other)[:96]},                       // Array cut off
+		{data: encode(other, 1<<40, denied)},                        // Array offset out of range
+		{data: encode(other, 96, denied)[:64]},                      // Array offset missing
+		{data: append(encode(other, 96, other), denied[:]...)},      // Length not covering the denied item
+		{data: encode(denied, 96)[:32]},                             // Address word missing
+		{data: encode(denied, 96), denied: true, word: 1},           // Empty array
+		{data: encode(other, 96, other, other, other, other)[:200]}, // Items cut off
+	} {
+		hit := validator.logDenyRule(&types.Log{Topics: []common.Hash{sig}, Data: tt.data})
+		if (hit != nil) != tt.denied {
+			t.Errorf("test %d: denial mismatch: have %v, want %v", i, hit != nil, tt.denied)
+			continue
+		}
+		if hit == nil {
+			continue
+		}
+		if hit.Address != denied || hit.DataWord == nil || *hit.DataWord != tt.word || hit.TopicIndex != nil {
+			t.Errorf("test %d: rule mismatch: %+v", i, hit)
+		}
+		if hit.Array != (tt.word == 2) || (hit.Array && *hit.ArrayIndex != tt.index) {
+			t.Errorf("test %d: array rule mismatch: %+v", i, hit)
+		}
+	}
+}
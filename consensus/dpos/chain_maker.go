@@ -0,0 +1,307 @@
+package dpos
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/misc"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"github.com/hypnosisfoundation/go-hypnosis/trie"
+)
+
+// errNoSealer is returned if no validator of the chain maker is allowed to seal a block.
+var errNoSealer = errors.New("no validator allowed to seal the block")
+
+// headerChain is an in-memory header chain with forks, whose canonical chain is
+// the last one generated on it.
+type headerChain struct {
+	config  *params.ChainConfig
+	headers map[common.Hash]*types.Header
+	canon   []*types.Header
+}
+
+func (c *headerChain) Config() *params.ChainConfig  { return c.config }
+func (c *headerChain) CurrentHeader() *types.Header { return c.canon[len(c.canon)-1] }
+func (c *headerChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.headers[hash]; header != nil && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+func (c *headerChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c.canon)) {
+		return nil
+	}
+	return c.canon[number]
+}
+func (c *headerChain) GetHeaderByHash(hash common.Hash) *types.Header { return c.headers[hash] }
+
+// setHead inserts the header and makes it the head of the canonical chain.
+func (c *headerChain) setHead(header *types.Header) {
+	c.headers[header.Hash()] = header
+
+	number := header.Number.Uint64()
+	c.canon = c.canon[:number]
+	for ; header != nil; header = c.headers[header.ParentHash] {
+		number = header.Number.Uint64()
+		if number < uint64(len(c.canon)) {
+			if c.canon[number] == header {
+				break
+			}
+			c.canon[number] = header
+		} else {
+			c.canon = append(c.canon, header)
+		}
+	}
+}
+
+// ChainMaker generates sealed dpos chains for tests, the way validators would
+// before the stake schedule: a block is sealed by its in-turn validator, or by
+// the next one which is online and didn't sign recently, and checkpoints list
+// the validators of the next epoch. On a genesis allocating the system contracts,
+// the engine also finalizes the blocks, running the system contracts, so that
+// they can be inserted into a blockchain.
+type ChainMaker struct {
+	engine  *Dpos
+	chain   *headerChain
+	keys    map[common.Address]*ecdsa.PrivateKey
+	offline map[common.Address]bool
+
+	database state.Database
+	states   map[common.Hash]common.Hash // State roots of the blocks, nil without a state
+}
+
+// BlockGen configures a block generated by the chain maker.
+type BlockGen struct {
+	header     *types.Header
+	sealer     common.Address       // Validator sealing the block, zero for the default one
+	validators []common.Address     // Validators listed by a checkpoint, nil for the current ones
+	systemTxs  []*types.Transaction // System transactions replayed when finalizing the block
+}
+
+// Number returns the number of the generated block.
+func (b *BlockGen) Number() uint64 { return b.header.Number.Uint64() }
+
+// SetSealer makes the validator seal the block, in turn or not.
+func (b *BlockGen) SetSealer(validator common.Address) { b.sealer = validator }
+
+// SetValidators sets the validators listed by a checkpoint block.
+func (b *BlockGen) SetValidators(validators []common.Address) { b.validators = validators }
+
+// AddSystemTx adds a system transaction to the block, as a governance proposal
+// or an evidence executed by its validator.
+func (b *BlockGen) AddSystemTx(tx *types.Transaction) { b.systemTxs = append(b.systemTxs, tx) }
+
+// NewChainMaker creates a chain maker on top of the genesis, with its own engine
+// and in-memory database, sealing the blocks with the given validator keys.
+func NewChainMaker(genesis *core.Genesis, keys ...*ecdsa.PrivateKey) *ChainMaker {
+	db := rawdb.NewMemoryDatabase()
+	maker := &ChainMaker{
+		engine:  New(genesis.Config, db),
+		chain:   &headerChain{config: genesis.Config, headers: make(map[common.Hash]*types.Header)},
+		keys:    make(map[common.Address]*ecdsa.PrivateKey),
+		offline: make(map[common.Address]bool),
+	}
+	for _, key := range keys {
+		maker.keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	if _, ok := genesis.Alloc[systemcontract.ValidatorsContractAddr]; ok {
+		maker.database = state.NewDatabase(db)
+		maker.states = make(map[common.Hash]common.Hash)
+	}
+	block := genesis.ToBlock(db)
+	if maker.states != nil {
+		maker.states[block.Hash()] = block.Root()
+		maker.engine.SetStateFn(func(root common.Hash) (*state.StateDB, error) {
+			return state.New(root, maker.database, nil)
+		})
+	}
+	maker.chain.setHead(block.Header())
+	maker.engine.SetChain(maker.chain)
+	return maker
+}
+
+// Engine returns the engine finalizing the blocks.
+func (m *ChainMaker) Engine() *Dpos { return m.engine }
+
+// Genesis returns the genesis header of the chain.
+func (m *ChainMaker) Genesis() *types.Header { return m.chain.canon[0] }
+
+// SetOffline makes a validator stop or resume sealing the blocks it would by default.
+func (m *ChainMaker) SetOffline(validator common.Address, offline bool) {
+	m.offline[validator] = offline
+}
+
+// Authorize authorizes the engine to seal blocks and sign system transactions
+// with the key of the validator.
+func (m *ChainMaker) Authorize(validator common.Address) {
+	key := m.keys[validator]
+	m.engine.Authorize(validator, func(account accounts.Account, mimeType string, message []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(message), key)
+	}, func(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+		return types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+	})
+}
+
+// Generate creates n blocks on top of the parent, calling gen to configure each
+// of them, and makes the last one the head of the chain.
+func (m *ChainMaker) Generate(parent *types.Header, n int, gen func(i int, b *BlockGen)) ([]*types.Block, error) {
+	blocks := make([]*types.Block, n)
+	for i := range blocks {
+		block, err := m.MakeBlock(parent, func(b *BlockGen) {
+			if gen != nil {
+				gen(i, b)
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", parent.Number.Uint64()+1, err)
+		}
+		m.chain.setHead(block.Header())
+		blocks[i], parent = block, block.Header()
+	}
+	return blocks, nil
+}
+
+// MakeBlock creates the sealed block after the parent as configured by gen,
+// without inserting it into the chain. On a chain with a state, it fails if the
+// engine rejects the block when finalizing it.
+func (m *ChainMaker) MakeBlock(parent *types.Header, gen func(b *BlockGen)) (*types.Block, error) {
+	config := m.chain.Config()
+	snap, err := m.engine.snapshot(m.chain, parent.Number.Uint64(), parent.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  types.CalcUncleHash(nil),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + config.Dpos.Period,
+		Extra:      make([]byte, extraVanity+extraSeal),
+	}
+	if config.IsLondon(header.Number) {
+		header.BaseFee = misc.CalcBaseFee(config, parent)
+	}
+	b := &BlockGen{header: header}
+	if gen != nil {
+		gen(b)
+	}
+	number := b.Number()
+	if number%config.Dpos.Epoch == 0 {
+		validators := b.validators
+		if validators == nil {
+			validators = snap.validators()
+		}
+		header.Extra = checkpointExtra(validators)
+	}
+	if b.sealer == (common.Address{}) {
+		if b.sealer = NextSealer(snap, number, m.online); b.sealer == (common.Address{}) {
+			return nil, errNoSealer
+		}
+	}
+	header.Coinbase = b.sealer
+	header.Difficulty = calcDifficulty(snap, b.sealer)
+
+	var (
+		txs      []*types.Transaction
+		receipts []*types.Receipt
+		root     common.Hash
+	)
+	if m.states != nil {
+		statedb, err := state.New(m.states[parent.Hash()], m.database, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := m.engine.PreHandle(m.chain, header, statedb); err != nil {
+			return nil, err
+		}
+		if err := m.engine.Finalize(m.chain, header, statedb, &txs, nil, &receipts, b.systemTxs); err != nil {
+			return nil, err
+		}
+		if root, err = statedb.Commit(config.IsEIP158(header.Number)); err != nil {
+			return nil, err
+		}
+	}
+	block := types.NewBlock(header, txs, nil, receipts, trie.NewStackTrie(nil))
+	header = block.Header()
+	if err := SealHeader(header, m.keys[b.sealer]); err != nil {
+		return nil, err
+	}
+	block = block.WithSeal(header)
+	if m.states != nil {
+		m.states[block.Hash()] = root
+	}
+	return block, nil
+}
+
+// State returns the state after the block.
+func (m *ChainMaker) State(header *types.Header) (*state.StateDB, error) {
+	return state.New(m.states[header.Hash()], m.database, nil)
+}
+
+// online reports whether the maker can seal blocks with the key of the validator.
+func (m *ChainMaker) online(validator common.Address) bool {
+	_, ok := m.keys[validator]
+	return ok && !m.offline[validator]
+}
+
+// checkpointExtra returns the extra-data of a checkpoint listing the validators.
+func checkpointExtra(validators []common.Address) []byte {
+	extra := make([]byte, extraVanity)
+	for _, validator := range newSnapshot(nil, &params.DposConfig{}, nil, 0, common.Hash{}, validators, nil).validators() {
+		extra = append(extra, validator.Bytes()...)
+	}
+	return append(extra, make([]byte, extraSeal)...)
+}
+
+// NextSealer returns the validator sealing the block after the snapshot by default:
+// the in-turn one, or the following one which is online and didn't sign recently.
+// The zero address is returned if no validator is allowed to seal it.
+func NextSealer(snap *Snapshot, number uint64, online func(validator common.Address) bool) common.Address {
+	var (
+		validators = snap.validators()
+		inturn     = snap.proposerSelector(number).Proposer(snap, number)
+		offset     int
+	)
+	for i, validator := range validators {
+		if validator == inturn {
+			offset = i
+		}
+	}
+	for i := range validators {
+		validator := validators[(offset+i)%len(validators)]
+		if !online(validator) {
+			continue
+		}
+		recent := false
+		for seen, signer := range snap.Recents {
+			if signer == validator && seen+snap.recentsLimit(validator, number) > number {
+				recent = true
+			}
+		}
+		if !recent {
+			return validator
+		}
+	}
+	return common.Address{}
+}
+
+// SealHeader signs the header with the key, which may not be the one of its coinbase.
+func SealHeader(header *types.Header, key *ecdsa.PrivateKey) error {
+	sig, err := crypto.Sign(SealHash(header).Bytes(), key)
+	if err != nil {
+		return err
+	}
+	copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+	return nil
+}
@@ -0,0 +1,107 @@
+package dpos
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+// testChainMaker is a chain maker failing the test on errors.
+type testChainMaker struct {
+	*ChainMaker
+	t *testing.T
+}
+
+// newTestChainMaker creates a header chain maker whose genesis lists n validators,
+// returned in their round-robin order.
+func newTestChainMaker(t *testing.T, n int, epoch uint64) (*testChainMaker, []common.Address) {
+	return newChainMaker(t, n, n, epoch, nil)
+}
+
+// newTestStateChainMaker creates a chain maker running the genesis system
+// contracts, with n validator keys of which the first one is the genesis
+// validator staking its initial deposit at block 1.
+func newTestStateChainMaker(t *testing.T, n int, epoch uint64) (*testChainMaker, []common.Address) {
+	return newChainMaker(t, n, 1, epoch, SystemContracts())
+}
+
+// newChainMaker creates a chain maker with n validator keys in their round-robin
+// order, the first genesis ones being listed by the genesis block, and the state
+// of the alloc if not nil.
+func newChainMaker(t *testing.T, n int, genesis int, epoch uint64, alloc core.GenesisAlloc) (*testChainMaker, []common.Address) {
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Period: 3, Epoch: epoch}
+
+	keys := make([]*ecdsa.PrivateKey, n)
+	validators := make([]common.Address, n)
+	for i := range validators {
+		keys[i], _ = crypto.GenerateKey()
+		validators[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	sort.Sort(validatorsAscending(validators))
+
+	spec := &core.Genesis{
+		Config:    &config,
+		GasLimit:  30_000_000,
+		ExtraData: checkpointExtra(validators[:genesis]),
+		Alloc:     alloc,
+	}
+	if alloc != nil {
+		account := alloc[validators[0]]
+		account.Balance = new(big.Int).Set(systemcontract.InitDeposit)
+		alloc[validators[0]] = account
+	}
+	return &testChainMaker{ChainMaker: NewChainMaker(spec, keys...), t: t}, validators
+}
+
+// genesis returns the genesis header of the chain.
+func (m *testChainMaker) genesis() *types.Header { return m.Genesis() }
+
+// generate creates n blocks on top of the parent, calling gen to configure each
+// of them, and makes the last one the head of the chain.
+func (m *testChainMaker) generate(parent *types.Header, n int, gen func(i int, b *BlockGen)) []*types.Header {
+	blocks, err := m.Generate(parent, n, gen)
+	if err != nil {
+		m.t.Fatalf("failed to generate chain: %v", err)
+	}
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = m.chain.headers[block.Hash()]
+	}
+	return headers
+}
+
+// makeBlock creates the header of the sealed block after the parent as configured
+// by gen, without inserting it into the chain.
+func (m *testChainMaker) makeBlock(parent *types.Header, gen func(b *BlockGen)) (*types.Header, error) {
+	block, err := m.MakeBlock(parent, gen)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+// seal signs the header with the key, which may not be the one of its coinbase.
+func (m *testChainMaker) seal(header *types.Header, key *ecdsa.PrivateKey) {
+	if err := SealHeader(header, key); err != nil {
+		m.t.Fatalf("failed to seal header: %v", err)
+	}
+}
+
+// state returns the state after the block.
+func (m *testChainMaker) state(header *types.Header) *state.StateDB {
+	statedb, err := m.State(header)
+	if err != nil {
+		m.t.Fatalf("failed to open state of block %d: %v", header.Number, err)
+	}
+	return statedb
+}
@@ -0,0 +1,34 @@
+package dpos
+
+import (
+	"math/big"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+)
+
+// developerEpoch is the epoch length of developer chains, short for the staking
+// changes to be elected quickly.
+const developerEpoch = 200
+
+// DeveloperGenesisBlock returns the 'geth --dev --dev.dpos' genesis block: a
+// chain following the mainnet rules with the system contracts, sealed by the
+// developer account as its only validator, which is pre-funded to stake its
+// initial deposit and more.
+func DeveloperGenesisBlock(period uint64, developer common.Address) *core.Genesis {
+	genesis, err := BuildGenesis(&GenesisSpec{
+		ChainID:  big.NewInt(1337),
+		Period:   period,
+		Epoch:    developerEpoch,
+		GasLimit: 11500000,
+		// Leave room in the balance for the rewards of the validator
+		Validators: []GenesisValidator{{Address: developer, Balance: new(big.Int).Lsh(big.NewInt(1), 255)}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	for i := byte(1); i <= 9; i++ {
+		genesis.Alloc[common.BytesToAddress([]byte{i})] = core.GenesisAccount{Balance: big.NewInt(1)} // Precompiles
+	}
+	return genesis
+}
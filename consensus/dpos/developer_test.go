@@ -0,0 +1,75 @@
+package dpos
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+)
+
+func TestDeveloperGenesisBlock(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	developer := crypto.PubkeyToAddress(key.PublicKey)
+
+	genesis := DeveloperGenesisBlock(0, developer)
+	maker := NewChainMaker(genesis, key)
+	blocks, err := maker.Generate(maker.Genesis(), 1, nil)
+	if err != nil {
+		t.Fatalf("failed to generate chain: %v", err)
+	}
+	// The developer is the sole validator, staking its deposit at block 1
+	header := blocks[0].Header()
+	statedb, err := maker.State(header)
+	if err != nil {
+		t.Fatalf("failed to open state: %v", err)
+	}
+	validators, err := maker.engine.getCurEpochValidators(maker.chain, header, statedb)
+	if err != nil {
+		t.Fatalf("failed to retrieve validators: %v", err)
+	}
+	if !reflect.DeepEqual(validators, []common.Address{developer}) {
+		t.Errorf("validators mismatch: have %v, want %x", validators, developer)
+	}
+	// The chain is accepted by a node running the developer genesis
+	db := rawdb.NewMemoryDatabase()
+	genesis.MustCommit(db)
+	engine := New(genesis.Config, db)
+	chain, err := core.NewBlockChain(db, nil, genesis.Config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	engine.SetStateFn(chain.StateAt)
+	engine.SetChain(chain)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	// Without a period, only the empty block initializing the contracts is sealed
+	next, err := maker.MakeBlock(header, nil)
+	if err != nil {
+		t.Fatalf("failed to make block: %v", err)
+	}
+	maker.Authorize(developer)
+	for number, block := range map[int64]*types.Block{1: blocks[0], 2: next} {
+		results := make(chan *types.Block, 1)
+		if err := maker.engine.Seal(maker.chain, block, results, nil); err != nil && number == 1 {
+			t.Fatalf("block %d: failed to seal: %v", number, err)
+		}
+		select {
+		case <-results:
+			if number != 1 {
+				t.Errorf("block %d: empty block sealed", number)
+			}
+		case <-time.After(100 * time.Millisecond):
+			if number == 1 {
+				t.Errorf("block %d: block not sealed", number)
+			}
+		}
+	}
+}
@@ -19,6 +19,7 @@ package dpos
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -36,6 +37,7 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/misc"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
 	"github.com/hypnosisfoundation/go-hypnosis/core/state"
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/crypto"
@@ -55,6 +57,8 @@ const (
 	inmemorySnapshots  = 128  // Number of recent vote snapshots to keep in memory
 	inmemorySignatures = 4096 // Number of recent block signatures to keep in memory
 
+	pruneInterval = 16 * checkpointInterval // Number of blocks after which to prune the frozen snapshots
+
 	wiggleTime    = 500 * time.Millisecond // Random delay (per validator) to allow concurrent validators
 	maxValidators = 99                     // Max validators allowed sealing.
 
@@ -69,6 +73,18 @@ const (
 	DirectionBoth
 )
 
+func (d blacklistDirection) String() string {
+	switch d {
+	case DirectionFrom:
+		return "from"
+	case DirectionTo:
+		return "to"
+	case DirectionBoth:
+		return "both"
+	}
+	return "unknown"
+}
+
 // Dpos delegated proof-of-stake protocol constants.
 var (
 	epochLength = uint64(14400) // Default number of blocks after which to checkpoint and reset the pending votes
@@ -153,8 +169,20 @@ var (
 	errInvalidCoinbase = errors.New("invalid coin base")
 
 	errInvalidSysGovCount = errors.New("invalid system governance tx count")
+
+	// errInvalidUpgradeTxCount is returned if a block upgrading system contracts doesn't
+	// include exactly one upgrade transaction, or another block includes one.
+	errInvalidUpgradeTxCount = errors.New("invalid system contract upgrade tx count")
+
+	// errInvalidUpgradeSender is returned if a system contract upgrade transaction is
+	// not sent by the validator of the block.
+	errInvalidUpgradeSender = errors.New("invalid sender for system contract upgrade transaction")
 )
 
+// upgradeLogsHash is the transaction hash the logs of the system contract migrations
+// are recorded under, until they are moved to the receipt of the upgrade transaction.
+var upgradeLogsHash = crypto.Keccak256Hash([]byte("dpos-upgrade-logs"))
+
 var (
 	getblacklistTimer = metrics.NewRegisteredTimer("dpos/blacklist/get", nil)
 	getRulesTimer     = metrics.NewRegisteredTimer("dpos/eventcheckrules/get", nil)
@@ -206,6 +234,8 @@ type Dpos struct {
 	blLock          sync.Mutex // Make sure only get blacklist once for each block
 	eventCheckRules *lru.Cache // eventCheckRules caches recent EventCheckRules to speed up log validation
 	rulesLock       sync.Mutex // Make sure only get eventCheckRules once for each block
+	addressLists    *lru.Cache // addressLists caches the AddressList contract content after recent blocks, maintained from its logs
+	alLock          sync.Mutex // Make sure only get the AddressList contract content once for each block
 
 	proposals map[common.Address]bool // Current list of proposals we are pushing
 
@@ -216,12 +246,27 @@ type Dpos struct {
 	signTxFn  SignTxFn
 	lock      sync.RWMutex // Protects the validator fields
 
-	stateFn StateFn // Function to get state by state root
+	stateFn       StateFn       // Function to get state by state root
+	headerStateFn HeaderStateFn // Function to get state by header, set by light clients
+	headerFn      HeaderFn      // Function to retrieve missing canonical headers, set by light clients
 
-	abi map[string]abi.ABI // Interactive with system contracts
+	abi      map[string]abi.ABI              // Interactive with system contracts
+	upgrades *systemcontract.UpgradeRegistry // System contract upgrades of the chain
 
 	chain consensus.ChainHeaderReader // chain is only for reading parent headers when getting blacklist and rules
 
+	finality *finality          // Attestation based finality gadget, nil if not started
+	activity *core.ChainIndexer // Validator activity indexer, nil if not started
+
+	seals        *lru.ARCCache                       // Recent seals keyed by height and signer to detect double signing
+	evidences    map[common.Hash]*DoubleSignEvidence // Double-sign evidences waiting to be included into a block
+	evidenceLock sync.Mutex                          // Protects the evidences field
+
+	unjails    map[common.Address]*types.Transaction // Unjail requests waiting to be included into a block
+	unjailLock sync.Mutex                            // Protects the unjails field
+
+	now func() time.Time // Clock the block times are checked against, shifted by simulations
+
 	// The fields below are for testing only
 	fakeDiff bool // Skip difficulty verifications
 }
@@ -239,6 +284,8 @@ func New(chainConfig *params.ChainConfig, db ethdb.Database) *Dpos {
 	signatures, _ := lru.NewARC(inmemorySignatures)
 	blacklists, _ := lru.New(inmemoryBlacklist)
 	rules, _ := lru.New(inmemoryBlacklist)
+	addressLists, _ := lru.New(inmemoryBlacklist)
+	seals, _ := lru.NewARC(inmemorySeals)
 
 	return &Dpos{
 		chainConfig:     chainConfig,
@@ -248,9 +295,15 @@ func New(chainConfig *params.ChainConfig, db ethdb.Database) *Dpos {
 		signatures:      signatures,
 		blacklists:      blacklists,
 		eventCheckRules: rules,
+		addressLists:    addressLists,
 		proposals:       make(map[common.Address]bool),
+		seals:           seals,
+		evidences:       make(map[common.Hash]*DoubleSignEvidence),
+		unjails:         make(map[common.Address]*types.Transaction),
 		abi:             systemcontract.GetInteractiveABI(),
+		upgrades:        systemcontract.ChainUpgrades(chainConfig),
 		signer:          types.LatestSignerForChainID(chainConfig.ChainID),
+		now:             time.Now,
 	}
 }
 
@@ -263,6 +316,12 @@ func (d *Dpos) SetStateFn(fn StateFn) {
 	d.stateFn = fn
 }
 
+// SetClock sets the clock the block times are prepared and checked against, e.g.
+// to simulate the passing of time.
+func (d *Dpos) SetClock(now func() time.Time) {
+	d.now = now
+}
+
 // Author implements consensus.Engine, returning the Ethereum address recovered
 // from the signature in the header's extra-data section.
 func (d *Dpos) Author(header *types.Header) (common.Address, error) {
@@ -307,7 +366,7 @@ func (d *Dpos) verifyHeader(chain consensus.ChainHeaderReader, header *types.Hea
 	number := header.Number.Uint64()
 
 	// Don't waste time checking blocks from the future
-	if header.Time > uint64(time.Now().Unix()) {
+	if header.Time > uint64(d.now().Unix()) {
 		return consensus.ErrFutureBlock
 	}
 	// Check that the extra-data contains the vanity, validators and signature.
@@ -326,7 +385,7 @@ func (d *Dpos) verifyHeader(chain consensus.ChainHeaderReader, header *types.Hea
 		return errExtraValidators
 	}
 	// Ensure that the validator bytes length is valid
-	if isEpoch && validatorsBytes%common.AddressLength != 0 {
+	if isEpoch && validatorsBytes%checkpointEntryLength(chain.Config(), header.Number) != 0 {
 		return errExtraValidators
 	}
 
@@ -416,7 +475,7 @@ func (d *Dpos) snapshot(chain consensus.ChainHeaderReader, number uint64, hash c
 		}
 		// If an on-disk checkpoint snapshot can be found, use that
 		if number%checkpointInterval == 0 {
-			if s, err := loadSnapshot(d.config, d.signatures, d.db, hash); err == nil {
+			if s, err := loadSnapshot(d.chainConfig, d.config, d.signatures, d.db, number, hash); err == nil {
 				log.Trace("Loaded voting snapshot from disk", "number", number, "hash", hash)
 				snap = s
 				break
@@ -431,11 +490,8 @@ func (d *Dpos) snapshot(chain consensus.ChainHeaderReader, number uint64, hash c
 			if checkpoint != nil {
 				hash := checkpoint.Hash()
 
-				validators := make([]common.Address, (len(checkpoint.Extra)-extraVanity-extraSeal)/common.AddressLength)
-				for i := 0; i < len(validators); i++ {
-					copy(validators[i][:], checkpoint.Extra[extraVanity+i*common.AddressLength:])
-				}
-				snap = newSnapshot(d.config, d.signatures, number, hash, validators)
+				validators, weights := parseCheckpointValidators(d.chainConfig, checkpoint)
+				snap = newSnapshot(d.chainConfig, d.config, d.signatures, number, hash, validators, weights)
 				if err := snap.store(d.db); err != nil {
 					return nil, err
 				}
@@ -443,6 +499,14 @@ func (d *Dpos) snapshot(chain consensus.ChainHeaderReader, number uint64, hash c
 				break
 			}
 		}
+		// If we're at a light client checkpoint within an epoch, retrieve the
+		// validators from the epoch header.
+		if s, err := d.lightCheckpoint(chain, number, hash); err != nil {
+			return nil, err
+		} else if s != nil {
+			snap = s
+			break
+		}
 		// No snapshot for this header, gather the header and move backward
 		var header *types.Header
 		if len(parents) > 0 {
@@ -479,9 +543,49 @@ func (d *Dpos) snapshot(chain consensus.ChainHeaderReader, number uint64, hash c
 		}
 		log.Trace("Stored voting snapshot to disk", "number", snap.Number, "hash", snap.Hash)
 	}
+	if snap.Number%pruneInterval == 0 && len(headers) > 0 {
+		if pruned := PruneSnapshots(d.db); pruned > 0 {
+			log.Debug("Pruned frozen voting snapshots", "count", pruned)
+		}
+	}
 	return snap, err
 }
 
+// ReplaySnapshots regenerates the snapshots of the canonical chain from the genesis
+// up to the given block, calling fn with the genesis snapshot and every following
+// one that is stored as a checkpoint while importing the chain.
+func (d *Dpos) ReplaySnapshots(chain consensus.ChainHeaderReader, head uint64, fn func(snap *Snapshot) error) error {
+	genesis := chain.GetHeaderByNumber(0)
+	if genesis == nil {
+		return errUnknownBlock
+	}
+	validators, weights := parseCheckpointValidators(d.chainConfig, genesis)
+	snap := newSnapshot(d.chainConfig, d.config, d.signatures, 0, genesis.Hash(), validators, weights)
+	if err := fn(snap); err != nil {
+		return err
+	}
+	headers := make([]*types.Header, 0, checkpointInterval)
+	for number := uint64(1); number <= head; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return fmt.Errorf("missing header %d", number)
+		}
+		headers = append(headers, header)
+		if number%checkpointInterval != 0 {
+			continue
+		}
+		var err error
+		if snap, err = snap.apply(headers, chain, nil); err != nil {
+			return err
+		}
+		if err := fn(snap); err != nil {
+			return err
+		}
+		headers = headers[:0]
+	}
+	return nil
+}
+
 // VerifyUncles implements consensus.Engine, always returning an error for any
 // uncles as this consensus mechanism doesn't permit uncles.
 func (d *Dpos) VerifyUncles(chain consensus.ChainReader, block *types.Block) error {
@@ -521,6 +625,7 @@ func (d *Dpos) verifySeal(chain consensus.ChainHeaderReader, header *types.Heade
 	if signer != header.Coinbase {
 		return errInvalidCoinbase
 	}
+	d.detectDoubleSign(header, signer)
 
 	if _, ok := snap.Validators[signer]; !ok {
 		return errUnauthorizedValidator
@@ -529,7 +634,7 @@ func (d *Dpos) verifySeal(chain consensus.ChainHeaderReader, header *types.Heade
 	for seen, recent := range snap.Recents {
 		if recent == signer {
 			// Validator is among recents, only fail if the current block doesn't shift it out
-			if limit := uint64(len(snap.Validators)/2 + 1); seen > number-limit {
+			if limit := snap.recentsLimit(signer, number); seen > number-limit {
 				return errRecentlySigned
 			}
 		}
@@ -576,20 +681,19 @@ func (d *Dpos) Prepare(chain consensus.ChainHeaderReader, header *types.Header)
 		return consensus.ErrUnknownAncestor
 	}
 
-	statedb, err := d.stateFn(parent.Root)
+	ctx, cancel := context.WithTimeout(context.Background(), stateRetrievalTimeout)
+	defer cancel()
+	statedb, err := d.stateAt(ctx, parent)
 	if err != nil {
 		return err
 	}
 
 	if number%d.config.Epoch == 0 {
-		newValidators, err := d.getCurEpochValidators(chain, header, statedb)
+		validatorsBytes, err := d.checkpointValidators(chain, header, statedb)
 		if err != nil {
 			return err
 		}
-
-		for _, validator := range newValidators {
-			header.Extra = append(header.Extra, validator.Bytes()...)
-		}
+		header.Extra = append(header.Extra, validatorsBytes...)
 	}
 	header.Extra = append(header.Extra, make([]byte, extraSeal)...)
 
@@ -598,8 +702,8 @@ func (d *Dpos) Prepare(chain consensus.ChainHeaderReader, header *types.Header)
 
 	// Ensure the timestamp has the correct delay
 	header.Time = parent.Time + d.config.Period
-	if header.Time < uint64(time.Now().Unix()) {
-		header.Time = uint64(time.Now().Unix())
+	if now := uint64(d.now().Unix()); header.Time < now {
+		header.Time = now
 	}
 	return nil
 }
@@ -619,6 +723,12 @@ func (d *Dpos) Finalize(chain consensus.ChainHeaderReader, header *types.Header,
 		if err := d.tryPunishValidator(chain, header, state); err != nil {
 			return err
 		}
+		// jail the in-turn validator in the contract if it missed too many turns
+		if d.chainConfig.IsJail(header.Number) {
+			if err := d.jailValidators(chain, header, state); err != nil {
+				return err
+			}
+		}
 	}
 
 	// avoid nil pointer
@@ -639,43 +749,54 @@ func (d *Dpos) Finalize(chain consensus.ChainHeaderReader, header *types.Header,
 	// do epoch thing at the end, because it will update active validators
 	if header.Number.Uint64()%d.config.Epoch == 0 {
 
-		newEpochValidators, err := d.getCurEpochValidators(chain, header, state)
+		validatorsBytes, err := d.checkpointValidators(chain, header, state)
 		if err != nil {
 			return err
 		}
 		log.Info("New Epoch", "header", header.Number.Uint64(), "epoch", header.Number.Uint64()/d.config.Epoch)
 
-		validatorsBytes := make([]byte, len(newEpochValidators)*common.AddressLength)
-		for i, validator := range newEpochValidators {
-			copy(validatorsBytes[i*common.AddressLength:], validator.Bytes())
-		}
-
 		extraSuffix := len(header.Extra) - extraSeal
 		if !bytes.Equal(header.Extra[extraVanity:extraSuffix], validatorsBytes) {
 			return errInvalidExtraValidators
 		}
 	}
 
+	groups, err := splitSystemTxs(systemTxs)
+	if err != nil {
+		return err
+	}
+	upgradeTxs, govTxs, evidenceTxs := groups[systemcontract.SysUpgradeToAddr], groups[systemcontract.SysGovToAddr], groups[systemcontract.SysEvidenceToAddr]
+
+	// include the system contract upgrades applied before the transactions
+	if upgrading := len(d.upgrades.UpgradesAt(header.Number)) > 0; upgrading != (len(upgradeTxs) == 1) || len(upgradeTxs) > 1 {
+		return errInvalidUpgradeTxCount
+	}
+	for _, tx := range upgradeTxs {
+		receipt, err := d.replayUpgrade(header, state, len(*txs), tx)
+		if err != nil {
+			return err
+		}
+		*txs = append(*txs, tx)
+		*receipts = append(*receipts, receipt)
+	}
+
 	//handle system governance Proposal
 	if chain.Config().IsRedCoast(header.Number) {
-		proposalCount, err := d.getPassedProposalCount(chain, header, state)
+		if err := d.queueProposals(chain, header, state); err != nil {
+			return err
+		}
+		props, err := d.readyProposals(chain, header, state)
 		if err != nil {
 			return err
 		}
-		if proposalCount != uint32(len(systemTxs)) {
+		if len(props) != len(govTxs) {
 			return errInvalidSysGovCount
 		}
-		// Due to the logics of the finish operation of contract `governance`, when finishing a proposal which
-		// is not the last passed proposal, it will change the sequence. So in here we must first executes all
-		// passed proposals, and then finish then all.
-		pIds := make([]*big.Int, 0, proposalCount)
-		for i := uint32(0); i < proposalCount; i++ {
-			prop, err := d.getPassedProposalByIndex(chain, header, state, i)
-			if err != nil {
-				return err
-			}
+		// Execute all ready proposals first, and then finish them all.
+		pIds := make([]*big.Int, 0, len(props))
+		for i, prop := range props {
 			// execute the system governance Proposal
-			tx := systemTxs[int(i)]
+			tx := govTxs[i]
 			receipt, err := d.replayProposal(chain, header, state, prop, len(*txs), tx)
 			if err != nil {
 				return err
@@ -686,11 +807,38 @@ func (d *Dpos) Finalize(chain consensus.ChainHeaderReader, header *types.Header,
 			pIds = append(pIds, prop.Id)
 		}
 		// Finish all proposal
-		for i := uint32(0); i < proposalCount; i++ {
-			err = d.finishProposalById(chain, header, state, pIds[i])
+		if err := d.finishProposals(chain, header, state, pIds); err != nil {
+			return err
+		}
+	}
+
+	// slash double signing validators
+	if chain.Config().IsDoubleSignSlash(header.Number) {
+		if len(evidenceTxs) > maxEvidencesPerBlock {
+			return errTooManyEvidences
+		}
+		for _, tx := range evidenceTxs {
+			receipt, err := d.replayEvidence(chain, header, state, len(*txs), tx)
+			if err != nil {
+				return err
+			}
+			*txs = append(*txs, tx)
+			*receipts = append(*receipts, receipt)
+		}
+	}
+
+	// release the jailed validators whose unjail requests the miner carried
+	if unjailTxs := groups[systemcontract.SysUnjailToAddr]; len(unjailTxs) > 0 {
+		if !d.chainConfig.IsJail(header.Number) {
+			return errInvalidUnjail
+		}
+		for _, tx := range unjailTxs {
+			receipt, err := d.replayUnjail(chain, header, state, len(*txs), tx)
 			if err != nil {
 				return err
 			}
+			*txs = append(*txs, tx)
+			*receipts = append(*receipts, receipt)
 		}
 	}
 
@@ -722,6 +870,12 @@ func (d *Dpos) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *ty
 		if err != nil {
 			panic(err)
 		}
+		// jail the in-turn validator in the contract if it missed too many turns
+		if d.chainConfig.IsJail(header.Number) {
+			if err := d.jailValidators(chain, header, state); err != nil {
+				panic(err)
+			}
+		}
 	}
 
 	// deposit block reward
@@ -732,11 +886,23 @@ func (d *Dpos) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *ty
 	// do  something at the epoch end
 	if header.Number.Uint64()%d.config.Epoch == 0 {
 
-		newEpochValidators, err := d.getCurEpochValidators(chain, header, state)
+		// run the same calls as Finalize does to verify the checkpoint validators
+		validatorsBytes, err := d.checkpointValidators(chain, header, state)
 		if err != nil {
 			panic(err)
 		}
-		log.Info("New Epoch", "header", header.Number.Uint64(), "epoch", header.Number.Uint64()/d.config.Epoch, "count", len(newEpochValidators), "newEpochValidators", newEpochValidators)
+		log.Info("New Epoch", "header", header.Number.Uint64(), "epoch", header.Number.Uint64()/d.config.Epoch, "count", len(validatorsBytes)/checkpointEntryLength(d.chainConfig, header.Number))
+	}
+
+	// include the system contract upgrades applied before the transactions, same as
+	// governance proposals only a miner can do it
+	if d.signTxFn != nil && len(d.upgrades.UpgradesAt(header.Number)) > 0 {
+		tx, receipt, err := d.executeUpgrade(chain, header, state, len(txs))
+		if err != nil {
+			return nil, nil, err
+		}
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
 	}
 
 	//handle system governance Proposal
@@ -746,20 +912,17 @@ func (d *Dpos) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *ty
 	// the 'miner.worker' will try to FinalizeAndAssemble a block,
 	// in this case, the signTxFn is not set. A `non-miner node` can't execute system governance proposal.
 	if d.signTxFn != nil && chain.Config().IsRedCoast(header.Number) {
-		proposalCount, err := d.getPassedProposalCount(chain, header, state)
+		if err := d.queueProposals(chain, header, state); err != nil {
+			return nil, nil, err
+		}
+		props, err := d.readyProposals(chain, header, state)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		// Due to the logics of the finish operation of contract `governance`, when finishing a proposal which
-		// is not the last passed proposal, it will change the sequence. So in here we must first executes all
-		// passed proposals, and then finish then all.
-		pIds := make([]*big.Int, 0, proposalCount)
-		for i := uint32(0); i < proposalCount; i++ {
-			prop, err := d.getPassedProposalByIndex(chain, header, state, i)
-			if err != nil {
-				return nil, nil, err
-			}
+		// Execute all ready proposals first, and then finish them all.
+		pIds := make([]*big.Int, 0, len(props))
+		for _, prop := range props {
 			// execute the system governance Proposal
 			tx, receipt, err := d.executeProposal(chain, header, state, prop, len(txs))
 			if err != nil {
@@ -771,11 +934,24 @@ func (d *Dpos) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *ty
 			pIds = append(pIds, prop.Id)
 		}
 		// Finish all proposal
-		for i := uint32(0); i < proposalCount; i++ {
-			err = d.finishProposalById(chain, header, state, pIds[i])
-			if err != nil {
-				return nil, nil, err
-			}
+		if err := d.finishProposals(chain, header, state, pIds); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// slash double signing validators, same as governance proposals only a miner can do it
+	if d.signTxFn != nil && chain.Config().IsDoubleSignSlash(header.Number) {
+		txs, receipts, err = d.assembleEvidences(chain, header, state, txs, receipts)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// carry the unjail requests of jailed validators, same as governance proposals only a miner can do it
+	if d.signTxFn != nil && d.chainConfig.IsJail(header.Number) {
+		txs, receipts, err = d.assembleUnjails(chain, header, state, txs, receipts)
+		if err != nil {
+			return nil, nil, err
 		}
 	}
 
@@ -803,10 +979,13 @@ func (d *Dpos) trySendBlockReward(chain consensus.ChainHeaderReader, header *typ
 	}
 
 	totalReward := new(big.Int).Add(epochInfo.BlockReward, state.GetBalance(consensus.FeeRecoder))
-	rewardToFoundation := new(big.Int).Div(new(big.Int).Mul(totalReward, big.NewInt(5)), big.NewInt(100))
-	rewardToMiner := new(big.Int).Sub(totalReward, rewardToFoundation)
 
-	state.AddBalance(foundationAddress, rewardToFoundation)
+	// the burnt share is simply never credited to anyone
+	dist := d.rewardDistribution(header.Number)
+	rewardToRecipients, _, rewardToMiner := splitBlockReward(totalReward, dist)
+	for i, recipient := range dist.Recipients {
+		state.AddBalance(recipient.Address, rewardToRecipients[i])
+	}
 	state.AddBalance(systemcontract.SystemRewardsContractAddr, rewardToMiner)
 
 	// reset tx fee recoder balance
@@ -836,8 +1015,7 @@ func (d *Dpos) tryPunishValidator(chain consensus.ChainHeaderReader, header *typ
 		return err
 	}
 
-	validators := snap.validators()
-	outTurnValidator := validators[number%uint64(len(validators))]
+	outTurnValidator := snap.proposerSelector(number).Proposer(snap, number)
 	// check sigend recently or not
 	signedRecently := false
 	for _, recent := range snap.Recents {
@@ -968,6 +1146,79 @@ func (d *Dpos) initializeSystemContracts(chain consensus.ChainHeaderReader, head
 	return nil
 }
 
+// upgradeSystemContracts replaces the code of the system contracts upgraded at the
+// block, keeping their storage, and runs their migrations. The logs of the migrations
+// are kept aside for the receipt of the upgrade transaction of the block.
+func (d *Dpos) upgradeSystemContracts(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
+	for _, upgrade := range d.upgrades.UpgradesAt(header.Number) {
+		state.SetCode(upgrade.ContractAddr, upgrade.Code)
+		if upgrade.Migration == nil {
+			continue
+		}
+		data, err := upgrade.Migration()
+		if err != nil {
+			return err
+		}
+		msg := vmcaller.NewLegacyMessage(header.Coinbase, &upgrade.ContractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
+
+		// execute message without a transaction
+		state.Prepare(upgradeLogsHash, 0)
+		if _, err := vmcaller.ExecuteMsg(msg, state, header, newChainContext(chain, d), d.chainConfig); err != nil {
+			log.Error("upgradeSystemContracts execute error", "contract", upgrade.Name, "address", upgrade.ContractAddr.String())
+			return err
+		}
+	}
+	return nil
+}
+
+// executeUpgrade includes the system contract upgrades of the block into the block
+// being assembled, as a transaction whose receipt carries the logs of the migrations.
+func (d *Dpos) executeUpgrade(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, totalTxIndex int) (*types.Transaction, *types.Receipt, error) {
+	nonce := state.GetNonce(d.validator)
+	tx := types.NewTransaction(nonce, systemcontract.SysUpgradeToAddr, new(big.Int), header.GasLimit, new(big.Int), nil)
+	tx, err := d.signTxFn(accounts.Account{Address: d.validator}, tx, chain.Config().ChainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	//add nonce for validator
+	state.SetNonce(d.validator, nonce+1)
+	return tx, upgradeReceipt(header, state, totalTxIndex, tx.Hash(), common.Hash{}), nil
+}
+
+// replayUpgrade applies the system contract upgrade transaction of an imported block.
+func (d *Dpos) replayUpgrade(header *types.Header, state *state.StateDB, totalTxIndex int, tx *types.Transaction) (*types.Receipt, error) {
+	sender, err := types.Sender(d.signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	if sender != header.Coinbase {
+		return nil, errInvalidUpgradeSender
+	}
+	nonce := state.GetNonce(sender)
+	//add nonce for validator
+	state.SetNonce(sender, nonce+1)
+	return upgradeReceipt(header, state, totalTxIndex, tx.Hash(), header.Hash()), nil
+}
+
+// upgradeReceipt creates the receipt of a system contract upgrade transaction, moving
+// the logs of the migrations run before the transactions of the block to it.
+func upgradeReceipt(header *types.Header, state *state.StateDB, totalTxIndex int, txHash, bHash common.Hash) *types.Receipt {
+	state.Prepare(txHash, totalTxIndex)
+
+	receipt := types.NewReceipt([]byte{}, false, header.GasUsed)
+	receipt.Logs = state.GetLogs(upgradeLogsHash, bHash)
+	for _, l := range receipt.Logs {
+		l.TxHash = txHash
+		l.TxIndex = uint(totalTxIndex)
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	receipt.TxHash = txHash
+	receipt.BlockHash = bHash
+	receipt.BlockNumber = header.Number
+	receipt.TransactionIndex = uint(totalTxIndex)
+	return receipt
+}
+
 // get current epoch validators after try elect
 func (d *Dpos) getCurEpochValidators(chain consensus.ChainHeaderReader, header *types.Header, statedb *state.StateDB) ([]common.Address, error) {
 
@@ -1020,8 +1271,9 @@ func (d *Dpos) Seal(chain consensus.ChainHeaderReader, block *types.Block, resul
 	if number == 0 {
 		return errUnknownBlock
 	}
-	// For 0-period chains, refuse to seal empty blocks (no reward but would spin sealing)
-	if d.config.Period == 0 && len(block.Transactions()) == 0 {
+	// For 0-period chains, refuse to seal empty blocks (no reward but would spin sealing),
+	// except for the first one initializing the system contracts
+	if d.config.Period == 0 && len(block.Transactions()) == 0 && number > 1 {
 		log.Info("Sealing paused, waiting for transactions")
 		return nil
 	}
@@ -1042,7 +1294,7 @@ func (d *Dpos) Seal(chain consensus.ChainHeaderReader, block *types.Block, resul
 	for seen, recent := range snap.Recents {
 		if recent == val {
 			// Validator is among recents, only wait if the current block doesn't shift it out
-			if limit := uint64(len(snap.Validators)/2 + 1); number < limit || seen > number-limit {
+			if limit := snap.recentsLimit(val, number); number < limit || seen > number-limit {
 				log.Info("Signed recently, must wait for others")
 				return nil
 			}
@@ -1107,8 +1359,18 @@ func (d *Dpos) SealHash(header *types.Header) common.Hash {
 	return SealHash(header)
 }
 
-// Close implements consensus.Engine. It's a noop for dpos as there are no background threads.
+// Close implements consensus.Engine, terminating the finality gadget if it's running.
 func (d *Dpos) Close() error {
+	d.lock.RLock()
+	f, activity := d.finality, d.activity
+	d.lock.RUnlock()
+
+	if f != nil {
+		f.stop()
+	}
+	if activity != nil {
+		return activity.Close()
+	}
 	return nil
 }
 
@@ -1167,8 +1429,13 @@ func encodeSigHeader(w io.Writer, header *types.Header) {
 	}
 }
 
+// PreHandle implements consensus.PoSA, applying the system contract upgrades of the
+// block before any of its transactions.
 func (d *Dpos) PreHandle(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
-	return nil
+	if block := slashEntryBlock(d.chainConfig); block != nil && block.Cmp(header.Number) == 0 {
+		installSlashEntry(state)
+	}
+	return d.upgradeSystemContracts(chain, header, state)
 }
 
 // IsSysTransaction checks whether a specific transaction is a system transaction.
@@ -1181,6 +1448,15 @@ func (d *Dpos) IsSysTransaction(sender common.Address, tx *types.Transaction, he
 	if sender == header.Coinbase && *to == systemcontract.SysGovToAddr && tx.GasPrice().Sign() == 0 {
 		return true, nil
 	}
+	if sender == header.Coinbase && *to == systemcontract.SysEvidenceToAddr && tx.GasPrice().Sign() == 0 && d.chainConfig.IsDoubleSignSlash(header.Number) {
+		return true, nil
+	}
+	if sender == header.Coinbase && *to == systemcontract.SysUpgradeToAddr && tx.GasPrice().Sign() == 0 && d.chainConfig.IsContractUpgrade(header.Number) {
+		return true, nil
+	}
+	if sender == header.Coinbase && *to == systemcontract.SysUnjailToAddr && tx.GasPrice().Sign() == 0 && d.chainConfig.IsJail(header.Number) {
+		return true, nil
+	}
 	// Make sure the miner can NOT call the system contract through a normal transaction.
 	if sender == header.Coinbase && *to == systemcontract.SysGovContractAddr {
 		return true, nil
@@ -1225,6 +1501,21 @@ func (d *Dpos) ValidateTx(sender common.Address, tx *types.Transaction, header *
 			}
 		}
 	}
+	// Unjail transactions only reach blocks through the engine, as system transactions
+	if to := tx.To(); to != nil && *to == systemcontract.SysUnjailToAddr && d.chainConfig.IsJail(header.Number) {
+		log.Trace("Hit unjail address", "tx", tx.Hash().String(), "addr", sender.String())
+		return types.ErrAddressDenied
+	}
+	if d.chainConfig.IsGovernanceActions(header.Number) {
+		if isFrozen(parentState, sender) {
+			log.Trace("Hit frozen account", "tx", tx.Hash().String(), "addr", sender.String())
+			return types.ErrAddressDenied
+		}
+		if to := tx.To(); to != nil && isFrozen(parentState, *to) {
+			log.Trace("Hit frozen account", "tx", tx.Hash().String(), "addr", to.String())
+			return types.ErrAddressDenied
+		}
+	}
 	return nil
 }
 
@@ -1243,64 +1534,25 @@ func (d *Dpos) getBlacklist(header *types.Header, parentState *state.StateDB) (m
 		return v.(map[common.Address]blacklistDirection), nil
 	}
 
-	// if the last updates is long ago, we don't need to get blacklist from the contract.
+	// After Sophon the blacklist is maintained from the logs of the contract
 	if d.chainConfig.SophonBlock != nil && header.Number.Cmp(d.chainConfig.SophonBlock) > 0 {
-		num := header.Number.Uint64()
-		lastUpdated := lastBlacklistUpdatedNumber(parentState)
-		if num >= 2 && num > lastUpdated+1 {
-			parent := d.chain.GetHeader(header.ParentHash, num-1)
-			if parent != nil {
-				if v, ok := d.blacklists.Get(parent.ParentHash); ok {
-					m := v.(map[common.Address]blacklistDirection)
-					d.blacklists.Add(header.ParentHash, m)
-					return m, nil
-				}
-			} else {
-				log.Error("Unexpected error when getBlacklist, can not get parent from chain", "number", num, "blockHash", header.Hash(), "parentHash", header.ParentHash)
-			}
-		}
-	}
-
-	// can't get blacklist from cache, try to call the contract
-	alABI := d.abi[systemcontract.AddressListContractName]
-	get := func(method string) ([]common.Address, error) {
-		ret, err := d.commonCallContract(header, parentState, alABI, systemcontract.AddressListContractAddr, method, 1)
+		list, err := d.getAddressList(header, parentState)
 		if err != nil {
-			log.Error(fmt.Sprintf("%s failed", method), "err", err)
 			return nil, err
 		}
-
-		blacks, ok := ret[0].([]common.Address)
-		if !ok {
-			return []common.Address{}, errors.New("invalid blacklist format")
-		}
-		return blacks, nil
+		d.blacklists.Add(header.ParentHash, list.blacks)
+		return list.blacks, nil
 	}
-	froms, err := get("getBlacksFrom")
+	m, err := d.loadBlacklist(header, parentState)
 	if err != nil {
 		return nil, err
 	}
-	tos, err := get("getBlacksTo")
-	if err != nil {
-		return nil, err
-	}
-
-	m := make(map[common.Address]blacklistDirection)
-	for _, from := range froms {
-		m[from] = DirectionFrom
-	}
-	for _, to := range tos {
-		if _, exist := m[to]; exist {
-			m[to] = DirectionBoth
-		} else {
-			m[to] = DirectionTo
-		}
-	}
 	d.blacklists.Add(header.ParentHash, m)
 	return m, nil
 }
 
 func (d *Dpos) CreateEvmExtraValidator(header *types.Header, parentState *state.StateDB) types.EvmExtraValidator {
+	validator := new(blacklistValidator)
 	if d.chainConfig.SophonBlock != nil && d.chainConfig.SophonBlock.Cmp(header.Number) < 0 {
 		blacks, err := d.getBlacklist(header, parentState)
 		if err != nil {
@@ -1312,12 +1564,15 @@ func (d *Dpos) CreateEvmExtraValidator(header *types.Header, parentState *state.
 			log.Error("getEventCheckRules failed", "err", err)
 			return nil
 		}
-		return &blacklistValidator{
-			blacks: blacks,
-			rules:  rules,
-		}
+		validator.blacks, validator.rules = blacks, rules
+	} else if !d.chainConfig.IsGovernanceActions(header.Number) {
+		return nil
 	}
-	return nil
+	// Frozen accounts are denied in internal calls too, even before Sophon
+	if d.chainConfig.IsGovernanceActions(header.Number) {
+		validator.frozen = parentState
+	}
+	return validator
 }
 
 func (d *Dpos) getEventCheckRules(header *types.Header, parentState *state.StateDB) (map[common.Hash]*EventCheckRule, error) {
@@ -1335,60 +1590,11 @@ func (d *Dpos) getEventCheckRules(header *types.Header, parentState *state.State
 		return v.(map[common.Hash]*EventCheckRule), nil
 	}
 
-	// if the last updates is long ago, we don't need to get blacklist from the contract.
-	num := header.Number.Uint64()
-	lastUpdated := lastRulesUpdatedNumber(parentState)
-	if num >= 2 && num > lastUpdated+1 {
-		parent := d.chain.GetHeader(header.ParentHash, num-1)
-		if parent != nil {
-			if v, ok := d.eventCheckRules.Get(parent.ParentHash); ok {
-				m := v.(map[common.Hash]*EventCheckRule)
-				d.eventCheckRules.Add(header.ParentHash, m)
-				return m, nil
-			}
-		} else {
-			log.Error("Unexpected error when getEventCheckRules, can not get parent from chain", "number", num, "blockHash", header.Hash(), "parentHash", header.ParentHash)
-		}
-	}
-
-	// can't get blacklist from cache, try to call the contract
-	alABI := d.abi[systemcontract.AddressListContractName]
-	method := "getRuleByIndex"
-	get := func(i uint32) (common.Hash, int, common.AddressCheckType, error) {
-		ret, err := d.commonCallContract(header, parentState, alABI, systemcontract.AddressListContractAddr, method, 3, i)
-		if err != nil {
-			return common.Hash{}, 0, common.CheckNone, err
-		}
-		sig := ret[0].([32]byte)
-		idx := ret[1].(*big.Int).Uint64()
-		ct := ret[2].(uint8)
-
-		return sig, int(idx), common.AddressCheckType(ct), nil
-	}
-
-	cnt, err := d.getEventCheckRulesLen(header, parentState)
+	list, err := d.getAddressList(header, parentState)
 	if err != nil {
-		log.Error("getEventCheckRulesLen failed", "err", err)
 		return nil, err
 	}
-	rules := make(map[common.Hash]*EventCheckRule)
-	for i := 0; i < cnt; i++ {
-		sig, idx, ct, err := get(uint32(i))
-		if err != nil {
-			log.Error("getRuleByIndex failed", "index", i, "number", num, "blockHash", header.Hash(), "err", err)
-			return nil, err
-		}
-		rule, exist := rules[sig]
-		if !exist {
-			rule = &EventCheckRule{
-				EventSig: sig,
-				Checks:   make(map[int]common.AddressCheckType),
-			}
-			rules[sig] = rule
-		}
-		rule.Checks[idx] = ct
-	}
-
+	rules := list.eventCheckRules(d.chainConfig.IsEventDataRules(header.Number))
 	d.eventCheckRules.Add(header.ParentHash, rules)
 	return rules, nil
 }
@@ -1,9 +1,47 @@
 package dpos
 
 import (
+	"math/big"
+
 	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
 )
 
 var (
 	foundationAddress = common.HexToAddress("0x731cb03Ab9609e76c0C012aBeB234D2227323781")
+
+	// defaultRewardDistribution is the block reward split in force when no scheduled
+	// distribution has taken effect, giving 5% to the foundation.
+	defaultRewardDistribution = &params.RewardDistribution{
+		Block:      common.Big0,
+		Recipients: []params.RewardRecipient{{Address: foundationAddress, Share: 500}},
+	}
 )
+
+// rewardDistribution returns the block reward split in force at the given block.
+func (d *Dpos) rewardDistribution(number *big.Int) *params.RewardDistribution {
+	if dist := d.config.RewardDistributionAt(number); dist != nil {
+		return dist
+	}
+	return defaultRewardDistribution
+}
+
+// rewardShare returns the given share in basis points of a reward.
+func rewardShare(reward *big.Int, share uint64) *big.Int {
+	amount := new(big.Int).Mul(reward, new(big.Int).SetUint64(share))
+	return amount.Div(amount, big.NewInt(params.MaxRewardShare))
+}
+
+// splitBlockReward splits a block reward according to a distribution, returning the
+// amount of each recipient, the burnt amount and what is left to validators.
+func splitBlockReward(reward *big.Int, dist *params.RewardDistribution) (recipients []*big.Int, burnt *big.Int, validators *big.Int) {
+	validators = new(big.Int).Set(reward)
+	for _, recipient := range dist.Recipients {
+		amount := rewardShare(reward, recipient.Share)
+		recipients = append(recipients, amount)
+		validators.Sub(validators, amount)
+	}
+	burnt = rewardShare(reward, dist.Burn)
+	validators.Sub(validators, burnt)
+	return recipients, burnt, validators
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/consensus"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/misc"
 	"github.com/hypnosisfoundation/go-hypnosis/core/state"
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
@@ -168,15 +169,19 @@ func (d *Dpos) replayProposal(chain consensus.ChainHeaderReader, header *types.H
 func (d *Dpos) executeProposalMsg(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, prop *Proposal, totalTxIndex int, txHash, bHash common.Hash) *types.Receipt {
 	var receipt *types.Receipt
 	action := prop.Action.Uint64()
-	switch action {
-	case 0:
+	switch {
+	case action == actionEvmCall:
 		// evm action.
 		receipt = d.executeEvmCallProposal(chain, header, state, prop, totalTxIndex, txHash, bHash)
-	case 1:
+	case action == actionEraseCode:
 		// delete code action
 		ok := state.Erase(prop.To)
 		receipt = types.NewReceipt([]byte{}, ok != true, header.GasUsed)
 		log.Info("executeProposalMsg", "action", "erase", "id", prop.Id.String(), "to", prop.To, "txHash", txHash.String(), "success", ok)
+	case isGovernanceAction(action) && d.chainConfig.IsGovernanceActions(header.Number):
+		receipt = d.executeGovernanceAction(header, state, prop, totalTxIndex, txHash, bHash)
+	case action == actionBatch && d.chainConfig.IsGovernanceTimelock(header.Number):
+		receipt = d.executeBatchProposal(chain, header, state, prop, totalTxIndex, txHash, bHash)
 	default:
 		receipt = types.NewReceipt([]byte{}, true, header.GasUsed)
 		log.Warn("executeProposalMsg failed, unsupported action", "action", action, "id", prop.Id.String(), "from", prop.From, "to", prop.To, "value", prop.Value.String(), "data", hexutil.Encode(prop.Data), "txHash", txHash.String())
@@ -208,11 +213,111 @@ func (d *Dpos) executeEvmCallProposal(chain consensus.ChainHeaderReader, header
 	return receipt
 }
 
+// the returned value should not nil.
+func (d *Dpos) executeGovernanceAction(header *types.Header, state *state.StateDB, prop *Proposal, totalTxIndex int, txHash, bHash common.Hash) *types.Receipt {
+	state.Prepare(txHash, totalTxIndex)
+	err := d.applyGovernanceAction(state, header.Number, prop)
+
+	// governance action will not actually consumes gas
+	receipt := types.NewReceipt([]byte{}, err != nil, header.GasUsed)
+	// Set the receipt logs and create a bloom for filtering
+	receipt.Logs = state.GetLogs(txHash, bHash)
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+	log.Info("executeProposalMsg", "action", prop.Action.Uint64(), "id", prop.Id.String(), "to", prop.To, "value", prop.Value.String(), "data", hexutil.Encode(prop.Data), "txHash", txHash.String(), "err", err)
+
+	return receipt
+}
+
+// PassedProposal returns the passed proposal with the given id.
+func (d *Dpos) PassedProposal(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, id *big.Int) (*Proposal, error) {
+	count, err := d.getPassedProposalCount(chain, header, state)
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < count; i++ {
+		prop, err := d.getPassedProposalByIndex(chain, header, state, i)
+		if err != nil {
+			return nil, err
+		}
+		if prop.Id.Cmp(id) == 0 {
+			return prop, nil
+		}
+	}
+	return nil, fmt.Errorf("proposal %v not passed", id)
+}
+
+// SimulateProposal executes a governance proposal on the state of parent, in the
+// block its in-turn validator would seal next, without signing its system
+// transaction. Without an id the proposal is made of the given fields, otherwise
+// it is the passed proposal with that id. It returns the header of the simulated
+// block, the unsigned transaction, which ApplySysTx can replay, and the receipt.
+func (d *Dpos) SimulateProposal(chain consensus.ChainHeaderReader, parent *types.Header, state *state.StateDB, id *big.Int, action uint64, from, to common.Address, value *big.Int, data []byte) (*types.Header, *types.Transaction, *types.Receipt, error) {
+	number := parent.Number.Uint64() + 1
+	snap, err := d.snapshot(chain, parent.Number.Uint64(), parent.Hash(), nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).SetUint64(number),
+		Coinbase:   snap.proposerSelector(number).Proposer(snap, number),
+		Difficulty: new(big.Int).Set(diffInTurn),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + d.config.Period,
+	}
+	if d.chainConfig.IsLondon(header.Number) {
+		header.BaseFee = misc.CalcBaseFee(d.chainConfig, parent)
+	}
+	prop := &Proposal{Id: new(big.Int), Action: new(big.Int).SetUint64(action), From: from, To: to, Value: value, Data: data}
+	if prop.Value == nil {
+		prop.Value = new(big.Int)
+	}
+	if id != nil {
+		if prop, err = d.PassedProposal(chain, header, state, id); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	tx, receipt, err := d.simulateProposal(chain, header, state, prop)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return header, tx, receipt, nil
+}
+
+// simulateProposal executes the proposal on the given state the same way the
+// producer of the header would, without signing its system transaction.
+func (d *Dpos) simulateProposal(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, prop *Proposal) (*types.Transaction, *types.Receipt, error) {
+	propRLP, err := rlp.EncodeToBytes(prop)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := state.GetNonce(header.Coinbase)
+	tx := types.NewTransaction(nonce, systemcontract.SysGovToAddr, new(big.Int), header.GasLimit, new(big.Int), propRLP)
+
+	state.SetNonce(header.Coinbase, nonce+1)
+	receipt := d.executeProposalMsg(chain, header, state, prop, 0, tx.Hash(), common.Hash{})
+	state.Finalise(true)
+
+	return tx, receipt, nil
+}
+
 // Methods for debug trace
 
 // ApplySysTx applies a system-transaction using a given evm,
 // the main purpose of this method is for tracing a system-transaction.
 func (d *Dpos) ApplySysTx(evm *vm.EVM, state *state.StateDB, txIndex int, sender common.Address, tx *types.Transaction) (ret []byte, vmerr error, err error) {
+	if *tx.To() == systemcontract.SysEvidenceToAddr {
+		return d.applyEvidenceTx(evm, state, txIndex, sender, tx)
+	}
+	if *tx.To() == systemcontract.SysUpgradeToAddr {
+		// The upgrades already ran before the transactions of the block
+		evm.StateDB.SetNonce(sender, evm.StateDB.GetNonce(sender)+1)
+		return
+	}
+	if *tx.To() == systemcontract.SysUnjailToAddr {
+		return d.applyUnjailTx(evm, state, txIndex, sender, tx)
+	}
 	var prop = &Proposal{}
 	if err = rlp.DecodeBytes(tx.Data(), prop); err != nil {
 		return
@@ -223,8 +328,8 @@ func (d *Dpos) ApplySysTx(evm *vm.EVM, state *state.StateDB, txIndex int, sender
 	evm.StateDB.SetNonce(sender, nonce+1)
 
 	action := prop.Action.Uint64()
-	switch action {
-	case 0:
+	switch {
+	case action == actionEvmCall:
 		// evm action.
 		// actually run the governance message
 		msg := vmcaller.NewLegacyMessage(prop.From, &prop.To, 0, prop.Value, tx.Gas(), new(big.Int), prop.Data, false)
@@ -235,9 +340,16 @@ func (d *Dpos) ApplySysTx(evm *vm.EVM, state *state.StateDB, txIndex int, sender
 		}
 		ret, _, vmerr = evm.Call(vm.AccountRef(msg.From()), *msg.To(), msg.Data(), msg.Gas(), msg.Value())
 		state.Finalise(true)
-	case 1:
+	case action == actionEraseCode:
 		// delete code action
 		_ = state.Erase(prop.To)
+	case isGovernanceAction(action) && d.chainConfig.IsGovernanceActions(evm.Context.BlockNumber):
+		state.Prepare(tx.Hash(), txIndex)
+		vmerr = d.applyGovernanceAction(state, evm.Context.BlockNumber, prop)
+	case action == actionBatch && d.chainConfig.IsGovernanceTimelock(evm.Context.BlockNumber):
+		state.Prepare(tx.Hash(), txIndex)
+		vmerr = d.applyBatch(state, evm.Context.BlockNumber, prop, batchEvmCall(evm, tx.Gas()))
+		state.Finalise(true)
 	default:
 		vmerr = errors.New("unsupported action")
 	}
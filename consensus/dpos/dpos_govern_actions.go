@@ -0,0 +1,189 @@
+package dpos
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+// Actions of the system governance proposals. The actions after actionEraseCode are
+// applied by the engine itself after the GovernanceActions fork, batches after the
+// GovernanceTimelock fork.
+const (
+	actionEvmCall     = 0 // Calls To from From with Value and Data
+	actionEraseCode   = 1 // Erases the code and storage of To
+	actionSetStorage  = 2 // Sets a storage slot of To, Data is the slot followed by the value
+	actionSetBalance  = 3 // Sets the balance of To to Value
+	actionMint        = 4 // Adds Value to the balance of To, usually a treasury
+	actionFreeze      = 5 // Denies every transaction from and to To
+	actionUnfreeze    = 6 // Lifts the freeze of To
+	actionReplaceCode = 7 // Replaces the code of To with Data, keeping its storage and balance
+	actionBatch       = 8 // Applies the calls RLP encoded in Data, all or none of them
+)
+
+var (
+	// errUnexpectedValue is returned if a governance action not moving funds has
+	// a value.
+	errUnexpectedValue = errors.New("governance action doesn't take a value")
+
+	// errUnexpectedData is returned if a governance action has data it doesn't use.
+	errUnexpectedData = errors.New("governance action doesn't take data")
+
+	// errInvalidStorageData is returned if the data of a set storage action isn't
+	// a 32 bytes slot followed by a 32 bytes value.
+	errInvalidStorageData = errors.New("set storage data must be a 32 bytes slot and a 32 bytes value")
+
+	// errInvalidMintAmount is returned if a mint action doesn't mint anything.
+	errInvalidMintAmount = errors.New("mint amount must be positive")
+
+	// errAccountFrozen is returned when freezing an account which is already frozen.
+	errAccountFrozen = errors.New("account already frozen")
+
+	// errAccountNotFrozen is returned when unfreezing an account which isn't frozen.
+	errAccountNotFrozen = errors.New("account not frozen")
+
+	// errInvalidCode is returned if the code of a replace code action is empty or
+	// couldn't be deployed by a transaction.
+	errInvalidCode = errors.New("invalid replacement code")
+)
+
+// isGovernanceAction returns whether the action is applied by applyGovernanceAction.
+func isGovernanceAction(action uint64) bool {
+	return action >= actionSetStorage && action <= actionReplaceCode
+}
+
+// frozenSlot returns the storage slot of FrozenAccountsAddr recording whether
+// the account is frozen.
+func frozenSlot(addr common.Address) common.Hash {
+	return crypto.Keccak256Hash(addr.Bytes())
+}
+
+// isFrozen returns whether the account was frozen by governance.
+func isFrozen(state consensus.StateReader, addr common.Address) bool {
+	return state.GetState(systemcontract.FrozenAccountsAddr, frozenSlot(addr)) != (common.Hash{})
+}
+
+// setFrozen records whether the account is frozen.
+func setFrozen(state *state.StateDB, addr common.Address, frozen bool) {
+	// Keep the recording account non-empty, otherwise it would be deleted along
+	// with its storage by EIP-158.
+	if state.GetNonce(systemcontract.FrozenAccountsAddr) == 0 {
+		state.SetNonce(systemcontract.FrozenAccountsAddr, 1)
+	}
+	var value common.Hash
+	if frozen {
+		value = common.BytesToHash([]byte{1})
+	}
+	state.SetState(systemcontract.FrozenAccountsAddr, frozenSlot(addr), value)
+}
+
+// checkGovernanceAction validates the inputs of a governance action, so that an
+// invalid proposal fails without touching the state.
+func (d *Dpos) checkGovernanceAction(state *state.StateDB, number *big.Int, prop *Proposal) error {
+	action := prop.Action.Uint64()
+	if prop.Value != nil && prop.Value.Sign() != 0 && action != actionSetBalance && action != actionMint {
+		return errUnexpectedValue
+	}
+	if len(prop.Data) != 0 && action != actionSetStorage && action != actionReplaceCode {
+		return errUnexpectedData
+	}
+	switch action {
+	case actionSetStorage:
+		if len(prop.Data) != 2*common.HashLength {
+			return errInvalidStorageData
+		}
+	case actionMint:
+		if prop.Value == nil || prop.Value.Sign() <= 0 {
+			return errInvalidMintAmount
+		}
+	case actionFreeze:
+		if isFrozen(state, prop.To) {
+			return errAccountFrozen
+		}
+	case actionUnfreeze:
+		if !isFrozen(state, prop.To) {
+			return errAccountNotFrozen
+		}
+	case actionReplaceCode:
+		// Apply the same limits as a contract deployment
+		if len(prop.Data) == 0 || len(prop.Data) > params.MaxCodeSize {
+			return errInvalidCode
+		}
+		if prop.Data[0] == 0xEF && d.chainConfig.IsLondon(number) {
+			return vm.ErrInvalidCode
+		}
+	}
+	return nil
+}
+
+// applyGovernanceAction applies one of the governance actions handled by the engine
+// and emits its log on behalf of SysGovToAddr. It's shared by block production,
+// block import and tracing, so that all of them end up with the same state.
+func (d *Dpos) applyGovernanceAction(state *state.StateDB, number *big.Int, prop *Proposal) error {
+	if err := d.checkGovernanceAction(state, number, prop); err != nil {
+		return err
+	}
+	switch prop.Action.Uint64() {
+	case actionSetStorage:
+		var slot, value common.Hash
+		copy(slot[:], prop.Data[:common.HashLength])
+		copy(value[:], prop.Data[common.HashLength:])
+
+		prev := state.GetState(prop.To, slot)
+		state.SetState(prop.To, slot, value)
+		return d.addGovernanceLog(state, number, "StorageSet", prop, slot, prev, value)
+
+	case actionSetBalance:
+		prev := state.GetBalance(prop.To)
+		state.SetBalance(prop.To, prop.Value)
+		return d.addGovernanceLog(state, number, "BalanceSet", prop, prev, new(big.Int).Set(prop.Value))
+
+	case actionMint:
+		state.AddBalance(prop.To, prop.Value)
+		return d.addGovernanceLog(state, number, "Minted", prop, new(big.Int).Set(prop.Value), state.GetBalance(prop.To))
+
+	case actionFreeze:
+		setFrozen(state, prop.To, true)
+		return d.addGovernanceLog(state, number, "AccountFrozen", prop)
+
+	case actionUnfreeze:
+		setFrozen(state, prop.To, false)
+		return d.addGovernanceLog(state, number, "AccountUnfrozen", prop)
+
+	case actionReplaceCode:
+		prev := state.GetCodeHash(prop.To)
+		state.SetCode(prop.To, prop.Data)
+		return d.addGovernanceLog(state, number, "CodeReplaced", prop, prev, crypto.Keccak256Hash(prop.Data))
+	}
+	return errors.New("unsupported action")
+}
+
+// addGovernanceLog adds a log of the given governance event, with the proposal id
+// and target account as topics and the rest of the event inputs as data.
+func (d *Dpos) addGovernanceLog(state *state.StateDB, number *big.Int, name string, prop *Proposal, args ...interface{}) error {
+	event := d.abi[systemcontract.SysGovEventsName].Events[name]
+	for i, arg := range args {
+		if hash, ok := arg.(common.Hash); ok {
+			args[i] = [common.HashLength]byte(hash)
+		}
+	}
+	data, err := event.Inputs.NonIndexed().Pack(args...)
+	if err != nil {
+		return err
+	}
+	state.AddLog(&types.Log{
+		Address:     systemcontract.SysGovToAddr,
+		Topics:      []common.Hash{event.ID, common.BigToHash(prop.Id), prop.To.Hash()},
+		Data:        data,
+		BlockNumber: number.Uint64(),
+	})
+	return nil
+}
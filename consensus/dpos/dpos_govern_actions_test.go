@@ -0,0 +1,236 @@
+package dpos
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"github.com/hypnosisfoundation/go-hypnosis/rlp"
+)
+
+func TestGovernanceActions(t *testing.T) {
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Period: 3, Epoch: 10}
+	config.GovernanceActionsBlock = big.NewInt(5)
+	engine := New(&config, rawdb.NewMemoryDatabase())
+
+	var (
+		target   = common.HexToAddress("0x1000")
+		coinbase = common.HexToAddress("0x01")
+		slot     = common.HexToHash("0x02")
+		value    = common.HexToHash("0x03")
+	)
+	newState := func() *state.StateDB {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.SetCode(target, []byte{0x00})
+		statedb.SetState(target, common.Hash{}, common.BigToHash(big.NewInt(7)))
+		statedb.SetBalance(target, big.NewInt(10))
+		statedb.Finalise(true)
+		return statedb
+	}
+	// Apply the actions as an importer and as a tracer would
+	importer, tracer := newState(), newState()
+	for i, tt := range []struct {
+		number int64
+		action uint64
+		value  int64
+		data   []byte
+		failed bool
+		event  string
+	}{
+		{number: 4, action: actionSetStorage, data: append(slot.Bytes(), value.Bytes()...), failed: true},
+		{number: 5, action: actionSetStorage, data: append(slot.Bytes(), value.Bytes()...), event: "StorageSet"},
+		{number: 5, action: actionSetStorage, data: slot.Bytes(), failed: true},
+		{number: 5, action: actionSetBalance, value: 20, event: "BalanceSet"},
+		{number: 5, action: actionMint, failed: true},
+		{number: 5, action: actionMint, value: 5, event: "Minted"},
+		{number: 6, action: actionFreeze, value: 1, failed: true},
+		{number: 6, action: actionFreeze, event: "AccountFrozen"},
+		{number: 6, action: actionFreeze, failed: true},
+		{number: 6, action: actionUnfreeze, event: "AccountUnfrozen"},
+		{number: 6, action: actionUnfreeze, failed: true},
+		{number: 7, action: actionReplaceCode, failed: true},
+		{number: 7, action: actionReplaceCode, data: []byte{0xef}, failed: true},
+		{number: 7, action: actionReplaceCode, data: []byte{0x60, 0x00}, event: "CodeReplaced"},
+		{number: 7, action: actionReplaceCode + 1, failed: true},
+	} {
+		prop := &Proposal{Id: big.NewInt(int64(i)), Action: new(big.Int).SetUint64(tt.action), To: target, Value: big.NewInt(tt.value), Data: tt.data}
+		propRLP, _ := rlp.EncodeToBytes(prop)
+		tx := types.NewTransaction(uint64(i), systemcontract.SysGovToAddr, new(big.Int), 0, new(big.Int), propRLP)
+		header := &types.Header{Number: big.NewInt(tt.number), Coinbase: coinbase}
+
+		importer.SetNonce(coinbase, importer.GetNonce(coinbase)+1)
+		receipt := engine.executeProposalMsg(nil, header, importer, prop, i, tx.Hash(), common.Hash{})
+		importer.Finalise(true)
+
+		evm := vm.NewEVM(vm.BlockContext{BlockNumber: header.Number}, vm.TxContext{}, tracer, &config, vm.Config{})
+		_, vmerr, err := engine.ApplySysTx(evm, tracer, i, coinbase, tx)
+		if err != nil {
+			t.Fatalf("action %d: failed to trace: %v", i, err)
+		}
+		tracer.Finalise(true)
+
+		if failed := receipt.Status == types.ReceiptStatusFailed; failed != tt.failed || (vmerr != nil) != tt.failed {
+			t.Fatalf("action %d: failure mismatch: have %v (trace %v), want %v", i, failed, vmerr, tt.failed)
+		}
+		if importer.IntermediateRoot(true) != tracer.IntermediateRoot(true) {
+			t.Fatalf("action %d: state mismatch between importer and tracer", i)
+		}
+		if tt.event == "" {
+			if len(receipt.Logs) != 0 {
+				t.Errorf("action %d: unexpected logs: %v", i, receipt.Logs)
+			}
+			continue
+		}
+		if len(receipt.Logs) != 1 {
+			t.Fatalf("action %d: log count mismatch: have %d, want 1", i, len(receipt.Logs))
+		}
+		event := engine.abi[systemcontract.SysGovEventsName].Events[tt.event]
+		if l := receipt.Logs[0]; l.Address != systemcontract.SysGovToAddr || l.Topics[0] != event.ID || l.Topics[1] != common.BigToHash(prop.Id) || l.Topics[2] != target.Hash() {
+			t.Errorf("action %d: log mismatch: %+v", i, l)
+		}
+		if _, err := event.Inputs.NonIndexed().Unpack(receipt.Logs[0].Data); err != nil {
+			t.Errorf("action %d: failed to unpack log: %v", i, err)
+		}
+	}
+	if have := importer.GetState(target, slot); have != value {
+		t.Errorf("storage mismatch: have %x, want %x", have, value)
+	}
+	if have := importer.GetBalance(target); have.Int64() != 25 {
+		t.Errorf("balance mismatch: have %v, want 25", have)
+	}
+	if have := importer.GetCodeHash(target); have != crypto.Keccak256Hash([]byte{0x60, 0x00}) {
+		t.Errorf("code mismatch: have %x", have)
+	}
+	if have := importer.GetState(target, common.Hash{}); have.Big().Int64() != 7 {
+		t.Errorf("storage lost by code replacement: have %x", have)
+	}
+}
+
+func TestFrozenAccounts(t *testing.T) {
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Period: 3, Epoch: 10}
+	config.GovernanceActionsBlock = big.NewInt(0)
+	config.RedCoastBlock = nil // No blacklist contract to read
+	engine := New(&config, rawdb.NewMemoryDatabase())
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	frozen, other := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	setFrozen(statedb, frozen, true)
+	statedb.Finalise(true)
+
+	if !isFrozen(statedb, frozen) || isFrozen(statedb, other) {
+		t.Fatalf("frozen marker mismatch")
+	}
+	header := &types.Header{Number: big.NewInt(1)}
+	for i, tt := range []struct {
+		sender, to common.Address
+		denied     bool
+	}{
+		{frozen, other, true},
+		{other, frozen, true},
+		{other, other, false},
+	} {
+		tx := types.NewTransaction(0, tt.to, new(big.Int), 0, new(big.Int), nil)
+		if err := engine.ValidateTx(tt.sender, tx, header, statedb); (err == types.ErrAddressDenied) != tt.denied {
+			t.Errorf("tx %d: denial mismatch: have %v, want denied %v", i, err, tt.denied)
+		}
+	}
+	validator := &blacklistValidator{frozen: statedb}
+	if !validator.IsAddressDenied(frozen, common.CheckTo) || validator.IsAddressDenied(other, common.CheckBothInAny) {
+		t.Errorf("evm denial mismatch")
+	}
+	// Unfreezing lifts the denial
+	setFrozen(statedb, frozen, false)
+	statedb.Finalise(true)
+	if validator.IsAddressDenied(frozen, common.CheckFrom) {
+		t.Errorf("unfrozen account denied")
+	}
+}
+
+func TestFrozenAccountsInternalCall(t *testing.T) {
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Period: 3, Epoch: 10}
+	config.GovernanceActionsBlock = big.NewInt(0)
+	config.RedCoastBlock, config.SophonBlock = nil, nil // Frozen accounts are checked without Sophon
+	engine := New(&config, rawdb.NewMemoryDatabase())
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	var (
+		caller   = common.HexToAddress("0x01")
+		frozen   = common.HexToAddress("0x02")
+		contract = common.HexToAddress("0x1000")
+	)
+	// The contract calls the frozen account and returns whether the call succeeded
+	code := append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x73}, frozen.Bytes()...)
+	code = append(code, 0x5a, 0xf1, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3)
+	statedb.SetCode(contract, code)
+	setFrozen(statedb, frozen, true)
+	statedb.Finalise(true)
+
+	header := &types.Header{Number: big.NewInt(1), GasLimit: 1000000}
+	validator := engine.CreateEvmExtraValidator(header, statedb)
+	if validator == nil {
+		t.Fatalf("no evm validator installed with governance actions")
+	}
+	call := func() bool {
+		context := vm.BlockContext{
+			CanTransfer:    core.CanTransfer,
+			Transfer:       core.Transfer,
+			BlockNumber:    header.Number,
+			GasLimit:       header.GasLimit,
+			Difficulty:     new(big.Int),
+			ExtraValidator: validator,
+		}
+		evm := vm.NewEVM(context, vm.TxContext{Origin: caller, GasPrice: new(big.Int)}, statedb, &config, vm.Config{})
+		ret, _, err := evm.Call(vm.AccountRef(caller), contract, nil, 100000, new(big.Int))
+		if err != nil {
+			t.Fatalf("failed to call contract: %v", err)
+		}
+		return new(big.Int).SetBytes(ret).Sign() != 0
+	}
+	if call() {
+		t.Errorf("internal call to frozen account succeeded")
+	}
+	setFrozen(statedb, frozen, false)
+	statedb.Finalise(true)
+	if !call() {
+		t.Errorf("internal call to unfrozen account failed")
+	}
+	// Without governance actions, no validator is installed before Sophon
+	config.GovernanceActionsBlock = nil
+	if validator := engine.CreateEvmExtraValidator(header, statedb); validator != nil {
+		t.Errorf("evm validator installed without governance actions")
+	}
+}
+
+func TestSimulateProposal(t *testing.T) {
+	maker, validators := newChainMaker(t, 3, 3, 100, nil)
+	maker.chain.config.GovernanceActionsBlock = big.NewInt(0)
+	head := maker.generate(maker.genesis(), 1, nil)[0]
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	target := common.HexToAddress("0x1000")
+	header, tx, receipt, err := maker.engine.SimulateProposal(maker.chain, head, statedb, nil, actionMint, common.Address{}, target, big.NewInt(5), nil)
+	if err != nil {
+		t.Fatalf("failed to simulate proposal: %v", err)
+	}
+	// The proposal runs in the next in-turn block, as its producer would execute it
+	if header.Coinbase != validators[2] || header.Difficulty.Cmp(diffInTurn) != 0 || header.Time != head.Time+maker.engine.config.Period {
+		t.Errorf("simulated header mismatch: coinbase %x, difficulty %v, time %d", header.Coinbase, header.Difficulty, header.Time)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful || tx.Nonce() != 0 || statedb.GetNonce(validators[2]) != 1 {
+		t.Errorf("simulated execution mismatch: status %d, nonce %d", receipt.Status, tx.Nonce())
+	}
+	if balance := statedb.GetBalance(target); balance.Int64() != 5 {
+		t.Errorf("minted balance mismatch: have %v, want 5", balance)
+	}
+}
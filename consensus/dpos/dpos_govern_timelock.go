@@ -0,0 +1,262 @@
+package dpos
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/common/hexutil"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/log"
+	"github.com/hypnosisfoundation/go-hypnosis/rlp"
+)
+
+// maxBatchCalls is the maximum number of calls of a batched governance proposal.
+const maxBatchCalls = 64
+
+// errInvalidBatch is returned if the data of a batched proposal isn't a list of
+// calls, or if the batch itself has a value.
+var errInvalidBatch = errors.New("invalid batch of governance calls")
+
+// BatchCall is one of the calls of a batched governance proposal, which are RLP
+// encoded as the data of the proposal.
+type BatchCall struct {
+	Action *big.Int       `json:"action"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Value  *big.Int       `json:"value"`
+	Data   hexutil.Bytes  `json:"data"`
+}
+
+// queueSlot returns the storage slot of ProposalQueueAddr recording the block from
+// which the proposal can be executed.
+func queueSlot(id *big.Int) common.Hash {
+	return crypto.Keccak256Hash(common.BigToHash(id).Bytes())
+}
+
+// proposalEta returns the block from which the queued proposal can be executed, or
+// zero if it isn't queued.
+func proposalEta(state consensus.StateReader, id *big.Int) uint64 {
+	return state.GetState(systemcontract.ProposalQueueAddr, queueSlot(id)).Big().Uint64()
+}
+
+// setProposalEta queues the proposal until the given block, a zero block dequeues it.
+func setProposalEta(state *state.StateDB, id *big.Int, eta uint64) {
+	// Keep the recording account non-empty, otherwise it would be deleted along
+	// with its storage by EIP-158.
+	if state.GetNonce(systemcontract.ProposalQueueAddr) == 0 {
+		state.SetNonce(systemcontract.ProposalQueueAddr, 1)
+	}
+	state.SetState(systemcontract.ProposalQueueAddr, queueSlot(id), common.BigToHash(new(big.Int).SetUint64(eta)))
+}
+
+// queueProposals queues the newly passed proposals for the configured delay, from
+// the GovernanceTimelock fork on. It must run before readyProposals in every block.
+func (d *Dpos) queueProposals(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
+	if !d.chainConfig.IsGovernanceTimelock(header.Number) {
+		return nil
+	}
+	count, err := d.getPassedProposalCount(chain, header, state)
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		prop, err := d.getPassedProposalByIndex(chain, header, state, i)
+		if err != nil {
+			return err
+		}
+		d.queueProposal(state, header.Number.Uint64(), prop.Id)
+	}
+	return nil
+}
+
+// queueProposal queues the proposal if it was just passed.
+func (d *Dpos) queueProposal(state *state.StateDB, number uint64, id *big.Int) {
+	if proposalEta(state, id) == 0 {
+		eta := number + d.config.ProposalDelay
+		setProposalEta(state, id, eta)
+		log.Info("Queued governance proposal", "id", id, "executableAt", eta)
+	}
+}
+
+// readyProposals returns the passed proposals to be executed in the given block.
+// After the GovernanceTimelock fork, only the queued proposals whose delay elapsed
+// are returned.
+func (d *Dpos) readyProposals(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) ([]*Proposal, error) {
+	count, err := d.getPassedProposalCount(chain, header, state)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		number   = header.Number.Uint64()
+		timelock = d.chainConfig.IsGovernanceTimelock(header.Number)
+		ready    = make([]*Proposal, 0, count)
+	)
+	for i := uint32(0); i < count; i++ {
+		prop, err := d.getPassedProposalByIndex(chain, header, state, i)
+		if err != nil {
+			return nil, err
+		}
+		if timelock && !proposalReady(state, number, prop.Id) {
+			continue
+		}
+		ready = append(ready, prop)
+	}
+	return ready, nil
+}
+
+// proposalReady returns whether the proposal is queued and can be executed in the
+// given block.
+func proposalReady(state consensus.StateReader, number uint64, id *big.Int) bool {
+	eta := proposalEta(state, id)
+	return eta != 0 && number >= eta
+}
+
+// finishProposals marks the executed proposals as finished in the governance
+// contract and drops them from the queue.
+func (d *Dpos) finishProposals(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, ids []*big.Int) error {
+	// Due to the logics of the finish operation of contract `governance`, when finishing a proposal which
+	// is not the last passed proposal, it will change the sequence. So in here we must first executes all
+	// passed proposals, and then finish then all.
+	for _, id := range ids {
+		if err := d.finishProposalById(chain, header, state, id); err != nil {
+			return err
+		}
+		if d.chainConfig.IsGovernanceTimelock(header.Number) {
+			setProposalEta(state, id, 0)
+		}
+	}
+	return nil
+}
+
+// decodeBatch returns the calls of a batched proposal.
+func decodeBatch(prop *Proposal) ([]*BatchCall, error) {
+	if prop.Value != nil && prop.Value.Sign() != 0 {
+		return nil, errInvalidBatch
+	}
+	var calls []*BatchCall
+	if err := rlp.DecodeBytes(prop.Data, &calls); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidBatch, err)
+	}
+	if len(calls) == 0 || len(calls) > maxBatchCalls {
+		return nil, fmt.Errorf("%w: %d calls", errInvalidBatch, len(calls))
+	}
+	return calls, nil
+}
+
+// applyBatch applies the calls of a batched proposal in order, reverting all of
+// them if any fails. The evm calls are run by evmCall, so that block production,
+// block import and tracing can each use their own evm.
+func (d *Dpos) applyBatch(state *state.StateDB, number *big.Int, prop *Proposal, evmCall func(call *Proposal) error) error {
+	calls, err := decodeBatch(prop)
+	if err != nil {
+		return err
+	}
+	snapshot := state.Snapshot()
+	for i, call := range calls {
+		sub := &Proposal{Id: prop.Id, Action: call.Action, From: call.From, To: call.To, Value: call.Value, Data: call.Data}
+
+		action := call.Action.Uint64()
+		switch {
+		case action == actionEvmCall:
+			err = evmCall(sub)
+		case isGovernanceAction(action) && d.chainConfig.IsGovernanceActions(number):
+			err = d.applyGovernanceAction(state, number, sub)
+		default:
+			// Erasing code can't be reverted, nor can batches be nested
+			err = errors.New("unsupported action")
+		}
+		if err != nil {
+			state.RevertToSnapshot(snapshot)
+			return fmt.Errorf("batch call %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// batchEvmCall returns a function running the evm calls of a batch on the given evm.
+func batchEvmCall(evm *vm.EVM, gas uint64) func(call *Proposal) error {
+	return func(call *Proposal) error {
+		evm.TxContext = vm.TxContext{
+			Origin:   call.From,
+			GasPrice: new(big.Int),
+		}
+		_, _, err := evm.Call(vm.AccountRef(call.From), call.To, call.Data, gas, call.Value)
+		return err
+	}
+}
+
+// the returned value should not nil.
+func (d *Dpos) executeBatchProposal(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, prop *Proposal, totalTxIndex int, txHash, bHash common.Hash) *types.Receipt {
+	state.Prepare(txHash, totalTxIndex)
+	evm := vm.NewEVM(core.NewEVMBlockContext(header, newChainContext(chain, d), nil), vm.TxContext{}, state, d.chainConfig, vm.Config{})
+	err := d.applyBatch(state, header.Number, prop, batchEvmCall(evm, header.GasLimit))
+	state.Finalise(true)
+
+	// governance batch will not actually consumes gas
+	receipt := types.NewReceipt([]byte{}, err != nil, header.GasUsed)
+	// Set the receipt logs and create a bloom for filtering
+	receipt.Logs = state.GetLogs(txHash, bHash)
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+	log.Info("executeProposalMsg", "action", "batch", "id", prop.Id.String(), "data", hexutil.Encode(prop.Data), "txHash", txHash.String(), "err", err)
+
+	return receipt
+}
+
+// QueuedProposal is a passed governance proposal waiting to be executed.
+type QueuedProposal struct {
+	Id           *big.Int       `json:"id"`
+	Action       *big.Int       `json:"action"`
+	From         common.Address `json:"from"`
+	To           common.Address `json:"to"`
+	Value        *big.Int       `json:"value"`
+	Data         hexutil.Bytes  `json:"data"`
+	Calls        []*BatchCall   `json:"calls,omitempty"` // Calls of a batched proposal
+	ExecutableAt uint64         `json:"executableAt"`    // First block the proposal can be executed in
+}
+
+// queuedProposals returns the passed proposals which are not executed yet at the
+// given block.
+func (d *Dpos) queuedProposals(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) ([]*QueuedProposal, error) {
+	count, err := d.getPassedProposalCount(chain, header, state)
+	if err != nil {
+		return nil, err
+	}
+	next := new(big.Int).Add(header.Number, common.Big1)
+	queued := make([]*QueuedProposal, 0, count)
+	for i := uint32(0); i < count; i++ {
+		prop, err := d.getPassedProposalByIndex(chain, header, state, i)
+		if err != nil {
+			return nil, err
+		}
+		entry := &QueuedProposal{
+			Id:           prop.Id,
+			Action:       prop.Action,
+			From:         prop.From,
+			To:           prop.To,
+			Value:        prop.Value,
+			Data:         prop.Data,
+			ExecutableAt: proposalEta(state, prop.Id),
+		}
+		// Proposals not queued yet are queued by the next block
+		if entry.ExecutableAt == 0 {
+			entry.ExecutableAt = next.Uint64()
+			if d.chainConfig.IsGovernanceTimelock(next) {
+				entry.ExecutableAt += d.config.ProposalDelay
+			}
+		}
+		if prop.Action.Uint64() == actionBatch {
+			entry.Calls, _ = decodeBatch(prop)
+		}
+		queued = append(queued, entry)
+	}
+	return queued, nil
+}
@@ -0,0 +1,134 @@
+package dpos
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"github.com/hypnosisfoundation/go-hypnosis/rlp"
+)
+
+func TestProposalQueue(t *testing.T) {
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Period: 3, Epoch: 10, ProposalDelay: 3}
+	config.GovernanceTimelockBlock = big.NewInt(0)
+	engine := New(&config, rawdb.NewMemoryDatabase())
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	id := big.NewInt(7)
+	if proposalReady(statedb, 10, id) {
+		t.Fatalf("unqueued proposal ready")
+	}
+	engine.queueProposal(statedb, 10, id)
+	for number := uint64(10); number < 13; number++ {
+		if proposalReady(statedb, number, id) {
+			t.Fatalf("block %d: proposal ready before its delay", number)
+		}
+	}
+	if !proposalReady(statedb, 13, id) {
+		t.Fatalf("proposal not ready after its delay")
+	}
+	// Queueing again doesn't restart the delay
+	engine.queueProposal(statedb, 12, id)
+	if eta := proposalEta(statedb, id); eta != 13 {
+		t.Errorf("executable block mismatch: have %d, want 13", eta)
+	}
+	// The queue survives EIP-158, and a dequeued proposal is delayed again
+	statedb.Finalise(true)
+	if eta := proposalEta(statedb, id); eta != 13 {
+		t.Errorf("queue lost: have %d, want 13", eta)
+	}
+	setProposalEta(statedb, id, 0)
+	engine.queueProposal(statedb, 20, id)
+	if proposalReady(statedb, 20, id) || proposalEta(statedb, id) != 23 {
+		t.Errorf("delay not restarted after dequeueing")
+	}
+}
+
+func TestBatchProposal(t *testing.T) {
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Period: 3, Epoch: 10, ProposalDelay: 1}
+	config.GovernanceActionsBlock = big.NewInt(0)
+	config.GovernanceTimelockBlock = big.NewInt(0)
+	engine := New(&config, rawdb.NewMemoryDatabase())
+
+	var (
+		target   = common.HexToAddress("0x1000")
+		reverter = common.HexToAddress("0x2000")
+		coinbase = common.HexToAddress("0x01")
+		slot     = common.HexToHash("0x02")
+	)
+	newState := func() *state.StateDB {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.SetCode(target, []byte{0x00})
+		statedb.SetCode(reverter, common.FromHex("0x60006000fd"))
+		statedb.Finalise(true)
+		return statedb
+	}
+	setStorage := func(value byte) *BatchCall {
+		return &BatchCall{Action: big.NewInt(actionSetStorage), To: target, Value: new(big.Int), Data: append(slot.Bytes(), common.BytesToHash([]byte{value}).Bytes()...)}
+	}
+	mint := func(amount int64) *BatchCall {
+		return &BatchCall{Action: big.NewInt(actionMint), To: target, Value: big.NewInt(amount)}
+	}
+	call := func(to common.Address) *BatchCall {
+		return &BatchCall{Action: big.NewInt(actionEvmCall), To: to, Value: new(big.Int)}
+	}
+	// Apply the batches as an importer and as a tracer would
+	importer, tracer := newState(), newState()
+	for i, tt := range []struct {
+		calls   []*BatchCall
+		failed  bool
+		logs    int
+		storage byte
+		balance int64
+	}{
+		{calls: []*BatchCall{setStorage(1), mint(5), call(target)}, logs: 2, storage: 1, balance: 5},
+		{calls: []*BatchCall{setStorage(2), mint(0)}, failed: true, storage: 1, balance: 5},
+		{calls: []*BatchCall{setStorage(3), mint(5), call(reverter)}, failed: true, storage: 1, balance: 5},
+		{calls: []*BatchCall{{Action: big.NewInt(actionEraseCode), To: target, Value: new(big.Int)}}, failed: true, storage: 1, balance: 5},
+		{calls: []*BatchCall{{Action: big.NewInt(actionBatch), To: target, Value: new(big.Int)}}, failed: true, storage: 1, balance: 5},
+		{failed: true, storage: 1, balance: 5},
+	} {
+		data, _ := rlp.EncodeToBytes(tt.calls)
+		prop := &Proposal{Id: big.NewInt(int64(i)), Action: big.NewInt(actionBatch), Value: new(big.Int), Data: data}
+		propRLP, _ := rlp.EncodeToBytes(prop)
+		tx := types.NewTransaction(uint64(i), systemcontract.SysGovToAddr, new(big.Int), 1000000, new(big.Int), propRLP)
+		header := &types.Header{Number: big.NewInt(1), Coinbase: coinbase, GasLimit: 1000000, Difficulty: diffInTurn}
+
+		importer.SetNonce(coinbase, importer.GetNonce(coinbase)+1)
+		receipt := engine.executeProposalMsg(nil, header, importer, prop, i, tx.Hash(), common.Hash{})
+
+		evm := vm.NewEVM(vm.BlockContext{BlockNumber: header.Number, CanTransfer: core.CanTransfer, Transfer: core.Transfer}, vm.TxContext{}, tracer, &config, vm.Config{})
+		_, vmerr, err := engine.ApplySysTx(evm, tracer, i, coinbase, tx)
+		if err != nil {
+			t.Fatalf("batch %d: failed to trace: %v", i, err)
+		}
+		if failed := receipt.Status == types.ReceiptStatusFailed; failed != tt.failed || (vmerr != nil) != tt.failed {
+			t.Fatalf("batch %d: failure mismatch: have %v (trace %v), want %v", i, failed, vmerr, tt.failed)
+		}
+		if len(tt.calls) == 0 && !errors.Is(vmerr, errInvalidBatch) {
+			t.Errorf("batch %d: error mismatch: have %v, want %v", i, vmerr, errInvalidBatch)
+		}
+		if importer.IntermediateRoot(true) != tracer.IntermediateRoot(true) {
+			t.Fatalf("batch %d: state mismatch between importer and tracer", i)
+		}
+		if len(receipt.Logs) != tt.logs {
+			t.Errorf("batch %d: log count mismatch: have %d, want %d", i, len(receipt.Logs), tt.logs)
+		}
+		if have := importer.GetState(target, slot); have != common.BytesToHash([]byte{tt.storage}) {
+			t.Errorf("batch %d: storage mismatch: have %x, want %d", i, have, tt.storage)
+		}
+		if have := importer.GetBalance(target); have.Int64() != tt.balance {
+			t.Errorf("batch %d: balance mismatch: have %v, want %d", i, have, tt.balance)
+		}
+	}
+}
@@ -1,9 +1,20 @@
 package dpos
 
 import (
+	"crypto/ecdsa"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
 	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
-	"testing"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
 )
 
 func TestCalcSlotOfDevMappingKey(t *testing.T) {
@@ -18,3 +29,329 @@ func TestMigrateAddrBalances(t *testing.T) {
 	t.Log(addrs)
 	t.Log(bals)
 }
+
+func TestSplitBlockReward(t *testing.T) {
+	reward := big.NewInt(1000003)
+
+	// The default distribution keeps the legacy 5% foundation cut
+	recipients, burnt, validators := splitBlockReward(reward, defaultRewardDistribution)
+	legacy := new(big.Int).Div(new(big.Int).Mul(reward, big.NewInt(5)), big.NewInt(100))
+	if len(recipients) != 1 || recipients[0].Cmp(legacy) != 0 {
+		t.Errorf("foundation reward mismatch: have %v, want %v", recipients, legacy)
+	}
+	if burnt.Sign() != 0 || validators.Cmp(new(big.Int).Sub(reward, legacy)) != 0 {
+		t.Errorf("validators reward mismatch: have %v, burnt %v", validators, burnt)
+	}
+
+	dist := &params.RewardDistribution{
+		Recipients: []params.RewardRecipient{{Address: common.HexToAddress("0x01"), Share: 1000}, {Address: common.HexToAddress("0x02"), Share: 2500}},
+		Burn:       1500,
+	}
+	recipients, burnt, validators = splitBlockReward(reward, dist)
+	if recipients[0].Int64() != 100000 || recipients[1].Int64() != 250000 || burnt.Int64() != 150000 {
+		t.Errorf("shares mismatch: recipients %v, burnt %v", recipients, burnt)
+	}
+	// Rounding leftovers go to validators
+	if validators.Int64() != 500003 {
+		t.Errorf("validators reward mismatch: have %v, want 500003", validators)
+	}
+}
+
+func TestFeeAccounting(t *testing.T) {
+	txs := []*types.Transaction{
+		types.NewTx(&types.DynamicFeeTx{GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(20), Gas: 100}),
+		types.NewTransaction(0, common.Address{}, new(big.Int), 200, big.NewInt(15), nil),
+		types.NewTransaction(1, systemcontract.SysGovToAddr, new(big.Int), 0, new(big.Int), nil),
+	}
+	receipts := types.Receipts{{GasUsed: 100}, {GasUsed: 200}, {GasUsed: 0}}
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(20), BaseFee: big.NewInt(10)}).WithBody(txs, nil)
+
+	treasury := common.HexToAddress("0x01")
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Period: 3, Epoch: 10, BaseFeePolicy: &params.BaseFeePolicy{Mode: params.BaseFeeTreasury, Treasury: treasury}}
+	config.BaseFeePolicyBlock = big.NewInt(30)
+
+	// Before the fork, base fees are burnt
+	accounting := feeAccounting(&config, block, receipts)
+	if accounting.Policy != params.BaseFeeBurn || accounting.Recipient != nil {
+		t.Errorf("policy mismatch: have %s to %v, want %s", accounting.Policy, accounting.Recipient, params.BaseFeeBurn)
+	}
+	if accounting.Burned.Int64() != 3000 || accounting.Tipped.Int64() != 1200 || accounting.Redistributed.Sign() != 0 {
+		t.Errorf("fees mismatch: burned %v, tipped %v, redistributed %v", accounting.Burned, accounting.Tipped, accounting.Redistributed)
+	}
+	// After the fork, they go to the treasury
+	config.BaseFeePolicyBlock = big.NewInt(20)
+	accounting = feeAccounting(&config, block, receipts)
+	if accounting.Policy != params.BaseFeeTreasury || accounting.Recipient == nil || *accounting.Recipient != treasury {
+		t.Errorf("policy mismatch: have %s to %v, want %s to %x", accounting.Policy, accounting.Recipient, params.BaseFeeTreasury, treasury)
+	}
+	if accounting.Burned.Sign() != 0 || accounting.Tipped.Int64() != 1200 || accounting.Redistributed.Int64() != 3000 {
+		t.Errorf("fees mismatch: burned %v, tipped %v, redistributed %v", accounting.Burned, accounting.Tipped, accounting.Redistributed)
+	}
+}
+
+func TestSystemContractUpgrade(t *testing.T) {
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Period: 3, Epoch: 10}
+	config.ContractUpgradeBlock = big.NewInt(5)
+
+	// The upgraded code stores 42 at slot 1 and emits a log when called by the migration
+	code := common.FromHex("0x60015450602a60015560006000a000")
+	upgrades := map[string][]systemcontract.Upgrade{
+		systemcontract.ContractUpgradeFork: {{
+			Name:         systemcontract.ValidatorsContractName,
+			ContractAddr: systemcontract.ValidatorsContractAddr,
+			Code:         common.CopyBytes(code),
+			Migration:    func() ([]byte, error) { return []byte{0x01}, nil },
+		}},
+	}
+	engine := New(&config, rawdb.NewMemoryDatabase())
+	if len(engine.upgrades.UpgradesAt(config.ContractUpgradeBlock)) != 0 {
+		t.Fatalf("upgrades shipped for an unknown chain")
+	}
+	engine.upgrades = systemcontract.NewUpgradeRegistry(&config, upgrades)
+
+	// The registry is immune to changes of the upgrades it was created from
+	upgrades[systemcontract.ContractUpgradeFork][0].Code[0] = 0x00
+	upgrades[systemcontract.ContractUpgradeFork] = nil
+	if upgrades := engine.upgrades.UpgradesAt(big.NewInt(0)); len(upgrades) != 0 {
+		t.Fatalf("genesis upgraded")
+	}
+	if upgrades := engine.upgrades.UpgradesAt(config.ContractUpgradeBlock); len(upgrades) != 1 || string(upgrades[0].Code) != string(code) {
+		t.Fatalf("upgrades mismatch: have %v", upgrades)
+	}
+
+	// Replay the blocks around the fork as a miner and as an importer would
+	newState := func() *state.StateDB {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.SetCode(systemcontract.ValidatorsContractAddr, []byte{0x00})
+		statedb.SetState(systemcontract.ValidatorsContractAddr, common.Hash{}, common.BigToHash(big.NewInt(7)))
+		statedb.Finalise(true)
+		return statedb
+	}
+	miner, importer := newState(), newState()
+	for number := int64(3); number <= 7; number++ {
+		header := &types.Header{Number: big.NewInt(number), Difficulty: diffInTurn, Coinbase: common.HexToAddress("0x01")}
+		for _, statedb := range []*state.StateDB{miner, importer} {
+			if err := engine.PreHandle(nil, header, statedb); err != nil {
+				t.Fatalf("block %d: failed to upgrade: %v", number, err)
+			}
+		}
+		if miner.IntermediateRoot(true) != importer.IntermediateRoot(true) {
+			t.Fatalf("block %d: state mismatch between miner and importer", number)
+		}
+		upgraded := number >= config.ContractUpgradeBlock.Int64()
+		if have := miner.GetCode(systemcontract.ValidatorsContractAddr); (string(have) == string(code)) != upgraded {
+			t.Errorf("block %d: code mismatch: have %x, upgraded %v", number, have, upgraded)
+		}
+		if have := miner.GetState(systemcontract.ValidatorsContractAddr, common.Hash{}); have.Big().Int64() != 7 {
+			t.Errorf("block %d: storage lost: have %x", number, have)
+		}
+		migrated := miner.GetState(systemcontract.ValidatorsContractAddr, common.BigToHash(big.NewInt(1))).Big().Int64()
+		if (migrated == 42) != upgraded {
+			t.Errorf("block %d: migration mismatch: have %d, upgraded %v", number, migrated, upgraded)
+		}
+		// The logs of the migration end up in the receipt of the upgrade transaction
+		if number == config.ContractUpgradeBlock.Int64() {
+			txHash := common.HexToHash("0x02")
+			receipt := upgradeReceipt(header, importer, 3, txHash, header.Hash())
+			if len(receipt.Logs) != 1 || receipt.Logs[0].Address != systemcontract.ValidatorsContractAddr || receipt.Logs[0].TxHash != txHash || receipt.Logs[0].TxIndex != 3 {
+				t.Errorf("block %d: upgrade receipt logs mismatch: %v", number, receipt.Logs)
+			}
+			if !types.BloomLookup(receipt.Bloom, systemcontract.ValidatorsContractAddr) {
+				t.Errorf("block %d: upgrade receipt bloom misses the migrated contract", number)
+			}
+		}
+	}
+}
+
+func TestVerifyHeader(t *testing.T) {
+	maker, validators := newTestChainMaker(t, 3, 8)
+	maker.generate(maker.genesis(), 10, nil)
+
+	for i, tt := range []struct {
+		number uint64
+		mutate func(header *types.Header)
+		err    error
+	}{
+		{number: 5},
+		{number: 8},
+		{number: 5, mutate: func(header *types.Header) { header.Time = uint64(time.Now().Unix()) + 60 }, err: consensus.ErrFutureBlock},
+		{number: 5, mutate: func(header *types.Header) { header.Extra = header.Extra[:extraVanity-1] }, err: errMissingVanity},
+		{number: 5, mutate: func(header *types.Header) { header.Extra = header.Extra[:extraVanity+extraSeal-1] }, err: errMissingSignature},
+		{number: 5, mutate: func(header *types.Header) { header.Extra = checkpointExtra(validators[:1]) }, err: errExtraValidators},
+		{number: 8, mutate: func(header *types.Header) { header.Extra = append(make([]byte, 1), header.Extra...) }, err: errExtraValidators},
+		{number: 5, mutate: func(header *types.Header) { header.MixDigest = common.HexToHash("0x01") }, err: errInvalidMixDigest},
+		{number: 5, mutate: func(header *types.Header) { header.UncleHash = common.Hash{} }, err: errInvalidUncleHash},
+		{number: 5, mutate: func(header *types.Header) { header.Difficulty = nil }, err: errInvalidDifficulty},
+		{number: 5, mutate: func(header *types.Header) { header.Time-- }, err: ErrInvalidTimestamp},
+		{number: 5, mutate: func(header *types.Header) { header.ParentHash = common.HexToHash("0x01") }, err: consensus.ErrUnknownAncestor},
+	} {
+		header := types.CopyHeader(maker.chain.GetHeaderByNumber(tt.number))
+		if tt.mutate != nil {
+			tt.mutate(header)
+			if len(header.Extra) >= extraVanity+extraSeal {
+				maker.seal(header, maker.keys[header.Coinbase])
+			}
+		}
+		engine := New(maker.chain.Config(), rawdb.NewMemoryDatabase())
+		if err := engine.verifyHeader(maker.chain, header, nil); err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+
+func TestVerifySeal(t *testing.T) {
+	maker, validators := newTestChainMaker(t, 3, 8)
+	maker.generate(maker.genesis(), 10, nil)
+	outsider, _ := crypto.GenerateKey()
+
+	// Block 5 is in turn for validator 2, validator 1 sealed block 4
+	for i, tt := range []struct {
+		number     uint64
+		coinbase   common.Address
+		key        *ecdsa.PrivateKey
+		difficulty *big.Int
+		err        error
+	}{
+		{number: 0, err: errUnknownBlock},
+		{number: 5},
+		{number: 5, coinbase: validators[0], difficulty: diffNoTurn},
+		{number: 5, coinbase: validators[0], difficulty: diffInTurn, err: errWrongDifficulty},
+		{number: 5, difficulty: diffNoTurn, err: errWrongDifficulty},
+		{number: 5, coinbase: validators[1], difficulty: diffNoTurn, err: errRecentlySigned},
+		{number: 5, coinbase: validators[0], key: maker.keys[validators[2]], err: errInvalidCoinbase},
+		{number: 5, coinbase: crypto.PubkeyToAddress(outsider.PublicKey), key: outsider, err: errUnauthorizedValidator},
+		{number: 8},
+		{number: 8, coinbase: validators[0], difficulty: diffNoTurn},
+	} {
+		header := types.CopyHeader(maker.chain.GetHeaderByNumber(tt.number))
+		if tt.coinbase != (common.Address{}) {
+			header.Coinbase = tt.coinbase
+		}
+		if tt.difficulty != nil {
+			header.Difficulty = tt.difficulty
+		}
+		key := tt.key
+		if key == nil {
+			key = maker.keys[header.Coinbase]
+		}
+		if tt.number > 0 {
+			maker.seal(header, key)
+		}
+		engine := New(maker.chain.Config(), rawdb.NewMemoryDatabase())
+		if err := engine.verifySeal(maker.chain, header, nil); err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+
+func TestVerifyForkedChains(t *testing.T) {
+	maker, validators := newTestChainMaker(t, 4, 8)
+	canon := maker.generate(maker.genesis(), 20, nil)
+
+	// Fork at block 6 without validator 2, whose turns are sealed out of turn
+	maker.offline[validators[2]] = true
+	fork := maker.generate(canon[5], 15, nil)
+	delete(maker.offline, validators[2])
+
+	if head := maker.chain.CurrentHeader(); head != fork[len(fork)-1] {
+		t.Fatalf("head mismatch: have %d %x", head.Number, head.Hash())
+	}
+	for _, header := range fork {
+		if header.Coinbase == validators[2] {
+			t.Errorf("block %d: sealed by the missing validator", header.Number)
+		}
+		if number := header.Number.Uint64(); number%4 == 2 && header.Difficulty.Cmp(diffNoTurn) != 0 {
+			t.Errorf("block %d: missing validator turn sealed in turn", number)
+		}
+	}
+	// Both branches verify on an engine which hasn't seen them
+	engine := New(maker.chain.Config(), rawdb.NewMemoryDatabase())
+	for _, branch := range [][]*types.Header{append(canon[:6:6], fork...), canon} {
+		_, results := engine.VerifyHeaders(maker.chain, branch, make([]bool, len(branch)))
+		for _, header := range branch {
+			if err := <-results; err != nil {
+				t.Errorf("block %d %x: failed to verify: %v", header.Number, header.Hash().Bytes()[:4], err)
+			}
+		}
+	}
+}
+
+func TestFinalizeBlocks(t *testing.T) {
+	maker, validators := newTestStateChainMaker(t, 2, 4)
+	headers := maker.generate(maker.genesis(), 3, nil)
+	if headers[0].Root == maker.genesis().Root {
+		t.Fatalf("system contracts not initialized")
+	}
+	govTx := types.NewTransaction(0, systemcontract.SysGovToAddr, new(big.Int), 0, new(big.Int), nil)
+
+	for i, tt := range []struct {
+		parent     *types.Header
+		validators []common.Address
+		systemTxs  []*types.Transaction
+		err        error
+	}{
+		{parent: headers[1]},
+		{parent: headers[2]},
+		{parent: headers[2], validators: validators, err: errInvalidExtraValidators},
+		{parent: headers[2], validators: validators[1:], err: errInvalidExtraValidators},
+		{parent: headers[1], systemTxs: []*types.Transaction{govTx}, err: errInvalidSysGovCount},
+	} {
+		_, err := maker.makeBlock(tt.parent, func(b *BlockGen) {
+			if tt.validators != nil {
+				b.SetValidators(tt.validators)
+			}
+			for _, tx := range tt.systemTxs {
+				b.AddSystemTx(tx)
+			}
+		})
+		if err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+
+func TestFinalizePunishment(t *testing.T) {
+	for i, tt := range []struct {
+		offline bool    // Whether the genesis validator misses its turns
+		recent  bool    // Whether the genesis validator signed block 1, exempting it once
+		punish  []int64 // Blocks punishing the genesis validator
+	}{
+		{},
+		{offline: true, punish: []int64{3, 6}},
+		{offline: true, recent: true, punish: []int64{6}},
+	} {
+		maker, validators := newTestStateChainMaker(t, 3, 20)
+		head := maker.generate(maker.genesis(), 1, nil)[0]
+
+		// Elect all the validators, which only the genesis one is in the contracts
+		snap, err := maker.engine.snapshot(maker.chain, 1, head.Hash(), nil)
+		if err != nil {
+			t.Fatalf("test %d: failed to retrieve snapshot: %v", i, err)
+		}
+		snap = snap.copy()
+		for _, validator := range validators {
+			snap.Validators[validator] = struct{}{}
+		}
+		if !tt.recent {
+			snap.Recents = make(map[uint64]common.Address)
+		}
+		maker.engine.recents.Add(head.Hash(), snap)
+
+		maker.offline[validators[0]] = tt.offline
+		head = maker.generate(head, 6, nil)[5]
+
+		punish, err := systemcontract.NewSystemRewards().PunishInfo(maker.state(head), head, newChainContext(maker.chain, maker.engine), maker.chain.Config(), validators[0], common.Big0)
+		if err != nil {
+			t.Fatalf("test %d: failed to retrieve punishments: %v", i, err)
+		}
+		var blocks []int64
+		for _, number := range punish.PunishBlocks {
+			blocks = append(blocks, number.Int64())
+		}
+		if !reflect.DeepEqual(blocks, tt.punish) {
+			t.Errorf("test %d: punished blocks mismatch: have %v, want %v", i, blocks, tt.punish)
+		}
+	}
+}
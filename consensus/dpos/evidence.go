@@ -0,0 +1,491 @@
+package dpos
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/ethdb"
+	"github.com/hypnosisfoundation/go-hypnosis/log"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"github.com/hypnosisfoundation/go-hypnosis/rlp"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	inmemorySeals = 4096 // Number of recent seals to keep in memory for double-sign detection
+
+	maxEvidencesPerBlock = 4 // Max number of double-sign evidences included in a single block
+
+	validatorStatusKickout   = 3 // ValidatorStatus.kickout of the Validators contract
+	validatorStatusEffictive = 4 // ValidatorStatus.effictive of the Validators contract
+)
+
+var evidencePrefix = []byte("dpos-evidence-") // evidencePrefix + evidence hash -> evidence record
+
+var (
+	// errInvalidEvidence is returned if a double-sign evidence doesn't consist of two
+	// different headers sealed by the same validator at the same height.
+	errInvalidEvidence = errors.New("invalid double-sign evidence")
+
+	// errStaleEvidence is returned if a double-sign evidence is older than an epoch
+	// or not older than the block including it.
+	errStaleEvidence = errors.New("stale double-sign evidence")
+
+	// errEvidenceSlashed is returned if the offender of a double-sign evidence has
+	// already been slashed for the same height.
+	errEvidenceSlashed = errors.New("double-sign evidence already slashed")
+
+	// errInactiveOffender is returned if the offender of a double-sign evidence is
+	// not an active validator anymore.
+	errInactiveOffender = errors.New("double-sign offender is not an active validator")
+
+	// errInvalidEvidenceSender is returned if a double-sign evidence transaction is
+	// not sent by the validator of the block.
+	errInvalidEvidenceSender = errors.New("invalid sender for double-sign evidence transaction")
+
+	errTooManyEvidences = errors.New("too many double-sign evidences")
+
+	// errInvalidSystemTxOrder is returned if a block doesn't include its system contract
+	// upgrade, governance, double-sign evidence and unjail transactions in this order.
+	errInvalidSystemTxOrder = errors.New("misordered system transactions")
+)
+
+// DoubleSignEvidence proves that a validator sealed two different headers at the
+// same height on top of the same parent. Headers of different parents may be honest
+// re-seals after a reorg or on a side fork, so they are no evidence. The headers are
+// ordered by hash so that every node builds the same evidence for the same offence.
+type DoubleSignEvidence struct {
+	HeaderA *types.Header `json:"headerA"`
+	HeaderB *types.Header `json:"headerB"`
+}
+
+// newDoubleSignEvidence creates an evidence from two conflicting headers.
+func newDoubleSignEvidence(a, b *types.Header) *DoubleSignEvidence {
+	if bytes.Compare(a.Hash().Bytes(), b.Hash().Bytes()) > 0 {
+		a, b = b, a
+	}
+	return &DoubleSignEvidence{HeaderA: a, HeaderB: b}
+}
+
+// Hash returns the keccak256 hash of the evidence's rlp encoding.
+func (e *DoubleSignEvidence) Hash() common.Hash {
+	data, err := rlp.EncodeToBytes(e)
+	if err != nil {
+		panic("can't encode: " + err.Error())
+	}
+	return crypto.Keccak256Hash(data)
+}
+
+// Number returns the height at which the offender double signed.
+func (e *DoubleSignEvidence) Number() uint64 {
+	return e.HeaderA.Number.Uint64()
+}
+
+// Offender verifies the evidence and returns the validator that sealed both headers.
+func (e *DoubleSignEvidence) Offender(sigcache *lru.ARCCache) (common.Address, error) {
+	if e.HeaderA == nil || e.HeaderB == nil || e.HeaderA.Number == nil || e.HeaderB.Number == nil {
+		return common.Address{}, errInvalidEvidence
+	}
+	if e.HeaderA.Number.Cmp(e.HeaderB.Number) != 0 || e.HeaderA.ParentHash != e.HeaderB.ParentHash {
+		return common.Address{}, errInvalidEvidence
+	}
+	if SealHash(e.HeaderA) == SealHash(e.HeaderB) {
+		return common.Address{}, errInvalidEvidence
+	}
+	signerA, err := ecrecover(e.HeaderA, sigcache)
+	if err != nil {
+		return common.Address{}, err
+	}
+	signerB, err := ecrecover(e.HeaderB, sigcache)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if signerA != signerB || signerA != e.HeaderA.Coinbase || signerB != e.HeaderB.Coinbase {
+		return common.Address{}, errInvalidEvidence
+	}
+	return signerA, nil
+}
+
+// EvidenceRecord is a double-sign evidence known by the local node.
+type EvidenceRecord struct {
+	Hash     common.Hash         `json:"hash"`
+	Offender common.Address      `json:"offender"`
+	Number   uint64              `json:"number"`
+	Evidence *DoubleSignEvidence `json:"evidence"`
+}
+
+// loadEvidenceRecord loads an existing evidence record from the database.
+func loadEvidenceRecord(db ethdb.Database, hash common.Hash) (*EvidenceRecord, error) {
+	blob, err := db.Get(append(evidencePrefix, hash[:]...))
+	if err != nil {
+		return nil, err
+	}
+	record := new(EvidenceRecord)
+	if err := json.Unmarshal(blob, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// loadEvidenceRecords loads all evidence records from the database, ordered by height.
+func loadEvidenceRecords(db ethdb.Database) ([]*EvidenceRecord, error) {
+	it := db.NewIterator(evidencePrefix, nil)
+	defer it.Release()
+
+	records := make([]*EvidenceRecord, 0)
+	for it.Next() {
+		record := new(EvidenceRecord)
+		if err := json.Unmarshal(it.Value(), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Number < records[j].Number })
+	return records, it.Error()
+}
+
+// store inserts the evidence record into the database.
+func (r *EvidenceRecord) store(db ethdb.Database) error {
+	blob, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return db.Put(append(evidencePrefix, r.Hash[:]...), blob)
+}
+
+// sealKey identifies a seal for double-sign detection.
+type sealKey struct {
+	number uint64
+	parent common.Hash
+	signer common.Address
+}
+
+// detectDoubleSign remembers the seal of a verified header, and builds an evidence
+// if the signer already sealed a different header at the same height on top of
+// the same parent.
+func (d *Dpos) detectDoubleSign(header *types.Header, signer common.Address) {
+	key := sealKey{number: header.Number.Uint64(), parent: header.ParentHash, signer: signer}
+	if prev, ok := d.seals.Get(key); ok {
+		if prev := prev.(*types.Header); SealHash(prev) != SealHash(header) {
+			d.addEvidence(newDoubleSignEvidence(prev, header))
+		}
+		return
+	}
+	d.seals.Add(key, header)
+}
+
+// addEvidence verifies an evidence, stores it and queues it to be included into
+// the next block sealed by the local validator.
+func (d *Dpos) addEvidence(evidence *DoubleSignEvidence) error {
+	offender, err := evidence.Offender(d.signatures)
+	if err != nil {
+		return err
+	}
+	hash := evidence.Hash()
+
+	d.evidenceLock.Lock()
+	defer d.evidenceLock.Unlock()
+
+	if _, ok := d.evidences[hash]; ok {
+		return nil
+	}
+	d.evidences[hash] = evidence
+
+	record := &EvidenceRecord{Hash: hash, Offender: offender, Number: evidence.Number(), Evidence: evidence}
+	if err := record.store(d.db); err != nil {
+		log.Warn("Failed to store double-sign evidence", "hash", hash, "err", err)
+	}
+	log.Warn("Detected double signing", "offender", offender, "number", record.Number,
+		"hashA", evidence.HeaderA.Hash(), "hashB", evidence.HeaderB.Hash())
+	return nil
+}
+
+// pendingEvidences returns the queued evidences that are not stale at the given
+// height, ordered by height. Stale ones are dropped from the queue.
+func (d *Dpos) pendingEvidences(number uint64) []*DoubleSignEvidence {
+	d.evidenceLock.Lock()
+	defer d.evidenceLock.Unlock()
+
+	evidences := make([]*DoubleSignEvidence, 0, len(d.evidences))
+	for hash, evidence := range d.evidences {
+		if evidence.Number()+d.config.Epoch < number {
+			delete(d.evidences, hash)
+			continue
+		}
+		evidences = append(evidences, evidence)
+	}
+	sort.Slice(evidences, func(i, j int) bool {
+		if evidences[i].Number() != evidences[j].Number() {
+			return evidences[i].Number() < evidences[j].Number()
+		}
+		return bytes.Compare(evidences[i].HeaderA.Hash().Bytes(), evidences[j].HeaderA.Hash().Bytes()) < 0
+	})
+	return evidences
+}
+
+// evidenceSlot returns the storage slot of SysEvidenceToAddr recording the block in
+// which the offender was slashed for double signing at the given height.
+func evidenceSlot(offender common.Address, number uint64) common.Hash {
+	return crypto.Keccak256Hash(offender.Bytes(), common.BigToHash(new(big.Int).SetUint64(number)).Bytes())
+}
+
+// slashedAt returns the block in which the offender was slashed for double signing at
+// the given height, or zero if it wasn't slashed.
+func slashedAt(state consensus.StateReader, offender common.Address, number uint64) uint64 {
+	return state.GetState(systemcontract.SysEvidenceToAddr, evidenceSlot(offender, number)).Big().Uint64()
+}
+
+// markSlashed records that the offender has been slashed for double signing at the
+// given height.
+func markSlashed(state *state.StateDB, offender common.Address, number uint64, slashed *big.Int) {
+	// Keep the recording account non-empty, otherwise it would be deleted along
+	// with its storage by EIP-158.
+	if state.GetNonce(systemcontract.SysEvidenceToAddr) == 0 {
+		state.SetNonce(systemcontract.SysEvidenceToAddr, 1)
+	}
+	state.SetState(systemcontract.SysEvidenceToAddr, evidenceSlot(offender, number), common.BigToHash(slashed))
+}
+
+// verifyEvidence checks whether an evidence can be applied on top of the given state,
+// returning the offender.
+func (d *Dpos) verifyEvidence(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, evidence *DoubleSignEvidence) (common.Address, error) {
+	offender, err := evidence.Offender(d.signatures)
+	if err != nil {
+		return common.Address{}, err
+	}
+	number := evidence.Number()
+	if number >= header.Number.Uint64() || number+d.config.Epoch < header.Number.Uint64() {
+		return common.Address{}, errStaleEvidence
+	}
+	if slashedAt(state, offender, number) != 0 {
+		return common.Address{}, errEvidenceSlashed
+	}
+	snap, err := d.snapshot(chain, header.Number.Uint64()-1, header.ParentHash, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if _, ok := snap.Validators[offender]; !ok {
+		return common.Address{}, errInactiveOffender
+	}
+	// The Validators contract only kicks out effective or already kicked out validators
+	val, err := systemcontract.NewValidators().GetValidator(state, header, newChainContext(chain, d), d.chainConfig, offender)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if val.Status < validatorStatusKickout {
+		return common.Address{}, errInactiveOffender
+	}
+	return offender, nil
+}
+
+// slashEntryBlock returns the block putting the slash entry point in front of the
+// SystemRewards contract, the one activating double-sign slashing. The code of the
+// genesis contracts comes from the alloc, so a chain slashing from its genesis gets
+// the entry point at block 1, before the contracts are initialized.
+func slashEntryBlock(config *params.ChainConfig) *big.Int {
+	block := config.DoubleSignSlashBlock
+	if block != nil && block.Sign() == 0 {
+		return common.Big1
+	}
+	return block
+}
+
+// installSlashEntry moves the code of the SystemRewards contract aside and puts the
+// slash entry point in front of it, keeping the storage of the contract.
+func installSlashEntry(state *state.StateDB) {
+	state.SetCode(systemcontract.SystemRewardsImplAddr, state.GetCode(systemcontract.SystemRewardsContractAddr))
+	state.SetCode(systemcontract.SystemRewardsContractAddr, systemcontract.SlashCode(systemcontract.SystemRewardsImplAddr))
+}
+
+// kickoutValidator slashes a double signing or jailed validator through the slash
+// entry point of the SystemRewards contract, which kicks it out right away and burns
+// its reward of the current epoch.
+func (d *Dpos) kickoutValidator(validator common.Address, chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
+	data, err := d.abi[systemcontract.SystemRewardsSlashName].Pack("slash", validator)
+	if err != nil {
+		log.Error("slash failed", "error", err)
+		return err
+	}
+	nonce := state.GetNonce(header.Coinbase)
+	msg := vmcaller.NewLegacyMessage(header.Coinbase, &systemcontract.SystemRewardsContractAddr, nonce, new(big.Int), header.GasLimit, new(big.Int), data, true)
+	if _, err := vmcaller.ExecuteMsg(msg, state, header, newChainContext(chain, d), d.chainConfig); err != nil {
+		return err
+	}
+	return nil
+}
+
+// executeEvidence includes a verified double-sign evidence into the block being assembled.
+func (d *Dpos) executeEvidence(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, evidence *DoubleSignEvidence, offender common.Address, totalTxIndex int) (*types.Transaction, *types.Receipt, error) {
+	data, err := rlp.EncodeToBytes(evidence)
+	if err != nil {
+		return nil, nil, err
+	}
+	//make double-sign evidence transaction
+	nonce := state.GetNonce(d.validator)
+	tx := types.NewTransaction(nonce, systemcontract.SysEvidenceToAddr, new(big.Int), header.GasLimit, new(big.Int), data)
+	tx, err = d.signTxFn(accounts.Account{Address: d.validator}, tx, chain.Config().ChainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	//add nonce for validator
+	state.SetNonce(d.validator, nonce+1)
+	receipt, err := d.executeEvidenceMsg(chain, header, state, evidence, offender, totalTxIndex, tx.Hash(), common.Hash{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, receipt, nil
+}
+
+// replayEvidence applies a double-sign evidence transaction of an imported block.
+func (d *Dpos) replayEvidence(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, totalTxIndex int, tx *types.Transaction) (*types.Receipt, error) {
+	sender, err := types.Sender(d.signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	if sender != header.Coinbase {
+		return nil, errInvalidEvidenceSender
+	}
+	evidence := new(DoubleSignEvidence)
+	if err := rlp.DecodeBytes(tx.Data(), evidence); err != nil {
+		return nil, err
+	}
+	offender, err := d.verifyEvidence(chain, header, state, evidence)
+	if err != nil {
+		return nil, err
+	}
+	nonce := state.GetNonce(sender)
+	//add nonce for validator
+	state.SetNonce(sender, nonce+1)
+	receipt, err := d.executeEvidenceMsg(chain, header, state, evidence, offender, totalTxIndex, tx.Hash(), header.Hash())
+	if err != nil {
+		return nil, err
+	}
+	// Remember evidences included by other validators too, so they can be queried
+	hash := evidence.Hash()
+	if _, err := loadEvidenceRecord(d.db, hash); err != nil {
+		record := &EvidenceRecord{Hash: hash, Offender: offender, Number: evidence.Number(), Evidence: evidence}
+		if err := record.store(d.db); err != nil {
+			log.Warn("Failed to store double-sign evidence", "hash", hash, "err", err)
+		}
+	}
+	return receipt, nil
+}
+
+func (d *Dpos) executeEvidenceMsg(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, evidence *DoubleSignEvidence, offender common.Address, totalTxIndex int, txHash, bHash common.Hash) (*types.Receipt, error) {
+	state.Prepare(txHash, totalTxIndex)
+	markSlashed(state, offender, evidence.Number(), header.Number)
+	if err := d.kickoutValidator(offender, chain, header, state); err != nil {
+		return nil, err
+	}
+	// evidence transaction will not actually consumes gas
+	receipt := types.NewReceipt([]byte{}, false, header.GasUsed)
+	receipt.Logs = state.GetLogs(txHash, bHash)
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	receipt.TxHash = txHash
+	receipt.BlockHash = bHash
+	receipt.BlockNumber = header.Number
+	receipt.TransactionIndex = uint(state.TxIndex())
+
+	log.Info("Slashed double signing validator", "offender", offender, "number", evidence.Number(), "txHash", txHash)
+	return receipt, nil
+}
+
+// assembleEvidences includes the pending double-sign evidences into the block being
+// assembled, skipping the ones not applicable anymore.
+func (d *Dpos) assembleEvidences(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt) ([]*types.Transaction, []*types.Receipt, error) {
+	included := 0
+	for _, evidence := range d.pendingEvidences(header.Number.Uint64()) {
+		if included >= maxEvidencesPerBlock {
+			break
+		}
+		offender, err := d.verifyEvidence(chain, header, state, evidence)
+		if err != nil {
+			log.Debug("Skipping double-sign evidence", "hash", evidence.Hash(), "err", err)
+			continue
+		}
+		tx, receipt, err := d.executeEvidence(chain, header, state, evidence, offender, len(txs))
+		if err != nil {
+			return nil, nil, err
+		}
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+		included++
+	}
+	return txs, receipts, nil
+}
+
+// systemTxOrder lists the kinds of system transactions, identified by their
+// recipient, in the order blocks execute them.
+var systemTxOrder = []common.Address{
+	systemcontract.SysUpgradeToAddr,
+	systemcontract.SysGovToAddr,
+	systemcontract.SysEvidenceToAddr,
+	systemcontract.SysUnjailToAddr,
+}
+
+// splitSystemTxs groups the system transactions by kind. Blocks execute the kinds
+// in systemTxOrder, so any other order is rejected to keep the replay in block
+// order. Transactions of other recipients count as governance ones.
+func splitSystemTxs(systemTxs []*types.Transaction) (map[common.Address][]*types.Transaction, error) {
+	var (
+		groups = make(map[common.Address][]*types.Transaction)
+		last   int
+	)
+	for _, tx := range systemTxs {
+		kind, rank := systemcontract.SysGovToAddr, 1
+		for i, to := range systemTxOrder {
+			if tx.To() != nil && *tx.To() == to {
+				kind, rank = to, i
+			}
+		}
+		if rank < last {
+			return nil, errInvalidSystemTxOrder
+		}
+		last = rank
+		groups[kind] = append(groups[kind], tx)
+	}
+	return groups, nil
+}
+
+// applyEvidenceTx applies a double-sign evidence transaction using a given evm,
+// the main purpose of this method is for tracing.
+func (d *Dpos) applyEvidenceTx(evm *vm.EVM, state *state.StateDB, txIndex int, sender common.Address, tx *types.Transaction) (ret []byte, vmerr error, err error) {
+	evidence := new(DoubleSignEvidence)
+	if err = rlp.DecodeBytes(tx.Data(), evidence); err != nil {
+		return
+	}
+	offender, err := evidence.Offender(d.signatures)
+	if err != nil {
+		return
+	}
+	data, err := d.abi[systemcontract.SystemRewardsSlashName].Pack("slash", offender)
+	if err != nil {
+		return
+	}
+	evm.Context.ExtraValidator = nil
+	nonce := evm.StateDB.GetNonce(sender)
+	//add nonce for validator
+	evm.StateDB.SetNonce(sender, nonce+1)
+
+	state.Prepare(tx.Hash(), txIndex)
+	markSlashed(state, offender, evidence.Number(), evm.Context.BlockNumber)
+	evm.TxContext = vm.TxContext{
+		Origin:   sender,
+		GasPrice: new(big.Int),
+	}
+	ret, _, vmerr = evm.Call(vm.AccountRef(sender), systemcontract.SystemRewardsContractAddr, data, tx.Gas(), new(big.Int))
+	state.Finalise(true)
+	return
+}
@@ -0,0 +1,214 @@
+package dpos
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"github.com/hypnosisfoundation/go-hypnosis/rlp"
+)
+
+// sealTestHeader creates a header at the given height sealed by the given key.
+func sealTestHeader(t *testing.T, key *ecdsa.PrivateKey, number int64, vanity string) *types.Header {
+	header := &types.Header{
+		Number:     big.NewInt(number),
+		Coinbase:   crypto.PubkeyToAddress(key.PublicKey),
+		Difficulty: diffInTurn,
+		Extra:      make([]byte, extraVanity+extraSeal),
+	}
+	copy(header.Extra, vanity)
+	sig, err := crypto.Sign(SealHash(header).Bytes(), key)
+	if err != nil {
+		t.Fatalf("failed to seal header: %v", err)
+	}
+	copy(header.Extra[extraVanity:], sig)
+	return header
+}
+
+func TestDoubleSignEvidenceOffender(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+	engine := New(params.MainnetChainConfig, rawdb.NewMemoryDatabase())
+
+	a := sealTestHeader(t, key, 10, "a")
+	b := sealTestHeader(t, key, 10, "b")
+
+	offender, err := newDoubleSignEvidence(a, b).Offender(engine.signatures)
+	if err != nil {
+		t.Fatalf("failed to verify evidence: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(key.PublicKey); offender != want {
+		t.Errorf("offender mismatch: have %x, want %x", offender, want)
+	}
+	if newDoubleSignEvidence(a, b).Hash() != newDoubleSignEvidence(b, a).Hash() {
+		t.Errorf("evidence depends on the order of the headers")
+	}
+
+	// Re-signing the same header is not an equivocation
+	resealed := types.CopyHeader(a)
+	resealed.Extra[len(resealed.Extra)-1] ^= 0x01
+
+	// Sealing a header on another parent is no equivocation either
+	forked := types.CopyHeader(b)
+	forked.ParentHash = common.HexToHash("0x01")
+	sig, _ := crypto.Sign(SealHash(forked).Bytes(), key)
+	copy(forked.Extra[extraVanity:], sig)
+	tests := []struct {
+		name     string
+		evidence *DoubleSignEvidence
+	}{
+		{"same seal", &DoubleSignEvidence{HeaderA: a, HeaderB: resealed}},
+		{"different parents", newDoubleSignEvidence(a, forked)},
+		{"different heights", newDoubleSignEvidence(a, sealTestHeader(t, key, 11, "b"))},
+		{"different signers", newDoubleSignEvidence(a, sealTestHeader(t, other, 10, "b"))},
+		{"missing header", &DoubleSignEvidence{HeaderA: a}},
+	}
+	for _, tt := range tests {
+		if _, err := tt.evidence.Offender(engine.signatures); err == nil {
+			t.Errorf("%s: invalid evidence accepted", tt.name)
+		}
+	}
+}
+
+func TestDetectDoubleSign(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	db := rawdb.NewMemoryDatabase()
+	engine := New(params.MainnetChainConfig, db)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+
+	a := sealTestHeader(t, key, 10, "a")
+	engine.detectDoubleSign(a, signer)
+	engine.detectDoubleSign(a, signer)
+	if pending := engine.pendingEvidences(11); len(pending) != 0 {
+		t.Fatalf("evidence built from a single header: %d", len(pending))
+	}
+	b := sealTestHeader(t, key, 10, "b")
+	engine.detectDoubleSign(b, signer)
+
+	pending := engine.pendingEvidences(11)
+	if len(pending) != 1 {
+		t.Fatalf("pending evidences mismatch: have %d, want 1", len(pending))
+	}
+	want := newDoubleSignEvidence(a, b).Hash()
+	if hash := pending[0].Hash(); hash != want {
+		t.Fatalf("evidence mismatch: have %x, want %x", hash, want)
+	}
+	record, err := loadEvidenceRecord(db, want)
+	if err != nil {
+		t.Fatalf("evidence not stored: %v", err)
+	}
+	if record.Offender != signer || record.Number != 10 {
+		t.Errorf("record mismatch: have %x at %d, want %x at 10", record.Offender, record.Number, signer)
+	}
+	if record.Evidence.Hash() != want {
+		t.Errorf("stored evidence mismatch: have %x, want %x", record.Evidence.Hash(), want)
+	}
+	// Evidences older than an epoch are dropped
+	if pending := engine.pendingEvidences(11 + engine.config.Epoch); len(pending) != 0 {
+		t.Errorf("stale evidence still pending")
+	}
+}
+
+func TestEvidenceSlashedMarker(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	offender := common.HexToAddress("0x01")
+
+	if slashedAt(statedb, offender, 10) != 0 {
+		t.Fatalf("offender slashed before marking")
+	}
+	markSlashed(statedb, offender, 10, big.NewInt(12))
+	statedb.Finalise(true)
+
+	if have := slashedAt(statedb, offender, 10); have != 12 {
+		t.Errorf("slashed block mismatch: have %d, want 12", have)
+	}
+	if slashedAt(statedb, offender, 11) != 0 {
+		t.Errorf("offender slashed at unrelated height")
+	}
+}
+
+func TestSplitSystemTxs(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	a := sealTestHeader(t, key, 10, "a")
+	b := sealTestHeader(t, key, 10, "b")
+	data, _ := rlp.EncodeToBytes(newDoubleSignEvidence(a, b))
+
+	gov := types.NewTransaction(0, systemcontract.SysGovToAddr, new(big.Int), 0, new(big.Int), nil)
+	evidence := types.NewTransaction(1, systemcontract.SysEvidenceToAddr, new(big.Int), 0, new(big.Int), data)
+
+	upgrade := types.NewTransaction(2, systemcontract.SysUpgradeToAddr, new(big.Int), 0, new(big.Int), nil)
+	unjail := types.NewTransaction(3, systemcontract.SysUnjailToAddr, new(big.Int), 0, new(big.Int), nil)
+	contract := types.NewTransaction(4, systemcontract.SysGovContractAddr, new(big.Int), 0, new(big.Int), nil)
+
+	groups, err := splitSystemTxs([]*types.Transaction{upgrade, gov, contract, evidence, unjail})
+	if err != nil {
+		t.Fatalf("failed to split system txs: %v", err)
+	}
+	for to, want := range map[common.Address][]*types.Transaction{
+		systemcontract.SysUpgradeToAddr:  {upgrade},
+		systemcontract.SysGovToAddr:      {gov, contract},
+		systemcontract.SysEvidenceToAddr: {evidence},
+		systemcontract.SysUnjailToAddr:   {unjail},
+	} {
+		if have := groups[to]; len(have) != len(want) || have[0] != want[0] || have[len(have)-1] != want[len(want)-1] {
+			t.Errorf("%x txs mismatch: have %v, want %v", to, have, want)
+		}
+	}
+	// Any other order than upgrades, governance, evidences and unjails is rejected
+	for i, txs := range [][]*types.Transaction{{evidence, gov}, {gov, upgrade}, {evidence, upgrade}, {unjail, evidence}, {unjail, contract}} {
+		if _, err := splitSystemTxs(txs); err != errInvalidSystemTxOrder {
+			t.Errorf("test %d: misordered system txs error mismatch: have %v, want %v", i, err, errInvalidSystemTxOrder)
+		}
+	}
+}
+
+func TestKickoutValidator(t *testing.T) {
+	maker, validators := newTestStateChainMaker(t, 1, 20)
+	maker.chain.config.DoubleSignSlashBlock = common.Big0
+	head := maker.generate(maker.genesis(), 1, nil)[0]
+	statedb := maker.state(head)
+
+	// The slash entry point is put in front of the genesis contract at block 1
+	genesis := SystemContracts()[systemcontract.SystemRewardsContractAddr].Code
+	if code := statedb.GetCode(systemcontract.SystemRewardsImplAddr); !bytes.Equal(code, genesis) {
+		t.Fatalf("SystemRewards code not moved aside")
+	}
+	if code := statedb.GetCode(systemcontract.SystemRewardsContractAddr); !bytes.Equal(code, systemcontract.SlashCode(systemcontract.SystemRewardsImplAddr)) {
+		t.Fatalf("slash entry point not installed")
+	}
+
+	header := &types.Header{
+		ParentHash: head.Hash(),
+		Number:     big.NewInt(2),
+		Time:       head.Time + 3,
+		Coinbase:   validators[0],
+		Difficulty: diffInTurn,
+		GasLimit:   head.GasLimit,
+	}
+	if err := maker.engine.kickoutValidator(validators[0], maker.chain, header, statedb); err != nil {
+		t.Fatalf("failed to slash validator: %v", err)
+	}
+	rewards := systemcontract.NewSystemRewards()
+	threshold, err := rewards.MaxPunishCount(statedb, header, newChainContext(maker.chain, maker.engine), maker.chain.Config())
+	if err != nil {
+		t.Fatalf("failed to retrieve punish threshold: %v", err)
+	}
+	punish, err := rewards.PunishInfo(statedb, header, newChainContext(maker.chain, maker.engine), maker.chain.Config(), validators[0], common.Big0)
+	if err != nil {
+		t.Fatalf("failed to retrieve punishments: %v", err)
+	}
+	if punish.Count.Cmp(threshold) != 0 {
+		t.Errorf("punish count mismatch: have %v, want %v", punish.Count, threshold)
+	}
+	if len(punish.PunishBlocks) != 1 || len(punish.KickoutBlocks) != 1 {
+		t.Errorf("punishments mismatch: have %d punished and %d kicked out blocks, want 1 and 1", len(punish.PunishBlocks), len(punish.KickoutBlocks))
+	}
+}
@@ -0,0 +1,440 @@
+package dpos
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/common/hexutil"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/event"
+	"github.com/hypnosisfoundation/go-hypnosis/log"
+	"github.com/hypnosisfoundation/go-hypnosis/rlp"
+)
+
+const finalityHeadChanSize = 10 // Size of the channel listening to chain head events
+
+var (
+	// errUnknownAttestationTarget is returned if an attestation votes for a block
+	// that is not known locally.
+	errUnknownAttestationTarget = errors.New("unknown attestation target")
+
+	// errInvalidAttestationSource is returned if the source of an attestation is
+	// unknown or not lower than its target.
+	errInvalidAttestationSource = errors.New("invalid attestation source")
+
+	// errStaleAttestation is returned if an attestation targets a block at or
+	// below the finalized one.
+	errStaleAttestation = errors.New("stale attestation")
+
+	// errUnauthorizedAttester is returned if an attestation is signed by an entity
+	// that is not a validator at the target block.
+	errUnauthorizedAttester = errors.New("unauthorized attester")
+
+	// errFinalityNotRunning is returned if attestations are submitted while the
+	// finality gadget isn't started.
+	errFinalityNotRunning = errors.New("finality gadget not running")
+
+	// errConflictingAttestation is returned if a validator attests to another
+	// block at the height of a target it already attested to.
+	errConflictingAttestation = errors.New("conflicting attestation")
+)
+
+// Attestation is a validator's vote that the target block is canonical. It also
+// links the target to the latest justified block (the source) known by the
+// validator, which is what eventually finalizes the source.
+type Attestation struct {
+	SourceNumber uint64        `json:"sourceNumber"`
+	SourceHash   common.Hash   `json:"sourceHash"`
+	TargetNumber uint64        `json:"targetNumber"`
+	TargetHash   common.Hash   `json:"targetHash"`
+	Signature    hexutil.Bytes `json:"signature"`
+}
+
+// signingData returns the rlp bytes that are signed by the attesting validator.
+func (a *Attestation) signingData() []byte {
+	data, err := rlp.EncodeToBytes([]interface{}{a.SourceNumber, a.SourceHash, a.TargetNumber, a.TargetHash})
+	if err != nil {
+		panic("can't encode: " + err.Error())
+	}
+	return data
+}
+
+// Hash returns the hash identifying the attestation, signature included.
+func (a *Attestation) Hash() common.Hash {
+	return crypto.Keccak256Hash(a.signingData(), a.Signature)
+}
+
+// Signer recovers the address of the validator that signed the attestation.
+func (a *Attestation) Signer() (common.Address, error) {
+	if len(a.Signature) != crypto.SignatureLength {
+		return common.Address{}, errMissingSignature
+	}
+	pubkey, err := crypto.Ecrecover(crypto.Keccak256(a.signingData()), a.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	var signer common.Address
+	copy(signer[:], crypto.Keccak256(pubkey[1:])[12:])
+	return signer, nil
+}
+
+// attestationKey identifies the attestation of a validator to a target block.
+type attestationKey struct {
+	signer common.Address
+	target common.Hash
+}
+
+// FinalityChain is the chain followed by the finality gadget, which reports the
+// justified and finalized blocks back to it.
+type FinalityChain interface {
+	consensus.ChainHeaderReader
+
+	// CurrentFinalizedBlock retrieves the latest finalized block known by the chain.
+	CurrentFinalizedBlock() *types.Header
+
+	// SubscribeChainHeadEvent subscribes to new canonical chain heads.
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+
+	// SetFinalized marks a block as finalized, refusing reorgs below it.
+	SetFinalized(header *types.Header)
+
+	// SetSafe marks a block as justified.
+	SetSafe(header *types.Header)
+}
+
+// finality is the attestation based finality gadget running on top of the
+// probabilistic dpos fork choice. A block is justified once 2/3+1 of the
+// validators attested to it (or to one of its descendants), and a justified
+// block is finalized once 2/3+1 of the validators used it as their source.
+type finality struct {
+	chain FinalityChain
+
+	votes     map[attestationKey]*Attestation           // Attestations above the finalized block by signer and target
+	targets   map[common.Address]map[uint64]common.Hash // Targets attested to by each validator, by number
+	conflicts map[common.Address][2]*Attestation        // First pair of conflicting attestations of each validator
+	justified *types.Header                             // Latest justified block
+	finalized *types.Header                             // Latest finalized block
+	lock      sync.RWMutex                              // Protects the fields above
+
+	feed  event.Feed
+	scope event.SubscriptionScope
+
+	quit      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// StartFinality starts the attestation based finality gadget following the given
+// chain. If the engine is authorized to seal, it attests to every new head.
+func (d *Dpos) StartFinality(chain FinalityChain) {
+	f := newFinality(chain)
+	f.justified = f.finalized
+
+	d.lock.Lock()
+	d.finality = f
+	d.lock.Unlock()
+
+	f.wg.Add(1)
+	go d.finalityLoop(f)
+}
+
+// newFinality creates a finality gadget following the given chain.
+func newFinality(chain FinalityChain) *finality {
+	return &finality{
+		chain:     chain,
+		votes:     make(map[attestationKey]*Attestation),
+		targets:   make(map[common.Address]map[uint64]common.Hash),
+		conflicts: make(map[common.Address][2]*Attestation),
+		finalized: chain.CurrentFinalizedBlock(),
+		quit:      make(chan struct{}),
+	}
+}
+
+// stop terminates the finality gadget.
+func (f *finality) stop() {
+	f.closeOnce.Do(func() {
+		close(f.quit)
+		f.scope.Close()
+	})
+	f.wg.Wait()
+}
+
+// finalityLoop attests to new chain heads and keeps the chain's finality markers
+// up to date.
+func (d *Dpos) finalityLoop(f *finality) {
+	defer f.wg.Done()
+
+	headCh := make(chan core.ChainHeadEvent, finalityHeadChanSize)
+	sub := f.chain.SubscribeChainHeadEvent(headCh)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-headCh:
+			if att := d.attest(f, ev.Block.Header()); att != nil {
+				if err := d.addAttestation(f, att); err != nil {
+					log.Debug("Failed to add local attestation", "number", att.TargetNumber, "err", err)
+				}
+			}
+			d.updateFinality(f)
+
+		case <-sub.Err():
+			return
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// attest signs an attestation for the given header if the local validator is
+// part of the validator set at that block.
+func (d *Dpos) attest(f *finality, header *types.Header) *Attestation {
+	d.lock.RLock()
+	val, signFn := d.validator, d.signFn
+	d.lock.RUnlock()
+
+	if signFn == nil {
+		return nil
+	}
+	snap, err := d.snapshot(f.chain, header.Number.Uint64(), header.Hash(), nil)
+	if err != nil {
+		return nil
+	}
+	if _, ok := snap.Validators[val]; !ok {
+		return nil
+	}
+	f.lock.RLock()
+	source := f.justified
+	_, attested := f.targets[val][header.Number.Uint64()]
+	f.lock.RUnlock()
+
+	// Never attest twice at a height, e.g. to the new head of a reorg, as that
+	// would be reported as a conflicting vote
+	if attested {
+		return nil
+	}
+	if source == nil {
+		source = f.chain.GetHeaderByNumber(0)
+	}
+	if source == nil || source.Number.Cmp(header.Number) >= 0 {
+		return nil
+	}
+	att := &Attestation{
+		SourceNumber: source.Number.Uint64(),
+		SourceHash:   source.Hash(),
+		TargetNumber: header.Number.Uint64(),
+		TargetHash:   header.Hash(),
+	}
+	sig, err := signFn(accounts.Account{Address: val}, accounts.MimetypeDposAttestation, att.signingData())
+	if err != nil {
+		log.Warn("Failed to sign attestation", "number", att.TargetNumber, "err", err)
+		return nil
+	}
+	att.Signature = sig
+	return att
+}
+
+// SubmitAttestation verifies an attestation relayed from another validator and
+// adds it to the finality gadget.
+func (d *Dpos) SubmitAttestation(att *Attestation) error {
+	d.lock.RLock()
+	f := d.finality
+	d.lock.RUnlock()
+
+	if f == nil {
+		return errFinalityNotRunning
+	}
+	if err := d.addAttestation(f, att); err != nil {
+		return err
+	}
+	d.updateFinality(f)
+	return nil
+}
+
+// SubscribeAttestations subscribes to the attestations accepted by the finality
+// gadget, so they can be relayed to other validators.
+func (d *Dpos) SubscribeAttestations(ch chan<- *Attestation) (event.Subscription, error) {
+	d.lock.RLock()
+	f := d.finality
+	d.lock.RUnlock()
+
+	if f == nil {
+		return nil, errFinalityNotRunning
+	}
+	return f.scope.Track(f.feed.Subscribe(ch)), nil
+}
+
+// addAttestation verifies the attestation and records it as a vote of its signer.
+// Votes for several blocks at the same height are rejected, and the first pair of
+// each validator is kept as the evidence of its equivocation.
+func (d *Dpos) addAttestation(f *finality, att *Attestation) error {
+	target := f.chain.GetHeader(att.TargetHash, att.TargetNumber)
+	if target == nil {
+		return errUnknownAttestationTarget
+	}
+	if att.SourceNumber >= att.TargetNumber || f.chain.GetHeader(att.SourceHash, att.SourceNumber) == nil {
+		return errInvalidAttestationSource
+	}
+	f.lock.RLock()
+	finalized := f.finalized
+	f.lock.RUnlock()
+	if finalized != nil && att.TargetNumber <= finalized.Number.Uint64() {
+		return errStaleAttestation
+	}
+	signer, err := att.Signer()
+	if err != nil {
+		return err
+	}
+	snap, err := d.snapshot(f.chain, att.TargetNumber, att.TargetHash, nil)
+	if err != nil {
+		return err
+	}
+	if _, ok := snap.Validators[signer]; !ok {
+		return errUnauthorizedAttester
+	}
+
+	f.lock.Lock()
+	if prev, ok := f.targets[signer][att.TargetNumber]; ok {
+		if prev == att.TargetHash {
+			f.lock.Unlock()
+			return nil
+		}
+		if _, ok := f.conflicts[signer]; !ok {
+			f.conflicts[signer] = [2]*Attestation{f.votes[attestationKey{signer, prev}], att}
+		}
+		f.lock.Unlock()
+
+		log.Warn("Conflicting attestations", "signer", signer, "number", att.TargetNumber, "first", prev, "second", att.TargetHash)
+		return errConflictingAttestation
+	}
+	if f.targets[signer] == nil {
+		f.targets[signer] = make(map[uint64]common.Hash)
+	}
+	f.targets[signer][att.TargetNumber] = att.TargetHash
+	f.votes[attestationKey{signer, att.TargetHash}] = att
+	f.lock.Unlock()
+
+	f.feed.Send(att)
+	return nil
+}
+
+// updateFinality recalculates the justified and finalized blocks from the latest
+// canonical attestation of each validator and reports any progress to the chain.
+func (d *Dpos) updateFinality(f *finality) {
+	f.lock.RLock()
+	latest := make(map[common.Address]*Attestation, len(f.targets))
+	for key, att := range f.votes {
+		if prev := latest[key.signer]; prev != nil && prev.TargetNumber >= att.TargetNumber {
+			continue
+		}
+		if isCanonical(f.chain, att.TargetNumber, att.TargetHash) {
+			latest[key.signer] = att
+		}
+	}
+	justified, finalized := f.justified, f.finalized
+	f.lock.RUnlock()
+
+	targets := make(map[common.Address]uint64, len(latest))
+	sources := make(map[common.Address]uint64, len(latest))
+	for val, att := range latest {
+		targets[val] = att.TargetNumber
+		if isCanonical(f.chain, att.SourceNumber, att.SourceHash) {
+			sources[val] = att.SourceNumber
+		}
+	}
+
+	if header := d.highestQuorum(f.chain, targets); header != nil && (justified == nil || header.Number.Cmp(justified.Number) > 0) {
+		justified = header
+		f.chain.SetSafe(header)
+		log.Debug("New justified block", "number", header.Number, "hash", header.Hash())
+	}
+	if header := d.highestQuorum(f.chain, sources); header != nil && (finalized == nil || header.Number.Cmp(finalized.Number) > 0) {
+		if justified != nil && header.Number.Cmp(justified.Number) <= 0 {
+			finalized = header
+			f.chain.SetFinalized(header)
+			log.Info("New finalized block", "number", header.Number, "hash", header.Hash())
+		}
+	}
+
+	f.lock.Lock()
+	f.justified, f.finalized = justified, finalized
+	if finalized != nil {
+		// Votes at or below the finalized block can't change anything anymore
+		for key, att := range f.votes {
+			if att.TargetNumber <= finalized.Number.Uint64() {
+				delete(f.votes, key)
+				delete(f.targets[key.signer], att.TargetNumber)
+				if len(f.targets[key.signer]) == 0 {
+					delete(f.targets, key.signer)
+				}
+			}
+		}
+	}
+	f.lock.Unlock()
+}
+
+// highestQuorum returns the highest canonical block for which at least 2/3+1 of
+// its validators voted for the block itself or a canonical descendant of it.
+func (d *Dpos) highestQuorum(chain consensus.ChainHeaderReader, votes map[common.Address]uint64) *types.Header {
+	candidates := make([]uint64, 0, len(votes))
+	seen := make(map[uint64]struct{})
+	for _, number := range votes {
+		if _, ok := seen[number]; !ok && number > 0 {
+			seen[number] = struct{}{}
+			candidates = append(candidates, number)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] > candidates[j] })
+
+	for _, number := range candidates {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			continue
+		}
+		snap, err := d.snapshot(chain, number, header.Hash(), nil)
+		if err != nil {
+			continue
+		}
+		count := 0
+		for val := range snap.Validators {
+			if voted, ok := votes[val]; ok && voted >= number {
+				count++
+			}
+		}
+		if count >= attestationQuorum(len(snap.Validators)) {
+			return header
+		}
+	}
+	return nil
+}
+
+// attestationQuorum returns the number of validators needed to justify a block.
+func attestationQuorum(validators int) int {
+	return validators*2/3 + 1
+}
+
+// isCanonical checks whether the given block is part of the canonical chain.
+func isCanonical(chain consensus.ChainHeaderReader, number uint64, hash common.Hash) bool {
+	header := chain.GetHeaderByNumber(number)
+	return header != nil && header.Hash() == hash
+}
+
+// latestAttestations returns the latest attestation of each validator.
+func (f *finality) latestAttestations() map[common.Address]*Attestation {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	votes := make(map[common.Address]*Attestation, len(f.targets))
+	for key, att := range f.votes {
+		if prev := votes[key.signer]; prev == nil || prev.TargetNumber < att.TargetNumber {
+			votes[key.signer] = att
+		}
+	}
+	return votes
+}
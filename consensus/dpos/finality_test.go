@@ -0,0 +1,285 @@
+package dpos
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/event"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"github.com/hypnosisfoundation/go-hypnosis/rpc"
+)
+
+// testFinalityChain is a header chain implementing FinalityChain, remembering the
+// headers reorged out of it.
+type testFinalityChain struct {
+	headers   []*types.Header
+	known     map[common.Hash]*types.Header
+	finalized *types.Header
+	safe      *types.Header
+	feed      event.Feed
+}
+
+func newTestFinalityChain(n int) *testFinalityChain {
+	chain := &testFinalityChain{known: make(map[common.Hash]*types.Header)}
+	chain.reorg(0, n+1, nil)
+	return chain
+}
+
+// reorg replaces the canonical headers from the given number with n new ones.
+func (c *testFinalityChain) reorg(number int, n int, extra []byte) {
+	c.headers = c.headers[:number]
+	for i := number; i < number+n; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i)), Difficulty: diffInTurn, Extra: extra}
+		if i > 0 {
+			header.ParentHash = c.headers[i-1].Hash()
+		}
+		c.headers = append(c.headers, header)
+		c.known[header.Hash()] = header
+	}
+}
+
+func (c *testFinalityChain) Config() *params.ChainConfig  { return params.MainnetChainConfig }
+func (c *testFinalityChain) CurrentHeader() *types.Header { return c.headers[len(c.headers)-1] }
+func (c *testFinalityChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.GetHeaderByHash(hash); header != nil && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+func (c *testFinalityChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c.headers)) {
+		return nil
+	}
+	return c.headers[number]
+}
+func (c *testFinalityChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	if header := c.known[hash]; header != nil {
+		return header
+	}
+	for _, header := range c.headers {
+		if header != nil && header.Hash() == hash {
+			return header
+		}
+	}
+	return nil
+}
+func (c *testFinalityChain) CurrentFinalizedBlock() *types.Header { return c.finalized }
+func (c *testFinalityChain) CurrentSafeBlock() *types.Header      { return c.safe }
+func (c *testFinalityChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+func (c *testFinalityChain) SetFinalized(header *types.Header) { c.finalized = header }
+func (c *testFinalityChain) SetSafe(header *types.Header)      { c.safe = header }
+
+// newFinalityTester creates an engine whose snapshots at every block of the
+// chain contain the given validators.
+func newFinalityTester(chain *testFinalityChain, keys []*ecdsa.PrivateKey) (*Dpos, *finality) {
+	engine := New(params.MainnetChainConfig, rawdb.NewMemoryDatabase())
+
+	validators := make([]common.Address, len(keys))
+	for i, key := range keys {
+		validators[i] = crypto.PubkeyToAddress(key.PublicKey)
+	}
+	for _, header := range chain.known {
+		engine.recents.Add(header.Hash(), newSnapshot(engine.chainConfig, engine.config, engine.signatures, header.Number.Uint64(), header.Hash(), validators, nil))
+	}
+	f := newFinality(chain)
+	engine.finality = f
+	return engine, f
+}
+
+func signAttestation(t *testing.T, key *ecdsa.PrivateKey, source, target *types.Header) *Attestation {
+	att := &Attestation{
+		SourceNumber: source.Number.Uint64(),
+		SourceHash:   source.Hash(),
+		TargetNumber: target.Number.Uint64(),
+		TargetHash:   target.Hash(),
+	}
+	sig, err := crypto.Sign(crypto.Keccak256(att.signingData()), key)
+	if err != nil {
+		t.Fatalf("failed to sign attestation: %v", err)
+	}
+	att.Signature = sig
+	return att
+}
+
+func TestAttestationSigner(t *testing.T) {
+	chain := newTestFinalityChain(2)
+	key, _ := crypto.GenerateKey()
+
+	att := signAttestation(t, key, chain.headers[0], chain.headers[2])
+	signer, err := att.Signer()
+	if err != nil {
+		t.Fatalf("failed to recover signer: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(key.PublicKey); signer != want {
+		t.Errorf("signer mismatch: have %x, want %x", signer, want)
+	}
+	att.Signature = att.Signature[:10]
+	if _, err := att.Signer(); err != errMissingSignature {
+		t.Errorf("short signature error mismatch: have %v, want %v", err, errMissingSignature)
+	}
+}
+
+func TestAttestationQuorum(t *testing.T) {
+	tests := []struct {
+		validators int
+		quorum     int
+	}{
+		{1, 1}, {2, 2}, {3, 3}, {4, 3}, {5, 4}, {7, 5}, {21, 15},
+	}
+	for _, tt := range tests {
+		if have := attestationQuorum(tt.validators); have != tt.quorum {
+			t.Errorf("validators %d: quorum mismatch: have %d, want %d", tt.validators, have, tt.quorum)
+		}
+	}
+}
+
+func TestFinalityJustifyAndFinalize(t *testing.T) {
+	chain := newTestFinalityChain(10)
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	engine, f := newFinalityTester(chain, keys)
+
+	// Two attestations out of four validators don't justify anything
+	for _, key := range keys[:2] {
+		if err := engine.SubmitAttestation(signAttestation(t, key, chain.headers[0], chain.headers[5])); err != nil {
+			t.Fatalf("failed to submit attestation: %v", err)
+		}
+	}
+	if chain.safe != nil {
+		t.Fatalf("block justified without quorum: %d", chain.safe.Number)
+	}
+	// A third vote on a descendant supports block 5 too
+	if err := engine.SubmitAttestation(signAttestation(t, keys[2], chain.headers[0], chain.headers[7])); err != nil {
+		t.Fatalf("failed to submit attestation: %v", err)
+	}
+	if chain.safe == nil || chain.safe.Number.Uint64() != 5 {
+		t.Fatalf("justified block mismatch: have %v, want 5", chain.safe)
+	}
+	if chain.finalized != nil {
+		t.Fatalf("block finalized without quorum on source: %d", chain.finalized.Number)
+	}
+	// Validators building on the justified block finalize it
+	for _, key := range keys[1:] {
+		if err := engine.SubmitAttestation(signAttestation(t, key, chain.headers[5], chain.headers[9])); err != nil {
+			t.Fatalf("failed to submit attestation: %v", err)
+		}
+	}
+	if chain.finalized == nil || chain.finalized.Number.Uint64() != 5 {
+		t.Fatalf("finalized block mismatch: have %v, want 5", chain.finalized)
+	}
+	if chain.safe.Number.Uint64() != 9 {
+		t.Fatalf("justified block mismatch: have %d, want 9", chain.safe.Number)
+	}
+	if f.finalized.Number.Uint64() != 5 {
+		t.Fatalf("gadget finalized block mismatch: have %d, want 5", f.finalized.Number)
+	}
+	// Anything at or below the finalized block is rejected from now on
+	if err := engine.SubmitAttestation(signAttestation(t, keys[0], chain.headers[0], chain.headers[4])); err != errStaleAttestation {
+		t.Fatalf("stale attestation error mismatch: have %v, want %v", err, errStaleAttestation)
+	}
+}
+
+func TestFinalityRejectsInvalidAttestations(t *testing.T) {
+	chain := newTestFinalityChain(5)
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	engine, _ := newFinalityTester(chain, keys)
+
+	outsider, _ := crypto.GenerateKey()
+	if err := engine.SubmitAttestation(signAttestation(t, outsider, chain.headers[0], chain.headers[3])); err != errUnauthorizedAttester {
+		t.Errorf("outsider error mismatch: have %v, want %v", err, errUnauthorizedAttester)
+	}
+	unknown := &types.Header{Number: big.NewInt(3), Extra: []byte("fork")}
+	if err := engine.SubmitAttestation(signAttestation(t, keys[0], chain.headers[0], unknown)); err != errUnknownAttestationTarget {
+		t.Errorf("unknown target error mismatch: have %v, want %v", err, errUnknownAttestationTarget)
+	}
+	if err := engine.SubmitAttestation(signAttestation(t, keys[0], chain.headers[4], chain.headers[3])); err != errInvalidAttestationSource {
+		t.Errorf("inverted source error mismatch: have %v, want %v", err, errInvalidAttestationSource)
+	}
+}
+
+// Tests that votes are kept per target block across reorgs, and that validators
+// voting for two blocks at the same height are caught.
+func TestFinalityReorgAndConflicts(t *testing.T) {
+	// Build the branch 6-7 to reorg to, then get back to the original 6-8 one
+	chain := newTestFinalityChain(8)
+	chain.reorg(6, 2, []byte("fork"))
+	chain.reorg(6, 3, nil)
+
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	engine, f := newFinalityTester(chain, keys)
+	genesis, a7, a8 := chain.headers[0], chain.headers[7], chain.headers[8]
+
+	for _, vote := range []struct {
+		key    *ecdsa.PrivateKey
+		target *types.Header
+	}{{keys[0], a7}, {keys[2], a8}} {
+		if err := engine.SubmitAttestation(signAttestation(t, vote.key, genesis, vote.target)); err != nil {
+			t.Fatalf("failed to submit attestation: %v", err)
+		}
+	}
+	chain.reorg(6, 2, []byte("fork"))
+	b6, b7 := chain.headers[6], chain.headers[7]
+
+	// A second vote at the same height is rejected and kept as evidence
+	conflict := signAttestation(t, keys[0], genesis, b7)
+	if err := engine.SubmitAttestation(conflict); err != errConflictingAttestation {
+		t.Fatalf("conflicting attestation error mismatch: have %v, want %v", err, errConflictingAttestation)
+	}
+	signer := crypto.PubkeyToAddress(keys[0].PublicKey)
+	if pair := f.conflicts[signer]; pair[0] == nil || pair[0].TargetHash != a7.Hash() || pair[1] != conflict {
+		t.Errorf("conflicting attestations mismatch: have %v", pair)
+	}
+	// Votes on the new branch count, even below the previous vote of a validator
+	for _, vote := range []struct {
+		key    *ecdsa.PrivateKey
+		target *types.Header
+	}{{keys[2], b7}, {keys[3], b7}, {keys[1], b6}} {
+		if err := engine.SubmitAttestation(signAttestation(t, vote.key, genesis, vote.target)); err != nil {
+			t.Fatalf("failed to submit attestation: %v", err)
+		}
+	}
+	if chain.safe == nil || chain.safe.Hash() != b6.Hash() {
+		t.Fatalf("justified block mismatch: have %v, want %x", chain.safe, b6.Hash())
+	}
+	// The local validator never signs a conflicting vote itself
+	engine.Authorize(signer, func(account accounts.Account, mimeType string, message []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(message), keys[0])
+	}, nil)
+	if att := engine.attest(f, b7); att != nil {
+		t.Errorf("conflicting attestation signed")
+	}
+}
+
+func TestAPIFinalityTags(t *testing.T) {
+	chain := newTestFinalityChain(5)
+	api := &API{chain: chain, dpos: New(params.MainnetChainConfig, rawdb.NewMemoryDatabase())}
+
+	safe, finalized := rpc.SafeBlockNumber, rpc.FinalizedBlockNumber
+	if header := api.headerByNumber(&finalized); header != nil {
+		t.Errorf("finalized block resolved before finality: %v", header.Number)
+	}
+	chain.safe, chain.finalized = chain.headers[4], chain.headers[2]
+	if header := api.headerByNumber(&safe); header != chain.headers[4] {
+		t.Errorf("safe block mismatch: have %v, want 4", header)
+	}
+	if header := api.headerByNumber(&finalized); header != chain.headers[2] {
+		t.Errorf("finalized block mismatch: have %v, want 2", header)
+	}
+}
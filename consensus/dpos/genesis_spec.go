@@ -0,0 +1,231 @@
+package dpos
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+// defaultGenesisGasLimit is the gas limit of the genesis blocks built from a spec
+// without one, the one of the mainnet.
+const defaultGenesisGasLimit = 0x280de80
+
+var (
+	// errNotDposGenesis is returned if a genesis has no dpos engine configuration.
+	errNotDposGenesis = errors.New("not a dpos genesis")
+
+	// errInvalidGenesisExtra is returned if the extra-data of a genesis isn't laid
+	// out as vanity, the genesis validator and an empty seal.
+	errInvalidGenesisExtra = errors.New("invalid genesis extra-data")
+
+	// errInvalidGenesisValidator is returned if the genesis validator can't stake
+	// its initial deposit when the system contracts are initialized.
+	errInvalidGenesisValidator = errors.New("invalid genesis validator")
+
+	// errMissingSystemContract is returned if a genesis lacks a system contract, or
+	// allocates another code at its address.
+	errMissingSystemContract = errors.New("missing system contract")
+)
+
+// GenesisValidator is the validator of a dpos genesis and its balance at genesis,
+// out of which it stakes its initial deposit when the system contracts are
+// initialized.
+type GenesisValidator struct {
+	Address common.Address
+	Balance *big.Int
+}
+
+// GenesisSpec specifies a dpos genesis block following the mainnet rules.
+type GenesisSpec struct {
+	ChainID   *big.Int
+	Period    uint64
+	Epoch     uint64
+	Timestamp uint64
+	GasLimit  uint64 // Gas limit of the genesis block, the mainnet one if zero
+
+	// Validators funded at genesis. Only a single one is supported, as the system
+	// contracts are initialized with one: it seals the chain and stakes its initial
+	// deposit when they are initialized at block 1.
+	Validators []GenesisValidator
+
+	Alloc core.GenesisAlloc   // Accounts pre-funded at genesis besides the validators
+	Forks map[string]*big.Int // Fork blocks by chain config name, e.g. redCoastBlock
+}
+
+// SystemContracts returns the genesis allocation of the system contracts.
+func SystemContracts() core.GenesisAlloc {
+	alloc := make(core.GenesisAlloc)
+	if err := json.NewDecoder(strings.NewReader(GenesisAlloc)).Decode(&alloc); err != nil {
+		panic(err)
+	}
+	return alloc
+}
+
+// BuildGenesis creates the genesis block of a dpos chain from its spec, with the
+// system contracts, and verifies it.
+func BuildGenesis(spec *GenesisSpec) (*core.Genesis, error) {
+	if len(spec.Validators) != 1 {
+		return nil, fmt.Errorf("%w: %d specified, the system contracts are initialized with one", errInvalidGenesisValidator, len(spec.Validators))
+	}
+	config := *params.MainnetChainConfig
+	if spec.ChainID != nil {
+		config.ChainID = spec.ChainID
+	}
+	config.Dpos = &params.DposConfig{
+		Period:                spec.Period,
+		Epoch:                 spec.Epoch,
+		EnableDevVerification: params.MainnetChainConfig.Dpos.EnableDevVerification,
+	}
+	names := make([]string, 0, len(spec.Forks))
+	for name := range spec.Forks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := setForkBlock(&config, name, spec.Forks[name]); err != nil {
+			return nil, err
+		}
+	}
+	// Fund the pre-funded accounts and the validator along the system contracts
+	var (
+		contracts = SystemContracts()
+		alloc     = SystemContracts()
+		validator = spec.Validators[0]
+	)
+	credit := func(addr common.Address, amount *big.Int) {
+		account := alloc[addr]
+		if account.Balance == nil {
+			account.Balance = new(big.Int)
+		}
+		account.Balance = new(big.Int).Add(account.Balance, amount)
+		alloc[addr] = account
+	}
+	for addr, account := range spec.Alloc {
+		if _, ok := contracts[addr]; ok {
+			return nil, fmt.Errorf("%w: %x pre-funded", errMissingSystemContract, addr)
+		}
+		alloc[addr] = account
+	}
+	if _, ok := contracts[validator.Address]; ok {
+		return nil, fmt.Errorf("%w: %x allocated twice", errInvalidGenesisValidator, validator.Address)
+	}
+	if validator.Balance == nil || validator.Balance.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %x has no balance", errInvalidGenesisValidator, validator.Address)
+	}
+	credit(validator.Address, validator.Balance)
+	// The migrated balances are registered in the Migrate contract at block 1 and
+	// claimed from its own balance, never credited to the accounts directly
+	_, migrated := systemcontract.InitMigrateAddrBalance()
+	for _, balance := range migrated {
+		credit(systemcontract.MigrateContractAddr, balance)
+	}
+	// Lay out the extra-data as a checkpoint listing the genesis validator
+	extra := make([]byte, extraVanity+checkpointEntryLength(&config, common.Big0)+extraSeal)
+	copy(extra[extraVanity:], validator.Address.Bytes())
+	if config.IsStakeSchedule(common.Big0) {
+		binary.BigEndian.PutUint64(extra[extraVanity+common.AddressLength:], stakeWeight(&systemcontract.Validator{Deposit: systemcontract.InitDeposit}))
+	}
+	gasLimit := spec.GasLimit
+	if gasLimit == 0 {
+		gasLimit = defaultGenesisGasLimit
+	}
+	genesis := &core.Genesis{
+		Config:     &config,
+		Timestamp:  spec.Timestamp,
+		ExtraData:  extra,
+		GasLimit:   gasLimit,
+		Difficulty: big.NewInt(1),
+		Alloc:      alloc,
+	}
+	if err := VerifyGenesis(genesis); err != nil {
+		return nil, err
+	}
+	return genesis, nil
+}
+
+// setForkBlock sets the block of the fork with the given chain config name.
+func setForkBlock(config *params.ChainConfig, name string, block *big.Int) error {
+	value := reflect.ValueOf(config).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if strings.Split(field.Tag.Get("json"), ",")[0] != name || !strings.HasSuffix(name, "Block") {
+			continue
+		}
+		if field.Type != reflect.TypeOf(block) {
+			break
+		}
+		value.Field(i).Set(reflect.ValueOf(block))
+		return nil
+	}
+	return fmt.Errorf("unknown fork %q", name)
+}
+
+// VerifyGenesis checks that the genesis can start a dpos chain: its forks are
+// ordered, its extra-data lists a single validator able to stake its initial
+// deposit, and it allocates all the system contracts.
+func VerifyGenesis(genesis *core.Genesis) error {
+	config := genesis.Config
+	if config == nil || config.Dpos == nil {
+		return errNotDposGenesis
+	}
+	if config.Dpos.Epoch == 0 {
+		return errors.New("invalid dpos config: zero epoch")
+	}
+	if err := config.CheckConfigForkOrder(); err != nil {
+		return err
+	}
+	if err := config.Dpos.CheckRewardSchedule(); err != nil {
+		return err
+	}
+	// Check the vanity, validators and seal layout of the extra-data
+	number := new(big.Int).SetUint64(genesis.Number)
+	extra := genesis.ExtraData
+	if len(extra) < extraVanity+extraSeal {
+		return fmt.Errorf("%w: %d bytes, want at least %d for the vanity and seal", errInvalidGenesisExtra, len(extra), extraVanity+extraSeal)
+	}
+	entry := checkpointEntryLength(config, number)
+	if size := len(extra) - extraVanity - extraSeal; size%entry != 0 {
+		return fmt.Errorf("%w: %d validator bytes, want a multiple of %d", errInvalidGenesisExtra, size, entry)
+	} else if size/entry != 1 {
+		return fmt.Errorf("%w: %d validators, the system contracts are initialized with one", errInvalidGenesisExtra, size/entry)
+	}
+	if !bytes.Equal(extra[len(extra)-extraSeal:], make([]byte, extraSeal)) {
+		return fmt.Errorf("%w: non-empty seal", errInvalidGenesisExtra)
+	}
+	validators, _ := parseCheckpointValidators(config, &types.Header{Number: number, Extra: extra})
+	if validators[0] == (common.Address{}) {
+		return fmt.Errorf("%w: zero address", errInvalidGenesisValidator)
+	}
+	if balance := genesis.Alloc[validators[0]].Balance; balance == nil || balance.Cmp(systemcontract.InitDeposit) < 0 {
+		return fmt.Errorf("%w: %x balance %v below the initial deposit %v", errInvalidGenesisValidator, validators[0], balance, systemcontract.InitDeposit)
+	}
+	// Check that the system contracts are allocated as built
+	contracts := SystemContracts()
+	addrs := make([]common.Address, 0, len(contracts))
+	for addr := range contracts {
+		addrs = append(addrs, addr)
+	}
+	sort.Sort(validatorsAscending(addrs))
+	for _, addr := range addrs {
+		account, ok := genesis.Alloc[addr]
+		if !ok {
+			return fmt.Errorf("%w: %x", errMissingSystemContract, addr)
+		}
+		if !bytes.Equal(account.Code, contracts[addr].Code) {
+			return fmt.Errorf("%w: %x has another code", errMissingSystemContract, addr)
+		}
+	}
+	return nil
+}
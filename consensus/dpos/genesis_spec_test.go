@@ -0,0 +1,125 @@
+package dpos
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+)
+
+func TestBuildGenesis(t *testing.T) {
+	validators := testValidators(2)
+	prefunded := common.HexToAddress("0x1000")
+	spec := &GenesisSpec{
+		ChainID:    big.NewInt(1337),
+		Period:     3,
+		Epoch:      100,
+		Validators: []GenesisValidator{{Address: validators[0], Balance: new(big.Int).Add(systemcontract.InitDeposit, big.NewInt(5))}},
+		Alloc:      core.GenesisAlloc{prefunded: {Balance: big.NewInt(7)}},
+		Forks:      map[string]*big.Int{"doubleSignSlashBlock": big.NewInt(0), "stakeScheduleBlock": big.NewInt(10)},
+	}
+	genesis, err := BuildGenesis(spec)
+	if err != nil {
+		t.Fatalf("failed to build genesis: %v", err)
+	}
+	if genesis.Config.StakeScheduleBlock.Int64() != 10 || genesis.Config.Dpos.Epoch != 100 {
+		t.Errorf("config mismatch: stake schedule %v, epoch %d", genesis.Config.StakeScheduleBlock, genesis.Config.Dpos.Epoch)
+	}
+	if balance, want := genesis.Alloc[validators[0]].Balance, spec.Validators[0].Balance; balance.Cmp(want) != 0 {
+		t.Errorf("validator balance mismatch: have %v, want %v", balance, want)
+	}
+	if balance := genesis.Alloc[prefunded].Balance; balance.Int64() != 7 {
+		t.Errorf("pre-funded balance mismatch: have %v, want 7", balance)
+	}
+	// The built-in migrations are claimed from the Migrate contract only
+	_, migrated := systemcontract.InitMigrateAddrBalance()
+	total := new(big.Int)
+	for _, balance := range migrated {
+		total.Add(total, balance)
+	}
+	if balance := genesis.Alloc[systemcontract.MigrateContractAddr].Balance; balance.Cmp(total) != 0 {
+		t.Errorf("migrate contract balance mismatch: have %v, want %v", balance, total)
+	}
+	// The validator is listed in the extra-data
+	block := genesis.ToBlock(rawdb.NewMemoryDatabase())
+	have, weights := parseCheckpointValidators(genesis.Config, block.Header())
+	if len(have) != 1 || have[0] != validators[0] || len(weights) != 0 {
+		t.Errorf("genesis validators mismatch: have %v %v", have, weights)
+	}
+	// Under the stake schedule, the genesis validator is weighted by its deposit
+	scheduled := *spec
+	scheduled.Forks = map[string]*big.Int{"stakeScheduleBlock": big.NewInt(0)}
+	if genesis, err = BuildGenesis(&scheduled); err != nil {
+		t.Fatalf("failed to build scheduled genesis: %v", err)
+	}
+	block = genesis.ToBlock(rawdb.NewMemoryDatabase())
+	want := stakeWeight(&systemcontract.Validator{Deposit: systemcontract.InitDeposit})
+	if have, weights = parseCheckpointValidators(genesis.Config, block.Header()); len(have) != 1 || weights[validators[0]] != want {
+		t.Errorf("scheduled genesis validators mismatch: have %v %v, want weight %d", have, weights, want)
+	}
+	// Invalid specs are rejected
+	for i, mutate := range []func(spec *GenesisSpec){
+		func(spec *GenesisSpec) { spec.Validators = nil },
+		func(spec *GenesisSpec) { spec.Validators[0].Balance = big.NewInt(1) },
+		func(spec *GenesisSpec) { spec.Validators[0].Address = systemcontract.ValidatorsContractAddr },
+		func(spec *GenesisSpec) {
+			spec.Validators = append(spec.Validators, GenesisValidator{Address: validators[1], Balance: systemcontract.InitDeposit})
+		},
+		func(spec *GenesisSpec) {
+			spec.Alloc = core.GenesisAlloc{systemcontract.MigrateContractAddr: {Balance: big.NewInt(1)}}
+		},
+		func(spec *GenesisSpec) { spec.Forks = map[string]*big.Int{"unknownBlock": big.NewInt(1)} },
+		func(spec *GenesisSpec) { spec.Forks = map[string]*big.Int{"chainId": big.NewInt(1)} },
+		func(spec *GenesisSpec) { spec.Forks = map[string]*big.Int{"berlinBlock": big.NewInt(10)} },
+		func(spec *GenesisSpec) { spec.Epoch = 0 },
+	} {
+		invalid := *spec
+		invalid.Validators = append([]GenesisValidator{}, spec.Validators...)
+		mutate(&invalid)
+		if _, err := BuildGenesis(&invalid); err == nil {
+			t.Errorf("test %d: invalid spec accepted", i)
+		}
+	}
+}
+
+func TestVerifyGenesis(t *testing.T) {
+	validator := testValidators(1)[0]
+	for i, tt := range []struct {
+		mutate func(genesis *core.Genesis)
+		err    error
+	}{
+		{},
+		{mutate: func(genesis *core.Genesis) { genesis.Config.Dpos = nil }, err: errNotDposGenesis},
+		{mutate: func(genesis *core.Genesis) { genesis.ExtraData = genesis.ExtraData[:extraVanity+extraSeal-1] }, err: errInvalidGenesisExtra},
+		{mutate: func(genesis *core.Genesis) { genesis.ExtraData = genesis.ExtraData[1:] }, err: errInvalidGenesisExtra},
+		{mutate: func(genesis *core.Genesis) { genesis.ExtraData = checkpointExtra(testValidators(2)) }, err: errInvalidGenesisExtra},
+		{mutate: func(genesis *core.Genesis) { genesis.ExtraData[len(genesis.ExtraData)-1] = 1 }, err: errInvalidGenesisExtra},
+		{mutate: func(genesis *core.Genesis) { genesis.ExtraData = checkpointExtra([]common.Address{{}}) }, err: errInvalidGenesisValidator},
+		{mutate: func(genesis *core.Genesis) { delete(genesis.Alloc, validator) }, err: errInvalidGenesisValidator},
+		{mutate: func(genesis *core.Genesis) { delete(genesis.Alloc, systemcontract.SysGovContractAddr) }, err: errMissingSystemContract},
+		{mutate: func(genesis *core.Genesis) {
+			genesis.Alloc[systemcontract.ValidatorsContractAddr] = core.GenesisAccount{Balance: new(big.Int), Code: []byte{0x00}}
+		}, err: errMissingSystemContract},
+	} {
+		genesis, err := BuildGenesis(&GenesisSpec{Epoch: 100, Validators: []GenesisValidator{{Address: validator, Balance: systemcontract.InitDeposit}}})
+		if err != nil {
+			t.Fatalf("failed to build genesis: %v", err)
+		}
+		if tt.mutate != nil {
+			tt.mutate(genesis)
+		}
+		if err := VerifyGenesis(genesis); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+	// Misordered forks are rejected too
+	genesis := DeveloperGenesisBlock(0, validator)
+	genesis.Config.LondonBlock = big.NewInt(10)
+	if err := VerifyGenesis(genesis); err == nil {
+		t.Errorf("misordered forks accepted")
+	}
+}
@@ -0,0 +1,423 @@
+package dpos
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/log"
+)
+
+var (
+	// errJailDisabled is returned if liveness records are requested while downtime
+	// jailing is not configured.
+	errJailDisabled = errors.New("downtime jailing is not configured")
+
+	// errInvalidUnjail is returned if an unjail request is not a fee-less call of
+	// SysUnjailToAddr without value nor data.
+	errInvalidUnjail = errors.New("invalid unjail request")
+
+	// errInvalidUnjailNonce is returned if an unjail request doesn't carry the next
+	// nonce of its signer.
+	errInvalidUnjailNonce = errors.New("invalid unjail request nonce")
+
+	// errInvalidUnjailSender is returned if an unjail transaction is not sent by the
+	// validator of the block.
+	errInvalidUnjailSender = errors.New("invalid sender for unjail transaction")
+
+	// errNotJailed is returned if an unjail request is signed by a validator which
+	// is not jailed.
+	errNotJailed = errors.New("unjail signer is not jailed")
+
+	// errJailCooldown is returned if an unjail request is signed before the cool-down
+	// since jailing is over.
+	errJailCooldown = errors.New("unjail before the jail cool-down is over")
+)
+
+// trackLiveness records a missed slot of the in-turn validator if the header was
+// sealed out of turn, and jails the validator once it misses too many slots within
+// the window. As for punishments, a validator that is not allowed to seal because
+// it signed recently doesn't miss its slot.
+func (s *Snapshot) trackLiveness(header *types.Header) {
+	number := header.Number.Uint64()
+	window := s.config.Jail.Window
+
+	// Forget the missed slots sliding out of the window
+	for validator, missed := range s.Missed {
+		for len(missed) > 0 && missed[0]+window <= number {
+			missed = missed[1:]
+		}
+		if len(missed) == 0 {
+			delete(s.Missed, validator)
+		} else {
+			s.Missed[validator] = missed
+		}
+	}
+	if header.Difficulty.Cmp(diffInTurn) == 0 {
+		return
+	}
+	validator := s.proposerSelector(number).Proposer(s, number)
+	for seen, recent := range s.Recents {
+		if recent == validator && seen+s.recentsLimit(validator, number) >= number {
+			return
+		}
+	}
+	s.Missed[validator] = append(s.Missed[validator], number)
+
+	if _, jailed := s.Jailed[validator]; !jailed && uint64(len(s.Missed[validator])) >= s.config.Jail.Threshold {
+		s.Jailed[validator] = number
+	}
+}
+
+// releaseJailed releases the jailed validators that a checkpoint elected again,
+// which only happens after they unjailed themselves.
+func (s *Snapshot) releaseJailed() {
+	for validator := range s.Jailed {
+		if _, ok := s.Validators[validator]; ok {
+			delete(s.Jailed, validator)
+			delete(s.Missed, validator)
+		}
+	}
+}
+
+// jailValidators kicks the validators jailed by the block out in the Validators
+// contract, the same way as double signing validators, so that the contract stops
+// electing them until they unjail themselves. The last effective validator is never
+// kicked out, so that the chain can go on.
+func (d *Dpos) jailValidators(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
+	snap, err := d.snapshot(chain, header.Number.Uint64()-1, header.ParentHash, nil)
+	if err != nil {
+		return err
+	}
+	jailed := snap.copy()
+	jailed.trackLiveness(header)
+
+	contract := systemcontract.NewValidators()
+	for validator, number := range jailed.Jailed {
+		if number != header.Number.Uint64() {
+			continue
+		}
+		val, err := contract.GetValidator(state, header, newChainContext(chain, d), d.chainConfig, validator)
+		if err != nil {
+			return err
+		}
+		if val.Status != validatorStatusEffictive {
+			continue
+		}
+		effective, err := contract.EffictiveValsLength(state, header, newChainContext(chain, d), d.chainConfig)
+		if err != nil {
+			return err
+		}
+		if effective.Cmp(common.Big1) <= 0 {
+			log.Warn("Not jailing the last effective validator", "validator", validator, "number", header.Number)
+			continue
+		}
+		if err := d.kickoutValidator(validator, chain, header, state); err != nil {
+			return err
+		}
+		log.Info("Validator jailed", "validator", validator, "number", header.Number)
+	}
+	return nil
+}
+
+// excludeJailed removes the validators which are still jailed in the Validators
+// contract from the validators elected by a checkpoint. The contract only elects
+// them again at its next election, while a checkpoint happens every epoch. If every
+// validator is jailed, none of them is excluded, so that the chain can go on.
+func (d *Dpos) excludeJailed(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, validators []common.Address) ([]common.Address, error) {
+	snap, err := d.snapshot(chain, header.Number.Uint64()-1, header.ParentHash, nil)
+	if err != nil {
+		return nil, err
+	}
+	contract := systemcontract.NewValidators()
+	free := make([]common.Address, 0, len(validators))
+	for _, validator := range validators {
+		if _, jailed := snap.Jailed[validator]; jailed {
+			val, err := contract.GetValidator(state, header, newChainContext(chain, d), d.chainConfig, validator)
+			if err != nil {
+				return nil, err
+			}
+			if val.Status == validatorStatusKickout {
+				continue
+			}
+		}
+		free = append(free, validator)
+	}
+	if len(free) == 0 {
+		log.Warn("All validators are jailed, ignoring jails", "number", header.Number)
+		return validators, nil
+	}
+	return free, nil
+}
+
+// SubmitUnjail verifies the unjail request of a jailed validator against the
+// current head and queues it to be included into the next block sealed by the
+// local validator. The request is a transaction signed by the jailed validator,
+// which the sealing validator carries in an unjail transaction of its own.
+func (d *Dpos) SubmitUnjail(request *types.Transaction) error {
+	if d.chain == nil {
+		return errors.New("unjail requests not accepted before the chain is set")
+	}
+	head := d.chain.CurrentHeader()
+	if !d.chainConfig.IsJail(new(big.Int).Add(head.Number, common.Big1)) {
+		return errJailDisabled
+	}
+	validator, err := types.Sender(d.signer, request)
+	if err != nil {
+		return err
+	}
+	if err := checkUnjailRequest(request); err != nil {
+		return err
+	}
+	d.unjailLock.Lock()
+	defer d.unjailLock.Unlock()
+
+	d.unjails[validator] = request
+	return nil
+}
+
+// pendingUnjails returns the queued unjail requests, ordered by signer.
+func (d *Dpos) pendingUnjails() []*types.Transaction {
+	d.unjailLock.Lock()
+	defer d.unjailLock.Unlock()
+
+	validators := make([]common.Address, 0, len(d.unjails))
+	for validator := range d.unjails {
+		validators = append(validators, validator)
+	}
+	sort.Sort(validatorsAscending(validators))
+
+	requests := make([]*types.Transaction, len(validators))
+	for i, validator := range validators {
+		requests[i] = d.unjails[validator]
+	}
+	return requests
+}
+
+// dropUnjail removes an unjail request from the queue.
+func (d *Dpos) dropUnjail(validator common.Address) {
+	d.unjailLock.Lock()
+	defer d.unjailLock.Unlock()
+
+	delete(d.unjails, validator)
+}
+
+// checkUnjailRequest checks the form of an unjail request, a fee-less call of
+// SysUnjailToAddr without value nor data.
+func checkUnjailRequest(request *types.Transaction) error {
+	if to := request.To(); to == nil || *to != systemcontract.SysUnjailToAddr {
+		return errInvalidUnjail
+	}
+	if request.Value().Sign() != 0 || len(request.Data()) != 0 || request.GasPrice().Sign() != 0 {
+		return errInvalidUnjail
+	}
+	return nil
+}
+
+// decodeUnjailRequest returns the unjail request carried by an unjail transaction.
+func decodeUnjailRequest(tx *types.Transaction) (*types.Transaction, error) {
+	request := new(types.Transaction)
+	if err := request.UnmarshalBinary(tx.Data()); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// verifyUnjail checks that an unjail request is signed by a validator jailed in the
+// Validators contract, after its cool-down is over, with its next nonce.
+func (d *Dpos) verifyUnjail(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, request *types.Transaction) (common.Address, error) {
+	if err := checkUnjailRequest(request); err != nil {
+		return common.Address{}, err
+	}
+	validator, err := types.Sender(d.signer, request)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if request.Nonce() != state.GetNonce(validator) {
+		return common.Address{}, errInvalidUnjailNonce
+	}
+	snap, err := d.snapshot(chain, header.Number.Uint64()-1, header.ParentHash, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	number, jailed := snap.Jailed[validator]
+	if !jailed {
+		return common.Address{}, errNotJailed
+	}
+	if header.Number.Uint64() < number+d.config.Jail.Cooldown {
+		return common.Address{}, errJailCooldown
+	}
+	val, err := systemcontract.NewValidators().GetValidator(state, header, newChainContext(chain, d), d.chainConfig, validator)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if val.Status != validatorStatusKickout {
+		return common.Address{}, errNotJailed
+	}
+	return validator, nil
+}
+
+// executeUnjailMsg restores a jailed validator in the Validators contract on its
+// behalf, which lets the contract elect it again. The nonce of the validator is
+// raised, so that its request can't be replayed.
+func (d *Dpos) executeUnjailMsg(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, validator common.Address, totalTxIndex int, txHash, bHash common.Hash) (*types.Receipt, error) {
+	data, err := d.abi[systemcontract.ValidatorsContractName].Pack("restore")
+	if err != nil {
+		return nil, err
+	}
+	state.SetNonce(validator, state.GetNonce(validator)+1)
+
+	state.Prepare(txHash, totalTxIndex)
+	msg := vmcaller.NewLegacyMessage(validator, &systemcontract.ValidatorsContractAddr, 0, new(big.Int), header.GasLimit, new(big.Int), data, false)
+	if _, err := vmcaller.ExecuteMsg(msg, state, header, newChainContext(chain, d), d.chainConfig); err != nil {
+		return nil, err
+	}
+	// unjail transaction will not actually consumes gas
+	receipt := types.NewReceipt([]byte{}, false, header.GasUsed)
+	receipt.Logs = state.GetLogs(txHash, bHash)
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	receipt.TxHash = txHash
+	receipt.BlockHash = bHash
+	receipt.BlockNumber = header.Number
+	receipt.TransactionIndex = uint(state.TxIndex())
+
+	log.Info("Validator unjailed", "validator", validator, "number", header.Number, "txHash", txHash)
+	return receipt, nil
+}
+
+// executeUnjail includes a verified unjail request into the block being assembled,
+// carried by an unjail transaction of the local validator.
+func (d *Dpos) executeUnjail(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, request *types.Transaction, validator common.Address, totalTxIndex int) (*types.Transaction, *types.Receipt, error) {
+	data, err := request.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := state.GetNonce(d.validator)
+	tx := types.NewTransaction(nonce, systemcontract.SysUnjailToAddr, new(big.Int), header.GasLimit, new(big.Int), data)
+	tx, err = d.signTxFn(accounts.Account{Address: d.validator}, tx, chain.Config().ChainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	//add nonce for validator
+	state.SetNonce(d.validator, nonce+1)
+	receipt, err := d.executeUnjailMsg(chain, header, state, validator, totalTxIndex, tx.Hash(), common.Hash{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, receipt, nil
+}
+
+// assembleUnjails includes the queued unjail requests which are valid at the block
+// being assembled, dropping the others.
+func (d *Dpos) assembleUnjails(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt) ([]*types.Transaction, []*types.Receipt, error) {
+	for _, request := range d.pendingUnjails() {
+		validator, err := d.verifyUnjail(chain, header, state, request)
+		if err != nil {
+			log.Debug("Dropping unjail request", "hash", request.Hash(), "err", err)
+			if signer, serr := types.Sender(d.signer, request); serr == nil && err != errJailCooldown {
+				d.dropUnjail(signer)
+			}
+			continue
+		}
+		tx, receipt, err := d.executeUnjail(chain, header, state, request, validator, len(txs))
+		if err != nil {
+			return nil, nil, err
+		}
+		d.dropUnjail(validator)
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+	}
+	return txs, receipts, nil
+}
+
+// replayUnjail applies an unjail transaction of an imported block.
+func (d *Dpos) replayUnjail(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, totalTxIndex int, tx *types.Transaction) (*types.Receipt, error) {
+	sender, err := types.Sender(d.signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	if sender != header.Coinbase {
+		return nil, errInvalidUnjailSender
+	}
+	request, err := decodeUnjailRequest(tx)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := d.verifyUnjail(chain, header, state, request)
+	if err != nil {
+		return nil, err
+	}
+	//add nonce for validator
+	state.SetNonce(sender, state.GetNonce(sender)+1)
+	return d.executeUnjailMsg(chain, header, state, validator, totalTxIndex, tx.Hash(), header.Hash())
+}
+
+// applyUnjailTx applies an unjail transaction using a given evm, the main purpose
+// of this method is for tracing.
+func (d *Dpos) applyUnjailTx(evm *vm.EVM, state *state.StateDB, txIndex int, sender common.Address, tx *types.Transaction) (ret []byte, vmerr error, err error) {
+	request, err := decodeUnjailRequest(tx)
+	if err != nil {
+		return
+	}
+	validator, err := types.Sender(d.signer, request)
+	if err != nil {
+		return
+	}
+	data, err := d.abi[systemcontract.ValidatorsContractName].Pack("restore")
+	if err != nil {
+		return
+	}
+	evm.Context.ExtraValidator = nil
+	//add nonce for validator
+	evm.StateDB.SetNonce(sender, evm.StateDB.GetNonce(sender)+1)
+	evm.StateDB.SetNonce(validator, evm.StateDB.GetNonce(validator)+1)
+
+	state.Prepare(tx.Hash(), txIndex)
+	evm.TxContext = vm.TxContext{
+		Origin:   validator,
+		GasPrice: new(big.Int),
+	}
+	ret, _, vmerr = evm.Call(vm.AccountRef(validator), systemcontract.ValidatorsContractAddr, data, tx.Gas(), new(big.Int))
+	state.Finalise(true)
+	return
+}
+
+// Liveness is the downtime record of a validator.
+type Liveness struct {
+	Missed       uint64 `json:"missed"`                 // Missed in-turn slots within the window
+	Window       uint64 `json:"window"`                 // Number of recent blocks in which missed slots are counted
+	Threshold    uint64 `json:"threshold"`              // Missed slots within the window to get jailed
+	Jailed       bool   `json:"jailed"`                 // Whether the validator is jailed
+	JailedAt     uint64 `json:"jailedAt,omitempty"`     // Block the validator was jailed at
+	UnjailableAt uint64 `json:"unjailableAt,omitempty"` // First block accepting an unjail transaction
+}
+
+// liveness returns the downtime records of the validators and the jailed ones.
+func (s *Snapshot) liveness() map[common.Address]*Liveness {
+	records := make(map[common.Address]*Liveness)
+	record := func(validator common.Address) *Liveness {
+		if records[validator] == nil {
+			records[validator] = &Liveness{Window: s.config.Jail.Window, Threshold: s.config.Jail.Threshold}
+		}
+		return records[validator]
+	}
+	for validator := range s.Validators {
+		record(validator)
+	}
+	for validator, missed := range s.Missed {
+		record(validator).Missed = uint64(len(missed))
+	}
+	for validator, number := range s.Jailed {
+		r := record(validator)
+		r.Jailed, r.JailedAt, r.UnjailableAt = true, number, number+s.config.Jail.Cooldown
+	}
+	return records
+}
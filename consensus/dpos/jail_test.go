@@ -0,0 +1,250 @@
+package dpos
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+func TestTrackLiveness(t *testing.T) {
+	config := &params.DposConfig{Epoch: 100, Jail: &params.JailConfig{Window: 10, Threshold: 3, Cooldown: 5}}
+	validators := testValidators(3)
+	snap := newSnapshot(nil, config, nil, 0, common.Hash{}, validators, nil)
+
+	// Blocks 1, 4 and 7 are out of turn for validators[1], which misses its slots
+	miss := func(number uint64) {
+		difficulty := diffInTurn
+		if number%3 == 1 {
+			difficulty = diffNoTurn
+		}
+		snap.trackLiveness(&types.Header{Number: new(big.Int).SetUint64(number), Difficulty: difficulty})
+	}
+	for number := uint64(1); number <= 6; number++ {
+		miss(number)
+	}
+	if missed := snap.Missed[validators[1]]; len(missed) != 2 || missed[0] != 1 || missed[1] != 4 {
+		t.Fatalf("missed slots mismatch: %v", missed)
+	}
+	if len(snap.Jailed) != 0 {
+		t.Fatalf("validator jailed below threshold: %v", snap.Jailed)
+	}
+	miss(7)
+	if number, ok := snap.Jailed[validators[1]]; !ok || number != 7 {
+		t.Fatalf("validator not jailed at threshold: %v", snap.Jailed)
+	}
+	// Old misses slide out of the window, the jail stays
+	for number := uint64(8); number <= 14; number++ {
+		snap.trackLiveness(&types.Header{Number: new(big.Int).SetUint64(number), Difficulty: diffInTurn})
+	}
+	if missed := snap.Missed[validators[1]]; len(missed) != 1 || missed[0] != 7 {
+		t.Fatalf("missed slots not slid out of the window: %v", missed)
+	}
+	liveness := snap.liveness()[validators[1]]
+	if !liveness.Jailed || liveness.JailedAt != 7 || liveness.UnjailableAt != 12 || liveness.Missed != 1 {
+		t.Errorf("liveness mismatch: %+v", liveness)
+	}
+	// A validator recently signing is not expected to seal
+	snap.Recents[14] = validators[1]
+	snap.trackLiveness(&types.Header{Number: big.NewInt(16), Difficulty: diffNoTurn})
+	if missed := snap.Missed[validators[1]]; len(missed) != 1 {
+		t.Errorf("recently signing validator missed a slot: %v", missed)
+	}
+	// Copies don't share the records
+	cpy := snap.copy()
+	cpy.Missed[validators[1]][0] = 0
+	delete(cpy.Jailed, validators[1])
+	if snap.Missed[validators[1]][0] != 7 || len(snap.Jailed) != 1 {
+		t.Errorf("copy shares liveness records")
+	}
+	// Being elected again releases the validator
+	snap.Validators = map[common.Address]struct{}{validators[0]: {}}
+	snap.releaseJailed()
+	if len(snap.Jailed) != 1 {
+		t.Fatalf("validator released without being elected")
+	}
+	snap.Validators[validators[1]] = struct{}{}
+	snap.releaseJailed()
+	if len(snap.Jailed) != 0 || len(snap.Missed[validators[1]]) != 0 {
+		t.Errorf("elected validator not released: %v %v", snap.Jailed, snap.Missed)
+	}
+}
+
+func TestUnjail(t *testing.T) {
+	maker, validators := newTestStateChainMaker(t, 1, 20)
+	maker.engine.config.Jail = &params.JailConfig{Window: 10, Threshold: 1, Cooldown: 5}
+	maker.chain.config.DoubleSignSlashBlock = common.Big0
+	head := maker.generate(maker.genesis(), 1, nil)[0]
+	statedb := maker.state(head)
+	validator := validators[0]
+
+	header := &types.Header{
+		ParentHash: head.Hash(),
+		Number:     big.NewInt(2),
+		Time:       head.Time + 3,
+		Coinbase:   validator,
+		Difficulty: diffNoTurn,
+		GasLimit:   head.GasLimit,
+	}
+	status := func() uint8 {
+		val, err := systemcontract.NewValidators().GetValidator(statedb, header, newChainContext(maker.chain, maker.engine), maker.chain.Config(), validator)
+		if err != nil {
+			t.Fatalf("failed to retrieve validator: %v", err)
+		}
+		return val.Status
+	}
+	sign := func(nonce uint64, value *big.Int, gasPrice *big.Int) *types.Transaction {
+		tx, err := types.SignTx(types.NewTransaction(nonce, systemcontract.SysUnjailToAddr, value, params.TxGas, gasPrice, nil), maker.engine.signer, maker.keys[validator])
+		if err != nil {
+			t.Fatalf("failed to sign unjail: %v", err)
+		}
+		return tx
+	}
+	unjail := sign(statedb.GetNonce(validator), new(big.Int), new(big.Int))
+	if _, err := maker.engine.verifyUnjail(maker.chain, header, statedb, unjail); err != errNotJailed {
+		t.Fatalf("free validator unjail error mismatch: have %v, want %v", err, errNotJailed)
+	}
+	// Missing its turn jails the validator, but the last effective one stays in the contract
+	snap, err := maker.engine.snapshot(maker.chain, 1, head.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve snapshot: %v", err)
+	}
+	snap = snap.copy()
+	snap.Recents = make(map[uint64]common.Address)
+	maker.engine.recents.Add(head.Hash(), snap)
+
+	if err := maker.engine.jailValidators(maker.chain, header, statedb); err != nil {
+		t.Fatalf("failed to jail validators: %v", err)
+	}
+	if have := status(); have != validatorStatusEffictive {
+		t.Fatalf("last effective validator kicked out: status %d", have)
+	}
+	snap.Jailed[validator] = 1
+
+	// The unjail is only accepted after the cool-down, from a validator jailed in the contract
+	if _, err := maker.engine.verifyUnjail(maker.chain, header, statedb, unjail); err != errJailCooldown {
+		t.Fatalf("cooling down unjail error mismatch: have %v, want %v", err, errJailCooldown)
+	}
+	maker.engine.config.Jail.Cooldown = 1
+	if _, err := maker.engine.verifyUnjail(maker.chain, header, statedb, unjail); err != errNotJailed {
+		t.Fatalf("effective validator unjail error mismatch: have %v, want %v", err, errNotJailed)
+	}
+	if err := maker.engine.kickoutValidator(validator, maker.chain, header, statedb); err != nil {
+		t.Fatalf("failed to kick out validator: %v", err)
+	}
+	for i, tt := range []struct {
+		tx  *types.Transaction
+		err error
+	}{
+		{sign(unjail.Nonce(), big.NewInt(1), new(big.Int)), errInvalidUnjail},
+		{sign(unjail.Nonce(), new(big.Int), big.NewInt(1)), errInvalidUnjail},
+		{sign(unjail.Nonce()+1, new(big.Int), new(big.Int)), errInvalidUnjailNonce},
+		{unjail, nil},
+	} {
+		if _, err := maker.engine.verifyUnjail(maker.chain, header, statedb, tt.tx); err != tt.err {
+			t.Errorf("test %d: unjail error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+	// Unjailing restores the validator in the contract, and can't be replayed
+	receipt, err := maker.engine.executeUnjailMsg(maker.chain, header, statedb, validator, 0, unjail.Hash(), common.Hash{})
+	if err != nil {
+		t.Fatalf("failed to unjail: %v", err)
+	}
+	if have := status(); have != validatorStatusEffictive {
+		t.Errorf("unjailed validator status mismatch: have %d, want %d", have, validatorStatusEffictive)
+	}
+	if len(receipt.Logs) == 0 || receipt.TxHash != unjail.Hash() {
+		t.Errorf("unjail receipt mismatch: %+v", receipt)
+	}
+	if _, err := maker.engine.verifyUnjail(maker.chain, header, statedb, unjail); err != errInvalidUnjailNonce {
+		t.Errorf("replayed unjail error mismatch: have %v, want %v", err, errInvalidUnjailNonce)
+	}
+}
+
+func TestUnjailByOtherValidator(t *testing.T) {
+	maker, validators := newTestStateChainMaker(t, 2, 20)
+	maker.engine.config.Jail = &params.JailConfig{Window: 10, Threshold: 1, Cooldown: 1}
+	maker.chain.config.DoubleSignSlashBlock = common.Big0
+	maker.chain.config.JailBlock = common.Big0
+	head := maker.generate(maker.genesis(), 1, nil)[0]
+	statedb := maker.state(head)
+	jailed, sealer := validators[0], validators[1]
+
+	header := &types.Header{
+		ParentHash: head.Hash(),
+		Number:     big.NewInt(2),
+		Time:       head.Time + 3,
+		Coinbase:   sealer,
+		Difficulty: diffInTurn,
+		GasLimit:   head.GasLimit,
+	}
+	snap, err := maker.engine.snapshot(maker.chain, 1, head.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve snapshot: %v", err)
+	}
+	snap = snap.copy()
+	snap.Jailed[jailed] = 1
+	maker.engine.recents.Add(head.Hash(), snap)
+
+	if err := maker.engine.kickoutValidator(jailed, maker.chain, header, statedb); err != nil {
+		t.Fatalf("failed to kick out validator: %v", err)
+	}
+	replayed := statedb.Copy()
+
+	// The jailed validator signs its request, the sealing validator carries it
+	request, err := types.SignTx(types.NewTransaction(statedb.GetNonce(jailed), systemcontract.SysUnjailToAddr, new(big.Int), params.TxGas, new(big.Int), nil), maker.engine.signer, maker.keys[jailed])
+	if err != nil {
+		t.Fatalf("failed to sign unjail request: %v", err)
+	}
+	signTx := func(validator common.Address) SignTxFn {
+		return func(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+			return types.SignTx(tx, types.NewEIP155Signer(chainID), maker.keys[validator])
+		}
+	}
+	maker.engine.Authorize(sealer, nil, signTx(sealer))
+	if err := maker.engine.SubmitUnjail(request); err != nil {
+		t.Fatalf("failed to submit unjail request: %v", err)
+	}
+	txs, receipts, err := maker.engine.assembleUnjails(maker.chain, header, statedb, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to assemble unjails: %v", err)
+	}
+	if len(txs) != 1 || len(receipts) != 1 || len(maker.engine.pendingUnjails()) != 0 {
+		t.Fatalf("unjail request not included: %d txs, %d receipts", len(txs), len(receipts))
+	}
+	tx := txs[0]
+	if sender, err := types.Sender(maker.engine.signer, tx); err != nil || sender != sealer {
+		t.Fatalf("unjail sender mismatch: have %x, want %x (%v)", sender, sealer, err)
+	}
+	if sys, err := maker.engine.IsSysTransaction(sealer, tx, header); err != nil || !sys {
+		t.Errorf("coinbase unjail not a system transaction: %v", err)
+	}
+	if sys, _ := maker.engine.IsSysTransaction(jailed, request, header); sys {
+		t.Errorf("unjail request of a non-coinbase sender is a system transaction")
+	}
+	// Importing the block checks the sender and restores the jailed validator
+	forged, err := signTx(jailed)(accounts.Account{Address: jailed}, types.NewTransaction(replayed.GetNonce(jailed), systemcontract.SysUnjailToAddr, new(big.Int), header.GasLimit, new(big.Int), tx.Data()), maker.chain.Config().ChainID)
+	if err != nil {
+		t.Fatalf("failed to sign forged unjail: %v", err)
+	}
+	if _, err := maker.engine.replayUnjail(maker.chain, header, replayed, 0, forged); err != errInvalidUnjailSender {
+		t.Fatalf("non-coinbase unjail error mismatch: have %v, want %v", err, errInvalidUnjailSender)
+	}
+	if _, err := maker.engine.replayUnjail(maker.chain, header, replayed, 0, tx); err != nil {
+		t.Fatalf("failed to replay unjail: %v", err)
+	}
+	val, err := systemcontract.NewValidators().GetValidator(replayed, header, newChainContext(maker.chain, maker.engine), maker.chain.Config(), jailed)
+	if err != nil {
+		t.Fatalf("failed to retrieve validator: %v", err)
+	}
+	if val.Status != validatorStatusEffictive {
+		t.Errorf("unjailed validator status mismatch: have %d, want %d", val.Status, validatorStatusEffictive)
+	}
+	if replayed.GetNonce(jailed) != request.Nonce()+1 || replayed.GetNonce(sealer) != tx.Nonce()+1 {
+		t.Errorf("nonces not raised: validator %d, sealer %d", replayed.GetNonce(jailed), replayed.GetNonce(sealer))
+	}
+}
@@ -0,0 +1,124 @@
+package dpos
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/log"
+)
+
+// stateRetrievalTimeout is the time allowed to retrieve the state the engine reads
+// internally, on demand on light clients.
+const stateRetrievalTimeout = 10 * time.Second
+
+// errInvalidLightCheckpoint is returned if the headers retrieved for a light
+// client checkpoint don't link up to it.
+var errInvalidLightCheckpoint = errors.New("invalid light checkpoint ancestry")
+
+// HeaderFn retrieves a canonical header by number, possibly from the network.
+type HeaderFn func(number uint64) (*types.Header, error)
+
+// HeaderStateFn returns the state of a header, possibly retrieved on demand as it's
+// read, for as long as the context is not done.
+type HeaderStateFn func(ctx context.Context, header *types.Header) (*state.StateDB, error)
+
+// SetHeaderFn sets the function to retrieve the canonical headers a light client
+// misses below its trusted checkpoint.
+func (d *Dpos) SetHeaderFn(fn HeaderFn) {
+	d.headerFn = fn
+}
+
+// SetHeaderStateFn sets the function to get the state of a header, used instead of
+// the state function by light clients which don't have the state roots locally.
+func (d *Dpos) SetHeaderStateFn(fn HeaderStateFn) {
+	d.headerStateFn = fn
+}
+
+// stateAt returns the state of the given header. The context must not be done
+// until the caller is done reading the state.
+func (d *Dpos) stateAt(ctx context.Context, header *types.Header) (*state.StateDB, error) {
+	if d.headerStateFn != nil {
+		return d.headerStateFn(ctx, header)
+	}
+	if d.stateFn == nil {
+		return nil, errors.New("state not available")
+	}
+	return d.stateFn(header.Root)
+}
+
+// lightCheckpoint creates the snapshot of a light client trusted checkpoint, the
+// head of a CHT section without its parent in the chain. The headers since the
+// last epoch one are retrieved on demand once, checked to link up to the
+// checkpoint, and applied on top of the epoch validators, verifying the transition
+// as the full chain would. It returns nil if the header is not such a checkpoint.
+func (d *Dpos) lightCheckpoint(chain consensus.ChainHeaderReader, number uint64, hash common.Hash) (*Snapshot, error) {
+	if d.headerFn == nil || number == 0 || number%d.config.Epoch == 0 || chain.GetHeaderByNumber(number-1) != nil {
+		return nil, nil
+	}
+	checkpoint := chain.GetHeaderByNumber(number)
+	if checkpoint == nil || checkpoint.Hash() != hash {
+		return nil, nil
+	}
+	if snap, err := loadSnapshot(d.chainConfig, d.config, d.signatures, d.db, number, hash); err == nil {
+		return snap, nil
+	}
+	// Retrieve the headers back to the epoch one, each the parent of the next
+	child := checkpoint
+	ancestor := func(n uint64) (*types.Header, error) {
+		header, err := d.headerFn(n)
+		if err != nil {
+			return nil, err
+		}
+		if header.Number.Uint64() != n || header.Hash() != child.ParentHash {
+			return nil, errInvalidLightCheckpoint
+		}
+		child = header
+		return header, nil
+	}
+	epochNumber := number - number%d.config.Epoch
+	headers := make([]*types.Header, number-epochNumber+1)
+	headers[len(headers)-1] = checkpoint
+	for i := len(headers) - 2; i >= 0; i-- {
+		header, err := ancestor(epochNumber + uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		headers[i] = header
+	}
+	epoch := headers[0]
+	validators, weights := parseCheckpointValidators(d.chainConfig, epoch)
+	snap := newSnapshot(d.chainConfig, d.config, d.signatures, epochNumber, epoch.Hash(), validators, weights)
+	snap.Checkpoint = epoch.Hash()
+
+	// Fill the recent signers up to the epoch header, as applying the headers would have
+	limit := uint64(len(snap.Validators)/2 + 1)
+	for n := epochNumber; n > 0 && n+limit > epochNumber; n-- {
+		header := epoch
+		if n != epochNumber {
+			var err error
+			if header, err = ancestor(n); err != nil {
+				return nil, err
+			}
+		}
+		validator, err := ecrecover(header, d.signatures)
+		if err != nil {
+			return nil, err
+		}
+		snap.Recents[n] = validator
+	}
+	// Verify the headers since the epoch are sealed by its validators
+	snap, err := snap.apply(headers[1:], chain, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := snap.store(d.db); err != nil {
+		return nil, err
+	}
+	log.Info("Stored light checkpoint snapshot to disk", "number", number, "hash", hash, "epoch", epoch.Number)
+	return snap, nil
+}
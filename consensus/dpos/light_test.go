@@ -0,0 +1,80 @@
+package dpos
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+)
+
+func TestLightCheckpointSnapshot(t *testing.T) {
+	engine, full := newSealedTestChain(t, 20, 8)
+
+	// Generate the reference snapshot from the full chain
+	head := full.headers[20]
+	want, err := New(engine.chainConfig, rawdb.NewMemoryDatabase()).snapshot(full, head.Number.Uint64(), head.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to generate snapshot: %v", err)
+	}
+	// The light chain only has the headers from its trusted checkpoint, 13
+	chain := &testFinalityChain{headers: make([]*types.Header, len(full.headers))}
+	copy(chain.headers[13:], full.headers[13:])
+
+	light := New(engine.chainConfig, rawdb.NewMemoryDatabase())
+	if _, err := light.snapshot(chain, head.Number.Uint64(), head.Hash(), nil); err == nil {
+		t.Fatalf("snapshot generated without the missing headers")
+	}
+	var retrieved []uint64
+	light.SetHeaderFn(func(number uint64) (*types.Header, error) {
+		retrieved = append(retrieved, number)
+		if header := full.GetHeaderByNumber(number); header != nil {
+			return header, nil
+		}
+		return nil, errors.New("unknown header")
+	})
+	have, err := light.snapshot(chain, head.Number.Uint64(), head.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to generate light snapshot: %v", err)
+	}
+	// The headers back to the epoch one and the recent signers before it are retrieved
+	if !reflect.DeepEqual(retrieved, []uint64{12, 11, 10, 9, 8, 7}) {
+		t.Errorf("retrieved headers mismatch: have %v", retrieved)
+	}
+	if !reflect.DeepEqual(have.Validators, want.Validators) || have.Checkpoint != want.Checkpoint {
+		t.Errorf("validators mismatch: have %v %x, want %v %x", have.Validators, have.Checkpoint, want.Validators, want.Checkpoint)
+	}
+	if !reflect.DeepEqual(have.Recents, want.Recents) {
+		t.Errorf("recents mismatch: have %v, want %v", have.Recents, want.Recents)
+	}
+	// Headers not linking up to the checkpoint are rejected
+	for i, tamper := range []func(header *types.Header) *types.Header{
+		func(header *types.Header) *types.Header {
+			header = types.CopyHeader(header)
+			header.Extra = append([]byte{}, header.Extra...)
+			header.Extra[0]++
+			return header
+		},
+		func(header *types.Header) *types.Header { return full.headers[header.Number.Uint64()-1] },
+	} {
+		tampered := New(engine.chainConfig, rawdb.NewMemoryDatabase())
+		tampered.SetHeaderFn(func(number uint64) (*types.Header, error) {
+			if number == 10 {
+				return tamper(full.headers[number]), nil
+			}
+			return full.GetHeaderByNumber(number), nil
+		})
+		if _, err := tampered.snapshot(chain, head.Number.Uint64(), head.Hash(), nil); err != errInvalidLightCheckpoint {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, errInvalidLightCheckpoint)
+		}
+	}
+	// The checkpoint snapshot is stored, a restarted client doesn't retrieve again
+	restarted := New(engine.chainConfig, light.db)
+	restarted.SetHeaderFn(func(number uint64) (*types.Header, error) {
+		return nil, errors.New("unexpected retrieval")
+	})
+	if _, err := restarted.snapshot(chain, head.Number.Uint64(), head.Hash(), nil); err != nil {
+		t.Errorf("failed to regenerate light snapshot: %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package dpos
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+// validatorWeightLength is the number of bytes following each validator address in
+// the checkpoint extra-data after the StakeSchedule fork, holding its stake weight.
+const validatorWeightLength = 8
+
+// weightUnit is the amount of stake worth a single unit of proposer weight.
+var weightUnit = big.NewInt(params.Ether)
+
+// ProposerSelector decides which validator is in-turn to propose a block.
+type ProposerSelector interface {
+	// Proposer returns the in-turn validator of the block following the snapshot.
+	Proposer(snap *Snapshot, number uint64) common.Address
+}
+
+// roundRobinSelector schedules proposers one after another in ascending address order.
+type roundRobinSelector struct{}
+
+// Proposer implements ProposerSelector.
+func (roundRobinSelector) Proposer(snap *Snapshot, number uint64) common.Address {
+	validators := snap.validators()
+	return validators[number%uint64(len(validators))]
+}
+
+// stakeWeightedSelector schedules proposers in weighted round-robin rounds, where
+// validators with more stake get proportionally more slots. The slots of a round
+// are interleaved in an order shuffled once per epoch from the checkpoint header
+// hash. A validator still in the recently signed limit at one of its slots lets
+// the block go out-of-turn to someone else.
+type stakeWeightedSelector struct{}
+
+// Proposer implements ProposerSelector.
+func (stakeWeightedSelector) Proposer(snap *Snapshot, number uint64) common.Address {
+	slots := snap.slots()
+	return slots[number%uint64(len(slots))]
+}
+
+// schedule returns the validators of the snapshot in the order of the stake-weighted
+// schedule. Each validator gets a pseudo random score seeded by the checkpoint hash,
+// divided by its weight, and the lowest scores come first.
+func (s *Snapshot) schedule() []common.Address {
+	validators := s.validators()
+	scores := make(map[common.Address]*big.Int, len(validators))
+	for _, validator := range validators {
+		score := new(big.Int).SetBytes(crypto.Keccak256(s.Checkpoint.Bytes(), validator.Bytes()))
+		scores[validator] = score.Div(score, new(big.Int).SetUint64(s.weight(validator)))
+	}
+	sort.SliceStable(validators, func(i, j int) bool {
+		return scores[validators[i]].Cmp(scores[validators[j]]) < 0
+	})
+	return validators
+}
+
+// weight returns the stake weight of a validator, at least 1.
+func (s *Snapshot) weight(validator common.Address) uint64 {
+	if weight := s.Weights[validator]; weight > 0 {
+		return weight
+	}
+	return 1
+}
+
+// slots returns a round of the stake-weighted schedule. Every validator gets one
+// slot per average stake it holds, but at least one, and the slots are spread
+// over the round by smooth weighted round-robin, starting from the head of the
+// schedule.
+func (s *Snapshot) slots() []common.Address {
+	schedule := s.schedule()
+
+	total := new(big.Int)
+	for _, validator := range schedule {
+		total.Add(total, new(big.Int).SetUint64(s.weight(validator)))
+	}
+	var (
+		counts = make([]int64, len(schedule))
+		round  int64
+	)
+	for i, validator := range schedule {
+		count := new(big.Int).SetUint64(s.weight(validator))
+		count.Mul(count, big.NewInt(int64(len(schedule))))
+		counts[i] = count.Div(count, total).Int64()
+		if counts[i] == 0 {
+			counts[i] = 1
+		}
+		round += counts[i]
+	}
+	var (
+		slots   = make([]common.Address, 0, round)
+		current = make([]int64, len(schedule))
+	)
+	for len(slots) < int(round) {
+		next := 0
+		for i := range schedule {
+			current[i] += counts[i]
+			if current[i] > current[next] {
+				next = i
+			}
+		}
+		current[next] -= round
+		slots = append(slots, schedule[next])
+	}
+	return slots
+}
+
+// recentsLimit returns the number of blocks a validator has to wait for after
+// signing one, before it may sign the given block. It is half of the validators
+// plus one, except under the stake-weighted schedule where heavy validators hold
+// slots closer to each other, down to consecutive ones: their limit is lowered to
+// the shortest distance between their slots of a round, so they can seal them all.
+func (s *Snapshot) recentsLimit(validator common.Address, number uint64) uint64 {
+	limit := uint64(len(s.Validators)/2 + 1)
+	if _, weighted := s.proposerSelector(number).(stakeWeightedSelector); !weighted {
+		return limit
+	}
+	slots := s.slots()
+	first, last := -1, -1
+	for i, slot := range slots {
+		if slot != validator {
+			continue
+		}
+		if first < 0 {
+			first = i
+		} else if distance := uint64(i - last); distance < limit {
+			limit = distance
+		}
+		last = i
+	}
+	// The last slot of a round is followed by the first one of the next round
+	if first >= 0 {
+		if distance := uint64(first + len(slots) - last); distance < limit {
+			limit = distance
+		}
+	}
+	return limit
+}
+
+// proposerSelector returns the selector scheduling the given block following the
+// snapshot, which depends on whether the checkpoint in force for the block is after
+// the StakeSchedule fork. The block number is passed in rather than taken from the
+// snapshot, so that the schedule is right while the snapshot is being applied.
+func (s *Snapshot) proposerSelector(number uint64) ProposerSelector {
+	if number == 0 {
+		return roundRobinSelector{}
+	}
+	parent := number - 1
+	if s.chainConfig != nil && s.chainConfig.IsStakeSchedule(new(big.Int).SetUint64(parent-parent%s.config.Epoch)) {
+		return stakeWeightedSelector{}
+	}
+	return roundRobinSelector{}
+}
+
+// checkpointEntryLength returns the number of bytes used by each validator in the
+// extra-data of the given checkpoint. Without a chain config, checkpoints are
+// assumed to only carry addresses.
+func checkpointEntryLength(config *params.ChainConfig, number *big.Int) int {
+	if config != nil && config.IsStakeSchedule(number) {
+		return common.AddressLength + validatorWeightLength
+	}
+	return common.AddressLength
+}
+
+// parseCheckpointValidators retrieves the validators and, after the StakeSchedule
+// fork, their weights from the extra-data of a checkpoint header.
+func parseCheckpointValidators(config *params.ChainConfig, checkpoint *types.Header) ([]common.Address, map[common.Address]uint64) {
+	entry := checkpointEntryLength(config, checkpoint.Number)
+	validators := make([]common.Address, (len(checkpoint.Extra)-extraVanity-extraSeal)/entry)
+	weights := make(map[common.Address]uint64)
+	for i := 0; i < len(validators); i++ {
+		offset := extraVanity + i*entry
+		copy(validators[i][:], checkpoint.Extra[offset:])
+		if entry > common.AddressLength {
+			weights[validators[i]] = binary.BigEndian.Uint64(checkpoint.Extra[offset+common.AddressLength:])
+		}
+	}
+	return validators, weights
+}
+
+// checkpointValidators returns the validators of the next epoch as encoded in the
+// extra-data of the checkpoint header.
+func (d *Dpos) checkpointValidators(chain consensus.ChainHeaderReader, header *types.Header, statedb *state.StateDB) ([]byte, error) {
+	validators, err := d.getCurEpochValidators(chain, header, statedb)
+	if err != nil {
+		return nil, err
+	}
+	if d.chainConfig.IsJail(header.Number) {
+		if validators, err = d.excludeJailed(chain, header, statedb, validators); err != nil {
+			return nil, err
+		}
+	}
+	if !d.chainConfig.IsStakeSchedule(header.Number) {
+		var extra bytes.Buffer
+		for _, validator := range validators {
+			extra.Write(validator.Bytes())
+		}
+		return extra.Bytes(), nil
+	}
+	contract := systemcontract.NewValidators()
+	extra := make([]byte, len(validators)*(common.AddressLength+validatorWeightLength))
+	for i, validator := range validators {
+		val, err := contract.GetValidator(statedb, header, newChainContext(chain, d), d.chainConfig, validator)
+		if err != nil {
+			return nil, err
+		}
+		offset := i * (common.AddressLength + validatorWeightLength)
+		copy(extra[offset:], validator.Bytes())
+		binary.BigEndian.PutUint64(extra[offset+common.AddressLength:], stakeWeight(val))
+	}
+	return extra, nil
+}
+
+// stakeWeight returns the proposer weight of a validator, its deposit and votes in
+// whole units of ether.
+func stakeWeight(val *systemcontract.Validator) uint64 {
+	stake := new(big.Int)
+	if val.Deposit != nil {
+		stake.Add(stake, val.Deposit)
+	}
+	if val.Votes != nil {
+		stake.Add(stake, val.Votes)
+	}
+	stake.Div(stake, weightUnit)
+	if !stake.IsUint64() {
+		return math.MaxUint64
+	}
+	return stake.Uint64()
+}
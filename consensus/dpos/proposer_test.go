@@ -0,0 +1,277 @@
+package dpos
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+// stakeScheduleConfig returns a chain config activating the stake-weighted schedule
+// at the given block.
+func stakeScheduleConfig(fork uint64) *params.ChainConfig {
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Period: 3, Epoch: 10}
+	config.StakeScheduleBlock = new(big.Int).SetUint64(fork)
+	return &config
+}
+
+func testValidators(n int) []common.Address {
+	validators := make([]common.Address, n)
+	for i := range validators {
+		validators[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+	}
+	return validators
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	validators := testValidators(5)
+	snap := newSnapshot(nil, &params.DposConfig{Epoch: 10}, nil, 0, common.Hash{}, validators, nil)
+
+	for number := uint64(0); number < 20; number++ {
+		want := validators[number%uint64(len(validators))]
+		if have := snap.proposerSelector(number).Proposer(snap, number); have != want {
+			t.Errorf("block %d: proposer mismatch: have %x, want %x", number, have, want)
+		}
+		if !snap.inturn(number, want) {
+			t.Errorf("block %d: proposer %x not in-turn", number, want)
+		}
+	}
+}
+
+func TestStakeWeightedSchedule(t *testing.T) {
+	config := stakeScheduleConfig(0)
+	validators := testValidators(7)
+
+	snap := newSnapshot(config, config.Dpos, nil, 0, common.HexToHash("0x01"), validators, nil)
+	schedule := snap.schedule()
+	if len(schedule) != len(validators) {
+		t.Fatalf("schedule length mismatch: have %d, want %d", len(schedule), len(validators))
+	}
+	seen := make(map[common.Address]bool)
+	for _, validator := range schedule {
+		if _, ok := snap.Validators[validator]; !ok || seen[validator] {
+			t.Fatalf("schedule is not a permutation of the validators: %v", schedule)
+		}
+		seen[validator] = true
+	}
+	// The schedule is stable for the same checkpoint and reshuffled by the next one
+	for i, validator := range snap.copy().schedule() {
+		if schedule[i] != validator {
+			t.Fatalf("schedule not deterministic")
+		}
+	}
+	reshuffled := false
+	for i := int64(2); i < 10 && !reshuffled; i++ {
+		other := newSnapshot(config, config.Dpos, nil, 0, common.BigToHash(big.NewInt(i)), validators, nil).schedule()
+		for j := range other {
+			if other[j] != schedule[j] {
+				reshuffled = true
+			}
+		}
+	}
+	if !reshuffled {
+		t.Errorf("schedule not reshuffled by different checkpoints")
+	}
+	// A validator with most of the stake comes first most of the time
+	weights := map[common.Address]uint64{validators[6]: 1000}
+	for _, validator := range validators[:6] {
+		weights[validator] = 1
+	}
+	first := 0
+	for i := int64(0); i < 100; i++ {
+		snap := newSnapshot(config, config.Dpos, nil, 0, common.BigToHash(big.NewInt(i)), validators, weights)
+		if snap.schedule()[0] == validators[6] {
+			first++
+		}
+	}
+	if first < 90 {
+		t.Errorf("heavy validator scheduled first %d times out of 100", first)
+	}
+}
+
+func TestStakeWeightedSlots(t *testing.T) {
+	config := stakeScheduleConfig(0)
+	validators := testValidators(4)
+
+	// Equal stakes give everyone a single slot in the schedule order
+	snap := newSnapshot(config, config.Dpos, nil, 0, common.HexToHash("0x01"), validators, nil)
+	schedule, slots := snap.schedule(), snap.slots()
+	if len(slots) != len(schedule) {
+		t.Fatalf("round length mismatch: have %d, want %d", len(slots), len(schedule))
+	}
+	for i := range slots {
+		if slots[i] != schedule[i] {
+			t.Errorf("slot %d mismatch: have %x, want %x", i, slots[i], schedule[i])
+		}
+	}
+	// Stakes are rewarded with proportional slots, spread over the round
+	weights := map[common.Address]uint64{validators[0]: 5, validators[1]: 1, validators[2]: 1, validators[3]: 1}
+	snap = newSnapshot(config, config.Dpos, nil, 0, common.HexToHash("0x01"), validators, weights)
+	slots = snap.slots()
+
+	counts := make(map[common.Address]int)
+	for i, validator := range slots {
+		counts[validator]++
+		if i > 0 && validator == slots[i-1] && validator != validators[0] {
+			t.Errorf("slot %d: light validator %x scheduled twice in a row", i, validator)
+		}
+	}
+	if len(slots) != 5 || counts[validators[0]] != 2 {
+		t.Errorf("slots mismatch: have %d slots, %d for the heavy validator, want 5 and 2", len(slots), counts[validators[0]])
+	}
+	for _, validator := range validators[1:] {
+		if counts[validator] != 1 {
+			t.Errorf("validator %x slots mismatch: have %d, want 1", validator, counts[validator])
+		}
+	}
+	for number := uint64(0); number < 20; number++ {
+		if have, want := snap.proposerSelector(number).Proposer(snap, number), slots[number%uint64(len(slots))]; have != want {
+			t.Errorf("block %d: proposer mismatch: have %x, want %x", number, have, want)
+		}
+	}
+}
+
+func TestProposerSelectorFork(t *testing.T) {
+	config := stakeScheduleConfig(20)
+	validators := testValidators(3)
+
+	tests := []struct {
+		number   uint64
+		weighted bool
+	}{
+		{0, false}, {19, false}, {20, true}, {25, true},
+	}
+	for _, tt := range tests {
+		snap := newSnapshot(config, config.Dpos, nil, tt.number, common.Hash{}, validators, nil)
+		_, weighted := snap.proposerSelector(tt.number + 1).(stakeWeightedSelector)
+		if weighted != tt.weighted {
+			t.Errorf("snapshot %d: weighted schedule mismatch: have %v, want %v", tt.number, weighted, tt.weighted)
+		}
+	}
+}
+
+// sealCheckpoint creates a checkpoint header carrying the given validator entries.
+func sealCheckpoint(t *testing.T, key *ecdsa.PrivateKey, number uint64, entries []byte) *types.Header {
+	header := &types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		Coinbase:   crypto.PubkeyToAddress(key.PublicKey),
+		Difficulty: diffInTurn,
+		Extra:      make([]byte, extraVanity+len(entries)+extraSeal),
+	}
+	copy(header.Extra[extraVanity:], entries)
+	sig, err := crypto.Sign(SealHash(header).Bytes(), key)
+	if err != nil {
+		t.Fatalf("failed to seal header: %v", err)
+	}
+	copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+	return header
+}
+
+func TestCheckpointWeights(t *testing.T) {
+	config := stakeScheduleConfig(20)
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	validators := []common.Address{signer, common.HexToAddress("0x02")}
+
+	// Before the fork, checkpoints only carry addresses
+	var legacy []byte
+	for _, validator := range validators {
+		legacy = append(legacy, validator.Bytes()...)
+	}
+	parsed, weights := parseCheckpointValidators(config, sealCheckpoint(t, key, 10, legacy))
+	if len(parsed) != 2 || parsed[0] != validators[0] || parsed[1] != validators[1] || len(weights) != 0 {
+		t.Fatalf("legacy checkpoint mismatch: %v %v", parsed, weights)
+	}
+	// Without a chain config, checkpoints only carry addresses too
+	if parsed, weights = parseCheckpointValidators(nil, sealCheckpoint(t, key, 20, legacy)); len(parsed) != 2 || len(weights) != 0 {
+		t.Fatalf("config-less checkpoint mismatch: %v %v", parsed, weights)
+	}
+	// After the fork, each address is followed by its weight
+	var entries []byte
+	for i, validator := range validators {
+		weight := make([]byte, validatorWeightLength)
+		binary.BigEndian.PutUint64(weight, uint64(i+5))
+		entries = append(append(entries, validator.Bytes()...), weight...)
+	}
+	checkpoint := sealCheckpoint(t, key, 20, entries)
+
+	engine := New(config, rawdb.NewMemoryDatabase())
+	snap := newSnapshot(config, engine.config, engine.signatures, 19, common.Hash{}, validators, nil)
+	snap, err := snap.apply([]*types.Header{checkpoint}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to apply checkpoint: %v", err)
+	}
+	if snap.Checkpoint != checkpoint.Hash() {
+		t.Errorf("checkpoint mismatch: have %x, want %x", snap.Checkpoint, checkpoint.Hash())
+	}
+	if snap.Weights[validators[0]] != 5 || snap.Weights[validators[1]] != 6 {
+		t.Errorf("weights mismatch: %v", snap.Weights)
+	}
+	if _, weighted := snap.proposerSelector(snap.Number + 1).(stakeWeightedSelector); !weighted {
+		t.Errorf("stake-weighted schedule not active after the checkpoint")
+	}
+}
+
+func TestStakeWeightedRecents(t *testing.T) {
+	config := stakeScheduleConfig(0)
+	config.Dpos.Epoch = 100
+	engine := New(config, rawdb.NewMemoryDatabase())
+
+	keys := make(map[common.Address]*ecdsa.PrivateKey)
+	validators := make([]common.Address, 4)
+	for i := range validators {
+		key, _ := crypto.GenerateKey()
+		validators[i] = crypto.PubkeyToAddress(key.PublicKey)
+		keys[validators[i]] = key
+	}
+	// The heavy validator gets three slots of a round for each one of the others
+	heavy := validators[0]
+	weights := map[common.Address]uint64{heavy: 9, validators[1]: 1, validators[2]: 1, validators[3]: 1}
+	snap := newSnapshot(config, engine.config, engine.signatures, 0, common.HexToHash("0x01"), validators, weights)
+
+	slots := snap.slots()
+	counts := make(map[common.Address]int)
+	for _, validator := range slots {
+		counts[validator]++
+	}
+	if len(slots) != 6 || counts[heavy] != 3 {
+		t.Fatalf("slots mismatch: have %d slots, %d for the heavy validator, want 6 and 3", len(slots), counts[heavy])
+	}
+	if limit := snap.recentsLimit(heavy, 1); limit >= uint64(len(validators)/2+1) {
+		t.Errorf("heavy validator recents limit not lowered: %d", limit)
+	}
+	for _, validator := range validators[1:] {
+		if limit := snap.recentsLimit(validator, 1); limit != uint64(len(validators)/2+1) {
+			t.Errorf("validator %x recents limit mismatch: have %d, want %d", validator, limit, len(validators)/2+1)
+		}
+	}
+	// Every slot of two full rounds is sealed in turn, back to back ones included,
+	// while light validators are still held back by the recents limit
+	consecutive := false
+	for number := uint64(1); number <= uint64(2*len(slots)); number++ {
+		proposer := snap.proposerSelector(number).Proposer(snap, number)
+		if recent, ok := snap.Recents[number-1]; ok && recent == proposer {
+			consecutive = true
+		}
+		next, err := snap.apply([]*types.Header{sealCheckpoint(t, keys[proposer], number, nil)}, nil, nil)
+		if err != nil {
+			t.Fatalf("block %d: in-turn validator %x rejected: %v", number, proposer, err)
+		}
+		if proposer != heavy {
+			if _, err := next.apply([]*types.Header{sealCheckpoint(t, keys[proposer], number+1, nil)}, nil, nil); err != errRecentlySigned {
+				t.Errorf("block %d: recently signing validator error mismatch: have %v, want %v", number+1, err, errRecentlySigned)
+			}
+		}
+		snap = next
+	}
+	if !consecutive {
+		t.Errorf("no validator sealed consecutive slots")
+	}
+}
@@ -19,25 +19,38 @@ package dpos
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"sort"
 
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/ethdb"
+	"github.com/hypnosisfoundation/go-hypnosis/log"
 	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"github.com/hypnosisfoundation/go-hypnosis/rlp"
 	lru "github.com/hashicorp/golang-lru"
 )
 
+// errUnknownSnapshot is returned if no snapshot is stored for the requested block.
+var errUnknownSnapshot = errors.New("unknown snapshot")
+
 // Snapshot is the state of the authorization voting at a given point in time.
 type Snapshot struct {
-	config   *params.DposConfig // Consensus engine parameters to fine tune behavior
-	sigcache *lru.ARCCache      // Cache of recent block signatures to speed up ecrecover
+	chainConfig *params.ChainConfig // Chain configuration to determine the proposer schedule
+	config      *params.DposConfig  // Consensus engine parameters to fine tune behavior
+	sigcache    *lru.ARCCache       // Cache of recent block signatures to speed up ecrecover
 
-	Number     uint64                      `json:"number"`     // Block number where the snapshot was created
-	Hash       common.Hash                 `json:"hash"`       // Block hash where the snapshot was created
-	Validators map[common.Address]struct{} `json:"validators"` // Set of authorized validators at this moment
-	Recents    map[uint64]common.Address   `json:"recents"`    // Set of recent validators for spam protections
+	Number     uint64                      `json:"number"`            // Block number where the snapshot was created
+	Hash       common.Hash                 `json:"hash"`              // Block hash where the snapshot was created
+	Checkpoint common.Hash                 `json:"checkpoint"`        // Hash of the checkpoint block which elected the validators
+	Validators map[common.Address]struct{} `json:"validators"`        // Set of authorized validators at this moment
+	Weights    map[common.Address]uint64   `json:"weights,omitempty"` // Stake weights of the validators after the StakeSchedule fork
+	Recents    map[uint64]common.Address   `json:"recents"`           // Set of recent validators for spam protections
+	Missed     map[common.Address][]uint64 `json:"missed,omitempty"`  // Missed in-turn blocks of the validators within the jail window
+	Jailed     map[common.Address]uint64   `json:"jailed,omitempty"`  // Validators jailed for downtime and the block they were jailed at
 }
 
 // validatorsAscending implements the sort interface to allow sorting a list of addresses
@@ -50,31 +63,55 @@ func (s validatorsAscending) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 // newSnapshot creates a new snapshot with the specified startup parameters. This
 // method does not initialize the set of recent validators, so only ever use if for
 // the genesis block.
-func newSnapshot(config *params.DposConfig, sigcache *lru.ARCCache, number uint64, hash common.Hash, validators []common.Address) *Snapshot {
+func newSnapshot(chainConfig *params.ChainConfig, config *params.DposConfig, sigcache *lru.ARCCache, number uint64, hash common.Hash, validators []common.Address, weights map[common.Address]uint64) *Snapshot {
 	snap := &Snapshot{
-		config:     config,
-		sigcache:   sigcache,
-		Number:     number,
-		Hash:       hash,
-		Validators: make(map[common.Address]struct{}),
-		Recents:    make(map[uint64]common.Address),
+		chainConfig: chainConfig,
+		config:      config,
+		sigcache:    sigcache,
+		Number:      number,
+		Hash:        hash,
+		Checkpoint:  hash,
+		Validators:  make(map[common.Address]struct{}),
+		Weights:     make(map[common.Address]uint64),
+		Recents:     make(map[uint64]common.Address),
+		Missed:      make(map[common.Address][]uint64),
+		Jailed:      make(map[common.Address]uint64),
 	}
 	for _, validator := range validators {
 		snap.Validators[validator] = struct{}{}
 	}
+	for validator, weight := range weights {
+		snap.Weights[validator] = weight
+	}
 	return snap
 }
 
-// loadSnapshot loads an existing snapshot from the database.
-func loadSnapshot(config *params.DposConfig, sigcache *lru.ARCCache, db ethdb.Database, hash common.Hash) (*Snapshot, error) {
-	blob, err := db.Get(append([]byte("dpos-"), hash[:]...))
-	if err != nil {
-		return nil, err
-	}
+// loadSnapshot loads an existing snapshot from the database. A snapshot stored by
+// the legacy schema is migrated to the current one.
+func loadSnapshot(chainConfig *params.ChainConfig, config *params.DposConfig, sigcache *lru.ARCCache, db ethdb.Database, number uint64, hash common.Hash) (*Snapshot, error) {
 	snap := new(Snapshot)
-	if err := json.Unmarshal(blob, snap); err != nil {
-		return nil, err
+	if blob := rawdb.ReadDposSnapshot(db, number, hash); len(blob) > 0 {
+		if err := rlp.DecodeBytes(blob, snap); err != nil {
+			return nil, err
+		}
+	} else if blob := rawdb.ReadLegacyDposSnapshot(db, hash); len(blob) > 0 {
+		if err := json.Unmarshal(blob, snap); err != nil {
+			return nil, err
+		}
+		if snap.Missed == nil {
+			snap.Missed = make(map[common.Address][]uint64)
+		}
+		if snap.Jailed == nil {
+			snap.Jailed = make(map[common.Address]uint64)
+		}
+		if err := snap.store(db); err != nil {
+			return nil, err
+		}
+		rawdb.DeleteLegacyDposSnapshot(db, hash)
+	} else {
+		return nil, errUnknownSnapshot
 	}
+	snap.chainConfig = chainConfig
 	snap.config = config
 	snap.sigcache = sigcache
 
@@ -83,29 +120,208 @@ func loadSnapshot(config *params.DposConfig, sigcache *lru.ARCCache, db ethdb.Da
 
 // store inserts the snapshot into the database.
 func (s *Snapshot) store(db ethdb.Database) error {
-	blob, err := json.Marshal(s)
+	blob, err := rlp.EncodeToBytes(s)
 	if err != nil {
 		return err
 	}
-	return db.Put(append([]byte("dpos-"), s.Hash[:]...), blob)
+	rawdb.WriteDposSnapshot(db, s.Number, s.Hash, blob)
+	return nil
 }
 
-// copy creates a deep copy of the snapshot, though not the individual votes.
-func (s *Snapshot) copy() *Snapshot {
-	cpy := &Snapshot{
-		config:     s.config,
-		sigcache:   s.sigcache,
+// storedSnapshot is the RLP encoding of a snapshot, with its maps flattened into
+// lists sorted by key.
+type storedSnapshot struct {
+	Number     uint64
+	Hash       common.Hash
+	Checkpoint common.Hash
+	Validators []common.Address
+	Weights    []storedWeight
+	Recents    []storedRecent
+	Missed     []storedMissed
+	Jailed     []storedJailed
+}
+
+type storedWeight struct {
+	Validator common.Address
+	Weight    uint64
+}
+
+type storedRecent struct {
+	Number    uint64
+	Validator common.Address
+}
+
+type storedMissed struct {
+	Validator common.Address
+	Numbers   []uint64
+}
+
+type storedJailed struct {
+	Validator common.Address
+	Number    uint64
+}
+
+// EncodeRLP implements rlp.Encoder, encoding the snapshot deterministically.
+func (s *Snapshot) EncodeRLP(w io.Writer) error {
+	enc := storedSnapshot{
 		Number:     s.Number,
 		Hash:       s.Hash,
+		Checkpoint: s.Checkpoint,
+		Validators: s.validators(),
+	}
+	weighted := make([]common.Address, 0, len(s.Weights))
+	for validator := range s.Weights {
+		weighted = append(weighted, validator)
+	}
+	sort.Sort(validatorsAscending(weighted))
+	for _, validator := range weighted {
+		enc.Weights = append(enc.Weights, storedWeight{validator, s.Weights[validator]})
+	}
+	numbers := make([]uint64, 0, len(s.Recents))
+	for number := range s.Recents {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	for _, number := range numbers {
+		enc.Recents = append(enc.Recents, storedRecent{number, s.Recents[number]})
+	}
+	missed := make([]common.Address, 0, len(s.Missed))
+	for validator := range s.Missed {
+		missed = append(missed, validator)
+	}
+	sort.Sort(validatorsAscending(missed))
+	for _, validator := range missed {
+		enc.Missed = append(enc.Missed, storedMissed{validator, s.Missed[validator]})
+	}
+	jailed := make([]common.Address, 0, len(s.Jailed))
+	for validator := range s.Jailed {
+		jailed = append(jailed, validator)
+	}
+	sort.Sort(validatorsAscending(jailed))
+	for _, validator := range jailed {
+		enc.Jailed = append(enc.Jailed, storedJailed{validator, s.Jailed[validator]})
+	}
+	return rlp.Encode(w, &enc)
+}
+
+// DecodeRLP implements rlp.Decoder, leaving the engine parameters of the snapshot
+// to be set by the caller.
+func (s *Snapshot) DecodeRLP(stream *rlp.Stream) error {
+	var dec storedSnapshot
+	if err := stream.Decode(&dec); err != nil {
+		return err
+	}
+	*s = Snapshot{
+		Number:     dec.Number,
+		Hash:       dec.Hash,
+		Checkpoint: dec.Checkpoint,
 		Validators: make(map[common.Address]struct{}),
+		Weights:    make(map[common.Address]uint64),
 		Recents:    make(map[uint64]common.Address),
+		Missed:     make(map[common.Address][]uint64),
+		Jailed:     make(map[common.Address]uint64),
+	}
+	for _, validator := range dec.Validators {
+		s.Validators[validator] = struct{}{}
+	}
+	for _, entry := range dec.Weights {
+		s.Weights[entry.Validator] = entry.Weight
+	}
+	for _, entry := range dec.Recents {
+		s.Recents[entry.Number] = entry.Validator
+	}
+	for _, entry := range dec.Missed {
+		s.Missed[entry.Validator] = entry.Numbers
+	}
+	for _, entry := range dec.Jailed {
+		s.Jailed[entry.Validator] = entry.Number
+	}
+	return nil
+}
+
+// PruneSnapshots deletes the snapshots stored for the blocks moved into the freezer,
+// which can't be reorged anymore, and returns the number of deleted snapshots. The
+// newest canonical snapshot below the freezer is kept to regenerate the following
+// ones from, and the legacy snapshots are pruned the same way.
+func PruneSnapshots(db ethdb.Database) int {
+	frozen, err := db.Ancients()
+	if err != nil || frozen == 0 {
+		return 0
+	}
+	batch := db.NewBatch()
+	refs := rawdb.ReadDposSnapshotRefs(db, frozen)
+	kept := -1
+	for i := len(refs) - 1; i >= 0 && kept < 0; i-- {
+		if rawdb.ReadCanonicalHash(db, refs[i].Number) == refs[i].Hash {
+			kept = i
+		}
+	}
+	var pruned int
+	for i, ref := range refs {
+		if i != kept {
+			rawdb.DeleteDposSnapshot(batch, ref.Number, ref.Hash)
+			pruned++
+		}
+	}
+	var (
+		legacy     []common.Hash
+		keptLegacy common.Hash
+		keptNumber uint64
+	)
+	for _, hash := range rawdb.ReadLegacyDposSnapshotHashes(db) {
+		// Snapshots of unknown blocks are dead weight too
+		number := rawdb.ReadHeaderNumber(db, hash)
+		if number != nil && *number >= frozen {
+			continue
+		}
+		legacy = append(legacy, hash)
+		if kept < 0 && number != nil && *number >= keptNumber && rawdb.ReadCanonicalHash(db, *number) == hash {
+			keptLegacy, keptNumber = hash, *number
+		}
+	}
+	for _, hash := range legacy {
+		if hash != keptLegacy {
+			rawdb.DeleteLegacyDposSnapshot(batch, hash)
+			pruned++
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Error("Failed to prune dpos snapshots", "err", err)
+		return 0
+	}
+	return pruned
+}
+
+// copy creates a deep copy of the snapshot, though not the individual votes.
+func (s *Snapshot) copy() *Snapshot {
+	cpy := &Snapshot{
+		chainConfig: s.chainConfig,
+		config:      s.config,
+		sigcache:    s.sigcache,
+		Number:      s.Number,
+		Hash:        s.Hash,
+		Checkpoint:  s.Checkpoint,
+		Validators:  make(map[common.Address]struct{}),
+		Weights:     make(map[common.Address]uint64),
+		Recents:     make(map[uint64]common.Address),
+		Missed:      make(map[common.Address][]uint64),
+		Jailed:      make(map[common.Address]uint64),
 	}
 	for validator := range s.Validators {
 		cpy.Validators[validator] = struct{}{}
 	}
+	for validator, weight := range s.Weights {
+		cpy.Weights[validator] = weight
+	}
 	for block, validator := range s.Recents {
 		cpy.Recents[block] = validator
 	}
+	for validator, missed := range s.Missed {
+		cpy.Missed[validator] = append([]uint64(nil), missed...)
+	}
+	for validator, number := range s.Jailed {
+		cpy.Jailed[validator] = number
+	}
 
 	return cpy
 }
@@ -132,6 +348,11 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 	for _, header := range headers {
 		// Remove any votes on checkpoint blocks
 		number := header.Number.Uint64()
+
+		// Track the liveness of the in-turn validator
+		if s.chainConfig != nil && s.chainConfig.IsJail(header.Number) {
+			snap.trackLiveness(header)
+		}
 		// Delete the oldest validator from the recent list to allow it signing again
 		if limit := uint64(len(snap.Validators)/2 + 1); number >= limit {
 			delete(snap.Recents, number-limit)
@@ -144,8 +365,8 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 		if _, ok := snap.Validators[validator]; !ok {
 			return nil, errUnauthorizedValidator
 		}
-		for _, recent := range snap.Recents {
-			if recent == validator {
+		for seen, recent := range snap.Recents {
+			if recent == validator && seen+snap.recentsLimit(validator, number) > number {
 				return nil, errRecentlySigned
 			}
 		}
@@ -156,10 +377,7 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 			checkpointHeader := header
 
 			// get validators from headers and use that for new validator set
-			validators := make([]common.Address, (len(checkpointHeader.Extra)-extraVanity-extraSeal)/common.AddressLength)
-			for i := 0; i < len(validators); i++ {
-				copy(validators[i][:], checkpointHeader.Extra[extraVanity+i*common.AddressLength:])
-			}
+			validators, weights := parseCheckpointValidators(s.chainConfig, checkpointHeader)
 
 			newValidators := make(map[common.Address]struct{})
 			for _, validator := range validators {
@@ -174,10 +392,16 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 			}
 
 			snap.Validators = newValidators
+			snap.Weights = weights
+			snap.Checkpoint = checkpointHeader.Hash()
+
+			if s.chainConfig != nil && s.chainConfig.IsJail(header.Number) {
+				snap.releaseJailed()
+			}
 		}
 	}
 
-	snap.Number += uint64(len(headers))
+	snap.Number = headers[len(headers)-1].Number.Uint64()
 	snap.Hash = headers[len(headers)-1].Hash()
 
 	return snap, nil
@@ -195,9 +419,5 @@ func (s *Snapshot) validators() []common.Address {
 
 // inturn returns if a validator at a given block height is in-turn or not.
 func (s *Snapshot) inturn(number uint64, validator common.Address) bool {
-	validators, offset := s.validators(), 0
-	for offset < len(validators) && validators[offset] != validator {
-		offset++
-	}
-	return (number % uint64(len(validators))) == uint64(offset)
+	return s.proposerSelector(number).Proposer(s, number) == validator
 }
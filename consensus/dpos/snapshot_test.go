@@ -0,0 +1,283 @@
+package dpos
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/ethdb"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"github.com/hypnosisfoundation/go-hypnosis/rlp"
+)
+
+// testSnapshot creates a snapshot with all of its records populated.
+func testSnapshot(number uint64) *Snapshot {
+	validators := testValidators(3)
+	snap := newSnapshot(nil, &params.DposConfig{Epoch: 200}, nil, number, common.BigToHash(new(big.Int).SetUint64(number)), validators, map[common.Address]uint64{validators[0]: 5, validators[2]: 1})
+	snap.Recents[number] = validators[1]
+	snap.Recents[number-1] = validators[0]
+	snap.Missed[validators[2]] = []uint64{number - 10, number - 4}
+	snap.Jailed[validators[2]] = number - 4
+	return snap
+}
+
+func TestSnapshotEncoding(t *testing.T) {
+	snap := testSnapshot(100)
+	blob, err := rlp.EncodeToBytes(snap)
+	if err != nil {
+		t.Fatalf("failed to encode snapshot: %v", err)
+	}
+	// Map iteration order must not leak into the encoding
+	for i := 0; i < 10; i++ {
+		if again, _ := rlp.EncodeToBytes(snap.copy()); !bytes.Equal(again, blob) {
+			t.Fatalf("snapshot encoding is not deterministic")
+		}
+	}
+	dec := new(Snapshot)
+	if err := rlp.DecodeBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+	dec.config = snap.config
+	if !reflect.DeepEqual(dec, snap) {
+		t.Errorf("snapshot mismatch: have %+v, want %+v", dec, snap)
+	}
+}
+
+func TestLegacySnapshotMigration(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	snap := testSnapshot(1024)
+
+	legacy, _ := json.Marshal(snap)
+	db.Put(append([]byte("dpos-"), snap.Hash[:]...), legacy)
+
+	loaded, err := loadSnapshot(nil, snap.config, nil, db, snap.Number, snap.Hash)
+	if err != nil {
+		t.Fatalf("failed to load legacy snapshot: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, snap) {
+		t.Errorf("legacy snapshot mismatch: have %+v, want %+v", loaded, snap)
+	}
+	if len(rawdb.ReadLegacyDposSnapshot(db, snap.Hash)) != 0 {
+		t.Errorf("legacy snapshot not deleted")
+	}
+	if len(rawdb.ReadDposSnapshot(db, snap.Number, snap.Hash)) == 0 {
+		t.Errorf("legacy snapshot not migrated")
+	}
+	if _, err := loadSnapshot(nil, snap.config, nil, db, snap.Number, snap.Hash); err != nil {
+		t.Errorf("failed to load migrated snapshot: %v", err)
+	}
+}
+
+// frozenDB is a database whose first blocks were moved into the freezer.
+type frozenDB struct {
+	ethdb.Database
+	frozen uint64
+}
+
+func (db *frozenDB) Ancients() (uint64, error) { return db.frozen, nil }
+
+func TestPruneSnapshots(t *testing.T) {
+	db := &frozenDB{Database: rawdb.NewMemoryDatabase(), frozen: 3000}
+	for _, number := range []uint64{1024, 2048, 3072} {
+		snap := testSnapshot(number)
+		if err := snap.store(db); err != nil {
+			t.Fatalf("failed to store snapshot: %v", err)
+		}
+		rawdb.WriteCanonicalHash(db, snap.Hash, number)
+	}
+	// Nothing is pruned while the newest frozen snapshot is the only one
+	db.frozen = 2000
+	if pruned := PruneSnapshots(db); pruned != 0 {
+		t.Errorf("pruned snapshot count mismatch: have %d, want 0", pruned)
+	}
+	db.frozen = 3000
+
+	// Legacy snapshots of unknown and frozen blocks are pruned too
+	var (
+		frozen  = common.HexToHash("0x01")
+		recent  = common.HexToHash("0x02")
+		unknown = common.HexToHash("0x03")
+	)
+	rawdb.WriteHeaderNumber(db, frozen, 10)
+	rawdb.WriteHeaderNumber(db, recent, 4000)
+	for _, hash := range []common.Hash{frozen, recent, unknown} {
+		db.Put(append([]byte("dpos-"), hash[:]...), []byte("{}"))
+	}
+	if pruned := PruneSnapshots(db); pruned != 3 {
+		t.Errorf("pruned snapshot count mismatch: have %d, want 3", pruned)
+	}
+	// The newest canonical frozen snapshot is kept
+	refs := rawdb.ReadDposSnapshotRefs(db, 1<<63)
+	if len(refs) != 2 || refs[0].Number != 2048 || refs[1].Number != 3072 {
+		t.Errorf("remaining snapshots mismatch: %v", refs)
+	}
+	if hashes := rawdb.ReadLegacyDposSnapshotHashes(db); len(hashes) != 1 || hashes[0] != recent {
+		t.Errorf("remaining legacy snapshots mismatch: %v", hashes)
+	}
+}
+
+// newSealedTestChain creates a chain of n blocks sealed round-robin by three
+// validators listed in the checkpoints of the given epoch, and an engine without
+// any snapshot.
+func newSealedTestChain(t *testing.T, n int, epoch uint64) (*Dpos, *testFinalityChain) {
+	keys := make(map[common.Address]*ecdsa.PrivateKey)
+	for len(keys) < 3 {
+		key, _ := crypto.GenerateKey()
+		keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	var validators []common.Address
+	for validator := range keys {
+		validators = append(validators, validator)
+	}
+	config := *params.MainnetChainConfig
+	config.Dpos = &params.DposConfig{Epoch: epoch}
+	engine := New(&config, rawdb.NewMemoryDatabase())
+
+	var checkpoint []byte
+	validators = newSnapshot(nil, engine.config, nil, 0, common.Hash{}, validators, nil).validators()
+	for _, validator := range validators {
+		checkpoint = append(checkpoint, validator.Bytes()...)
+	}
+	chain := &testFinalityChain{}
+	parent := common.Hash{}
+	for i := 0; i <= n; i++ {
+		number := uint64(i)
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     new(big.Int).SetUint64(number),
+			Coinbase:   validators[number%3],
+			Difficulty: diffInTurn,
+			Extra:      make([]byte, extraVanity+extraSeal),
+		}
+		if number%epoch == 0 {
+			header.Extra = append(append(make([]byte, extraVanity), checkpoint...), make([]byte, extraSeal)...)
+		}
+		sig, err := crypto.Sign(SealHash(header).Bytes(), keys[header.Coinbase])
+		if err != nil {
+			t.Fatalf("failed to seal header: %v", err)
+		}
+		copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+
+		chain.headers = append(chain.headers, header)
+		parent = header.Hash()
+
+		rawdb.WriteHeader(engine.db, header)
+		rawdb.WriteCanonicalHash(engine.db, header.Hash(), number)
+	}
+	return engine, chain
+}
+
+func TestReplaySnapshots(t *testing.T) {
+	engine, chain := newSealedTestChain(t, checkpointInterval, 256)
+
+	// Generate the snapshots the usual way on a clean engine
+	clean := New(engine.chainConfig, engine.db)
+	head := chain.headers[checkpointInterval]
+	if _, err := clean.snapshot(chain, head.Number.Uint64(), head.Hash(), nil); err != nil {
+		t.Fatalf("failed to generate snapshot: %v", err)
+	}
+	var replayed []uint64
+	err := engine.ReplaySnapshots(chain, checkpointInterval, func(snap *Snapshot) error {
+		blob, err := rlp.EncodeToBytes(snap)
+		if err != nil {
+			return err
+		}
+		if stored := rawdb.ReadDposSnapshot(engine.db, snap.Number, snap.Hash); !bytes.Equal(stored, blob) {
+			t.Errorf("snapshot %d differs from the stored one", snap.Number)
+		}
+		replayed = append(replayed, snap.Number)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to replay snapshots: %v", err)
+	}
+	if !reflect.DeepEqual(replayed, []uint64{0, checkpointInterval}) {
+		t.Errorf("replayed snapshots mismatch: have %v", replayed)
+	}
+}
+
+func TestSnapshotApply(t *testing.T) {
+	for i, tt := range []struct {
+		keys       int
+		genesis    int
+		gen        func(validators []common.Address, n int, b *BlockGen)
+		skip       int // Header not applied, breaking the chain
+		validators int // Number of validators after the blocks, in round-robin order
+		err        error
+	}{
+		// In and out of turn blocks across an epoch keep the validators
+		{keys: 3, genesis: 3, validators: 3},
+		{keys: 3, genesis: 3, validators: 3, gen: func(validators []common.Address, n int, b *BlockGen) {
+			if b.Number()%3 == 2 {
+				b.SetSealer(validators[0])
+			}
+		}},
+		// Checkpoints shrink and grow the validators
+		{keys: 4, genesis: 4, validators: 2, gen: func(validators []common.Address, n int, b *BlockGen) {
+			if b.Number() == 4 {
+				b.SetValidators(validators[:2])
+			}
+		}},
+		{keys: 5, genesis: 2, validators: 5, gen: func(validators []common.Address, n int, b *BlockGen) {
+			if b.Number() == 4 {
+				b.SetValidators(validators)
+			}
+		}},
+		// Blocks sealed by validators not allowed to, the last ones as the maker
+		// can't build on them
+		{keys: 3, genesis: 2, err: errUnauthorizedValidator, gen: func(validators []common.Address, n int, b *BlockGen) {
+			if b.Number() == 6 {
+				b.SetSealer(validators[2])
+			}
+		}},
+		{keys: 3, genesis: 3, err: errRecentlySigned, gen: func(validators []common.Address, n int, b *BlockGen) {
+			if b.Number() == 6 {
+				b.SetSealer(validators[2])
+			}
+		}},
+		{keys: 3, genesis: 3, skip: 3, err: errInvalidVotingChain},
+	} {
+		maker, validators := newChainMaker(t, tt.keys, tt.genesis, 4, nil)
+		headers := maker.generate(maker.genesis(), 6, func(n int, b *BlockGen) {
+			if tt.gen != nil {
+				tt.gen(validators, n, b)
+			}
+		})
+		if tt.skip > 0 {
+			headers = append(headers[:tt.skip-1:tt.skip-1], headers[tt.skip:]...)
+		}
+		engine := New(maker.chain.Config(), rawdb.NewMemoryDatabase())
+		genesis := maker.genesis()
+		snap := newSnapshot(maker.chain.Config(), engine.config, engine.signatures, 0, genesis.Hash(), validators[:tt.genesis], nil)
+
+		snap, err := snap.apply(headers, maker.chain, nil)
+		if err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if have := snap.validators(); !reflect.DeepEqual(have, validators[:tt.validators]) {
+			t.Errorf("test %d: validators mismatch: have %v, want %v", i, have, validators[:tt.validators])
+		}
+		if snap.Checkpoint != headers[3].Hash() {
+			t.Errorf("test %d: checkpoint mismatch: have %x, want %x", i, snap.Checkpoint, headers[3].Hash())
+		}
+		// The last signers are the recent ones, as many as the signing limit
+		recents := make(map[uint64]common.Address)
+		for _, header := range headers[len(headers)-tt.validators/2-1:] {
+			recents[header.Number.Uint64()] = header.Coinbase
+		}
+		if !reflect.DeepEqual(snap.Recents, recents) {
+			t.Errorf("test %d: recents mismatch: have %v, want %v", i, snap.Recents, recents)
+		}
+	}
+}
@@ -8,15 +8,13 @@ import (
 	"strings"
 )
 
-// ValidatorsABI contains all methods to interactive with validator contracts.
-const ValidatorsABI = `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"_val","type":"address"},{"indexed":false,"internalType":"uint256","name":"_deposit","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"_rate","type":"uint256"}],"name":"LogAddValidator","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"_val","type":"address"}],"name":"LogRedeemValidator","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"_val","type":"address"}],"name":"LogRestoreValidator","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"_val","type":"address"}],"name":"LogUnstakeValidator","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"_val","type":"address"},{"indexed":false,"internalType":"uint256","name":"_deposit","type":"uint256"}],"name":"LogUpdateValidatorDeposit","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"_val","type":"address"},{"indexed":false,"internalType":"uint8","name":"_preRate","type":"uint8"},{"indexed":false,"internalType":"uint8","name":"_rate","type":"uint8"}],"name":"LogUpdateValidatorRate","type":"event"},{"inputs":[],"name":"BLACK_HOLE_ADDRESS","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"BLOCK_SECONDS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"CancelQueueValidatorsLength","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"EPOCH_BLOCKS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_PUNISH_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_RATE","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATORS_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_DETAIL_LENGTH","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_NAME_LENGTH","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MEDIUM_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_DEPOSIT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_RATE","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"PROPOSAL_DURATION_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"RATE_SET_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV1_TO_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV2_TO_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV3_TO_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV4_TO_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_OVER_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_UNDER_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"SAFE_MULTIPLIER","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VALIDATOR_REWARD_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VALIDATOR_UNSTAKE_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VOTE_CANCEL_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"uint256","name":"_deposit","type":"uint256"},{"internalType":"uint8","name":"_rate","type":"uint8"},{"internalType":"string","name":"_name","type":"string"},{"internalType":"string","name":"_details","type":"string"}],"name":"addValidatorFromProposal","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[{"internalType":"address[]","name":"_vals","type":"address[]"}],"name":"batchValidators","outputs":[{"components":[{"internalType":"enum Validators.ValidatorStatus","name":"status","type":"uint8"},{"internalType":"uint256","name":"deposit","type":"uint256"},{"internalType":"uint8","name":"rate","type":"uint8"},{"internalType":"string","name":"name","type":"string"},{"internalType":"string","name":"details","type":"string"},{"internalType":"uint256","name":"votes","type":"uint256"},{"internalType":"uint256","name":"unstakeLockingEndBlock","type":"uint256"},{"internalType":"uint256","name":"rateSettLockingEndBlock","type":"uint256"}],"internalType":"struct Validators.Validator[]","name":"","type":"tuple[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_voter","type":"address"},{"internalType":"address","name":"_val","type":"address"},{"internalType":"uint256","name":"_votes","type":"uint256"},{"internalType":"bool","name":"_clear","type":"bool"}],"name":"cancelVoteValidator","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"uint256","name":"","type":"uint256"}],"name":"curEpochValidators","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"","type":"address"}],"name":"curEpochValidatorsIdMap","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"currentEpoch","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"effictiveValsLength","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getCancelQueueValidators","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getCurEpochValidators","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getEffictiveValidators","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"page","type":"uint256"},{"internalType":"uint256","name":"size","type":"uint256"}],"name":"getEffictiveValidatorsWithPage","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getInvalidValidators","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"page","type":"uint256"},{"internalType":"uint256","name":"size","type":"uint256"}],"name":"getInvalidValidatorsWithPage","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"uint256","name":"page","type":"uint256"},{"internalType":"uint256","name":"size","type":"uint256"}],"name":"getValidatorVoters","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_proposal","type":"address"},{"internalType":"address","name":"_sysReward","type":"address"},{"internalType":"address","name":"_nodeVote","type":"address"},{"internalType":"address","name":"_initVal","type":"address"},{"internalType":"uint256","name":"_initDeposit","type":"uint256"},{"internalType":"uint8","name":"_initRate","type":"uint8"},{"internalType":"string","name":"_name","type":"string"},{"internalType":"string","name":"_details","type":"string"}],"name":"initialize","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[],"name":"invalidValsLength","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"addr","type":"address"}],"name":"isEffictiveValidator","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"kickoutValidator","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"nodeVote","outputs":[{"internalType":"contract INodeVote","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"proposals","outputs":[{"internalType":"contract IProposals","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"redeem","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"restore","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"sysRewards","outputs":[{"internalType":"contract ISystemRewards","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"totalDeposit","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"tryElect","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"unstake","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"uint256","name":"_deposit","type":"uint256"}],"name":"updateValidatorDeposit","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[{"internalType":"string","name":"_name","type":"string"},{"internalType":"string","name":"_details","type":"string"}],"name":"updateValidatorNameDetails","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"uint8","name":"_rate","type":"uint8"}],"name":"updateValidatorRate","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"validatorVotersLength","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"validators","outputs":[{"components":[{"internalType":"enum Validators.ValidatorStatus","name":"status","type":"uint8"},{"internalType":"uint256","name":"deposit","type":"uint256"},{"internalType":"uint8","name":"rate","type":"uint8"},{"internalType":"string","name":"name","type":"string"},{"internalType":"string","name":"details","type":"string"},{"internalType":"uint256","name":"votes","type":"uint256"},{"internalType":"uint256","name":"unstakeLockingEndBlock","type":"uint256"},{"internalType":"uint256","name":"rateSettLockingEndBlock","type":"uint256"}],"internalType":"struct Validators.Validator","name":"","type":"tuple"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_voter","type":"address"},{"internalType":"address","name":"_val","type":"address"},{"internalType":"uint256","name":"_votes","type":"uint256"}],"name":"voteValidator","outputs":[],"stateMutability":"payable","type":"function"}]`
-
-// ValidatorProposalsABI `Proposals` contract in systemContracts
-const ValidatorProposalsABI = `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"id","type":"bytes32"},{"indexed":true,"internalType":"address","name":"proposer","type":"address"},{"indexed":false,"internalType":"uint256","name":"block","type":"uint256"}],"name":"LogCancelProposal","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"id","type":"bytes32"},{"indexed":true,"internalType":"address","name":"guarantee","type":"address"},{"indexed":false,"internalType":"uint256","name":"block","type":"uint256"}],"name":"LogGuarantee","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"id","type":"bytes32"},{"indexed":true,"internalType":"address","name":"proposer","type":"address"},{"indexed":false,"internalType":"uint256","name":"block","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"deposit","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"rate","type":"uint256"}],"name":"LogInitProposal","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"id","type":"bytes32"},{"indexed":true,"internalType":"address","name":"proposer","type":"address"},{"indexed":false,"internalType":"uint256","name":"block","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"deposit","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"rate","type":"uint256"}],"name":"LogUpdateProposal","type":"event"},{"inputs":[],"name":"BLACK_HOLE_ADDRESS","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"BLOCK_SECONDS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"EPOCH_BLOCKS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_PUNISH_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_RATE","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATORS_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_DETAIL_LENGTH","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_NAME_LENGTH","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MEDIUM_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_DEPOSIT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_RATE","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"PROPOSAL_DURATION_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"RATE_SET_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV1_TO_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV2_TO_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV3_TO_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV4_TO_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_OVER_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_UNDER_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"SAFE_MULTIPLIER","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VALIDATOR_REWARD_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VALIDATOR_UNSTAKE_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VOTE_CANCEL_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"val","type":"address"}],"name":"addressProposalCount","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"val","type":"address"},{"internalType":"uint256","name":"page","type":"uint256"},{"internalType":"uint256","name":"size","type":"uint256"}],"name":"addressProposalSets","outputs":[{"internalType":"bytes4[]","name":"","type":"bytes4[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"val","type":"address"},{"internalType":"uint256","name":"page","type":"uint256"},{"internalType":"uint256","name":"size","type":"uint256"}],"name":"addressProposals","outputs":[{"components":[{"internalType":"bytes4","name":"id","type":"bytes4"},{"internalType":"address","name":"proposer","type":"address"},{"internalType":"enum Proposals.ProposalType","name":"pType","type":"uint8"},{"internalType":"uint256","name":"deposit","type":"uint256"},{"internalType":"uint8","name":"rate","type":"uint8"},{"internalType":"string","name":"name","type":"string"},{"internalType":"string","name":"details","type":"string"},{"internalType":"uint256","name":"initBlock","type":"uint256"},{"internalType":"address","name":"guarantee","type":"address"},{"internalType":"uint256","name":"updateBlock","type":"uint256"},{"internalType":"enum Proposals.ProposalStatus","name":"status","type":"uint8"}],"internalType":"struct Proposals.ProposalInfo[]","name":"","type":"tuple[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"page","type":"uint256"},{"internalType":"uint256","name":"size","type":"uint256"}],"name":"allProposalSets","outputs":[{"internalType":"bytes4[]","name":"","type":"bytes4[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"page","type":"uint256"},{"internalType":"uint256","name":"size","type":"uint256"}],"name":"allProposals","outputs":[{"components":[{"internalType":"bytes4","name":"id","type":"bytes4"},{"internalType":"address","name":"proposer","type":"address"},{"internalType":"enum Proposals.ProposalType","name":"pType","type":"uint8"},{"internalType":"uint256","name":"deposit","type":"uint256"},{"internalType":"uint8","name":"rate","type":"uint8"},{"internalType":"string","name":"name","type":"string"},{"internalType":"string","name":"details","type":"string"},{"internalType":"uint256","name":"initBlock","type":"uint256"},{"internalType":"address","name":"guarantee","type":"address"},{"internalType":"uint256","name":"updateBlock","type":"uint256"},{"internalType":"enum Proposals.ProposalStatus","name":"status","type":"uint8"}],"internalType":"struct Proposals.ProposalInfo[]","name":"","type":"tuple[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"bytes4","name":"id","type":"bytes4"}],"name":"cancelProposal","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"currentEpoch","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"bytes4","name":"id","type":"bytes4"}],"name":"guarantee","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"enum Proposals.ProposalType","name":"pType","type":"uint8"},{"internalType":"uint8","name":"rate","type":"uint8"},{"internalType":"string","name":"name","type":"string"},{"internalType":"string","name":"details","type":"string"}],"name":"initProposal","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[{"internalType":"address","name":"_validator","type":"address"}],"name":"initialize","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"proposalCount","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"bytes4","name":"","type":"bytes4"}],"name":"proposalInfos","outputs":[{"internalType":"bytes4","name":"id","type":"bytes4"},{"internalType":"address","name":"proposer","type":"address"},{"internalType":"enum Proposals.ProposalType","name":"pType","type":"uint8"},{"internalType":"uint256","name":"deposit","type":"uint256"},{"internalType":"uint8","name":"rate","type":"uint8"},{"internalType":"string","name":"name","type":"string"},{"internalType":"string","name":"details","type":"string"},{"internalType":"uint256","name":"initBlock","type":"uint256"},{"internalType":"address","name":"guarantee","type":"address"},{"internalType":"uint256","name":"updateBlock","type":"uint256"},{"internalType":"enum Proposals.ProposalStatus","name":"status","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"","type":"address"},{"internalType":"uint256","name":"","type":"uint256"}],"name":"proposals","outputs":[{"internalType":"bytes4","name":"","type":"bytes4"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"bytes4","name":"id","type":"bytes4"},{"internalType":"uint8","name":"rate","type":"uint8"},{"internalType":"uint256","name":"deposit","type":"uint256"},{"internalType":"string","name":"name","type":"string"},{"internalType":"string","name":"details","type":"string"}],"name":"updateProposal","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[],"name":"validators","outputs":[{"internalType":"contract IValidators","name":"","type":"address"}],"stateMutability":"view","type":"function"}]`
-
-const NodeVotesABI = `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"voter","type":"address"},{"indexed":true,"internalType":"address","name":"validator","type":"address"},{"indexed":false,"internalType":"uint256","name":"votes","type":"uint256"}],"name":"LogCancelVote","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"voter","type":"address"},{"indexed":true,"internalType":"address","name":"validator","type":"address"},{"indexed":false,"internalType":"uint256","name":"reward","type":"uint256"}],"name":"LogEarn","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"voter","type":"address"},{"indexed":true,"internalType":"address","name":"validator","type":"address"},{"indexed":false,"internalType":"uint256","name":"votes","type":"uint256"}],"name":"LogRedeem","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"from","type":"address"},{"indexed":true,"internalType":"address","name":"to","type":"address"},{"indexed":false,"internalType":"uint256","name":"amount","type":"uint256"}],"name":"LogRewardTransfer","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"voter","type":"address"},{"indexed":true,"internalType":"address","name":"validator","type":"address"},{"indexed":false,"internalType":"uint256","name":"votes","type":"uint256"}],"name":"LogVote","type":"event"},{"inputs":[],"name":"BLACK_HOLE_ADDRESS","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"BLOCK_SECONDS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"EPOCH_BLOCKS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_PUNISH_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_RATE","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATORS_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_DETAIL_LENGTH","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_NAME_LENGTH","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MEDIUM_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_DEPOSIT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_RATE","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"PROPOSAL_DURATION_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"RATE_SET_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV1_TO_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV2_TO_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV3_TO_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV4_TO_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_OVER_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_UNDER_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"SAFE_MULTIPLIER","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VALIDATOR_REWARD_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VALIDATOR_UNSTAKE_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VOTE_CANCEL_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"uint256","name":"_amount","type":"uint256"}],"name":"cancelVote","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"currentEpoch","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"earn","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"_validator","type":"address"},{"internalType":"address","name":"_sysReward","type":"address"}],"name":"initialize","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"address","name":"_voter","type":"address"}],"name":"pendingRedeem","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"address","name":"_voter","type":"address"}],"name":"pendingReward","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"redeem","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"sysRewards","outputs":[{"internalType":"contract ISystemRewards","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"totalVotes","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"validators","outputs":[{"internalType":"contract IValidators","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"vote","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[{"internalType":"address","name":"","type":"address"},{"internalType":"address","name":"","type":"address"}],"name":"voteInfos","outputs":[{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint256","name":"rewardDebt","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_voter","type":"address"}],"name":"voteListLength","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"address","name":"_voter","type":"address"}],"name":"votesRewardRedeemInfo","outputs":[{"components":[{"internalType":"address","name":"validator","type":"address"},{"internalType":"string","name":"validatorName","type":"string"},{"internalType":"uint8","name":"validatorRate","type":"uint8"},{"internalType":"uint256","name":"validatorTotalVotes","type":"uint256"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint256","name":"pendingReward","type":"uint256"},{"internalType":"uint256","name":"pendingRedeem","type":"uint256"},{"internalType":"uint256[]","name":"lockRedeemEpochs","type":"uint256[]"},{"internalType":"uint256[]","name":"lockRedeemVotes","type":"uint256[]"}],"internalType":"struct NodeVotes.VotesRewardRedeemInfo","name":"","type":"tuple"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_voter","type":"address"},{"internalType":"uint256","name":"page","type":"uint256"},{"internalType":"uint256","name":"size","type":"uint256"}],"name":"votesRewardRedeemInfoWithPage","outputs":[{"components":[{"internalType":"address","name":"validator","type":"address"},{"internalType":"string","name":"validatorName","type":"string"},{"internalType":"uint8","name":"validatorRate","type":"uint8"},{"internalType":"uint256","name":"validatorTotalVotes","type":"uint256"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint256","name":"pendingReward","type":"uint256"},{"internalType":"uint256","name":"pendingRedeem","type":"uint256"},{"internalType":"uint256[]","name":"lockRedeemEpochs","type":"uint256[]"},{"internalType":"uint256[]","name":"lockRedeemVotes","type":"uint256[]"}],"internalType":"struct NodeVotes.VotesRewardRedeemInfo[]","name":"","type":"tuple[]"}],"stateMutability":"view","type":"function"},{"stateMutability":"payable","type":"receive"}]`
-
-const SystemRewardsABI = `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"_val","type":"address"},{"indexed":false,"internalType":"uint256","name":"_amount","type":"uint256"}],"name":"LogEarnValidatorReward","type":"event"},{"inputs":[],"name":"BLACK_HOLE_ADDRESS","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"BLOCK_SECONDS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"EPOCH_BLOCKS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_PUNISH_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_RATE","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATORS_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_COUNT_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_DETAIL_LENGTH","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MAX_VALIDATOR_NAME_LENGTH","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MEDIUM_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_DEPOSIT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_LEVEL_VALIDATOR_COUNT","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"MIN_RATE","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"PROPOSAL_DURATION_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"RATE_SET_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV1_TO_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV2_TO_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV3_TO_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_FROM_LV4_TO_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_OVER_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"REWARD_DEPOSIT_UNDER_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"SAFE_MULTIPLIER","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV1","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV2","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV3","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV4","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"TOTAL_DEPOSIT_LV5","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VALIDATOR_REWARD_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VALIDATOR_UNSTAKE_LOCK_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"VOTE_CANCEL_EPOCHS","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"currentEpoch","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"_reward","type":"uint256"}],"name":"distributeBlockReward","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"uint256","name":"_block","type":"uint256"}],"name":"distributeBlockRewardInfo","outputs":[{"internalType":"uint256[]","name":"","type":"uint256[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"earnValRewardFromValidatorC","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"earnValidatorReward","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"uint256","name":"","type":"uint256"}],"name":"epochs","outputs":[{"internalType":"uint256","name":"blockReward","type":"uint256"},{"internalType":"uint256","name":"tvl","type":"uint256"},{"internalType":"uint256","name":"validatorCount","type":"uint256"},{"internalType":"uint256","name":"effictiveValCount","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"getRewardPerVote","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_validator","type":"address"},{"internalType":"address","name":"_node","type":"address"}],"name":"initialize","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"uint256","name":"_epoch","type":"uint256"}],"name":"kickoutInfo","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"nodeVoteC","outputs":[{"internalType":"contract INodeVote","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"pendingValidatorReward","outputs":[{"internalType":"uint256","name":"","type":"uint256"},{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"punish","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"uint256","name":"_epoch","type":"uint256"}],"name":"punishInfo","outputs":[{"components":[{"internalType":"uint256","name":"count","type":"uint256"},{"internalType":"uint256[]","name":"punishBlocks","type":"uint256[]"},{"internalType":"uint256[]","name":"kickoutBlocks","type":"uint256[]"},{"internalType":"uint256[]","name":"burnRewards","type":"uint256[]"}],"internalType":"struct SystemRewards.Punish","name":"","type":"tuple"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"_tvl","type":"uint256"},{"internalType":"uint256","name":"_valCount","type":"uint256"},{"internalType":"uint256","name":"_effictiveValCount","type":"uint256"},{"internalType":"uint256","name":"_newEpoch","type":"uint256"}],"name":"updateEpochWhileElect","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"uint8","name":"_rate","type":"uint8"},{"internalType":"uint256","name":"_newEpoch","type":"uint256"}],"name":"updateValidatorWhileElect","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"uint256","name":"_votes","type":"uint256"}],"name":"updateValidatorWhileEpochEnd","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"validatorC","outputs":[{"internalType":"contract IValidators","name":"","type":"address"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"},{"internalType":"uint256","name":"_epoch","type":"uint256"}],"name":"validatorEpochRewardInfo","outputs":[{"components":[{"internalType":"uint256","name":"validatorReward","type":"uint256"},{"internalType":"uint256","name":"delegatorsReward","type":"uint256"},{"internalType":"uint8","name":"rate","type":"uint8"}],"internalType":"struct SystemRewards.Reward","name":"","type":"tuple"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"validatorRewardsInfo","outputs":[{"components":[{"internalType":"uint256[]","name":"epochs","type":"uint256[]"},{"internalType":"uint256[]","name":"validatorRewards","type":"uint256[]"},{"internalType":"uint256[]","name":"delegatorsRewards","type":"uint256[]"},{"internalType":"uint8[]","name":"rates","type":"uint8[]"},{"internalType":"uint256","name":"pendingReward","type":"uint256"},{"internalType":"uint256","name":"frozenReward","type":"uint256"},{"internalType":"uint256","name":"rewardPerVote","type":"uint256"}],"internalType":"struct SystemRewards.SysRewardsInfo","name":"","type":"tuple"}],"stateMutability":"view","type":"function"}]`
+// The read-only callers of the staking contracts are generated from the ABIs in
+// the contracts folder, so that changes to the contracts break the build instead
+// of failing to unpack at runtime.
+//go:generate go run ../../../cmd/abigen --abi contracts/Validators.abi --pkg systemcontract --type Validators --lang vmcaller --out gen_validators.go
+//go:generate go run ../../../cmd/abigen --abi contracts/Proposals.abi --pkg systemcontract --type ValidatorProposals --lang vmcaller --out gen_validator_proposals.go
+//go:generate go run ../../../cmd/abigen --abi contracts/NodeVotes.abi --pkg systemcontract --type NodeVotes --lang vmcaller --out gen_node_votes.go
+//go:generate go run ../../../cmd/abigen --abi contracts/SystemRewards.abi --pkg systemcontract --type SystemRewards --lang vmcaller --out gen_system_rewards.go
 
 const MigrateABI = `[{"inputs":[{"internalType":"address","name":"_owner","type":"address"},{"internalType":"address[]","name":"_addrs","type":"address[]"},{"internalType":"uint256[]","name":"_bals","type":"uint256[]"}],"name":"initialize","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
 
@@ -24,7 +22,11 @@ const ProposalsABI = `[{"inputs":[{"internalType":"address[]","name":"vals","typ
 
 const SysGovABI = `[{"inputs":[{"internalType":"uint256","name":"id","type":"uint256"}],"name":"finishProposalById","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"uint32","name":"index","type":"uint32"}],"name":"getPassedProposalByIndex","outputs":[{"internalType":"uint256","name":"id","type":"uint256"},{"internalType":"uint256","name":"action","type":"uint256"},{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getPassedProposalCount","outputs":[{"internalType":"uint32","name":"","type":"uint32"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"address","name":"_admin","type":"address"}],"name":"initialize","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
 
-const AddressListABI = `[{"inputs":[],"name":"blackLastUpdatedNumber","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"devVerifyEnabled","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getBlacksFrom","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getBlacksTo","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint32","name":"i","type":"uint32"}],"name":"getRuleByIndex","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"},{"internalType":"uint128","name":"","type":"uint128"},{"internalType":"enum AddressList.CheckType","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"initializeV2","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"_admin","type":"address"}],"name":"initialize","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"addr","type":"address"}],"name":"isDeveloper","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"rulesLastUpdatedNumber","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"rulesLen","outputs":[{"internalType":"uint32","name":"","type":"uint32"}],"stateMutability":"view","type":"function"}]`
+// SysGovEventsABI describes the logs emitted by the governance actions which are
+// applied by the engine itself, on behalf of SysGovToAddr.
+const SysGovEventsABI = `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"uint256","name":"id","type":"uint256"},{"indexed":true,"internalType":"address","name":"account","type":"address"},{"indexed":false,"internalType":"bytes32","name":"slot","type":"bytes32"},{"indexed":false,"internalType":"bytes32","name":"prevValue","type":"bytes32"},{"indexed":false,"internalType":"bytes32","name":"newValue","type":"bytes32"}],"name":"StorageSet","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"uint256","name":"id","type":"uint256"},{"indexed":true,"internalType":"address","name":"account","type":"address"},{"indexed":false,"internalType":"uint256","name":"prevBalance","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"newBalance","type":"uint256"}],"name":"BalanceSet","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"uint256","name":"id","type":"uint256"},{"indexed":true,"internalType":"address","name":"account","type":"address"},{"indexed":false,"internalType":"uint256","name":"amount","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"newBalance","type":"uint256"}],"name":"Minted","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"uint256","name":"id","type":"uint256"},{"indexed":true,"internalType":"address","name":"account","type":"address"}],"name":"AccountFrozen","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"uint256","name":"id","type":"uint256"},{"indexed":true,"internalType":"address","name":"account","type":"address"}],"name":"AccountUnfrozen","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"uint256","name":"id","type":"uint256"},{"indexed":true,"internalType":"address","name":"account","type":"address"},{"indexed":false,"internalType":"bytes32","name":"prevCodeHash","type":"bytes32"},{"indexed":false,"internalType":"bytes32","name":"newCodeHash","type":"bytes32"}],"name":"CodeReplaced","type":"event"}]`
+
+const AddressListABI = `[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"addr","type":"address"},{"indexed":false,"internalType":"enum AddressList.Direction","name":"d","type":"uint8"}],"name":"BlackAddrAdded","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"addr","type":"address"},{"indexed":false,"internalType":"enum AddressList.Direction","name":"d","type":"uint8"}],"name":"BlackAddrRemoved","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"eventSig","type":"bytes32"},{"indexed":false,"internalType":"uint128","name":"checkIdx","type":"uint128"},{"indexed":false,"internalType":"enum AddressList.CheckType","name":"t","type":"uint8"}],"name":"RuleAdded","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"eventSig","type":"bytes32"},{"indexed":false,"internalType":"uint128","name":"checkIdx","type":"uint128"},{"indexed":false,"internalType":"enum AddressList.CheckType","name":"t","type":"uint8"}],"name":"RuleRemoved","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"eventSig","type":"bytes32"},{"indexed":false,"internalType":"uint128","name":"checkIdx","type":"uint128"},{"indexed":false,"internalType":"enum AddressList.CheckType","name":"t","type":"uint8"}],"name":"RuleUpdated","type":"event"},{"inputs":[],"name":"blackLastUpdatedNumber","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"devVerifyEnabled","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getBlacksFrom","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"getBlacksTo","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint32","name":"i","type":"uint32"}],"name":"getRuleByIndex","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"},{"internalType":"uint128","name":"","type":"uint128"},{"internalType":"enum AddressList.CheckType","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"initializeV2","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"_admin","type":"address"}],"name":"initialize","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"addr","type":"address"}],"name":"isDeveloper","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"rulesLastUpdatedNumber","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"rulesLen","outputs":[{"internalType":"uint32","name":"","type":"uint32"}],"stateMutability":"view","type":"function"}]`
 
 // DevMappingPosition is the position of the state variable `devs`.
 // Since the state variables are as follows:
@@ -53,8 +55,14 @@ const AddressListABI = `[{"inputs":[],"name":"blackLastUpdatedNumber","outputs":
 const DevMappingPosition = 2
 
 var (
+	BlacksFromPosition             = common.BytesToHash([]byte{0x03}) // Slot of the length of `blacksFrom`
+	BlacksToPosition               = common.BytesToHash([]byte{0x04}) // Slot of the length of `blacksTo`
+	BlacksFromMapPosition          = common.BytesToHash([]byte{0x05}) // Slot of `blacksFromMap`
+	BlacksToMapPosition            = common.BytesToHash([]byte{0x06}) // Slot of `blacksToMap`
 	BlackLastUpdatedNumberPosition = common.BytesToHash([]byte{0x07})
 	RulesLastUpdatedNumberPosition = common.BytesToHash([]byte{0x08})
+	RulesPosition                  = common.BytesToHash([]byte{0x09}) // Slot of the length of `rules`
+	RulesMapPosition               = common.BytesToHash([]byte{0x0a}) // Slot of `rulesMap`
 )
 
 var (
@@ -66,6 +74,7 @@ var (
 
 	ProposalsContractName   = "Proposals"
 	AddressListContractName = "address_list"
+	SysGovEventsName        = "governance_events"
 	SysGovContractName      = "governance"
 
 	ValidatorsContractAddr         = common.HexToAddress("0x0000000000000000000000000000000000fff001")
@@ -80,8 +89,23 @@ var (
 
 	// SysGovToAddr is the To address for the system governance transaction, NOT contract address
 	SysGovToAddr = common.HexToAddress("0x000000000000000000000000000000000000ffff")
+	// SysEvidenceToAddr is the To address for the double-sign evidence transaction, NOT contract address
+	SysEvidenceToAddr = common.HexToAddress("0x000000000000000000000000000000000000fffe")
+	// SysUnjailToAddr is the To address for the validator unjail transaction, NOT contract address
+	SysUnjailToAddr = common.HexToAddress("0x000000000000000000000000000000000000fffd")
+	// FrozenAccountsAddr records the accounts frozen by governance in its storage, NOT contract address
+	FrozenAccountsAddr = common.HexToAddress("0x000000000000000000000000000000000000fffc")
+	// SysUpgradeToAddr is the To address for the system contract upgrade transaction, NOT contract address
+	SysUpgradeToAddr = common.HexToAddress("0x000000000000000000000000000000000000fffa")
+	// ProposalQueueAddr records when the queued governance proposals become executable in its storage, NOT contract address
+	ProposalQueueAddr = common.HexToAddress("0x000000000000000000000000000000000000fffb")
 
 	abiMap map[string]abi.ABI
+
+	validatorsCaller         *ValidatorsCaller
+	validatorProposalsCaller *ValidatorProposalsCaller
+	nodeVotesCaller          *NodeVotesCaller
+	systemRewardsCaller      *SystemRewardsCaller
 )
 
 func init() {
@@ -96,6 +120,8 @@ func init() {
 	abiMap[SystemRewardsContractName] = tmpABI
 	tmpABI, _ = abi.JSON(strings.NewReader(MigrateABI))
 	abiMap[MigrateContractName] = tmpABI
+	tmpABI, _ = abi.JSON(strings.NewReader(SystemRewardsSlashABI))
+	abiMap[SystemRewardsSlashName] = tmpABI
 
 	tmpABI, _ = abi.JSON(strings.NewReader(ProposalsABI))
 	abiMap[ProposalsContractName] = tmpABI
@@ -103,7 +129,13 @@ func init() {
 	abiMap[AddressListContractName] = tmpABI
 	tmpABI, _ = abi.JSON(strings.NewReader(SysGovABI))
 	abiMap[SysGovContractName] = tmpABI
+	tmpABI, _ = abi.JSON(strings.NewReader(SysGovEventsABI))
+	abiMap[SysGovEventsName] = tmpABI
 
+	validatorsCaller, _ = NewValidatorsCaller(ValidatorsContractAddr)
+	validatorProposalsCaller, _ = NewValidatorProposalsCaller(ValidatorProposalsContractAddr)
+	nodeVotesCaller, _ = NewNodeVotesCaller(NodeVotesContractAddr)
+	systemRewardsCaller, _ = NewSystemRewardsCaller(SystemRewardsContractAddr)
 }
 
 func GetInteractiveABI() map[string]abi.ABI {
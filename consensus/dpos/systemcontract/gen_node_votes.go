@@ -0,0 +1,766 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package systemcontract
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = common.Big1
+)
+
+// NodeVotesVotesRewardRedeemInfo is an auto generated low-level Go binding around an user-defined struct.
+type NodeVotesVotesRewardRedeemInfo struct {
+	Validator           common.Address
+	ValidatorName       string
+	ValidatorRate       uint8
+	ValidatorTotalVotes *big.Int
+	Amount              *big.Int
+	PendingReward       *big.Int
+	PendingRedeem       *big.Int
+	LockRedeemEpochs    []*big.Int
+	LockRedeemVotes     []*big.Int
+}
+
+// NodeVotesABI is the input ABI used to generate the binding from.
+const NodeVotesABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"validator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"votes\",\"type\":\"uint256\"}],\"name\":\"LogCancelVote\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"validator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"reward\",\"type\":\"uint256\"}],\"name\":\"LogEarn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"validator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"votes\",\"type\":\"uint256\"}],\"name\":\"LogRedeem\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"LogRewardTransfer\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"validator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"votes\",\"type\":\"uint256\"}],\"name\":\"LogVote\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"BLACK_HOLE_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"BLOCK_SECONDS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"EPOCH_BLOCKS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_PUNISH_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_RATE\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATORS_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_DETAIL_LENGTH\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_NAME_LENGTH\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MEDIUM_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_DEPOSIT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_RATE\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"PROPOSAL_DURATION_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"RATE_SET_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV1_TO_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV2_TO_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV3_TO_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV4_TO_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_OVER_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_UNDER_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"SAFE_MULTIPLIER\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VALIDATOR_REWARD_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VALIDATOR_UNSTAKE_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VOTE_CANCEL_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_amount\",\"type\":\"uint256\"}],\"name\":\"cancelVote\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"currentEpoch\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"earn\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_validator\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_sysReward\",\"type\":\"address\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_voter\",\"type\":\"address\"}],\"name\":\"pendingRedeem\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_voter\",\"type\":\"address\"}],\"name\":\"pendingReward\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"redeem\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"sysRewards\",\"outputs\":[{\"internalType\":\"contractISystemRewards\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalVotes\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"validators\",\"outputs\":[{\"internalType\":\"contractIValidators\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"vote\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"voteInfos\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"rewardDebt\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_voter\",\"type\":\"address\"}],\"name\":\"voteListLength\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_voter\",\"type\":\"address\"}],\"name\":\"votesRewardRedeemInfo\",\"outputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"validator\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"validatorName\",\"type\":\"string\"},{\"internalType\":\"uint8\",\"name\":\"validatorRate\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"validatorTotalVotes\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"pendingReward\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"pendingRedeem\",\"type\":\"uint256\"},{\"internalType\":\"uint256[]\",\"name\":\"lockRedeemEpochs\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"lockRedeemVotes\",\"type\":\"uint256[]\"}],\"internalType\":\"structNodeVotes.VotesRewardRedeemInfo\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_voter\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"page\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"}],\"name\":\"votesRewardRedeemInfoWithPage\",\"outputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"validator\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"validatorName\",\"type\":\"string\"},{\"internalType\":\"uint8\",\"name\":\"validatorRate\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"validatorTotalVotes\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"pendingReward\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"pendingRedeem\",\"type\":\"uint256\"},{\"internalType\":\"uint256[]\",\"name\":\"lockRedeemEpochs\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"lockRedeemVotes\",\"type\":\"uint256[]\"}],\"internalType\":\"structNodeVotes.VotesRewardRedeemInfo[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]"
+
+// NodeVotesCaller is an auto generated read-only Go binding around a system contract,
+// executing calls directly against the state of a block.
+type NodeVotesCaller struct {
+	abi     abi.ABI        // Parsed contract ABI to pack calls and unpack results with
+	address common.Address // Address the contract is deployed at
+}
+
+// NewNodeVotesCaller creates a new read-only instance of NodeVotes, bound to a specific deployed contract.
+func NewNodeVotesCaller(address common.Address) (*NodeVotesCaller, error) {
+	parsed, err := abi.JSON(strings.NewReader(NodeVotesABI))
+	if err != nil {
+		return nil, err
+	}
+	return &NodeVotesCaller{abi: parsed, address: address}, nil
+}
+
+// BLACKHOLEADDRESS is a free data retrieval call binding the contract method 0x3cdfef01.
+//
+// Solidity: function BLACK_HOLE_ADDRESS() view returns(address)
+func (_NodeVotes *NodeVotesCaller) BLACKHOLEADDRESS(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "BLACK_HOLE_ADDRESS")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// BLOCKSECONDS is a free data retrieval call binding the contract method 0x583284ed.
+//
+// Solidity: function BLOCK_SECONDS() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) BLOCKSECONDS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "BLOCK_SECONDS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// EPOCHBLOCKS is a free data retrieval call binding the contract method 0x74c259c6.
+//
+// Solidity: function EPOCH_BLOCKS() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) EPOCHBLOCKS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "EPOCH_BLOCKS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0xc3f5b2bd.
+//
+// Solidity: function MAX_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MAXLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MAX_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXPUNISHCOUNT is a free data retrieval call binding the contract method 0xd4c28809.
+//
+// Solidity: function MAX_PUNISH_COUNT() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MAXPUNISHCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MAX_PUNISH_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXRATE is a free data retrieval call binding the contract method 0xc24dbebd.
+//
+// Solidity: function MAX_RATE() view returns(uint8)
+func (_NodeVotes *NodeVotesCaller) MAXRATE(ctx *vmcaller.CallContext) (uint8, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MAX_RATE")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORSCOUNT is a free data retrieval call binding the contract method 0x632c93a0.
+//
+// Solidity: function MAX_VALIDATORS_COUNT() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MAXVALIDATORSCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MAX_VALIDATORS_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV1 is a free data retrieval call binding the contract method 0x616f8601.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV1() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MAXVALIDATORCOUNTLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MAX_VALIDATOR_COUNT_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV2 is a free data retrieval call binding the contract method 0x57477c42.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV2() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MAXVALIDATORCOUNTLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MAX_VALIDATOR_COUNT_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV3 is a free data retrieval call binding the contract method 0x17d69d83.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV3() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MAXVALIDATORCOUNTLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MAX_VALIDATOR_COUNT_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV4 is a free data retrieval call binding the contract method 0x6af7cd57.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV4() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MAXVALIDATORCOUNTLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MAX_VALIDATOR_COUNT_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORDETAILLENGTH is a free data retrieval call binding the contract method 0xd9cfcb5d.
+//
+// Solidity: function MAX_VALIDATOR_DETAIL_LENGTH() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MAXVALIDATORDETAILLENGTH(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MAX_VALIDATOR_DETAIL_LENGTH")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORNAMELENGTH is a free data retrieval call binding the contract method 0xb44e55ea.
+//
+// Solidity: function MAX_VALIDATOR_NAME_LENGTH() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MAXVALIDATORNAMELENGTH(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MAX_VALIDATOR_NAME_LENGTH")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MEDIUMLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0x049f8269.
+//
+// Solidity: function MEDIUM_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MEDIUMLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MEDIUM_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINDEPOSIT is a free data retrieval call binding the contract method 0xe1e158a5.
+//
+// Solidity: function MIN_DEPOSIT() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MINDEPOSIT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MIN_DEPOSIT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0x18e0d5cf.
+//
+// Solidity: function MIN_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) MINLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MIN_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINRATE is a free data retrieval call binding the contract method 0xd819bfef.
+//
+// Solidity: function MIN_RATE() view returns(uint8)
+func (_NodeVotes *NodeVotesCaller) MINRATE(ctx *vmcaller.CallContext) (uint8, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "MIN_RATE")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// PROPOSALDURATIONEPOCHS is a free data retrieval call binding the contract method 0xbff6091b.
+//
+// Solidity: function PROPOSAL_DURATION_EPOCHS() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) PROPOSALDURATIONEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "PROPOSAL_DURATION_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RATESETLOCKEPOCHS is a free data retrieval call binding the contract method 0x7b8fe9e8.
+//
+// Solidity: function RATE_SET_LOCK_EPOCHS() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) RATESETLOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "RATE_SET_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV1TOLV2 is a free data retrieval call binding the contract method 0xf8f4fb0a.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV1_TO_LV2() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) REWARDDEPOSITFROMLV1TOLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "REWARD_DEPOSIT_FROM_LV1_TO_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV2TOLV3 is a free data retrieval call binding the contract method 0x02d2b177.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV2_TO_LV3() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) REWARDDEPOSITFROMLV2TOLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "REWARD_DEPOSIT_FROM_LV2_TO_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV3TOLV4 is a free data retrieval call binding the contract method 0x7e02733c.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV3_TO_LV4() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) REWARDDEPOSITFROMLV3TOLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "REWARD_DEPOSIT_FROM_LV3_TO_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV4TOLV5 is a free data retrieval call binding the contract method 0x0b4d69a4.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV4_TO_LV5() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) REWARDDEPOSITFROMLV4TOLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "REWARD_DEPOSIT_FROM_LV4_TO_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITOVERLV5 is a free data retrieval call binding the contract method 0xc38c16bf.
+//
+// Solidity: function REWARD_DEPOSIT_OVER_LV5() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) REWARDDEPOSITOVERLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "REWARD_DEPOSIT_OVER_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITUNDERLV1 is a free data retrieval call binding the contract method 0xa0c6211a.
+//
+// Solidity: function REWARD_DEPOSIT_UNDER_LV1() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) REWARDDEPOSITUNDERLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "REWARD_DEPOSIT_UNDER_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SAFEMULTIPLIER is a free data retrieval call binding the contract method 0xdc7e0ce8.
+//
+// Solidity: function SAFE_MULTIPLIER() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) SAFEMULTIPLIER(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "SAFE_MULTIPLIER")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV1 is a free data retrieval call binding the contract method 0xfec11efe.
+//
+// Solidity: function TOTAL_DEPOSIT_LV1() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) TOTALDEPOSITLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "TOTAL_DEPOSIT_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV2 is a free data retrieval call binding the contract method 0xd1861c31.
+//
+// Solidity: function TOTAL_DEPOSIT_LV2() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) TOTALDEPOSITLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "TOTAL_DEPOSIT_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV3 is a free data retrieval call binding the contract method 0x2ee7655e.
+//
+// Solidity: function TOTAL_DEPOSIT_LV3() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) TOTALDEPOSITLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "TOTAL_DEPOSIT_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV4 is a free data retrieval call binding the contract method 0x1d78ef9f.
+//
+// Solidity: function TOTAL_DEPOSIT_LV4() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) TOTALDEPOSITLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "TOTAL_DEPOSIT_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV5 is a free data retrieval call binding the contract method 0x4a1ecf21.
+//
+// Solidity: function TOTAL_DEPOSIT_LV5() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) TOTALDEPOSITLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "TOTAL_DEPOSIT_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VALIDATORREWARDLOCKEPOCHS is a free data retrieval call binding the contract method 0x25442055.
+//
+// Solidity: function VALIDATOR_REWARD_LOCK_EPOCHS() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) VALIDATORREWARDLOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "VALIDATOR_REWARD_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VALIDATORUNSTAKELOCKEPOCHS is a free data retrieval call binding the contract method 0x4b318db8.
+//
+// Solidity: function VALIDATOR_UNSTAKE_LOCK_EPOCHS() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) VALIDATORUNSTAKELOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "VALIDATOR_UNSTAKE_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VOTECANCELEPOCHS is a free data retrieval call binding the contract method 0x19e52a62.
+//
+// Solidity: function VOTE_CANCEL_EPOCHS() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) VOTECANCELEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "VOTE_CANCEL_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CurrentEpoch is a free data retrieval call binding the contract method 0x76671808.
+//
+// Solidity: function currentEpoch() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) CurrentEpoch(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "currentEpoch")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PendingRedeem is a free data retrieval call binding the contract method 0x6c10edfe.
+//
+// Solidity: function pendingRedeem(address _val, address _voter) view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) PendingRedeem(ctx *vmcaller.CallContext, _val common.Address, _voter common.Address) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "pendingRedeem", _val, _voter)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PendingReward is a free data retrieval call binding the contract method 0x9ced7e76.
+//
+// Solidity: function pendingReward(address _val, address _voter) view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) PendingReward(ctx *vmcaller.CallContext, _val common.Address, _voter common.Address) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "pendingReward", _val, _voter)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SysRewards is a free data retrieval call binding the contract method 0x2e897c5d.
+//
+// Solidity: function sysRewards() view returns(address)
+func (_NodeVotes *NodeVotesCaller) SysRewards(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "sysRewards")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// TotalVotes is a free data retrieval call binding the contract method 0x0d15fd77.
+//
+// Solidity: function totalVotes() view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) TotalVotes(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "totalVotes")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Validators is a free data retrieval call binding the contract method 0xca1e7819.
+//
+// Solidity: function validators() view returns(address)
+func (_NodeVotes *NodeVotesCaller) Validators(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "validators")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// VoteInfos is a free data retrieval call binding the contract method 0xa2c01846.
+//
+// Solidity: function voteInfos(address , address ) view returns(uint256 amount, uint256 rewardDebt)
+func (_NodeVotes *NodeVotesCaller) VoteInfos(ctx *vmcaller.CallContext, arg0 common.Address, arg1 common.Address) (struct {
+	Amount     *big.Int
+	RewardDebt *big.Int
+}, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "voteInfos", arg0, arg1)
+
+	outstruct := new(struct {
+		Amount     *big.Int
+		RewardDebt *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Amount = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.RewardDebt = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// VoteListLength is a free data retrieval call binding the contract method 0x8f597608.
+//
+// Solidity: function voteListLength(address _voter) view returns(uint256)
+func (_NodeVotes *NodeVotesCaller) VoteListLength(ctx *vmcaller.CallContext, _voter common.Address) (*big.Int, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "voteListLength", _voter)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VotesRewardRedeemInfo is a free data retrieval call binding the contract method 0x29221381.
+//
+// Solidity: function votesRewardRedeemInfo(address _val, address _voter) view returns((address,string,uint8,uint256,uint256,uint256,uint256,uint256[],uint256[]))
+func (_NodeVotes *NodeVotesCaller) VotesRewardRedeemInfo(ctx *vmcaller.CallContext, _val common.Address, _voter common.Address) (NodeVotesVotesRewardRedeemInfo, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "votesRewardRedeemInfo", _val, _voter)
+
+	if err != nil {
+		return *new(NodeVotesVotesRewardRedeemInfo), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(NodeVotesVotesRewardRedeemInfo)).(*NodeVotesVotesRewardRedeemInfo)
+
+	return out0, err
+
+}
+
+// VotesRewardRedeemInfoWithPage is a free data retrieval call binding the contract method 0x872ed8e5.
+//
+// Solidity: function votesRewardRedeemInfoWithPage(address _voter, uint256 page, uint256 size) view returns((address,string,uint8,uint256,uint256,uint256,uint256,uint256[],uint256[])[])
+func (_NodeVotes *NodeVotesCaller) VotesRewardRedeemInfoWithPage(ctx *vmcaller.CallContext, _voter common.Address, page *big.Int, size *big.Int) ([]NodeVotesVotesRewardRedeemInfo, error) {
+	out, err := ctx.Call(_NodeVotes.abi, _NodeVotes.address, "votesRewardRedeemInfoWithPage", _voter, page, size)
+
+	if err != nil {
+		return *new([]NodeVotesVotesRewardRedeemInfo), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]NodeVotesVotesRewardRedeemInfo)).(*[]NodeVotesVotesRewardRedeemInfo)
+
+	return out0, err
+
+}
@@ -0,0 +1,802 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package systemcontract
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = common.Big1
+)
+
+// SystemRewardsPunish is an auto generated low-level Go binding around an user-defined struct.
+type SystemRewardsPunish struct {
+	Count         *big.Int
+	PunishBlocks  []*big.Int
+	KickoutBlocks []*big.Int
+	BurnRewards   []*big.Int
+}
+
+// SystemRewardsReward is an auto generated low-level Go binding around an user-defined struct.
+type SystemRewardsReward struct {
+	ValidatorReward  *big.Int
+	DelegatorsReward *big.Int
+	Rate             uint8
+}
+
+// SystemRewardsSysRewardsInfo is an auto generated low-level Go binding around an user-defined struct.
+type SystemRewardsSysRewardsInfo struct {
+	Epochs            []*big.Int
+	ValidatorRewards  []*big.Int
+	DelegatorsRewards []*big.Int
+	Rates             []uint8
+	PendingReward     *big.Int
+	FrozenReward      *big.Int
+	RewardPerVote     *big.Int
+}
+
+// SystemRewardsABI is the input ABI used to generate the binding from.
+const SystemRewardsABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"_amount\",\"type\":\"uint256\"}],\"name\":\"LogEarnValidatorReward\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"BLACK_HOLE_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"BLOCK_SECONDS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"EPOCH_BLOCKS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_PUNISH_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_RATE\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATORS_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_DETAIL_LENGTH\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_NAME_LENGTH\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MEDIUM_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_DEPOSIT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_RATE\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"PROPOSAL_DURATION_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"RATE_SET_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV1_TO_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV2_TO_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV3_TO_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV4_TO_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_OVER_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_UNDER_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"SAFE_MULTIPLIER\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VALIDATOR_REWARD_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VALIDATOR_UNSTAKE_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VOTE_CANCEL_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"currentEpoch\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_reward\",\"type\":\"uint256\"}],\"name\":\"distributeBlockReward\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_block\",\"type\":\"uint256\"}],\"name\":\"distributeBlockRewardInfo\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"earnValRewardFromValidatorC\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"earnValidatorReward\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"epochs\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"blockReward\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"tvl\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"validatorCount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"effictiveValCount\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"getRewardPerVote\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_validator\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_node\",\"type\":\"address\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_epoch\",\"type\":\"uint256\"}],\"name\":\"kickoutInfo\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nodeVoteC\",\"outputs\":[{\"internalType\":\"contractINodeVote\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"pendingValidatorReward\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"punish\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_epoch\",\"type\":\"uint256\"}],\"name\":\"punishInfo\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"count\",\"type\":\"uint256\"},{\"internalType\":\"uint256[]\",\"name\":\"punishBlocks\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"kickoutBlocks\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"burnRewards\",\"type\":\"uint256[]\"}],\"internalType\":\"structSystemRewards.Punish\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_tvl\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_valCount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_effictiveValCount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_newEpoch\",\"type\":\"uint256\"}],\"name\":\"updateEpochWhileElect\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint8\",\"name\":\"_rate\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"_newEpoch\",\"type\":\"uint256\"}],\"name\":\"updateValidatorWhileElect\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_votes\",\"type\":\"uint256\"}],\"name\":\"updateValidatorWhileEpochEnd\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"validatorC\",\"outputs\":[{\"internalType\":\"contractIValidators\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_epoch\",\"type\":\"uint256\"}],\"name\":\"validatorEpochRewardInfo\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"validatorReward\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"delegatorsReward\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"rate\",\"type\":\"uint8\"}],\"internalType\":\"structSystemRewards.Reward\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"validatorRewardsInfo\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256[]\",\"name\":\"epochs\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"validatorRewards\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"delegatorsRewards\",\"type\":\"uint256[]\"},{\"internalType\":\"uint8[]\",\"name\":\"rates\",\"type\":\"uint8[]\"},{\"internalType\":\"uint256\",\"name\":\"pendingReward\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"frozenReward\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"rewardPerVote\",\"type\":\"uint256\"}],\"internalType\":\"structSystemRewards.SysRewardsInfo\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// SystemRewardsCaller is an auto generated read-only Go binding around a system contract,
+// executing calls directly against the state of a block.
+type SystemRewardsCaller struct {
+	abi     abi.ABI        // Parsed contract ABI to pack calls and unpack results with
+	address common.Address // Address the contract is deployed at
+}
+
+// NewSystemRewardsCaller creates a new read-only instance of SystemRewards, bound to a specific deployed contract.
+func NewSystemRewardsCaller(address common.Address) (*SystemRewardsCaller, error) {
+	parsed, err := abi.JSON(strings.NewReader(SystemRewardsABI))
+	if err != nil {
+		return nil, err
+	}
+	return &SystemRewardsCaller{abi: parsed, address: address}, nil
+}
+
+// BLACKHOLEADDRESS is a free data retrieval call binding the contract method 0x3cdfef01.
+//
+// Solidity: function BLACK_HOLE_ADDRESS() view returns(address)
+func (_SystemRewards *SystemRewardsCaller) BLACKHOLEADDRESS(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "BLACK_HOLE_ADDRESS")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// BLOCKSECONDS is a free data retrieval call binding the contract method 0x583284ed.
+//
+// Solidity: function BLOCK_SECONDS() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) BLOCKSECONDS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "BLOCK_SECONDS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// EPOCHBLOCKS is a free data retrieval call binding the contract method 0x74c259c6.
+//
+// Solidity: function EPOCH_BLOCKS() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) EPOCHBLOCKS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "EPOCH_BLOCKS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0xc3f5b2bd.
+//
+// Solidity: function MAX_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MAXLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MAX_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXPUNISHCOUNT is a free data retrieval call binding the contract method 0xd4c28809.
+//
+// Solidity: function MAX_PUNISH_COUNT() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MAXPUNISHCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MAX_PUNISH_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXRATE is a free data retrieval call binding the contract method 0xc24dbebd.
+//
+// Solidity: function MAX_RATE() view returns(uint8)
+func (_SystemRewards *SystemRewardsCaller) MAXRATE(ctx *vmcaller.CallContext) (uint8, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MAX_RATE")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORSCOUNT is a free data retrieval call binding the contract method 0x632c93a0.
+//
+// Solidity: function MAX_VALIDATORS_COUNT() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MAXVALIDATORSCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MAX_VALIDATORS_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV1 is a free data retrieval call binding the contract method 0x616f8601.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV1() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MAXVALIDATORCOUNTLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MAX_VALIDATOR_COUNT_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV2 is a free data retrieval call binding the contract method 0x57477c42.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV2() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MAXVALIDATORCOUNTLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MAX_VALIDATOR_COUNT_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV3 is a free data retrieval call binding the contract method 0x17d69d83.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV3() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MAXVALIDATORCOUNTLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MAX_VALIDATOR_COUNT_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV4 is a free data retrieval call binding the contract method 0x6af7cd57.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV4() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MAXVALIDATORCOUNTLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MAX_VALIDATOR_COUNT_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORDETAILLENGTH is a free data retrieval call binding the contract method 0xd9cfcb5d.
+//
+// Solidity: function MAX_VALIDATOR_DETAIL_LENGTH() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MAXVALIDATORDETAILLENGTH(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MAX_VALIDATOR_DETAIL_LENGTH")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORNAMELENGTH is a free data retrieval call binding the contract method 0xb44e55ea.
+//
+// Solidity: function MAX_VALIDATOR_NAME_LENGTH() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MAXVALIDATORNAMELENGTH(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MAX_VALIDATOR_NAME_LENGTH")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MEDIUMLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0x049f8269.
+//
+// Solidity: function MEDIUM_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MEDIUMLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MEDIUM_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINDEPOSIT is a free data retrieval call binding the contract method 0xe1e158a5.
+//
+// Solidity: function MIN_DEPOSIT() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MINDEPOSIT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MIN_DEPOSIT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0x18e0d5cf.
+//
+// Solidity: function MIN_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) MINLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MIN_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINRATE is a free data retrieval call binding the contract method 0xd819bfef.
+//
+// Solidity: function MIN_RATE() view returns(uint8)
+func (_SystemRewards *SystemRewardsCaller) MINRATE(ctx *vmcaller.CallContext) (uint8, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "MIN_RATE")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// PROPOSALDURATIONEPOCHS is a free data retrieval call binding the contract method 0xbff6091b.
+//
+// Solidity: function PROPOSAL_DURATION_EPOCHS() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) PROPOSALDURATIONEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "PROPOSAL_DURATION_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RATESETLOCKEPOCHS is a free data retrieval call binding the contract method 0x7b8fe9e8.
+//
+// Solidity: function RATE_SET_LOCK_EPOCHS() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) RATESETLOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "RATE_SET_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV1TOLV2 is a free data retrieval call binding the contract method 0xf8f4fb0a.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV1_TO_LV2() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) REWARDDEPOSITFROMLV1TOLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "REWARD_DEPOSIT_FROM_LV1_TO_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV2TOLV3 is a free data retrieval call binding the contract method 0x02d2b177.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV2_TO_LV3() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) REWARDDEPOSITFROMLV2TOLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "REWARD_DEPOSIT_FROM_LV2_TO_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV3TOLV4 is a free data retrieval call binding the contract method 0x7e02733c.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV3_TO_LV4() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) REWARDDEPOSITFROMLV3TOLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "REWARD_DEPOSIT_FROM_LV3_TO_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV4TOLV5 is a free data retrieval call binding the contract method 0x0b4d69a4.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV4_TO_LV5() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) REWARDDEPOSITFROMLV4TOLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "REWARD_DEPOSIT_FROM_LV4_TO_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITOVERLV5 is a free data retrieval call binding the contract method 0xc38c16bf.
+//
+// Solidity: function REWARD_DEPOSIT_OVER_LV5() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) REWARDDEPOSITOVERLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "REWARD_DEPOSIT_OVER_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITUNDERLV1 is a free data retrieval call binding the contract method 0xa0c6211a.
+//
+// Solidity: function REWARD_DEPOSIT_UNDER_LV1() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) REWARDDEPOSITUNDERLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "REWARD_DEPOSIT_UNDER_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SAFEMULTIPLIER is a free data retrieval call binding the contract method 0xdc7e0ce8.
+//
+// Solidity: function SAFE_MULTIPLIER() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) SAFEMULTIPLIER(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "SAFE_MULTIPLIER")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV1 is a free data retrieval call binding the contract method 0xfec11efe.
+//
+// Solidity: function TOTAL_DEPOSIT_LV1() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) TOTALDEPOSITLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "TOTAL_DEPOSIT_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV2 is a free data retrieval call binding the contract method 0xd1861c31.
+//
+// Solidity: function TOTAL_DEPOSIT_LV2() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) TOTALDEPOSITLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "TOTAL_DEPOSIT_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV3 is a free data retrieval call binding the contract method 0x2ee7655e.
+//
+// Solidity: function TOTAL_DEPOSIT_LV3() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) TOTALDEPOSITLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "TOTAL_DEPOSIT_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV4 is a free data retrieval call binding the contract method 0x1d78ef9f.
+//
+// Solidity: function TOTAL_DEPOSIT_LV4() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) TOTALDEPOSITLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "TOTAL_DEPOSIT_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV5 is a free data retrieval call binding the contract method 0x4a1ecf21.
+//
+// Solidity: function TOTAL_DEPOSIT_LV5() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) TOTALDEPOSITLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "TOTAL_DEPOSIT_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VALIDATORREWARDLOCKEPOCHS is a free data retrieval call binding the contract method 0x25442055.
+//
+// Solidity: function VALIDATOR_REWARD_LOCK_EPOCHS() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) VALIDATORREWARDLOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "VALIDATOR_REWARD_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VALIDATORUNSTAKELOCKEPOCHS is a free data retrieval call binding the contract method 0x4b318db8.
+//
+// Solidity: function VALIDATOR_UNSTAKE_LOCK_EPOCHS() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) VALIDATORUNSTAKELOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "VALIDATOR_UNSTAKE_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VOTECANCELEPOCHS is a free data retrieval call binding the contract method 0x19e52a62.
+//
+// Solidity: function VOTE_CANCEL_EPOCHS() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) VOTECANCELEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "VOTE_CANCEL_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CurrentEpoch is a free data retrieval call binding the contract method 0x76671808.
+//
+// Solidity: function currentEpoch() view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) CurrentEpoch(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "currentEpoch")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// DistributeBlockRewardInfo is a free data retrieval call binding the contract method 0xcefeccf4.
+//
+// Solidity: function distributeBlockRewardInfo(address _val, uint256 _block) view returns(uint256[])
+func (_SystemRewards *SystemRewardsCaller) DistributeBlockRewardInfo(ctx *vmcaller.CallContext, _val common.Address, _block *big.Int) ([]*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "distributeBlockRewardInfo", _val, _block)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// Epochs is a free data retrieval call binding the contract method 0xc6b61e4c.
+//
+// Solidity: function epochs(uint256 ) view returns(uint256 blockReward, uint256 tvl, uint256 validatorCount, uint256 effictiveValCount)
+func (_SystemRewards *SystemRewardsCaller) Epochs(ctx *vmcaller.CallContext, arg0 *big.Int) (struct {
+	BlockReward       *big.Int
+	Tvl               *big.Int
+	ValidatorCount    *big.Int
+	EffictiveValCount *big.Int
+}, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "epochs", arg0)
+
+	outstruct := new(struct {
+		BlockReward       *big.Int
+		Tvl               *big.Int
+		ValidatorCount    *big.Int
+		EffictiveValCount *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.BlockReward = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Tvl = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.ValidatorCount = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.EffictiveValCount = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetRewardPerVote is a free data retrieval call binding the contract method 0x642c51f0.
+//
+// Solidity: function getRewardPerVote(address _val) view returns(uint256)
+func (_SystemRewards *SystemRewardsCaller) GetRewardPerVote(ctx *vmcaller.CallContext, _val common.Address) (*big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "getRewardPerVote", _val)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// KickoutInfo is a free data retrieval call binding the contract method 0xe0a5dfb1.
+//
+// Solidity: function kickoutInfo(uint256 _epoch) view returns(address[])
+func (_SystemRewards *SystemRewardsCaller) KickoutInfo(ctx *vmcaller.CallContext, _epoch *big.Int) ([]common.Address, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "kickoutInfo", _epoch)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// NodeVoteC is a free data retrieval call binding the contract method 0xeeb56859.
+//
+// Solidity: function nodeVoteC() view returns(address)
+func (_SystemRewards *SystemRewardsCaller) NodeVoteC(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "nodeVoteC")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PendingValidatorReward is a free data retrieval call binding the contract method 0x41040667.
+//
+// Solidity: function pendingValidatorReward(address _val) view returns(uint256, uint256)
+func (_SystemRewards *SystemRewardsCaller) PendingValidatorReward(ctx *vmcaller.CallContext, _val common.Address) (*big.Int, *big.Int, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "pendingValidatorReward", _val)
+
+	if err != nil {
+		return *new(*big.Int), *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	out1 := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return out0, out1, err
+
+}
+
+// PunishInfo is a free data retrieval call binding the contract method 0xcf028ff5.
+//
+// Solidity: function punishInfo(address _val, uint256 _epoch) view returns((uint256,uint256[],uint256[],uint256[]))
+func (_SystemRewards *SystemRewardsCaller) PunishInfo(ctx *vmcaller.CallContext, _val common.Address, _epoch *big.Int) (SystemRewardsPunish, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "punishInfo", _val, _epoch)
+
+	if err != nil {
+		return *new(SystemRewardsPunish), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(SystemRewardsPunish)).(*SystemRewardsPunish)
+
+	return out0, err
+
+}
+
+// ValidatorC is a free data retrieval call binding the contract method 0x5743edf1.
+//
+// Solidity: function validatorC() view returns(address)
+func (_SystemRewards *SystemRewardsCaller) ValidatorC(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "validatorC")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// ValidatorEpochRewardInfo is a free data retrieval call binding the contract method 0xb44b34b6.
+//
+// Solidity: function validatorEpochRewardInfo(address _val, uint256 _epoch) view returns((uint256,uint256,uint8))
+func (_SystemRewards *SystemRewardsCaller) ValidatorEpochRewardInfo(ctx *vmcaller.CallContext, _val common.Address, _epoch *big.Int) (SystemRewardsReward, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "validatorEpochRewardInfo", _val, _epoch)
+
+	if err != nil {
+		return *new(SystemRewardsReward), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(SystemRewardsReward)).(*SystemRewardsReward)
+
+	return out0, err
+
+}
+
+// ValidatorRewardsInfo is a free data retrieval call binding the contract method 0xf20aa5a1.
+//
+// Solidity: function validatorRewardsInfo(address _val) view returns((uint256[],uint256[],uint256[],uint8[],uint256,uint256,uint256))
+func (_SystemRewards *SystemRewardsCaller) ValidatorRewardsInfo(ctx *vmcaller.CallContext, _val common.Address) (SystemRewardsSysRewardsInfo, error) {
+	out, err := ctx.Call(_SystemRewards.abi, _SystemRewards.address, "validatorRewardsInfo", _val)
+
+	if err != nil {
+		return *new(SystemRewardsSysRewardsInfo), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(SystemRewardsSysRewardsInfo)).(*SystemRewardsSysRewardsInfo)
+
+	return out0, err
+
+}
@@ -0,0 +1,41 @@
+package systemcontract
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi/bind"
+)
+
+// Tests that the generated callers are up to date with the contract ABIs, so
+// that `go generate` has to be rerun whenever the ABIs change.
+func TestGeneratedCallers(t *testing.T) {
+	tests := []struct {
+		abi  string
+		kind string
+		out  string
+	}{
+		{"Validators.abi", "Validators", "gen_validators.go"},
+		{"Proposals.abi", "ValidatorProposals", "gen_validator_proposals.go"},
+		{"NodeVotes.abi", "NodeVotes", "gen_node_votes.go"},
+		{"SystemRewards.abi", "SystemRewards", "gen_system_rewards.go"},
+	}
+	for _, tt := range tests {
+		abi, err := ioutil.ReadFile(filepath.Join("contracts", tt.abi))
+		if err != nil {
+			t.Fatalf("%s: failed to read abi: %v", tt.abi, err)
+		}
+		want, err := bind.Bind([]string{tt.kind}, []string{string(abi)}, []string{""}, []map[string]string{nil}, "systemcontract", bind.LangVMCaller, map[string]string{}, map[string]string{})
+		if err != nil {
+			t.Fatalf("%s: failed to generate caller: %v", tt.abi, err)
+		}
+		have, err := ioutil.ReadFile(tt.out)
+		if err != nil {
+			t.Fatalf("%s: failed to read caller: %v", tt.out, err)
+		}
+		if string(have) != want {
+			t.Errorf("%s is out of date with %s, run go generate", tt.out, tt.abi)
+		}
+	}
+}
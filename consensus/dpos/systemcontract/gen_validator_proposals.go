@@ -0,0 +1,795 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package systemcontract
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = common.Big1
+)
+
+// ProposalsProposalInfo is an auto generated low-level Go binding around an user-defined struct.
+type ProposalsProposalInfo struct {
+	Id          [4]byte
+	Proposer    common.Address
+	PType       uint8
+	Deposit     *big.Int
+	Rate        uint8
+	Name        string
+	Details     string
+	InitBlock   *big.Int
+	Guarantee   common.Address
+	UpdateBlock *big.Int
+	Status      uint8
+}
+
+// ValidatorProposalsABI is the input ABI used to generate the binding from.
+const ValidatorProposalsABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"id\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"proposer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"block\",\"type\":\"uint256\"}],\"name\":\"LogCancelProposal\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"id\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"guarantee\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"block\",\"type\":\"uint256\"}],\"name\":\"LogGuarantee\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"id\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"proposer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"block\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"rate\",\"type\":\"uint256\"}],\"name\":\"LogInitProposal\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"id\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"proposer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"block\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"rate\",\"type\":\"uint256\"}],\"name\":\"LogUpdateProposal\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"BLACK_HOLE_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"BLOCK_SECONDS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"EPOCH_BLOCKS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_PUNISH_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_RATE\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATORS_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_DETAIL_LENGTH\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_NAME_LENGTH\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MEDIUM_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_DEPOSIT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_RATE\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"PROPOSAL_DURATION_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"RATE_SET_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV1_TO_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV2_TO_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV3_TO_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV4_TO_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_OVER_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_UNDER_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"SAFE_MULTIPLIER\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VALIDATOR_REWARD_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VALIDATOR_UNSTAKE_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VOTE_CANCEL_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"val\",\"type\":\"address\"}],\"name\":\"addressProposalCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"page\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"}],\"name\":\"addressProposalSets\",\"outputs\":[{\"internalType\":\"bytes4[]\",\"name\":\"\",\"type\":\"bytes4[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"page\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"}],\"name\":\"addressProposals\",\"outputs\":[{\"components\":[{\"internalType\":\"bytes4\",\"name\":\"id\",\"type\":\"bytes4\"},{\"internalType\":\"address\",\"name\":\"proposer\",\"type\":\"address\"},{\"internalType\":\"enumProposals.ProposalType\",\"name\":\"pType\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"rate\",\"type\":\"uint8\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"details\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"initBlock\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"guarantee\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"updateBlock\",\"type\":\"uint256\"},{\"internalType\":\"enumProposals.ProposalStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"internalType\":\"structProposals.ProposalInfo[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"page\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"}],\"name\":\"allProposalSets\",\"outputs\":[{\"internalType\":\"bytes4[]\",\"name\":\"\",\"type\":\"bytes4[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"page\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"}],\"name\":\"allProposals\",\"outputs\":[{\"components\":[{\"internalType\":\"bytes4\",\"name\":\"id\",\"type\":\"bytes4\"},{\"internalType\":\"address\",\"name\":\"proposer\",\"type\":\"address\"},{\"internalType\":\"enumProposals.ProposalType\",\"name\":\"pType\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"rate\",\"type\":\"uint8\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"details\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"initBlock\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"guarantee\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"updateBlock\",\"type\":\"uint256\"},{\"internalType\":\"enumProposals.ProposalStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"internalType\":\"structProposals.ProposalInfo[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"id\",\"type\":\"bytes4\"}],\"name\":\"cancelProposal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"currentEpoch\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"id\",\"type\":\"bytes4\"}],\"name\":\"guarantee\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"enumProposals.ProposalType\",\"name\":\"pType\",\"type\":\"uint8\"},{\"internalType\":\"uint8\",\"name\":\"rate\",\"type\":\"uint8\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"details\",\"type\":\"string\"}],\"name\":\"initProposal\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_validator\",\"type\":\"address\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"proposalCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"\",\"type\":\"bytes4\"}],\"name\":\"proposalInfos\",\"outputs\":[{\"internalType\":\"bytes4\",\"name\":\"id\",\"type\":\"bytes4\"},{\"internalType\":\"address\",\"name\":\"proposer\",\"type\":\"address\"},{\"internalType\":\"enumProposals.ProposalType\",\"name\":\"pType\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"rate\",\"type\":\"uint8\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"details\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"initBlock\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"guarantee\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"updateBlock\",\"type\":\"uint256\"},{\"internalType\":\"enumProposals.ProposalStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"proposals\",\"outputs\":[{\"internalType\":\"bytes4\",\"name\":\"\",\"type\":\"bytes4\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"id\",\"type\":\"bytes4\"},{\"internalType\":\"uint8\",\"name\":\"rate\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"details\",\"type\":\"string\"}],\"name\":\"updateProposal\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"validators\",\"outputs\":[{\"internalType\":\"contractIValidators\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// ValidatorProposalsCaller is an auto generated read-only Go binding around a system contract,
+// executing calls directly against the state of a block.
+type ValidatorProposalsCaller struct {
+	abi     abi.ABI        // Parsed contract ABI to pack calls and unpack results with
+	address common.Address // Address the contract is deployed at
+}
+
+// NewValidatorProposalsCaller creates a new read-only instance of ValidatorProposals, bound to a specific deployed contract.
+func NewValidatorProposalsCaller(address common.Address) (*ValidatorProposalsCaller, error) {
+	parsed, err := abi.JSON(strings.NewReader(ValidatorProposalsABI))
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorProposalsCaller{abi: parsed, address: address}, nil
+}
+
+// BLACKHOLEADDRESS is a free data retrieval call binding the contract method 0x3cdfef01.
+//
+// Solidity: function BLACK_HOLE_ADDRESS() view returns(address)
+func (_ValidatorProposals *ValidatorProposalsCaller) BLACKHOLEADDRESS(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "BLACK_HOLE_ADDRESS")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// BLOCKSECONDS is a free data retrieval call binding the contract method 0x583284ed.
+//
+// Solidity: function BLOCK_SECONDS() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) BLOCKSECONDS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "BLOCK_SECONDS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// EPOCHBLOCKS is a free data retrieval call binding the contract method 0x74c259c6.
+//
+// Solidity: function EPOCH_BLOCKS() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) EPOCHBLOCKS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "EPOCH_BLOCKS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0xc3f5b2bd.
+//
+// Solidity: function MAX_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MAXLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MAX_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXPUNISHCOUNT is a free data retrieval call binding the contract method 0xd4c28809.
+//
+// Solidity: function MAX_PUNISH_COUNT() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MAXPUNISHCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MAX_PUNISH_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXRATE is a free data retrieval call binding the contract method 0xc24dbebd.
+//
+// Solidity: function MAX_RATE() view returns(uint8)
+func (_ValidatorProposals *ValidatorProposalsCaller) MAXRATE(ctx *vmcaller.CallContext) (uint8, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MAX_RATE")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORSCOUNT is a free data retrieval call binding the contract method 0x632c93a0.
+//
+// Solidity: function MAX_VALIDATORS_COUNT() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MAXVALIDATORSCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MAX_VALIDATORS_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV1 is a free data retrieval call binding the contract method 0x616f8601.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV1() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MAXVALIDATORCOUNTLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MAX_VALIDATOR_COUNT_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV2 is a free data retrieval call binding the contract method 0x57477c42.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV2() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MAXVALIDATORCOUNTLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MAX_VALIDATOR_COUNT_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV3 is a free data retrieval call binding the contract method 0x17d69d83.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV3() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MAXVALIDATORCOUNTLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MAX_VALIDATOR_COUNT_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV4 is a free data retrieval call binding the contract method 0x6af7cd57.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV4() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MAXVALIDATORCOUNTLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MAX_VALIDATOR_COUNT_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORDETAILLENGTH is a free data retrieval call binding the contract method 0xd9cfcb5d.
+//
+// Solidity: function MAX_VALIDATOR_DETAIL_LENGTH() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MAXVALIDATORDETAILLENGTH(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MAX_VALIDATOR_DETAIL_LENGTH")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORNAMELENGTH is a free data retrieval call binding the contract method 0xb44e55ea.
+//
+// Solidity: function MAX_VALIDATOR_NAME_LENGTH() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MAXVALIDATORNAMELENGTH(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MAX_VALIDATOR_NAME_LENGTH")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MEDIUMLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0x049f8269.
+//
+// Solidity: function MEDIUM_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MEDIUMLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MEDIUM_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINDEPOSIT is a free data retrieval call binding the contract method 0xe1e158a5.
+//
+// Solidity: function MIN_DEPOSIT() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MINDEPOSIT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MIN_DEPOSIT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0x18e0d5cf.
+//
+// Solidity: function MIN_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) MINLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MIN_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINRATE is a free data retrieval call binding the contract method 0xd819bfef.
+//
+// Solidity: function MIN_RATE() view returns(uint8)
+func (_ValidatorProposals *ValidatorProposalsCaller) MINRATE(ctx *vmcaller.CallContext) (uint8, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "MIN_RATE")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// PROPOSALDURATIONEPOCHS is a free data retrieval call binding the contract method 0xbff6091b.
+//
+// Solidity: function PROPOSAL_DURATION_EPOCHS() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) PROPOSALDURATIONEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "PROPOSAL_DURATION_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RATESETLOCKEPOCHS is a free data retrieval call binding the contract method 0x7b8fe9e8.
+//
+// Solidity: function RATE_SET_LOCK_EPOCHS() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) RATESETLOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "RATE_SET_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV1TOLV2 is a free data retrieval call binding the contract method 0xf8f4fb0a.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV1_TO_LV2() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) REWARDDEPOSITFROMLV1TOLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "REWARD_DEPOSIT_FROM_LV1_TO_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV2TOLV3 is a free data retrieval call binding the contract method 0x02d2b177.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV2_TO_LV3() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) REWARDDEPOSITFROMLV2TOLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "REWARD_DEPOSIT_FROM_LV2_TO_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV3TOLV4 is a free data retrieval call binding the contract method 0x7e02733c.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV3_TO_LV4() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) REWARDDEPOSITFROMLV3TOLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "REWARD_DEPOSIT_FROM_LV3_TO_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV4TOLV5 is a free data retrieval call binding the contract method 0x0b4d69a4.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV4_TO_LV5() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) REWARDDEPOSITFROMLV4TOLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "REWARD_DEPOSIT_FROM_LV4_TO_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITOVERLV5 is a free data retrieval call binding the contract method 0xc38c16bf.
+//
+// Solidity: function REWARD_DEPOSIT_OVER_LV5() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) REWARDDEPOSITOVERLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "REWARD_DEPOSIT_OVER_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITUNDERLV1 is a free data retrieval call binding the contract method 0xa0c6211a.
+//
+// Solidity: function REWARD_DEPOSIT_UNDER_LV1() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) REWARDDEPOSITUNDERLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "REWARD_DEPOSIT_UNDER_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SAFEMULTIPLIER is a free data retrieval call binding the contract method 0xdc7e0ce8.
+//
+// Solidity: function SAFE_MULTIPLIER() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) SAFEMULTIPLIER(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "SAFE_MULTIPLIER")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV1 is a free data retrieval call binding the contract method 0xfec11efe.
+//
+// Solidity: function TOTAL_DEPOSIT_LV1() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) TOTALDEPOSITLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "TOTAL_DEPOSIT_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV2 is a free data retrieval call binding the contract method 0xd1861c31.
+//
+// Solidity: function TOTAL_DEPOSIT_LV2() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) TOTALDEPOSITLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "TOTAL_DEPOSIT_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV3 is a free data retrieval call binding the contract method 0x2ee7655e.
+//
+// Solidity: function TOTAL_DEPOSIT_LV3() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) TOTALDEPOSITLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "TOTAL_DEPOSIT_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV4 is a free data retrieval call binding the contract method 0x1d78ef9f.
+//
+// Solidity: function TOTAL_DEPOSIT_LV4() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) TOTALDEPOSITLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "TOTAL_DEPOSIT_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV5 is a free data retrieval call binding the contract method 0x4a1ecf21.
+//
+// Solidity: function TOTAL_DEPOSIT_LV5() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) TOTALDEPOSITLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "TOTAL_DEPOSIT_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VALIDATORREWARDLOCKEPOCHS is a free data retrieval call binding the contract method 0x25442055.
+//
+// Solidity: function VALIDATOR_REWARD_LOCK_EPOCHS() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) VALIDATORREWARDLOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "VALIDATOR_REWARD_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VALIDATORUNSTAKELOCKEPOCHS is a free data retrieval call binding the contract method 0x4b318db8.
+//
+// Solidity: function VALIDATOR_UNSTAKE_LOCK_EPOCHS() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) VALIDATORUNSTAKELOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "VALIDATOR_UNSTAKE_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VOTECANCELEPOCHS is a free data retrieval call binding the contract method 0x19e52a62.
+//
+// Solidity: function VOTE_CANCEL_EPOCHS() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) VOTECANCELEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "VOTE_CANCEL_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AddressProposalCount is a free data retrieval call binding the contract method 0x8e391b8b.
+//
+// Solidity: function addressProposalCount(address val) view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) AddressProposalCount(ctx *vmcaller.CallContext, val common.Address) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "addressProposalCount", val)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AddressProposalSets is a free data retrieval call binding the contract method 0x399cddf5.
+//
+// Solidity: function addressProposalSets(address val, uint256 page, uint256 size) view returns(bytes4[])
+func (_ValidatorProposals *ValidatorProposalsCaller) AddressProposalSets(ctx *vmcaller.CallContext, val common.Address, page *big.Int, size *big.Int) ([][4]byte, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "addressProposalSets", val, page, size)
+
+	if err != nil {
+		return *new([][4]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([][4]byte)).(*[][4]byte)
+
+	return out0, err
+
+}
+
+// AddressProposals is a free data retrieval call binding the contract method 0xd6b69942.
+//
+// Solidity: function addressProposals(address val, uint256 page, uint256 size) view returns((bytes4,address,uint8,uint256,uint8,string,string,uint256,address,uint256,uint8)[])
+func (_ValidatorProposals *ValidatorProposalsCaller) AddressProposals(ctx *vmcaller.CallContext, val common.Address, page *big.Int, size *big.Int) ([]ProposalsProposalInfo, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "addressProposals", val, page, size)
+
+	if err != nil {
+		return *new([]ProposalsProposalInfo), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]ProposalsProposalInfo)).(*[]ProposalsProposalInfo)
+
+	return out0, err
+
+}
+
+// AllProposalSets is a free data retrieval call binding the contract method 0xe722ec2e.
+//
+// Solidity: function allProposalSets(uint256 page, uint256 size) view returns(bytes4[])
+func (_ValidatorProposals *ValidatorProposalsCaller) AllProposalSets(ctx *vmcaller.CallContext, page *big.Int, size *big.Int) ([][4]byte, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "allProposalSets", page, size)
+
+	if err != nil {
+		return *new([][4]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([][4]byte)).(*[][4]byte)
+
+	return out0, err
+
+}
+
+// AllProposals is a free data retrieval call binding the contract method 0xc0a93d59.
+//
+// Solidity: function allProposals(uint256 page, uint256 size) view returns((bytes4,address,uint8,uint256,uint8,string,string,uint256,address,uint256,uint8)[])
+func (_ValidatorProposals *ValidatorProposalsCaller) AllProposals(ctx *vmcaller.CallContext, page *big.Int, size *big.Int) ([]ProposalsProposalInfo, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "allProposals", page, size)
+
+	if err != nil {
+		return *new([]ProposalsProposalInfo), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]ProposalsProposalInfo)).(*[]ProposalsProposalInfo)
+
+	return out0, err
+
+}
+
+// CurrentEpoch is a free data retrieval call binding the contract method 0x76671808.
+//
+// Solidity: function currentEpoch() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) CurrentEpoch(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "currentEpoch")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ProposalCount is a free data retrieval call binding the contract method 0xda35c664.
+//
+// Solidity: function proposalCount() view returns(uint256)
+func (_ValidatorProposals *ValidatorProposalsCaller) ProposalCount(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "proposalCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ProposalInfos is a free data retrieval call binding the contract method 0x8760c693.
+//
+// Solidity: function proposalInfos(bytes4 ) view returns(bytes4 id, address proposer, uint8 pType, uint256 deposit, uint8 rate, string name, string details, uint256 initBlock, address guarantee, uint256 updateBlock, uint8 status)
+func (_ValidatorProposals *ValidatorProposalsCaller) ProposalInfos(ctx *vmcaller.CallContext, arg0 [4]byte) (struct {
+	Id          [4]byte
+	Proposer    common.Address
+	PType       uint8
+	Deposit     *big.Int
+	Rate        uint8
+	Name        string
+	Details     string
+	InitBlock   *big.Int
+	Guarantee   common.Address
+	UpdateBlock *big.Int
+	Status      uint8
+}, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "proposalInfos", arg0)
+
+	outstruct := new(struct {
+		Id          [4]byte
+		Proposer    common.Address
+		PType       uint8
+		Deposit     *big.Int
+		Rate        uint8
+		Name        string
+		Details     string
+		InitBlock   *big.Int
+		Guarantee   common.Address
+		UpdateBlock *big.Int
+		Status      uint8
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Id = *abi.ConvertType(out[0], new([4]byte)).(*[4]byte)
+	outstruct.Proposer = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.PType = *abi.ConvertType(out[2], new(uint8)).(*uint8)
+	outstruct.Deposit = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.Rate = *abi.ConvertType(out[4], new(uint8)).(*uint8)
+	outstruct.Name = *abi.ConvertType(out[5], new(string)).(*string)
+	outstruct.Details = *abi.ConvertType(out[6], new(string)).(*string)
+	outstruct.InitBlock = *abi.ConvertType(out[7], new(*big.Int)).(**big.Int)
+	outstruct.Guarantee = *abi.ConvertType(out[8], new(common.Address)).(*common.Address)
+	outstruct.UpdateBlock = *abi.ConvertType(out[9], new(*big.Int)).(**big.Int)
+	outstruct.Status = *abi.ConvertType(out[10], new(uint8)).(*uint8)
+
+	return *outstruct, err
+
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x8e23a2f5.
+//
+// Solidity: function proposals(address , uint256 ) view returns(bytes4)
+func (_ValidatorProposals *ValidatorProposalsCaller) Proposals(ctx *vmcaller.CallContext, arg0 common.Address, arg1 *big.Int) ([4]byte, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "proposals", arg0, arg1)
+
+	if err != nil {
+		return *new([4]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([4]byte)).(*[4]byte)
+
+	return out0, err
+
+}
+
+// Validators is a free data retrieval call binding the contract method 0xca1e7819.
+//
+// Solidity: function validators() view returns(address)
+func (_ValidatorProposals *ValidatorProposalsCaller) Validators(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_ValidatorProposals.abi, _ValidatorProposals.address, "validators")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
@@ -0,0 +1,933 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package systemcontract
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = common.Big1
+)
+
+// ValidatorsValidator is an auto generated low-level Go binding around an user-defined struct.
+type ValidatorsValidator struct {
+	Status                  uint8
+	Deposit                 *big.Int
+	Rate                    uint8
+	Name                    string
+	Details                 string
+	Votes                   *big.Int
+	UnstakeLockingEndBlock  *big.Int
+	RateSettLockingEndBlock *big.Int
+}
+
+// ValidatorsABI is the input ABI used to generate the binding from.
+const ValidatorsABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"_deposit\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"_rate\",\"type\":\"uint256\"}],\"name\":\"LogAddValidator\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"LogRedeemValidator\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"LogRestoreValidator\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"LogUnstakeValidator\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"_deposit\",\"type\":\"uint256\"}],\"name\":\"LogUpdateValidatorDeposit\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint8\",\"name\":\"_preRate\",\"type\":\"uint8\"},{\"indexed\":false,\"internalType\":\"uint8\",\"name\":\"_rate\",\"type\":\"uint8\"}],\"name\":\"LogUpdateValidatorRate\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"BLACK_HOLE_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"BLOCK_SECONDS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"CancelQueueValidatorsLength\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"EPOCH_BLOCKS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_PUNISH_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_RATE\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATORS_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_COUNT_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_DETAIL_LENGTH\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MAX_VALIDATOR_NAME_LENGTH\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MEDIUM_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_DEPOSIT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_LEVEL_VALIDATOR_COUNT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MIN_RATE\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"PROPOSAL_DURATION_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"RATE_SET_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV1_TO_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV2_TO_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV3_TO_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_FROM_LV4_TO_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_OVER_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"REWARD_DEPOSIT_UNDER_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"SAFE_MULTIPLIER\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV2\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV3\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV4\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOTAL_DEPOSIT_LV5\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VALIDATOR_REWARD_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VALIDATOR_UNSTAKE_LOCK_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VOTE_CANCEL_EPOCHS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_deposit\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"_rate\",\"type\":\"uint8\"},{\"internalType\":\"string\",\"name\":\"_name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"_details\",\"type\":\"string\"}],\"name\":\"addValidatorFromProposal\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_vals\",\"type\":\"address[]\"}],\"name\":\"batchValidators\",\"outputs\":[{\"components\":[{\"internalType\":\"enumValidators.ValidatorStatus\",\"name\":\"status\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"rate\",\"type\":\"uint8\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"details\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"votes\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unstakeLockingEndBlock\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"rateSettLockingEndBlock\",\"type\":\"uint256\"}],\"internalType\":\"structValidators.Validator[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_voter\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_votes\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"_clear\",\"type\":\"bool\"}],\"name\":\"cancelVoteValidator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"curEpochValidators\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"curEpochValidatorsIdMap\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"currentEpoch\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"effictiveValsLength\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getCancelQueueValidators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getCurEpochValidators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getEffictiveValidators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"page\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"}],\"name\":\"getEffictiveValidatorsWithPage\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getInvalidValidators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"page\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"}],\"name\":\"getInvalidValidatorsWithPage\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"page\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"}],\"name\":\"getValidatorVoters\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_proposal\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_sysReward\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_nodeVote\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_initVal\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_initDeposit\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"_initRate\",\"type\":\"uint8\"},{\"internalType\":\"string\",\"name\":\"_name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"_details\",\"type\":\"string\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"invalidValsLength\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"isEffictiveValidator\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"kickoutValidator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nodeVote\",\"outputs\":[{\"internalType\":\"contractINodeVote\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"proposals\",\"outputs\":[{\"internalType\":\"contractIProposals\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"redeem\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"restore\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"sysRewards\",\"outputs\":[{\"internalType\":\"contractISystemRewards\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalDeposit\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"tryElect\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unstake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_deposit\",\"type\":\"uint256\"}],\"name\":\"updateValidatorDeposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"_details\",\"type\":\"string\"}],\"name\":\"updateValidatorNameDetails\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"_rate\",\"type\":\"uint8\"}],\"name\":\"updateValidatorRate\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"validatorVotersLength\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"validators\",\"outputs\":[{\"components\":[{\"internalType\":\"enumValidators.ValidatorStatus\",\"name\":\"status\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"rate\",\"type\":\"uint8\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"details\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"votes\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unstakeLockingEndBlock\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"rateSettLockingEndBlock\",\"type\":\"uint256\"}],\"internalType\":\"structValidators.Validator\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_voter\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_votes\",\"type\":\"uint256\"}],\"name\":\"voteValidator\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]"
+
+// ValidatorsCaller is an auto generated read-only Go binding around a system contract,
+// executing calls directly against the state of a block.
+type ValidatorsCaller struct {
+	abi     abi.ABI        // Parsed contract ABI to pack calls and unpack results with
+	address common.Address // Address the contract is deployed at
+}
+
+// NewValidatorsCaller creates a new read-only instance of Validators, bound to a specific deployed contract.
+func NewValidatorsCaller(address common.Address) (*ValidatorsCaller, error) {
+	parsed, err := abi.JSON(strings.NewReader(ValidatorsABI))
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorsCaller{abi: parsed, address: address}, nil
+}
+
+// BLACKHOLEADDRESS is a free data retrieval call binding the contract method 0x3cdfef01.
+//
+// Solidity: function BLACK_HOLE_ADDRESS() view returns(address)
+func (_Validators *ValidatorsCaller) BLACKHOLEADDRESS(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "BLACK_HOLE_ADDRESS")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// BLOCKSECONDS is a free data retrieval call binding the contract method 0x583284ed.
+//
+// Solidity: function BLOCK_SECONDS() view returns(uint256)
+func (_Validators *ValidatorsCaller) BLOCKSECONDS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "BLOCK_SECONDS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CancelQueueValidatorsLength is a free data retrieval call binding the contract method 0xe2f3cc36.
+//
+// Solidity: function CancelQueueValidatorsLength() view returns(uint256)
+func (_Validators *ValidatorsCaller) CancelQueueValidatorsLength(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "CancelQueueValidatorsLength")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// EPOCHBLOCKS is a free data retrieval call binding the contract method 0x74c259c6.
+//
+// Solidity: function EPOCH_BLOCKS() view returns(uint256)
+func (_Validators *ValidatorsCaller) EPOCHBLOCKS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "EPOCH_BLOCKS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0xc3f5b2bd.
+//
+// Solidity: function MAX_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_Validators *ValidatorsCaller) MAXLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MAX_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXPUNISHCOUNT is a free data retrieval call binding the contract method 0xd4c28809.
+//
+// Solidity: function MAX_PUNISH_COUNT() view returns(uint256)
+func (_Validators *ValidatorsCaller) MAXPUNISHCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MAX_PUNISH_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXRATE is a free data retrieval call binding the contract method 0xc24dbebd.
+//
+// Solidity: function MAX_RATE() view returns(uint8)
+func (_Validators *ValidatorsCaller) MAXRATE(ctx *vmcaller.CallContext) (uint8, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MAX_RATE")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORSCOUNT is a free data retrieval call binding the contract method 0x632c93a0.
+//
+// Solidity: function MAX_VALIDATORS_COUNT() view returns(uint256)
+func (_Validators *ValidatorsCaller) MAXVALIDATORSCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MAX_VALIDATORS_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV1 is a free data retrieval call binding the contract method 0x616f8601.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV1() view returns(uint256)
+func (_Validators *ValidatorsCaller) MAXVALIDATORCOUNTLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MAX_VALIDATOR_COUNT_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV2 is a free data retrieval call binding the contract method 0x57477c42.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV2() view returns(uint256)
+func (_Validators *ValidatorsCaller) MAXVALIDATORCOUNTLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MAX_VALIDATOR_COUNT_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV3 is a free data retrieval call binding the contract method 0x17d69d83.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV3() view returns(uint256)
+func (_Validators *ValidatorsCaller) MAXVALIDATORCOUNTLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MAX_VALIDATOR_COUNT_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORCOUNTLV4 is a free data retrieval call binding the contract method 0x6af7cd57.
+//
+// Solidity: function MAX_VALIDATOR_COUNT_LV4() view returns(uint256)
+func (_Validators *ValidatorsCaller) MAXVALIDATORCOUNTLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MAX_VALIDATOR_COUNT_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORDETAILLENGTH is a free data retrieval call binding the contract method 0xd9cfcb5d.
+//
+// Solidity: function MAX_VALIDATOR_DETAIL_LENGTH() view returns(uint256)
+func (_Validators *ValidatorsCaller) MAXVALIDATORDETAILLENGTH(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MAX_VALIDATOR_DETAIL_LENGTH")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXVALIDATORNAMELENGTH is a free data retrieval call binding the contract method 0xb44e55ea.
+//
+// Solidity: function MAX_VALIDATOR_NAME_LENGTH() view returns(uint256)
+func (_Validators *ValidatorsCaller) MAXVALIDATORNAMELENGTH(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MAX_VALIDATOR_NAME_LENGTH")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MEDIUMLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0x049f8269.
+//
+// Solidity: function MEDIUM_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_Validators *ValidatorsCaller) MEDIUMLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MEDIUM_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINDEPOSIT is a free data retrieval call binding the contract method 0xe1e158a5.
+//
+// Solidity: function MIN_DEPOSIT() view returns(uint256)
+func (_Validators *ValidatorsCaller) MINDEPOSIT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MIN_DEPOSIT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINLEVELVALIDATORCOUNT is a free data retrieval call binding the contract method 0x18e0d5cf.
+//
+// Solidity: function MIN_LEVEL_VALIDATOR_COUNT() view returns(uint256)
+func (_Validators *ValidatorsCaller) MINLEVELVALIDATORCOUNT(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MIN_LEVEL_VALIDATOR_COUNT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MINRATE is a free data retrieval call binding the contract method 0xd819bfef.
+//
+// Solidity: function MIN_RATE() view returns(uint8)
+func (_Validators *ValidatorsCaller) MINRATE(ctx *vmcaller.CallContext) (uint8, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "MIN_RATE")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// PROPOSALDURATIONEPOCHS is a free data retrieval call binding the contract method 0xbff6091b.
+//
+// Solidity: function PROPOSAL_DURATION_EPOCHS() view returns(uint256)
+func (_Validators *ValidatorsCaller) PROPOSALDURATIONEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "PROPOSAL_DURATION_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RATESETLOCKEPOCHS is a free data retrieval call binding the contract method 0x7b8fe9e8.
+//
+// Solidity: function RATE_SET_LOCK_EPOCHS() view returns(uint256)
+func (_Validators *ValidatorsCaller) RATESETLOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "RATE_SET_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV1TOLV2 is a free data retrieval call binding the contract method 0xf8f4fb0a.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV1_TO_LV2() view returns(uint256)
+func (_Validators *ValidatorsCaller) REWARDDEPOSITFROMLV1TOLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "REWARD_DEPOSIT_FROM_LV1_TO_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV2TOLV3 is a free data retrieval call binding the contract method 0x02d2b177.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV2_TO_LV3() view returns(uint256)
+func (_Validators *ValidatorsCaller) REWARDDEPOSITFROMLV2TOLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "REWARD_DEPOSIT_FROM_LV2_TO_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV3TOLV4 is a free data retrieval call binding the contract method 0x7e02733c.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV3_TO_LV4() view returns(uint256)
+func (_Validators *ValidatorsCaller) REWARDDEPOSITFROMLV3TOLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "REWARD_DEPOSIT_FROM_LV3_TO_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITFROMLV4TOLV5 is a free data retrieval call binding the contract method 0x0b4d69a4.
+//
+// Solidity: function REWARD_DEPOSIT_FROM_LV4_TO_LV5() view returns(uint256)
+func (_Validators *ValidatorsCaller) REWARDDEPOSITFROMLV4TOLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "REWARD_DEPOSIT_FROM_LV4_TO_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITOVERLV5 is a free data retrieval call binding the contract method 0xc38c16bf.
+//
+// Solidity: function REWARD_DEPOSIT_OVER_LV5() view returns(uint256)
+func (_Validators *ValidatorsCaller) REWARDDEPOSITOVERLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "REWARD_DEPOSIT_OVER_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// REWARDDEPOSITUNDERLV1 is a free data retrieval call binding the contract method 0xa0c6211a.
+//
+// Solidity: function REWARD_DEPOSIT_UNDER_LV1() view returns(uint256)
+func (_Validators *ValidatorsCaller) REWARDDEPOSITUNDERLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "REWARD_DEPOSIT_UNDER_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SAFEMULTIPLIER is a free data retrieval call binding the contract method 0xdc7e0ce8.
+//
+// Solidity: function SAFE_MULTIPLIER() view returns(uint256)
+func (_Validators *ValidatorsCaller) SAFEMULTIPLIER(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "SAFE_MULTIPLIER")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV1 is a free data retrieval call binding the contract method 0xfec11efe.
+//
+// Solidity: function TOTAL_DEPOSIT_LV1() view returns(uint256)
+func (_Validators *ValidatorsCaller) TOTALDEPOSITLV1(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "TOTAL_DEPOSIT_LV1")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV2 is a free data retrieval call binding the contract method 0xd1861c31.
+//
+// Solidity: function TOTAL_DEPOSIT_LV2() view returns(uint256)
+func (_Validators *ValidatorsCaller) TOTALDEPOSITLV2(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "TOTAL_DEPOSIT_LV2")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV3 is a free data retrieval call binding the contract method 0x2ee7655e.
+//
+// Solidity: function TOTAL_DEPOSIT_LV3() view returns(uint256)
+func (_Validators *ValidatorsCaller) TOTALDEPOSITLV3(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "TOTAL_DEPOSIT_LV3")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV4 is a free data retrieval call binding the contract method 0x1d78ef9f.
+//
+// Solidity: function TOTAL_DEPOSIT_LV4() view returns(uint256)
+func (_Validators *ValidatorsCaller) TOTALDEPOSITLV4(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "TOTAL_DEPOSIT_LV4")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TOTALDEPOSITLV5 is a free data retrieval call binding the contract method 0x4a1ecf21.
+//
+// Solidity: function TOTAL_DEPOSIT_LV5() view returns(uint256)
+func (_Validators *ValidatorsCaller) TOTALDEPOSITLV5(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "TOTAL_DEPOSIT_LV5")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VALIDATORREWARDLOCKEPOCHS is a free data retrieval call binding the contract method 0x25442055.
+//
+// Solidity: function VALIDATOR_REWARD_LOCK_EPOCHS() view returns(uint256)
+func (_Validators *ValidatorsCaller) VALIDATORREWARDLOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "VALIDATOR_REWARD_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VALIDATORUNSTAKELOCKEPOCHS is a free data retrieval call binding the contract method 0x4b318db8.
+//
+// Solidity: function VALIDATOR_UNSTAKE_LOCK_EPOCHS() view returns(uint256)
+func (_Validators *ValidatorsCaller) VALIDATORUNSTAKELOCKEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "VALIDATOR_UNSTAKE_LOCK_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VOTECANCELEPOCHS is a free data retrieval call binding the contract method 0x19e52a62.
+//
+// Solidity: function VOTE_CANCEL_EPOCHS() view returns(uint256)
+func (_Validators *ValidatorsCaller) VOTECANCELEPOCHS(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "VOTE_CANCEL_EPOCHS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BatchValidators is a free data retrieval call binding the contract method 0xd62fe58e.
+//
+// Solidity: function batchValidators(address[] _vals) view returns((uint8,uint256,uint8,string,string,uint256,uint256,uint256)[])
+func (_Validators *ValidatorsCaller) BatchValidators(ctx *vmcaller.CallContext, _vals []common.Address) ([]ValidatorsValidator, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "batchValidators", _vals)
+
+	if err != nil {
+		return *new([]ValidatorsValidator), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]ValidatorsValidator)).(*[]ValidatorsValidator)
+
+	return out0, err
+
+}
+
+// CurEpochValidators is a free data retrieval call binding the contract method 0x4ebe2099.
+//
+// Solidity: function curEpochValidators(uint256 ) view returns(address)
+func (_Validators *ValidatorsCaller) CurEpochValidators(ctx *vmcaller.CallContext, arg0 *big.Int) (common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "curEpochValidators", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// CurEpochValidatorsIdMap is a free data retrieval call binding the contract method 0xc7f76d9d.
+//
+// Solidity: function curEpochValidatorsIdMap(address ) view returns(uint256)
+func (_Validators *ValidatorsCaller) CurEpochValidatorsIdMap(ctx *vmcaller.CallContext, arg0 common.Address) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "curEpochValidatorsIdMap", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CurrentEpoch is a free data retrieval call binding the contract method 0x76671808.
+//
+// Solidity: function currentEpoch() view returns(uint256)
+func (_Validators *ValidatorsCaller) CurrentEpoch(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "currentEpoch")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// EffictiveValsLength is a free data retrieval call binding the contract method 0xe1c30854.
+//
+// Solidity: function effictiveValsLength() view returns(uint256)
+func (_Validators *ValidatorsCaller) EffictiveValsLength(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "effictiveValsLength")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetCancelQueueValidators is a free data retrieval call binding the contract method 0x25131d9e.
+//
+// Solidity: function getCancelQueueValidators() view returns(address[])
+func (_Validators *ValidatorsCaller) GetCancelQueueValidators(ctx *vmcaller.CallContext) ([]common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "getCancelQueueValidators")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetCurEpochValidators is a free data retrieval call binding the contract method 0x8ab7fe93.
+//
+// Solidity: function getCurEpochValidators() view returns(address[])
+func (_Validators *ValidatorsCaller) GetCurEpochValidators(ctx *vmcaller.CallContext) ([]common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "getCurEpochValidators")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetEffictiveValidators is a free data retrieval call binding the contract method 0xfd61a90d.
+//
+// Solidity: function getEffictiveValidators() view returns(address[])
+func (_Validators *ValidatorsCaller) GetEffictiveValidators(ctx *vmcaller.CallContext) ([]common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "getEffictiveValidators")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetEffictiveValidatorsWithPage is a free data retrieval call binding the contract method 0x30e8de06.
+//
+// Solidity: function getEffictiveValidatorsWithPage(uint256 page, uint256 size) view returns(address[])
+func (_Validators *ValidatorsCaller) GetEffictiveValidatorsWithPage(ctx *vmcaller.CallContext, page *big.Int, size *big.Int) ([]common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "getEffictiveValidatorsWithPage", page, size)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetInvalidValidators is a free data retrieval call binding the contract method 0x19c560b6.
+//
+// Solidity: function getInvalidValidators() view returns(address[])
+func (_Validators *ValidatorsCaller) GetInvalidValidators(ctx *vmcaller.CallContext) ([]common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "getInvalidValidators")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetInvalidValidatorsWithPage is a free data retrieval call binding the contract method 0xcca86995.
+//
+// Solidity: function getInvalidValidatorsWithPage(uint256 page, uint256 size) view returns(address[])
+func (_Validators *ValidatorsCaller) GetInvalidValidatorsWithPage(ctx *vmcaller.CallContext, page *big.Int, size *big.Int) ([]common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "getInvalidValidatorsWithPage", page, size)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetValidatorVoters is a free data retrieval call binding the contract method 0x5d9747ba.
+//
+// Solidity: function getValidatorVoters(address _val, uint256 page, uint256 size) view returns(address[])
+func (_Validators *ValidatorsCaller) GetValidatorVoters(ctx *vmcaller.CallContext, _val common.Address, page *big.Int, size *big.Int) ([]common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "getValidatorVoters", _val, page, size)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// InvalidValsLength is a free data retrieval call binding the contract method 0xb845a41c.
+//
+// Solidity: function invalidValsLength() view returns(uint256)
+func (_Validators *ValidatorsCaller) InvalidValsLength(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "invalidValsLength")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// IsEffictiveValidator is a free data retrieval call binding the contract method 0xb334a0cc.
+//
+// Solidity: function isEffictiveValidator(address addr) view returns(bool)
+func (_Validators *ValidatorsCaller) IsEffictiveValidator(ctx *vmcaller.CallContext, addr common.Address) (bool, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "isEffictiveValidator", addr)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// NodeVote is a free data retrieval call binding the contract method 0xf1dc8ff8.
+//
+// Solidity: function nodeVote() view returns(address)
+func (_Validators *ValidatorsCaller) NodeVote(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "nodeVote")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x55ef20e6.
+//
+// Solidity: function proposals() view returns(address)
+func (_Validators *ValidatorsCaller) Proposals(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "proposals")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// SysRewards is a free data retrieval call binding the contract method 0x2e897c5d.
+//
+// Solidity: function sysRewards() view returns(address)
+func (_Validators *ValidatorsCaller) SysRewards(ctx *vmcaller.CallContext) (common.Address, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "sysRewards")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// TotalDeposit is a free data retrieval call binding the contract method 0xf6153ccd.
+//
+// Solidity: function totalDeposit() view returns(uint256)
+func (_Validators *ValidatorsCaller) TotalDeposit(ctx *vmcaller.CallContext) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "totalDeposit")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ValidatorVotersLength is a free data retrieval call binding the contract method 0x22d752f4.
+//
+// Solidity: function validatorVotersLength(address _val) view returns(uint256)
+func (_Validators *ValidatorsCaller) ValidatorVotersLength(ctx *vmcaller.CallContext, _val common.Address) (*big.Int, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "validatorVotersLength", _val)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Validators is a free data retrieval call binding the contract method 0xfa52c7d8.
+//
+// Solidity: function validators(address _val) view returns((uint8,uint256,uint8,string,string,uint256,uint256,uint256))
+func (_Validators *ValidatorsCaller) Validators(ctx *vmcaller.CallContext, _val common.Address) (ValidatorsValidator, error) {
+	out, err := ctx.Call(_Validators.abi, _Validators.address, "validators", _val)
+
+	if err != nil {
+		return *new(ValidatorsValidator), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(ValidatorsValidator)).(*ValidatorsValidator)
+
+	return out0, err
+
+}
@@ -1,7 +1,6 @@
 package systemcontract
 
 import (
-	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
@@ -9,200 +8,78 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
 	"github.com/hypnosisfoundation/go-hypnosis/params"
-	"math"
 	"math/big"
 )
 
 type NodeVotes struct {
-	abi          abi.ABI
-	contractAddr common.Address
+	caller *NodeVotesCaller
 }
 
-type VotesRewardRedeemInfo struct {
-	Validator           common.Address
-	ValidatorName       string
-	ValidatorRate       uint8
-	ValidatorTotalVotes *big.Int
-	Amount              *big.Int
-	PendingReward       *big.Int
-	PendingRedeem       *big.Int
-	LockRedeemEpochs    []*big.Int
-	LockRedeemVotes     []*big.Int
-}
+// VotesRewardRedeemInfo struct `VotesRewardRedeemInfo` in NodeVotes contract
+type VotesRewardRedeemInfo = NodeVotesVotesRewardRedeemInfo
 
 // NewNodeVotes return Proposals contract instance
 func NewNodeVotes() *NodeVotes {
 	return &NodeVotes{
-		abi:          abiMap[NodeVotesContractName],
-		contractAddr: NodeVotesContractAddr,
+		caller: nodeVotesCaller,
 	}
 }
 
 // TotalVotes function totalVotes
 func (n *NodeVotes) TotalVotes(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (*big.Int, error) {
-	method := "totalVotes"
-	data, err := n.abi.Pack(method)
-
-	if err != nil {
-		log.Error("NodeVotes Pack error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &n.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("NodeVotes contract execute error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	ret, err := n.abi.Unpack(method, result)
+	votes, err := n.caller.TotalVotes(vmcaller.NewCallContext(statedb, header, chainContext, config))
 	if err != nil {
-		log.Error("NodeVotes contract Unpack error", "method", method, "error", err, "result", result)
-		return big.NewInt(0), err
-	}
-	value, ok := ret[0].(*big.Int)
-	if !ok {
-		log.Error("NodeVotes contract format result error", "method", method, "error", err)
+		log.Error("NodeVotes contract call error", "method", "totalVotes", "error", err)
 		return big.NewInt(0), err
 	}
-	return value, nil
+	return votes, nil
 }
 
 // PendingVoteReward function pendingReward
 func (n *NodeVotes) PendingVoteReward(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, val common.Address, voter common.Address) (*big.Int, error) {
-	method := "pendingReward"
-	data, err := n.abi.Pack(method, val, voter)
-
+	reward, err := n.caller.PendingReward(vmcaller.NewCallContext(statedb, header, chainContext, config), val, voter)
 	if err != nil {
-		log.Error("NodeVotes Pack error", "method", method, "error", err)
+		log.Error("NodeVotes contract call error", "method", "pendingReward", "error", err)
 		return big.NewInt(0), err
 	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &n.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("NodeVotes contract execute error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	ret, err := n.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("NodeVotes contract Unpack error", "method", method, "error", err, "result", result)
-		return big.NewInt(0), err
-	}
-	value, ok := ret[0].(*big.Int)
-	if !ok {
-		log.Error("NodeVotes contract format result error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-	return value, nil
+	return reward, nil
 }
 
 // PendingVoteRedeem function PendingRedeem
 func (n *NodeVotes) PendingVoteRedeem(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, val common.Address, voter common.Address) (*big.Int, error) {
-	method := "pendingRedeem"
-	data, err := n.abi.Pack(method, val, voter)
-
+	redeem, err := n.caller.PendingRedeem(vmcaller.NewCallContext(statedb, header, chainContext, config), val, voter)
 	if err != nil {
-		log.Error("NodeVotes Pack error", "method", method, "error", err)
+		log.Error("NodeVotes contract call error", "method", "pendingRedeem", "error", err)
 		return big.NewInt(0), err
 	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &n.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("NodeVotes contract execute error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	ret, err := n.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("NodeVotes contract Unpack error", "method", method, "error", err, "result", result)
-		return big.NewInt(0), err
-	}
-	value, ok := ret[0].(*big.Int)
-	if !ok {
-		log.Error("NodeVotes contract format result error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-	return value, nil
+	return redeem, nil
 }
 
 // VoteListLength function VoteListLength
 func (n *NodeVotes) VoteListLength(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, voter common.Address) (*big.Int, error) {
-	method := "voteListLength"
-	data, err := n.abi.Pack(method, voter)
-
-	if err != nil {
-		log.Error("NodeVotes Pack error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &n.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
+	length, err := n.caller.VoteListLength(vmcaller.NewCallContext(statedb, header, chainContext, config), voter)
 	if err != nil {
-		log.Error("NodeVotes contract execute error", "method", method, "error", err)
+		log.Error("NodeVotes contract call error", "method", "voteListLength", "error", err)
 		return big.NewInt(0), err
 	}
-
-	ret, err := n.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("NodeVotes contract Unpack error", "method", method, "error", err, "result", result)
-		return big.NewInt(0), err
-	}
-	count, ok := ret[0].(*big.Int)
-	if !ok {
-		log.Error("NodeVotes contract format result error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-	return count, nil
+	return length, nil
 }
 
 // VotesRewardRedeemInfo function votesRewardRedeemInfo
 func (n *NodeVotes) VotesRewardRedeemInfo(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, val common.Address, voter common.Address) (*VotesRewardRedeemInfo, error) {
-	method := "votesRewardRedeemInfo"
-	data, err := n.abi.Pack(method, val, voter)
-
+	rewardRedeemInfo, err := n.caller.VotesRewardRedeemInfo(vmcaller.NewCallContext(statedb, header, chainContext, config), val, voter)
 	if err != nil {
-		log.Error("NodeVotes Pack error", "method", method, "error", err)
+		log.Error("NodeVotes contract call error", "method", "votesRewardRedeemInfo", "error", err)
 		return &VotesRewardRedeemInfo{}, err
 	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &n.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("NodeVotes contract execute error", "method", method, "error", err)
-		return &VotesRewardRedeemInfo{}, err
-	}
-	rewardRedeemInfo := &VotesRewardRedeemInfo{}
-	err = n.abi.UnpackIntoInterface(rewardRedeemInfo, method, result)
-	if err != nil {
-		log.Error("NodeVotes contract Unpack error", "method", method, "error", err, "result", result)
-		return &VotesRewardRedeemInfo{}, err
-	}
-	return rewardRedeemInfo, nil
+	return &rewardRedeemInfo, nil
 }
 
 // VotesRewardRedeemInfoWithPage function votesRewardRedeemInfoWithPage
 func (n *NodeVotes) VotesRewardRedeemInfoWithPage(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, voter common.Address, page *big.Int, size *big.Int) ([]VotesRewardRedeemInfo, error) {
-	method := "votesRewardRedeemInfoWithPage"
-	data, err := n.abi.Pack(method, voter, page, size)
-
-	if err != nil {
-		log.Error("NodeVotes Pack error", "method", method, "error", err)
-		return []VotesRewardRedeemInfo{}, err
-	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &n.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("NodeVotes contract execute error", "method", method, "error", err)
-		return []VotesRewardRedeemInfo{}, err
-	}
-	var rewardRedeemInfos []VotesRewardRedeemInfo
-	err = n.abi.UnpackIntoInterface(&rewardRedeemInfos, method, result)
+	rewardRedeemInfos, err := n.caller.VotesRewardRedeemInfoWithPage(vmcaller.NewCallContext(statedb, header, chainContext, config), voter, page, size)
 	if err != nil {
-		log.Error("NodeVotes contract Unpack error", "method", method, "error", err, "result", result)
+		log.Error("NodeVotes contract call error", "method", "votesRewardRedeemInfoWithPage", "error", err)
 		return []VotesRewardRedeemInfo{}, err
 	}
 	return rewardRedeemInfos, nil
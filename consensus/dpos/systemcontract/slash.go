@@ -0,0 +1,204 @@
+package systemcontract
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+)
+
+// SystemRewardsSlashName is the name of the ABI of the slash entry point of the
+// SystemRewards contract.
+const SystemRewardsSlashName = "SystemRewardsSlash"
+
+// SystemRewardsSlashABI describes the slash entry point added to the SystemRewards
+// contract by the double-sign slashing fork.
+const SystemRewardsSlashABI = `[{"inputs":[{"internalType":"address","name":"_val","type":"address"}],"name":"slash","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}]`
+
+// SystemRewardsImplAddr keeps the original code of the SystemRewards contract once
+// the double-sign slashing fork puts the slash entry point in front of it.
+var SystemRewardsImplAddr = common.HexToAddress("0x0000000000000000000000000000000000fff009")
+
+const (
+	punishInfoSlot = 6     // Storage slot of the _punishInfo mapping of SystemRewards
+	epochBlocks    = 14400 // EPOCH_BLOCKS of the Base contract
+	maxPunishCount = 139   // MAX_PUNISH_COUNT of the Base contract
+)
+
+// SlashCode returns the code of the SystemRewards contract after the double-sign
+// slashing fork, which delegates every call to the original code at impl except
+// slash(address). Slashing raises the punish count of the validator in the current
+// epoch right below the next multiple of MAX_PUNISH_COUNT and punishes it, which
+// kicks it out and burns its reward of the epoch. Same as punish, only the miner
+// can slash, through a fee-less call.
+func SlashCode(impl common.Address) []byte {
+	var (
+		a      = newAssembler()
+		slash  = crypto.Keccak256([]byte("slash(address)"))[:4]
+		punish = crypto.Keccak256([]byte("punish(address)"))[:4]
+	)
+	// Dispatch slash(address), delegate anything else
+	a.push(4)
+	a.op(vm.CALLDATASIZE, vm.LT)
+	a.jump("delegate", vm.JUMPI)
+	a.push(0)
+	a.op(vm.CALLDATALOAD)
+	a.push(0xe0)
+	a.op(vm.SHR)
+	a.pushBytes(slash)
+	a.op(vm.EQ)
+	a.jump("slash", vm.JUMPI)
+
+	a.label("delegate")
+	a.op(vm.CALLDATASIZE)
+	a.push(0)
+	a.push(0)
+	a.op(vm.CALLDATACOPY)
+	a.push(0)
+	a.push(0)
+	a.op(vm.CALLDATASIZE)
+	a.push(0)
+	a.pushBytes(impl.Bytes())
+	a.op(vm.GAS, vm.DELEGATECALL)
+
+	// Forward the outcome of the delegated call
+	a.label("result")
+	a.op(vm.RETURNDATASIZE)
+	a.push(0)
+	a.push(0)
+	a.op(vm.RETURNDATACOPY)
+	a.jump("return", vm.JUMPI)
+	a.op(vm.RETURNDATASIZE)
+	a.push(0)
+	a.op(vm.REVERT)
+	a.label("return")
+	a.op(vm.RETURNDATASIZE)
+	a.push(0)
+	a.op(vm.RETURN)
+
+	// slash(address): onlyMiner, onlySystem, not payable
+	a.label("slash")
+	a.op(vm.CALLER, vm.COINBASE, vm.EQ, vm.ISZERO)
+	a.jump("revert", vm.JUMPI)
+	a.op(vm.GASPRICE)
+	a.jump("revert", vm.JUMPI)
+	a.op(vm.CALLVALUE)
+	a.jump("revert", vm.JUMPI)
+	a.push(0x24)
+	a.op(vm.CALLDATASIZE, vm.LT)
+	a.jump("revert", vm.JUMPI)
+
+	// slot = keccak256(currentEpoch() . keccak256(_val . punishInfoSlot))
+	a.push(4)
+	a.op(vm.CALLDATALOAD)
+	a.pushBytes(bytes.Repeat([]byte{0xff}, common.AddressLength))
+	a.op(vm.AND, vm.DUP1)
+	a.push(0)
+	a.op(vm.MSTORE)
+	a.push(punishInfoSlot)
+	a.push(0x20)
+	a.op(vm.MSTORE)
+	a.push(0x40)
+	a.push(0)
+	a.op(vm.SHA3)
+	a.push(0x20)
+	a.op(vm.MSTORE)
+	a.push(epochBlocks)
+	a.op(vm.NUMBER, vm.DIV)
+	a.push(0)
+	a.op(vm.MSTORE)
+	a.push(0x40)
+	a.push(0)
+	a.op(vm.SHA3)
+
+	// count += MAX_PUNISH_COUNT - 1 - count % MAX_PUNISH_COUNT
+	a.op(vm.DUP1, vm.SLOAD)
+	a.push(maxPunishCount)
+	a.op(vm.DUP2, vm.MOD)
+	a.push(maxPunishCount - 1)
+	a.op(vm.SUB, vm.ADD, vm.SWAP1, vm.SSTORE)
+
+	// punish(_val), leaving the validator on the stack as its argument
+	a.pushBytes(punish)
+	a.push(0xe0)
+	a.op(vm.SHL)
+	a.push(0)
+	a.op(vm.MSTORE)
+	a.push(4)
+	a.op(vm.MSTORE)
+	a.push(0)
+	a.push(0)
+	a.push(0x24)
+	a.push(0)
+	a.pushBytes(impl.Bytes())
+	a.op(vm.GAS, vm.DELEGATECALL)
+	a.jump("result", vm.JUMP)
+
+	a.label("revert")
+	a.push(0)
+	a.op(vm.DUP1, vm.REVERT)
+
+	return a.assemble()
+}
+
+// assembler builds EVM code whose jumps target labels.
+type assembler struct {
+	code   []byte
+	labels map[string]int
+	jumps  map[int]string // Offset of the PUSH2 operand of each jump -> target label
+}
+
+func newAssembler() *assembler {
+	return &assembler{labels: make(map[string]int), jumps: make(map[int]string)}
+}
+
+// op appends the opcodes.
+func (a *assembler) op(ops ...vm.OpCode) {
+	for _, op := range ops {
+		a.code = append(a.code, byte(op))
+	}
+}
+
+// push appends the smallest push of the value.
+func (a *assembler) push(value uint64) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, value)
+	for len(data) > 1 && data[0] == 0 {
+		data = data[1:]
+	}
+	a.pushBytes(data)
+}
+
+// pushBytes appends a push of the data, at most 32 bytes long.
+func (a *assembler) pushBytes(data []byte) {
+	a.op(vm.PUSH1 + vm.OpCode(len(data)-1))
+	a.code = append(a.code, data...)
+}
+
+// jump appends a JUMP or JUMPI to the label.
+func (a *assembler) jump(label string, op vm.OpCode) {
+	a.op(vm.PUSH2)
+	a.jumps[len(a.code)] = label
+	a.code = append(a.code, 0, 0)
+	a.op(op)
+}
+
+// label marks the next opcode as the target of the label.
+func (a *assembler) label(name string) {
+	a.labels[name] = len(a.code)
+	a.op(vm.JUMPDEST)
+}
+
+// assemble resolves the jumps and returns the code.
+func (a *assembler) assemble() []byte {
+	for offset, label := range a.jumps {
+		target, ok := a.labels[label]
+		if !ok {
+			panic("unknown label " + label)
+		}
+		binary.BigEndian.PutUint16(a.code[offset:], uint16(target))
+	}
+	return a.code
+}
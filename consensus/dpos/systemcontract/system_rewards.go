@@ -1,7 +1,6 @@
 package systemcontract
 
 import (
-	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
@@ -9,13 +8,11 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
 	"github.com/hypnosisfoundation/go-hypnosis/params"
-	"math"
 	"math/big"
 )
 
 type SystemRewards struct {
-	abi          abi.ABI
-	contractAddr common.Address
+	caller *SystemRewardsCaller
 }
 
 // EpochInfo struct `epoch` in SystemRewards contract
@@ -26,145 +23,55 @@ type EpochInfo struct {
 	EffictiveValCount *big.Int
 }
 
-type Reward struct {
-	ValidatorReward  *big.Int
-	DelegatorsReward *big.Int
-	Rate             uint8
-}
+type Reward = SystemRewardsReward
 
-type SysRewardsInfo struct {
-	Epochs            []*big.Int
-	ValidatorRewards  []*big.Int
-	DelegatorsRewards []*big.Int
-	Rates             []uint8
-	PendingReward     *big.Int
-	FrozenReward      *big.Int
-	RewardPerVote     *big.Int
-}
+type SysRewardsInfo = SystemRewardsSysRewardsInfo
 
-type Punish struct {
-	Count         *big.Int
-	PunishBlocks  []*big.Int
-	KickoutBlocks []*big.Int
-	BurnRewards   []*big.Int
-}
+type Punish = SystemRewardsPunish
 
 // NewSystemRewards return SystemRewards contract instance
 func NewSystemRewards() *SystemRewards {
 	return &SystemRewards{
-		abi:          abiMap[SystemRewardsContractName],
-		contractAddr: SystemRewardsContractAddr,
+		caller: systemRewardsCaller,
 	}
 }
 
 // GetEpochInfo return epoch info
 func (s *SystemRewards) GetEpochInfo(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, epoch *big.Int) (*EpochInfo, error) {
-	method := "epochs"
-
-	data, err := s.abi.Pack(method, epoch)
+	ret, err := s.caller.Epochs(vmcaller.NewCallContext(statedb, header, chainContext, config), epoch)
 	if err != nil {
-		log.Error("can't pack SystemRewards contract method", "method", method)
+		log.Error("SystemRewards contract call error", "method", "epochs", "error", err)
 		return &EpochInfo{}, err
 	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &s.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("SystemRewards contract execute error", "method", method, "error", err)
-		return &EpochInfo{}, err
-	}
-	epochInfo := &EpochInfo{}
-	err = s.abi.UnpackIntoInterface(epochInfo, method, result)
-	if err != nil {
-		log.Error("SystemRewards contract Unpack error", "method", method, "error", err, "result", result)
-		return &EpochInfo{}, err
-	}
-
-	return epochInfo, nil
+	epochInfo := EpochInfo(ret)
+	return &epochInfo, nil
 }
 
 // KickoutInfo return kickout addresses in epoch
 func (s *SystemRewards) KickoutInfo(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, epoch *big.Int) ([]common.Address, error) {
-	method := "kickoutInfo"
-
-	data, err := s.abi.Pack(method, epoch)
-	if err != nil {
-		log.Error("can't pack SystemRewards contract method", "method", method)
-		return []common.Address{}, err
-	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &s.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
+	vals, err := s.caller.KickoutInfo(vmcaller.NewCallContext(statedb, header, chainContext, config), epoch)
 	if err != nil {
-		log.Error("SystemRewards contract execute error", "method", method, "error", err)
+		log.Error("SystemRewards contract call error", "method", "kickoutInfo", "error", err)
 		return []common.Address{}, err
 	}
-
-	ret, err := s.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("SystemRewards contract Unpack error", "method", method, "error", err, "result", result)
-		return []common.Address{}, err
-	}
-
-	vals, ok := ret[0].([]common.Address)
-	if !ok {
-		log.Error("SystemRewards contract format result error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-
 	return vals, nil
 }
 
 // GetValRewardInfoByEpoch return the address and the epoch reward info
 func (s *SystemRewards) GetValRewardInfoByEpoch(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, addr common.Address, epoch *big.Int) (*Reward, error) {
-	method := "validatorEpochRewardInfo"
-
-	data, err := s.abi.Pack(method, addr, epoch)
+	reward, err := s.caller.ValidatorEpochRewardInfo(vmcaller.NewCallContext(statedb, header, chainContext, config), addr, epoch)
 	if err != nil {
-		log.Error("can't pack SystemRewards contract method", "method", method)
+		log.Error("SystemRewards contract call error", "method", "validatorEpochRewardInfo", "error", err)
 		return &Reward{}, err
 	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &s.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("SystemRewards contract execute error", "method", method, "error", err)
-		return &Reward{}, err
-	}
-	rewards := &Reward{}
-	err = s.abi.UnpackIntoInterface(rewards, method, result)
-	if err != nil {
-		log.Error("SystemRewards contract Unpack error", "method", method, "error", err, "result", result)
-		return &Reward{}, err
-	}
-
-	return rewards, nil
+	return &reward, nil
 }
 
 // PendingValidatorReward return the address reward
 func (s *SystemRewards) PendingValidatorReward(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, addr common.Address) (*big.Int, *big.Int, error) {
-	method := "pendingValidatorReward"
-	data, err := s.abi.Pack(method, addr)
-	if err != nil {
-		log.Error("can't pack SystemRewards contract method", "method", method)
-		return big.NewInt(0), big.NewInt(0), err
-	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &s.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("SystemRewards contract execute error", "method", method, "error", err)
-		return big.NewInt(0), big.NewInt(0), err
-	}
-	ret, err := s.abi.Unpack(method, result)
+	avaliable, frozen, err := s.caller.PendingValidatorReward(vmcaller.NewCallContext(statedb, header, chainContext, config), addr)
 	if err != nil {
-		log.Error("SystemRewards contract Unpack error", "method", method, "error", err, "result", result)
-		return big.NewInt(0), big.NewInt(0), err
-	}
-	avaliable, ok := ret[0].(*big.Int)
-	if !ok {
-		log.Error("SystemRewards contract format result error", "method", method, "error", err)
-		return big.NewInt(0), big.NewInt(0), err
-	}
-	frozen, ok := ret[1].(*big.Int)
-	if !ok {
-		log.Error("SystemRewards contract format result error", "method", method, "error", err)
+		log.Error("SystemRewards contract call error", "method", "pendingValidatorReward", "error", err)
 		return big.NewInt(0), big.NewInt(0), err
 	}
 	return avaliable, frozen, nil
@@ -172,51 +79,40 @@ func (s *SystemRewards) PendingValidatorReward(statedb *state.StateDB, header *t
 
 // ValidatorRewardsInfo return the address sys rewards
 func (s *SystemRewards) ValidatorRewardsInfo(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, addr common.Address) (*SysRewardsInfo, error) {
-	method := "validatorRewardsInfo"
-
-	data, err := s.abi.Pack(method, addr)
-	if err != nil {
-		log.Error("can't pack SystemRewards contract method", "method", method)
-		return &SysRewardsInfo{}, err
-	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &s.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
+	info, err := s.caller.ValidatorRewardsInfo(vmcaller.NewCallContext(statedb, header, chainContext, config), addr)
 	if err != nil {
-		log.Error("SystemRewards contract execute error", "method", method, "error", err)
+		log.Error("SystemRewards contract call error", "method", "validatorRewardsInfo", "error", err)
 		return &SysRewardsInfo{}, err
 	}
-	rewardInfo := &SysRewardsInfo{}
-	err = s.abi.UnpackIntoInterface(rewardInfo, method, result)
-	if err != nil {
-		log.Error("SystemRewards contract Unpack error", "method", method, "error", err, "result", result)
-		return &SysRewardsInfo{}, err
-	}
-
-	return rewardInfo, nil
+	return &info, nil
 }
 
 // PunishInfo punishInfo function of systemRewards contract
 func (s *SystemRewards) PunishInfo(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, addr common.Address, epoch *big.Int) (*Punish, error) {
-	method := "punishInfo"
-
-	data, err := s.abi.Pack(method, addr, epoch)
+	punish, err := s.caller.PunishInfo(vmcaller.NewCallContext(statedb, header, chainContext, config), addr, epoch)
 	if err != nil {
-		log.Error("can't pack SystemRewards contract method", "method", method)
+		log.Error("SystemRewards contract call error", "method", "punishInfo", "error", err)
 		return &Punish{}, err
 	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &s.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
+	return &punish, nil
+}
+
+// MaxPunishCount return the punish count after which a validator is kicked out
+func (s *SystemRewards) MaxPunishCount(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (*big.Int, error) {
+	count, err := s.caller.MAXPUNISHCOUNT(vmcaller.NewCallContext(statedb, header, chainContext, config))
 	if err != nil {
-		log.Error("SystemRewards contract execute error", "method", method, "error", err)
-		return &Punish{}, err
+		log.Error("SystemRewards contract call error", "method", "MAX_PUNISH_COUNT", "error", err)
+		return nil, err
 	}
-	punish := &Punish{}
-	err = s.abi.UnpackIntoInterface(punish, method, result)
-	log.Info("punish", "rst", punish)
+	return count, nil
+}
+
+// CurrentEpoch return the epoch of the block
+func (s *SystemRewards) CurrentEpoch(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (*big.Int, error) {
+	epoch, err := s.caller.CurrentEpoch(vmcaller.NewCallContext(statedb, header, chainContext, config))
 	if err != nil {
-		log.Error("SystemRewards contract Unpack error", "method", method, "error", err, "result", result)
-		return &Punish{}, err
+		log.Error("SystemRewards contract call error", "method", "currentEpoch", "error", err)
+		return nil, err
 	}
-
-	return punish, nil
+	return epoch, nil
 }
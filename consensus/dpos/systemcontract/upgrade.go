@@ -0,0 +1,86 @@
+package systemcontract
+
+import (
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"math/big"
+)
+
+// ContractUpgradeFork is the name of the fork activated by ChainConfig.ContractUpgradeBlock.
+const ContractUpgradeFork = "contractUpgrade"
+
+// Upgrade replaces the code of a system contract at a hard fork. The storage of the
+// contract is kept, and an optional migration call can adapt it to the new code.
+type Upgrade struct {
+	Name         string
+	ContractAddr common.Address
+	Code         []byte                 // Runtime bytecode replacing the deployed one
+	Migration    func() ([]byte, error) // Packs the call data of the migration, nil if there is none
+}
+
+// upgradeFork links a fork of the upgrade registry to its block in the chain config.
+type upgradeFork struct {
+	name  string
+	block func(config *params.ChainConfig) *big.Int
+}
+
+// upgradeForks are the forks upgrading system contracts, in the order their upgrades
+// are applied if several of them are activated at the same block.
+var upgradeForks = []upgradeFork{
+	{ContractUpgradeFork, func(config *params.ChainConfig) *big.Int { return config.ContractUpgradeBlock }},
+}
+
+// chainUpgrades are the system contract upgrades shipped for the known networks,
+// indexed by chain id and by the fork they are shipped in.
+var chainUpgrades = map[uint64]map[string][]Upgrade{}
+
+// UpgradeRegistry is the set of system contract upgrades of a chain config, resolved
+// to the blocks activating them. A registry can't be modified once created.
+type UpgradeRegistry struct {
+	blocks   []*big.Int  // Activation block of each upgrading fork, in upgradeForks order
+	upgrades [][]Upgrade // Upgrades of each fork, applied in order
+}
+
+// NewUpgradeRegistry creates the registry of the given upgrades, indexed by the fork
+// they are shipped in and activated at the fork blocks of the config.
+func NewUpgradeRegistry(config *params.ChainConfig, upgrades map[string][]Upgrade) *UpgradeRegistry {
+	registry := new(UpgradeRegistry)
+	for _, fork := range upgradeForks {
+		block := fork.block(config)
+		if block == nil || len(upgrades[fork.name]) == 0 {
+			continue
+		}
+		copied := make([]Upgrade, len(upgrades[fork.name]))
+		for i, upgrade := range upgrades[fork.name] {
+			copied[i] = upgrade
+			copied[i].Code = common.CopyBytes(upgrade.Code)
+		}
+		registry.blocks = append(registry.blocks, new(big.Int).Set(block))
+		registry.upgrades = append(registry.upgrades, copied)
+	}
+	return registry
+}
+
+// ChainUpgrades returns the registry of the upgrades shipped for the chain of the config.
+func ChainUpgrades(config *params.ChainConfig) *UpgradeRegistry {
+	var upgrades map[string][]Upgrade
+	if config.ChainID != nil && config.ChainID.IsUint64() {
+		upgrades = chainUpgrades[config.ChainID.Uint64()]
+	}
+	return NewUpgradeRegistry(config, upgrades)
+}
+
+// UpgradesAt returns the system contract upgrades activated exactly at the given block.
+// The genesis block can't be upgraded, the code of its contracts comes from the alloc.
+func (r *UpgradeRegistry) UpgradesAt(number *big.Int) []Upgrade {
+	if number.Sign() <= 0 {
+		return nil
+	}
+	var upgrades []Upgrade
+	for i, block := range r.blocks {
+		if block.Cmp(number) == 0 {
+			upgrades = append(upgrades, r.upgrades[i]...)
+		}
+	}
+	return upgrades
+}
@@ -1,7 +1,6 @@
 package systemcontract
 
 import (
-	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/vmcaller"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
@@ -9,382 +8,137 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
 	"github.com/hypnosisfoundation/go-hypnosis/params"
-	"math"
 	"math/big"
 )
 
 type Validators struct {
-	abi          abi.ABI
-	contractAddr common.Address
+	caller *ValidatorsCaller
 }
 
 func NewValidators() *Validators {
 	return &Validators{
-		abi:          abiMap[ValidatorsContractName],
-		contractAddr: ValidatorsContractAddr,
+		caller: validatorsCaller,
 	}
 }
 
-type Validator struct {
-	Status                  uint8
-	Deposit                 *big.Int
-	Rate                    uint8
-	Name                    string
-	Details                 string
-	Votes                   *big.Int
-	UnstakeLockingEndBlock  *big.Int
-	RateSettLockingEndBlock *big.Int
-}
+// Validator struct `Validator` in Validators contract
+type Validator = ValidatorsValidator
 
 func (v *Validators) GetCurrentEpochValidators(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) ([]common.Address, error) {
-	method := "getCurEpochValidators"
-	data, err := v.abi.Pack(method)
+	vals, err := v.caller.GetCurEpochValidators(vmcaller.NewCallContext(statedb, header, chainContext, config))
 	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
+		log.Error("Validators contract call error", "method", "getCurEpochValidators", "error", err)
 		return []common.Address{}, err
 	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-
-	ret, err := v.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
-		return []common.Address{}, err
-	}
-
-	vals, ok := ret[0].([]common.Address)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-
 	return vals, nil
 }
 
+// GetEffictiveValidatorsWithPage get effictive validators
 func (v *Validators) GetEffictiveValidatorsWithPage(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, page *big.Int, size *big.Int) ([]common.Address, error) {
-	method := "getEffictiveValidatorsWithPage"
-	data, err := v.abi.Pack(method, page, size)
-	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
+	vals, err := v.caller.GetEffictiveValidatorsWithPage(vmcaller.NewCallContext(statedb, header, chainContext, config), page, size)
 	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
+		log.Error("Validators contract call error", "method", "getEffictiveValidatorsWithPage", "error", err)
 		return []common.Address{}, err
 	}
-
-	ret, err := v.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
-		return []common.Address{}, err
-	}
-	vals, ok := ret[0].([]common.Address)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-
 	return vals, nil
 }
 
 // GetValidatorVoters get the address voters
 func (v *Validators) GetValidatorVoters(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, addr common.Address, page *big.Int, size *big.Int) ([]common.Address, error) {
-	method := "getValidatorVoters"
-	data, err := v.abi.Pack(method, addr, page, size)
-	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
+	voters, err := v.caller.GetValidatorVoters(vmcaller.NewCallContext(statedb, header, chainContext, config), addr, page, size)
 	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
+		log.Error("Validators contract call error", "method", "getValidatorVoters", "error", err)
 		return []common.Address{}, err
 	}
-
-	ret, err := v.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
-		return []common.Address{}, err
-	}
-	voters, ok := ret[0].([]common.Address)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-
 	return voters, nil
 }
 
 // GetInvalidValidatorsWithPage get invalid validators
 func (v *Validators) GetInvalidValidatorsWithPage(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, page *big.Int, size *big.Int) ([]common.Address, error) {
-	method := "getInvalidValidatorsWithPage"
-	data, err := v.abi.Pack(method, page, size)
+	vals, err := v.caller.GetInvalidValidatorsWithPage(vmcaller.NewCallContext(statedb, header, chainContext, config), page, size)
 	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
+		log.Error("Validators contract call error", "method", "getInvalidValidatorsWithPage", "error", err)
 		return []common.Address{}, err
 	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-
-	ret, err := v.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
-		return []common.Address{}, err
-	}
-	vals, ok := ret[0].([]common.Address)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-
 	return vals, nil
 }
 
 // GetCancelQueueValidators get canceling queue validators
 func (v *Validators) GetCancelQueueValidators(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) ([]common.Address, error) {
-	method := "getCancelQueueValidators"
-	data, err := v.abi.Pack(method)
-	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-
-	ret, err := v.abi.Unpack(method, result)
+	vals, err := v.caller.GetCancelQueueValidators(vmcaller.NewCallContext(statedb, header, chainContext, config))
 	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
+		log.Error("Validators contract call error", "method", "getCancelQueueValidators", "error", err)
 		return []common.Address{}, err
 	}
-	vals, ok := ret[0].([]common.Address)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
-		return []common.Address{}, err
-	}
-
 	return vals, nil
 }
 
 // EffictiveValsLength function EffictiveValsLength
 func (v *Validators) EffictiveValsLength(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (*big.Int, error) {
-	method := "effictiveValsLength"
-	data, err := v.abi.Pack(method)
-
-	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
+	count, err := v.caller.EffictiveValsLength(vmcaller.NewCallContext(statedb, header, chainContext, config))
 	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	ret, err := v.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
-		return big.NewInt(0), err
-	}
-	count, ok := ret[0].(*big.Int)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
+		log.Error("Validators contract call error", "method", "effictiveValsLength", "error", err)
 		return big.NewInt(0), err
 	}
 	return count, nil
-
 }
 
 // InvalidValsLength function InvalidValsLength
 func (v *Validators) InvalidValsLength(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (*big.Int, error) {
-	method := "invalidValsLength"
-	data, err := v.abi.Pack(method)
-
+	count, err := v.caller.InvalidValsLength(vmcaller.NewCallContext(statedb, header, chainContext, config))
 	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	ret, err := v.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
-		return big.NewInt(0), err
-	}
-	count, ok := ret[0].(*big.Int)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
+		log.Error("Validators contract call error", "method", "invalidValsLength", "error", err)
 		return big.NewInt(0), err
 	}
 	return count, nil
-
 }
 
 // CancelQueueValidatorsLength function CancelQueueValidatorsLength
 func (v *Validators) CancelQueueValidatorsLength(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (*big.Int, error) {
-	method := "CancelQueueValidatorsLength"
-	data, err := v.abi.Pack(method)
-
-	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
+	count, err := v.caller.CancelQueueValidatorsLength(vmcaller.NewCallContext(statedb, header, chainContext, config))
 	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	ret, err := v.abi.Unpack(method, result)
-	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
-		return big.NewInt(0), err
-	}
-	count, ok := ret[0].(*big.Int)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
+		log.Error("Validators contract call error", "method", "CancelQueueValidatorsLength", "error", err)
 		return big.NewInt(0), err
 	}
 	return count, nil
-
 }
 
 // ValidatorVotersLength function validatorVotersLength
 func (v *Validators) ValidatorVotersLength(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, addr common.Address) (*big.Int, error) {
-	method := "validatorVotersLength"
-	data, err := v.abi.Pack(method, addr)
-
-	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	ret, err := v.abi.Unpack(method, result)
+	count, err := v.caller.ValidatorVotersLength(vmcaller.NewCallContext(statedb, header, chainContext, config), addr)
 	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
-		return big.NewInt(0), err
-	}
-	count, ok := ret[0].(*big.Int)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
+		log.Error("Validators contract call error", "method", "validatorVotersLength", "error", err)
 		return big.NewInt(0), err
 	}
 	return count, nil
-
 }
 
 // IsEffictiveValidator function IsEffictiveValidator
 func (v *Validators) IsEffictiveValidator(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, addr common.Address) (bool, error) {
-	method := "isEffictiveValidator"
-	data, err := v.abi.Pack(method, addr)
-
-	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
-		return false, err
-	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
-		return false, err
-	}
-
-	ret, err := v.abi.Unpack(method, result)
+	effictive, err := v.caller.IsEffictiveValidator(vmcaller.NewCallContext(statedb, header, chainContext, config), addr)
 	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
-		return false, err
-	}
-	val, ok := ret[0].(bool)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
+		log.Error("Validators contract call error", "method", "isEffictiveValidator", "error", err)
 		return false, err
 	}
-	return val, nil
-
+	return effictive, nil
 }
 
 // GetValidator function GetValidator
 func (v *Validators) GetValidator(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig, addr common.Address) (*Validator, error) {
-	method := "validators"
-	data, err := v.abi.Pack(method, addr)
-	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
-		return &Validator{}, err
-	}
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
-		return &Validator{}, err
-	}
-	val := &Validator{}
-	err = v.abi.UnpackIntoInterface(val, method, result)
+	val, err := v.caller.Validators(vmcaller.NewCallContext(statedb, header, chainContext, config), addr)
 	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
+		log.Error("Validators contract call error", "method", "validators", "error", err)
 		return &Validator{}, err
 	}
-	return val, nil
+	return &val, nil
 }
 
 // TotalDeposit function TotalDeposit
 func (v *Validators) TotalDeposit(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (*big.Int, error) {
-	method := "totalDeposit"
-	data, err := v.abi.Pack(method)
-
-	if err != nil {
-		log.Error("Validators Pack error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	msg := vmcaller.NewLegacyMessage(header.Coinbase, &v.contractAddr, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
-	result, err := vmcaller.ExecuteMsg(msg, statedb, header, chainContext, config)
-	if err != nil {
-		log.Error("Validators contract execute error", "method", method, "error", err)
-		return big.NewInt(0), err
-	}
-
-	ret, err := v.abi.Unpack(method, result)
+	deposit, err := v.caller.TotalDeposit(vmcaller.NewCallContext(statedb, header, chainContext, config))
 	if err != nil {
-		log.Error("Validators contract Unpack error", "method", method, "error", err, "result", result)
-		return big.NewInt(0), err
-	}
-	deposit, ok := ret[0].(*big.Int)
-	if !ok {
-		log.Error("Validators contract format result error", "method", method, "error", err)
+		log.Error("Validators contract call error", "method", "totalDeposit", "error", err)
 		return big.NewInt(0), err
 	}
 	return deposit, nil
-
 }
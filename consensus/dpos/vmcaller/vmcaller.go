@@ -1,12 +1,14 @@
 package vmcaller
 
 import (
+	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi"
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
 	"github.com/hypnosisfoundation/go-hypnosis/core/state"
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
 	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"math"
 	"math/big"
 )
 
@@ -27,3 +29,37 @@ func ExecuteMsg(msg core.Message, state *state.StateDB, header *types.Header, ch
 func NewLegacyMessage(from common.Address, to *common.Address, nonce uint64, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, checkNonce bool) types.Message {
 	return types.NewMessage(from, to, nonce, amount, gasLimit, gasPrice, gasPrice, gasPrice, data, nil, checkNonce)
 }
+
+// CallContext is the state and block that read-only calls to system contracts are
+// executed against.
+type CallContext struct {
+	Statedb      *state.StateDB
+	Header       *types.Header
+	ChainContext core.ChainContext
+	ChainConfig  *params.ChainConfig
+}
+
+// NewCallContext creates a context executing calls against the given state and block.
+func NewCallContext(statedb *state.StateDB, header *types.Header, chainContext core.ChainContext, chainConfig *params.ChainConfig) *CallContext {
+	return &CallContext{
+		Statedb:      statedb,
+		Header:       header,
+		ChainContext: chainContext,
+		ChainConfig:  chainConfig,
+	}
+}
+
+// Call packs a call to a contract method, executes it without any gas limit or fee
+// and unpacks its results.
+func (c *CallContext) Call(contract abi.ABI, to common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := contract.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	msg := NewLegacyMessage(c.Header.Coinbase, &to, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
+	result, err := ExecuteMsg(msg, c.Statedb, c.Header, c.ChainContext, c.ChainConfig)
+	if err != nil {
+		return nil, err
+	}
+	return contract.Unpack(method, result)
+}
@@ -52,6 +52,8 @@ var (
 	headHeaderGauge    = metrics.NewRegisteredGauge("chain/head/header", nil)
 	headFastBlockGauge = metrics.NewRegisteredGauge("chain/head/receipt", nil)
 
+	headFinalizedBlockGauge = metrics.NewRegisteredGauge("chain/head/finalized", nil)
+
 	accountReadTimer   = metrics.NewRegisteredTimer("chain/account/reads", nil)
 	accountHashTimer   = metrics.NewRegisteredTimer("chain/account/hashes", nil)
 	accountUpdateTimer = metrics.NewRegisteredTimer("chain/account/updates", nil)
@@ -80,6 +82,7 @@ var (
 	blockPrefetchInterruptMeter = metrics.NewRegisteredMeter("chain/prefetch/interrupts", nil)
 
 	errInsertionInterrupted = errors.New("insertion is interrupted")
+	errReorgFinalized       = errors.New("reorg below finalized block")
 )
 
 const (
@@ -183,10 +186,13 @@ type BlockChain struct {
 	scope         event.SubscriptionScope
 	genesisBlock  *types.Block
 
-	chainmu sync.RWMutex // blockchain insertion lock
+	chainmu    sync.RWMutex // blockchain insertion lock
+	finalitymu sync.Mutex   // finalized/safe marker update lock, independent of insertion to avoid stalling the engine
 
-	currentBlock     atomic.Value // Current head of the block chain
-	currentFastBlock atomic.Value // Current head of the fast-sync chain (may be above the block chain!)
+	currentBlock          atomic.Value // Current head of the block chain
+	currentFastBlock      atomic.Value // Current head of the fast-sync chain (may be above the block chain!)
+	currentFinalizedBlock atomic.Value // Latest block finalized by the consensus engine, reorgs below it are refused
+	currentSafeBlock      atomic.Value // Latest block justified by the consensus engine
 
 	stateCache    state.Database // State database to reuse between imports (contains state cache)
 	bodyCache     *lru.Cache     // Cache for the most recent block bodies
@@ -264,6 +270,10 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 	bc.currentBlock.Store(nilBlock)
 	bc.currentFastBlock.Store(nilBlock)
 
+	var nilHeader *types.Header
+	bc.currentFinalizedBlock.Store(nilHeader)
+	bc.currentSafeBlock.Store(nilHeader)
+
 	// Initialize the chain with ancient data if it isn't empty.
 	var txIndexBlock uint64
 
@@ -456,6 +466,19 @@ func (bc *BlockChain) loadLastState() error {
 			headFastBlockGauge.Update(int64(block.NumberU64()))
 		}
 	}
+	// Restore the last known finalized block, dropping it if it's no longer part
+	// of the canonical chain (e.g. after an explicit rewind).
+	var nilHeader *types.Header
+	bc.currentFinalizedBlock.Store(nilHeader)
+	bc.currentSafeBlock.Store(nilHeader)
+
+	if head := rawdb.ReadFinalizedBlockHash(bc.db); head != (common.Hash{}) {
+		if header := bc.GetHeaderByHash(head); header != nil && header.Number.Uint64() <= currentBlock.NumberU64() && bc.GetCanonicalHash(header.Number.Uint64()) == head {
+			bc.currentFinalizedBlock.Store(header)
+			bc.currentSafeBlock.Store(header)
+			headFinalizedBlockGauge.Update(int64(header.Number.Uint64()))
+		}
+	}
 	// Issue a status log for the user
 	currentFastBlock := bc.CurrentFastBlock()
 
@@ -671,6 +694,77 @@ func (bc *BlockChain) CurrentFastBlock() *types.Block {
 	return bc.currentFastBlock.Load().(*types.Block)
 }
 
+// CurrentFinalizedBlock retrieves the header of the latest block finalized by
+// the consensus engine, or nil if no block has been finalized yet.
+func (bc *BlockChain) CurrentFinalizedBlock() *types.Header {
+	return bc.currentFinalizedBlock.Load().(*types.Header)
+}
+
+// CurrentSafeBlock retrieves the header of the latest block justified by the
+// consensus engine, or nil if no block has been justified yet.
+func (bc *BlockChain) CurrentSafeBlock() *types.Header {
+	return bc.currentSafeBlock.Load().(*types.Header)
+}
+
+// SetFinalized marks a canonical block as finalized. The chain will refuse to
+// reorganise below it from now on. Attempts to move the marker backwards or to
+// a non-canonical block are ignored.
+func (bc *BlockChain) SetFinalized(header *types.Header) {
+	bc.finalitymu.Lock()
+	defer bc.finalitymu.Unlock()
+
+	number := header.Number.Uint64()
+	if bc.GetCanonicalHash(number) != header.Hash() {
+		log.Warn("Ignoring non-canonical finalized block", "number", number, "hash", header.Hash())
+		return
+	}
+	if current := bc.CurrentFinalizedBlock(); current != nil && current.Number.Uint64() >= number {
+		return
+	}
+	rawdb.WriteFinalizedBlockHash(bc.db, header.Hash())
+	bc.currentFinalizedBlock.Store(header)
+	headFinalizedBlockGauge.Update(int64(number))
+
+	if safe := bc.CurrentSafeBlock(); safe == nil || safe.Number.Uint64() < number {
+		bc.currentSafeBlock.Store(header)
+	}
+	log.Debug("Updated finalized block", "number", number, "hash", header.Hash())
+}
+
+// SetSafe marks a canonical block as justified (safe). The safe block is never
+// behind the finalized one.
+func (bc *BlockChain) SetSafe(header *types.Header) {
+	bc.finalitymu.Lock()
+	defer bc.finalitymu.Unlock()
+
+	number := header.Number.Uint64()
+	if bc.GetCanonicalHash(number) != header.Hash() {
+		return
+	}
+	if finalized := bc.CurrentFinalizedBlock(); finalized != nil && finalized.Number.Uint64() > number {
+		return
+	}
+	bc.currentSafeBlock.Store(header)
+}
+
+// descendsFromFinalized reports whether the given block is built on top of the
+// finalized block, or whether no block has been finalized yet.
+func (bc *BlockChain) descendsFromFinalized(block *types.Block) bool {
+	finalized := bc.CurrentFinalizedBlock()
+	if finalized == nil {
+		return true
+	}
+	number := finalized.Number.Uint64()
+	if block.NumberU64() < number {
+		return false
+	}
+	header := block.Header()
+	for header != nil && header.Number.Uint64() > number {
+		header = bc.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	}
+	return header != nil && header.Hash() == finalized.Hash()
+}
+
 // Validator returns the current validator.
 func (bc *BlockChain) Validator() Validator {
 	return bc.validator
@@ -1548,6 +1642,10 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 			reorg = !currentPreserve && (blockPreserve || mrand.Float64() < 0.5)
 		}
 	}
+	if reorg && block.ParentHash() != currentBlock.Hash() && !bc.descendsFromFinalized(block) {
+		log.Warn("Refusing to reorg below the finalized block", "number", block.Number(), "hash", block.Hash(), "finalized", bc.CurrentFinalizedBlock().Number)
+		reorg = false
+	}
 	if reorg {
 		// Reorganise the chain if the parent is not the head block
 		if block.ParentHash() != currentBlock.Hash() {
@@ -2194,6 +2292,10 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 			return fmt.Errorf("invalid new chain")
 		}
 	}
+	// Never rewrite history that the consensus engine already finalized
+	if finalized := bc.CurrentFinalizedBlock(); finalized != nil && len(oldChain) > 0 && commonBlock.NumberU64() < finalized.Number.Uint64() {
+		return errReorgFinalized
+	}
 	// Ensure the user sees large reorgs
 	if len(oldChain) > 0 && len(newChain) > 0 {
 		logFn := log.Info
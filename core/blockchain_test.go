@@ -2041,6 +2041,51 @@ func TestReorgToShorterRemovesCanonMapping(t *testing.T) {
 	}
 }
 
+// Tests that once a block is finalized, heavier side chains forking off below
+// it are stored but never become canonical.
+func TestReorgBelowFinalizedRefused(t *testing.T) {
+	db, blockchain, err := newCanonical(ethash.NewFaker(), 0, true)
+	if err != nil {
+		t.Fatalf("failed to create pristine chain: %v", err)
+	}
+	defer blockchain.Stop()
+
+	canon := makeBlockChain(blockchain.CurrentBlock(), 5, ethash.NewFaker(), db, canonicalSeed)
+	if n, err := blockchain.InsertChain(canon); err != nil {
+		t.Fatalf("block %d: failed to insert canonical chain: %v", n, err)
+	}
+	blockchain.SetFinalized(canon[2].Header())
+	if have := blockchain.CurrentFinalizedBlock(); have == nil || have.Hash() != canon[2].Hash() {
+		t.Fatalf("finalized block mismatch: have %v, want %v", have, canon[2].Number())
+	}
+	// Moving the finalized marker backwards is a noop
+	blockchain.SetFinalized(canon[0].Header())
+	if have := blockchain.CurrentFinalizedBlock(); have.Hash() != canon[2].Hash() {
+		t.Fatalf("finalized block moved backwards to %d", have.Number)
+	}
+	// A longer fork from block #1 must not replace the finalized segment
+	fork := makeBlockChain(canon[0], 8, ethash.NewFaker(), db, forkSeed)
+	blockchain.InsertChain(fork)
+	if head := blockchain.CurrentBlock(); head.Hash() != canon[len(canon)-1].Hash() {
+		t.Fatalf("head reorged below finalized block: have #%d [%x]", head.NumberU64(), head.Hash().Bytes()[:4])
+	}
+	// A longer fork on top of the finalized block is accepted
+	extension := makeBlockChain(canon[3], 8, ethash.NewFaker(), db, forkSeed)
+	if n, err := blockchain.InsertChain(extension); err != nil {
+		t.Fatalf("block %d: failed to insert fork above finalized block: %v", n, err)
+	}
+	if head := blockchain.CurrentBlock(); head.Hash() != extension[len(extension)-1].Hash() {
+		t.Fatalf("head mismatch: have #%d, want #%d", head.NumberU64(), extension[len(extension)-1].NumberU64())
+	}
+	// Rewinding below the finalized block drops the marker
+	if err := blockchain.SetHead(1); err != nil {
+		t.Fatalf("failed to rewind chain: %v", err)
+	}
+	if have := blockchain.CurrentFinalizedBlock(); have != nil {
+		t.Fatalf("finalized block retained after rewind: #%d", have.Number)
+	}
+}
+
 // TestReorgToShorterRemovesCanonMappingHeaderChain is the same scenario
 // as TestReorgToShorterRemovesCanonMapping, but applied on headerchain
 // imports -- that is, for fast sync
@@ -0,0 +1,120 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/ethdb"
+	"github.com/hypnosisfoundation/go-hypnosis/log"
+)
+
+// DposSnapshotRef identifies a dpos snapshot stored in the database.
+type DposSnapshotRef struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// ReadDposSnapshot retrieves the encoded dpos snapshot of a block.
+func ReadDposSnapshot(db ethdb.KeyValueReader, number uint64, hash common.Hash) []byte {
+	data, _ := db.Get(dposSnapshotKey(number, hash))
+	return data
+}
+
+// WriteDposSnapshot stores the encoded dpos snapshot of a block.
+func WriteDposSnapshot(db ethdb.KeyValueWriter, number uint64, hash common.Hash, blob []byte) {
+	if err := db.Put(dposSnapshotKey(number, hash), blob); err != nil {
+		log.Crit("Failed to store dpos snapshot", "err", err)
+	}
+}
+
+// DeleteDposSnapshot removes the dpos snapshot of a block.
+func DeleteDposSnapshot(db ethdb.KeyValueWriter, number uint64, hash common.Hash) {
+	if err := db.Delete(dposSnapshotKey(number, hash)); err != nil {
+		log.Crit("Failed to delete dpos snapshot", "err", err)
+	}
+}
+
+// ReadDposSnapshotRefs retrieves the references of the dpos snapshots stored for
+// the blocks below the given number, in ascending block order.
+func ReadDposSnapshotRefs(db ethdb.Iteratee, limit uint64) []DposSnapshotRef {
+	it := db.NewIterator(dposSnapshotPrefix, nil)
+	defer it.Release()
+
+	var refs []DposSnapshotRef
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(dposSnapshotPrefix)+8+common.HashLength {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(dposSnapshotPrefix):])
+		if number >= limit {
+			break
+		}
+		refs = append(refs, DposSnapshotRef{Number: number, Hash: common.BytesToHash(key[len(dposSnapshotPrefix)+8:])})
+	}
+	return refs
+}
+
+// ReadDposAddressList retrieves the encoded checkpoint of the dpos AddressList
+// contract content.
+func ReadDposAddressList(db ethdb.KeyValueReader) []byte {
+	data, _ := db.Get(dposAddressListKey)
+	return data
+}
+
+// WriteDposAddressList stores the encoded checkpoint of the dpos AddressList
+// contract content.
+func WriteDposAddressList(db ethdb.KeyValueWriter, blob []byte) {
+	if err := db.Put(dposAddressListKey, blob); err != nil {
+		log.Crit("Failed to store dpos address list", "err", err)
+	}
+}
+
+// ReadLegacyDposSnapshot retrieves the json encoded dpos snapshot of a block stored
+// by the legacy schema, which only keyed snapshots by block hash.
+func ReadLegacyDposSnapshot(db ethdb.KeyValueReader, hash common.Hash) []byte {
+	data, _ := db.Get(legacyDposSnapshotKey(hash))
+	return data
+}
+
+// DeleteLegacyDposSnapshot removes the dpos snapshot of a block stored by the
+// legacy schema.
+func DeleteLegacyDposSnapshot(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(legacyDposSnapshotKey(hash)); err != nil {
+		log.Crit("Failed to delete legacy dpos snapshot", "err", err)
+	}
+}
+
+// ReadLegacyDposSnapshotHashes retrieves the block hashes of all the dpos snapshots
+// stored by the legacy schema.
+func ReadLegacyDposSnapshotHashes(db ethdb.Iteratee) []common.Hash {
+	it := db.NewIterator(legacyDposSnapshotPrefix, nil)
+	defer it.Release()
+
+	var hashes []common.Hash
+	for it.Next() {
+		key := it.Key()
+		// Other dpos records share the prefix, but not the key length
+		if len(key) != len(legacyDposSnapshotPrefix)+common.HashLength {
+			continue
+		}
+		hashes = append(hashes, common.BytesToHash(key[len(legacyDposSnapshotPrefix):]))
+	}
+	return hashes
+}
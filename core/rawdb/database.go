@@ -368,7 +368,9 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 			bloomBits.Add(size)
 		case bytes.HasPrefix(key, []byte("clique-")) && len(key) == 7+common.HashLength:
 			cliqueSnaps.Add(size)
-		case bytes.HasPrefix(key, []byte("dpos-")) && len(key) == 7+common.HashLength:
+		case bytes.HasPrefix(key, dposSnapshotPrefix) && len(key) == len(dposSnapshotPrefix)+8+common.HashLength:
+			dposSnaps.Add(size)
+		case bytes.HasPrefix(key, legacyDposSnapshotPrefix) && len(key) == len(legacyDposSnapshotPrefix)+common.HashLength:
 			dposSnaps.Add(size)
 		case bytes.HasPrefix(key, []byte("cht-")) ||
 			bytes.HasPrefix(key, []byte("chtIndexV2-")) ||
@@ -381,10 +383,10 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		default:
 			var accounted bool
 			for _, meta := range [][]byte{
-				databaseVersionKey, headHeaderKey, headBlockKey, headFastBlockKey, lastPivotKey,
+				databaseVersionKey, headHeaderKey, headBlockKey, headFastBlockKey, headFinalizedBlockKey, lastPivotKey,
 				fastTrieProgressKey, snapshotDisabledKey, snapshotRootKey, snapshotJournalKey,
 				snapshotGeneratorKey, snapshotRecoveryKey, txIndexTailKey, fastTxLookupLimitKey,
-				uncleanShutdownKey, badBlockKey,
+				uncleanShutdownKey, badBlockKey, dposAddressListKey,
 			} {
 				if bytes.Equal(key, meta) {
 					metadata.Add(size)
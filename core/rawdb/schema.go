@@ -39,6 +39,9 @@ var (
 	// headFastBlockKey tracks the latest known incomplete block's hash during fast sync.
 	headFastBlockKey = []byte("LastFast")
 
+	// headFinalizedBlockKey tracks the latest known finalized block's hash.
+	headFinalizedBlockKey = []byte("LastFinalized")
+
 	// lastPivotKey tracks the last pivot block used by fast sync (to reenable on sethead).
 	lastPivotKey = []byte("LastPivot")
 
@@ -75,6 +78,10 @@ var (
 	// uncleanShutdownKey tracks the list of local crashes
 	uncleanShutdownKey = []byte("unclean-shutdown") // config prefix for the db
 
+	// dposAddressListKey tracks the content of the dpos AddressList contract after
+	// the last block changing it.
+	dposAddressListKey = []byte("DposAddressList")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
@@ -96,6 +103,10 @@ var (
 	// Chain index prefixes (use `i` + single byte to avoid mixing data types).
 	BloomBitsIndexPrefix = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
 
+	// Consensus engine prefixes.
+	dposSnapshotPrefix       = []byte("dpos-snap-") // dposSnapshotPrefix + num (uint64 big endian) + hash -> dpos snapshot
+	legacyDposSnapshotPrefix = []byte("dpos-")      // legacyDposSnapshotPrefix + hash -> json encoded dpos snapshot
+
 	preimageCounter    = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 )
@@ -207,6 +218,16 @@ func bloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
 	return key
 }
 
+// dposSnapshotKey = dposSnapshotPrefix + num (uint64 big endian) + hash
+func dposSnapshotKey(number uint64, hash common.Hash) []byte {
+	return append(append(dposSnapshotPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// legacyDposSnapshotKey = legacyDposSnapshotPrefix + hash
+func legacyDposSnapshotKey(hash common.Hash) []byte {
+	return append(legacyDposSnapshotPrefix, hash.Bytes()...)
+}
+
 // preimageKey = preimagePrefix + hash
 func preimageKey(hash common.Hash) []byte {
 	return append(preimagePrefix, hash.Bytes()...)
@@ -785,6 +785,22 @@ func (s *StateDB) GetRefund() uint64 {
 	return s.refund
 }
 
+// PendingChanges returns the accounts modified since the last time the state root
+// was computed, along with the storage slots written in each of them.
+func (s *StateDB) PendingChanges() map[common.Address][]common.Hash {
+	changes := make(map[common.Address][]common.Hash, len(s.stateObjectsPending))
+	for addr := range s.stateObjectsPending {
+		var slots []common.Hash
+		if obj := s.stateObjects[addr]; obj != nil {
+			for key := range obj.pendingStorage {
+				slots = append(slots, key)
+			}
+		}
+		changes[addr] = slots
+	}
+	return changes
+}
+
 // Finalise finalises the state by removing the s destructed objects and clears
 // the journal as well as the refunds. Finalise, however, will not push any updates
 // into the tries just yet. Only IntermediateRoot or Commit will do that.
@@ -398,10 +398,13 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 		effectiveTip = cmath.BigMin(st.gasTipCap, new(big.Int).Sub(st.gasFeeCap, st.evm.Context.BaseFee))
 	}
 	tip := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), effectiveTip)
-	if st.evm.ChainConfig().Dpos != nil {
-		st.state.AddBalance(consensus.FeeRecoder, tip)
-	} else {
-		st.state.AddBalance(st.evm.Context.Coinbase, tip)
+	st.state.AddBalance(TipRecipient(st.evm.ChainConfig(), st.evm.Context.Coinbase), tip)
+
+	// The base fee is burnt unless the base fee policy redirects it. Never credit more
+	// than was paid per gas, which may happen for calls executed without a base fee.
+	if recipient := BaseFeeRecipient(st.evm.ChainConfig(), st.evm.Context.BlockNumber); london && recipient != nil {
+		baseFee := cmath.BigMin(st.evm.Context.BaseFee, st.gasPrice)
+		st.state.AddBalance(*recipient, new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), baseFee))
 	}
 
 	return &ExecutionResult{
@@ -411,6 +414,30 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	}, nil
 }
 
+// TipRecipient returns the account credited with the priority fees of transactions,
+// the fee recorder of the validators' reward pool on dpos chains.
+func TipRecipient(config *params.ChainConfig, coinbase common.Address) common.Address {
+	if config.Dpos != nil {
+		return consensus.FeeRecoder
+	}
+	return coinbase
+}
+
+// BaseFeeRecipient returns the account credited with the base fee of transactions
+// in the given block, or nil if the base fee is burnt.
+func BaseFeeRecipient(config *params.ChainConfig, number *big.Int) *common.Address {
+	policy := config.BaseFeePolicyAt(number)
+	switch policy.Mode {
+	case params.BaseFeeValidators:
+		recipient := consensus.FeeRecoder
+		return &recipient
+	case params.BaseFeeTreasury:
+		recipient := policy.Treasury
+		return &recipient
+	}
+	return nil
+}
+
 func (st *StateTransition) refundGas(refundQuotient uint64) {
 	// Apply refund counter, capped to a refund quotient
 	refund := st.gasUsed() / refundQuotient
@@ -7,12 +7,22 @@ import (
 	"time"
 
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/event"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
 	"github.com/hypnosisfoundation/go-hypnosis/metrics"
 )
 
 var (
 	jamIndexMeter = metrics.NewRegisteredGauge("txpool/jamindex", nil)
+
+	// Components of the jam index
+	jamUnderPricedGauge  = metrics.NewRegisteredGauge("txpool/jamindex/underpriced", nil)
+	jamPendingScoreGauge = metrics.NewRegisteredGauge("txpool/jamindex/pending/score", nil)
+	jamPendingCountGauge = metrics.NewRegisteredGauge("txpool/jamindex/pending/count", nil)
+	jamLatencyP50Gauge   = metrics.NewRegisteredGauge("txpool/jamindex/latency/p50", nil)
+	jamLatencyP90Gauge   = metrics.NewRegisteredGauge("txpool/jamindex/latency/p90", nil)
+	jamLatencyP99Gauge   = metrics.NewRegisteredGauge("txpool/jamindex/latency/p99", nil)
+	jamLatencyMaxGauge   = metrics.NewRegisteredGauge("txpool/jamindex/latency/max", nil)
 )
 
 var oneGwei = big.NewInt(1e9)
@@ -23,6 +33,7 @@ var DefaultJamConfig = TxJamConfig{
 	UnderPricedFactor:   3,
 	PendingFactor:       1,
 	MaxValidPendingSecs: 300,
+	HistoryLen:          1200,
 }
 
 type TxJamConfig struct {
@@ -32,6 +43,8 @@ type TxJamConfig struct {
 	PendingFactor     int
 
 	MaxValidPendingSecs int //
+
+	HistoryLen int // how many jam index samples to keep in history
 }
 
 func (c *TxJamConfig) sanity() TxJamConfig {
@@ -56,9 +69,28 @@ func (c *TxJamConfig) sanity() TxJamConfig {
 		log.Info("JamConfig sanity MaxValidPendingSecs", "old", cfg.MaxValidPendingSecs, "new", DefaultJamConfig.MaxValidPendingSecs)
 		cfg.MaxValidPendingSecs = DefaultJamConfig.MaxValidPendingSecs
 	}
+	if cfg.HistoryLen < 1 {
+		log.Info("JamConfig sanity HistoryLen", "old", cfg.HistoryLen, "new", DefaultJamConfig.HistoryLen)
+		cfg.HistoryLen = DefaultJamConfig.HistoryLen
+	}
 	return cfg
 }
 
+// JamIndexSample is a jam index evaluation along with the components it's made of.
+type JamIndexSample struct {
+	Time         uint64 `json:"time"`         // Unix time of the evaluation
+	JamIndex     int    `json:"jamIndex"`     // Weighted sum of the under-priced rate and the pending score
+	UnderPriced  int    `json:"underPriced"`  // Under-priced transactions rejected in the last period
+	PendingScore int    `json:"pendingScore"` // Percentage of the pending transactions age, in JamSecs
+	Pending      int    `json:"pending"`      // Pending transactions accounted
+
+	// Pending time percentiles of the accounted transactions, in milliseconds
+	LatencyP50 uint64 `json:"latencyP50"`
+	LatencyP90 uint64 `json:"latencyP90"`
+	LatencyP99 uint64 `json:"latencyP99"`
+	LatencyMax uint64 `json:"latencyMax"`
+}
+
 // txJamIndexer try to give a quantitative index to reflects the tx-jam.
 type txJamIndexer struct {
 	cfg  TxJamConfig
@@ -67,9 +99,12 @@ type txJamIndexer struct {
 
 	undCounter      *underPricedCounter
 	currentJamIndex int
+	history         []*JamIndexSample // Ring buffer of the recent samples
+	historyHead     int               // Index of the next sample in history
 
 	pendingLock sync.Mutex
 	jamLock     sync.RWMutex
+	jamFeed     event.Feed
 
 	quit        chan struct{}
 	chainHeadCh chan *types.Header
@@ -82,6 +117,7 @@ func newTxJamIndexer(cfg TxJamConfig, pool *TxPool) *txJamIndexer {
 		cfg:         cfg,
 		pool:        pool,
 		undCounter:  newUnderPricedCounter(cfg.PeriodsSecs),
+		history:     make([]*JamIndexSample, 0, cfg.HistoryLen),
 		quit:        make(chan struct{}),
 		chainHeadCh: make(chan *types.Header, 1),
 	}
@@ -104,6 +140,30 @@ func (indexer *txJamIndexer) JamIndex() int {
 	return indexer.currentJamIndex
 }
 
+// JamIndexHistory returns the recent jam index samples, oldest first, at most n
+// of them if n is positive.
+func (indexer *txJamIndexer) JamIndexHistory(n int) []*JamIndexSample {
+	indexer.jamLock.RLock()
+	defer indexer.jamLock.RUnlock()
+
+	size := len(indexer.history)
+	if n <= 0 || n > size {
+		n = size
+	}
+	samples := make([]*JamIndexSample, 0, n)
+	for i := size - n; i < size; i++ {
+		// The oldest sample is at the head once the buffer is full
+		samples = append(samples, indexer.history[(indexer.historyHead+i)%size])
+	}
+	return samples
+}
+
+// SubscribeJamIndexEvent registers a subscription of JamIndexEvent, sent on each
+// jam index evaluation.
+func (indexer *txJamIndexer) SubscribeJamIndexEvent(ch chan<- JamIndexEvent) event.Subscription {
+	return indexer.jamFeed.Subscribe(ch)
+}
+
 func (indexer *txJamIndexer) updateLoop() {
 	tick := time.NewTicker(time.Second * time.Duration(indexer.cfg.PeriodsSecs))
 	defer tick.Stop()
@@ -113,73 +173,116 @@ func (indexer *txJamIndexer) updateLoop() {
 		case h := <-indexer.chainHeadCh:
 			indexer.head = h
 		case <-tick.C:
-			d := indexer.undCounter.Sum()
-			pendings, _ := indexer.pool.Pending(true)
-			if d == 0 && len(pendings) == 0 {
-				break
-			}
-			// flatten
-			var p int
-			max := indexer.cfg.MaxValidPendingSecs
-			jamsecs := indexer.cfg.JamSecs
-			maxGas := uint64(10000000)
-			if indexer.head != nil {
-				maxGas = (indexer.head.GasLimit / 10) * 6
+			if sample := indexer.evaluate(); sample != nil {
+				indexer.record(sample)
 			}
-			durs := make([]time.Duration, 0, 1024)
-			for _, txs := range pendings {
-				for _, tx := range txs {
-					// filtering
-					if tx.GasPrice().Cmp(oneGwei) < 0 ||
-						tx.Gas() > maxGas {
-						continue
-					}
-
-					dur := time.Since(tx.LocalSeenTime())
-					sec := int(dur / time.Second)
-					if sec > max {
-						continue
-					}
-
-					durs = append(durs, dur)
-					if sec >= jamsecs {
-						p += sec / jamsecs
-					}
-				}
+		case <-indexer.quit:
+			return
+		}
+	}
+}
+
+// evaluate computes the jam index from the under-priced transactions rejected and
+// the pending transactions age. It returns nil if the pool is idle, leaving the
+// current jam index untouched.
+func (indexer *txJamIndexer) evaluate() *JamIndexSample {
+	d := indexer.undCounter.Sum()
+	pendings, _ := indexer.pool.Pending(true)
+	if d == 0 && len(pendings) == 0 {
+		return nil
+	}
+
+	// flatten
+	var p int
+	max := indexer.cfg.MaxValidPendingSecs
+	jamsecs := indexer.cfg.JamSecs
+	maxGas := uint64(10000000)
+	if indexer.head != nil {
+		maxGas = (indexer.head.GasLimit / 10) * 6
+	}
+	durs := make([]time.Duration, 0, 1024)
+	for _, txs := range pendings {
+		for _, tx := range txs {
+			// filtering
+			if tx.GasPrice().Cmp(oneGwei) < 0 ||
+				tx.Gas() > maxGas {
+				continue
 			}
-			nTotal := len(durs)
 
-			if nTotal == 0 {
-				p = 0
-			} else {
-				p = 100 * p / nTotal
+			dur := time.Since(tx.LocalSeenTime())
+			sec := int(dur / time.Second)
+			if sec > max {
+				continue
 			}
 
-			idx := d*indexer.cfg.UnderPricedFactor + p*indexer.cfg.PendingFactor
-			indexer.jamLock.Lock()
-			indexer.currentJamIndex = idx
-			indexer.jamLock.Unlock()
-			jamIndexMeter.Update(int64(idx))
-
-			var dists []time.Duration
-			sort.Slice(durs, func(i, j int) bool {
-				return durs[i] < durs[j]
-			})
-			if nTotal > 10 {
-				dists = append(dists, durs[0])
-				for i := 1; i < 10; i++ {
-					dists = append(dists, durs[nTotal*i/10])
-				}
-				dists = append(dists, durs[nTotal-1])
-			} else {
-				dists = durs
+			durs = append(durs, dur)
+			if sec >= jamsecs {
+				p += sec / jamsecs
 			}
+		}
+	}
+	nTotal := len(durs)
 
-			log.Trace("TxJamIndexer", "jamIndex", idx, "d", d, "p", p, "n", nTotal, "dists", dists)
-		case <-indexer.quit:
-			return
+	if nTotal == 0 {
+		p = 0
+	} else {
+		p = 100 * p / nTotal
+	}
+
+	sample := &JamIndexSample{
+		Time:         uint64(time.Now().Unix()),
+		JamIndex:     d*indexer.cfg.UnderPricedFactor + p*indexer.cfg.PendingFactor,
+		UnderPriced:  d,
+		PendingScore: p,
+		Pending:      nTotal,
+	}
+	if nTotal == 0 {
+		return sample
+	}
+	sort.Slice(durs, func(i, j int) bool {
+		return durs[i] < durs[j]
+	})
+	percentile := func(pct int) uint64 {
+		return uint64(durs[(nTotal-1)*pct/100] / time.Millisecond)
+	}
+	sample.LatencyP50, sample.LatencyP90, sample.LatencyP99, sample.LatencyMax = percentile(50), percentile(90), percentile(99), percentile(100)
+
+	var dists []time.Duration
+	if nTotal > 10 {
+		dists = append(dists, durs[0])
+		for i := 1; i < 10; i++ {
+			dists = append(dists, durs[nTotal*i/10])
 		}
+		dists = append(dists, durs[nTotal-1])
+	} else {
+		dists = durs
+	}
+	log.Trace("TxJamIndexer", "jamIndex", sample.JamIndex, "d", d, "p", p, "n", nTotal, "dists", dists)
+	return sample
+}
+
+// record makes the sample the current jam index and adds it to the history.
+func (indexer *txJamIndexer) record(sample *JamIndexSample) {
+	indexer.jamLock.Lock()
+	indexer.currentJamIndex = sample.JamIndex
+	if len(indexer.history) < indexer.cfg.HistoryLen {
+		indexer.history = append(indexer.history, sample)
+	} else {
+		indexer.history[indexer.historyHead] = sample
 	}
+	indexer.historyHead = (indexer.historyHead + 1) % indexer.cfg.HistoryLen
+	indexer.jamLock.Unlock()
+
+	jamIndexMeter.Update(int64(sample.JamIndex))
+	jamUnderPricedGauge.Update(int64(sample.UnderPriced))
+	jamPendingScoreGauge.Update(int64(sample.PendingScore))
+	jamPendingCountGauge.Update(int64(sample.Pending))
+	jamLatencyP50Gauge.Update(int64(sample.LatencyP50))
+	jamLatencyP90Gauge.Update(int64(sample.LatencyP90))
+	jamLatencyP99Gauge.Update(int64(sample.LatencyP99))
+	jamLatencyMaxGauge.Update(int64(sample.LatencyMax))
+
+	indexer.jamFeed.Send(JamIndexEvent{Sample: sample})
 }
 
 func (indexer *txJamIndexer) UpdateHeader(h *types.Header) {
@@ -0,0 +1,93 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+// Tests that the jam index history keeps the latest samples in order, and that
+// the samples are sent to the subscribers.
+func TestJamIndexHistory(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupTxPool()
+	defer pool.Stop()
+
+	indexer := &txJamIndexer{
+		undCounter: newUnderPricedCounter(1),
+		cfg:        TxJamConfig{HistoryLen: 3},
+		pool:       pool,
+		history:    make([]*JamIndexSample, 0, 3),
+	}
+	defer indexer.undCounter.Stop()
+
+	events := make(chan JamIndexEvent, 5)
+	sub := indexer.SubscribeJamIndexEvent(events)
+	defer sub.Unsubscribe()
+
+	for i := 1; i <= 5; i++ {
+		indexer.record(&JamIndexSample{JamIndex: i})
+		if i == 2 {
+			if history := indexer.JamIndexHistory(0); len(history) != 2 || history[0].JamIndex != 1 || history[1].JamIndex != 2 {
+				t.Fatalf("partial history mismatch: %v", history)
+			}
+		}
+	}
+	if indexer.JamIndex() != 5 {
+		t.Errorf("jam index mismatch: have %d, want %d", indexer.JamIndex(), 5)
+	}
+	history := indexer.JamIndexHistory(0)
+	if len(history) != 3 {
+		t.Fatalf("history length mismatch: have %d, want %d", len(history), 3)
+	}
+	for i, sample := range history {
+		if sample.JamIndex != i+3 {
+			t.Errorf("sample %d: jam index mismatch: have %d, want %d", i, sample.JamIndex, i+3)
+		}
+	}
+	if latest := indexer.JamIndexHistory(1); len(latest) != 1 || latest[0].JamIndex != 5 {
+		t.Errorf("latest sample mismatch: %v", latest)
+	}
+	for i := 1; i <= 5; i++ {
+		select {
+		case ev := <-events:
+			if ev.Sample.JamIndex != i {
+				t.Errorf("event %d: jam index mismatch: have %d, want %d", i, ev.Sample.JamIndex, i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %d not sent", i)
+		}
+	}
+	// An idle pool is not sampled, keeping the last index
+	if sample := indexer.evaluate(); sample != nil {
+		t.Errorf("idle pool sampled: %+v", sample)
+	}
+	// Rejected transactions are sampled even with no pending ones
+	indexer.UnderPricedInc()
+	for indexer.undCounter.Sum() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	sample := indexer.evaluate()
+	if sample == nil {
+		t.Fatalf("under-priced transactions not sampled")
+	}
+	if sample.UnderPriced != 1 || sample.Pending != 0 || sample.LatencyMax != 0 {
+		t.Errorf("under-priced sample mismatch: %+v", sample)
+	}
+}
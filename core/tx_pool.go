@@ -97,6 +97,7 @@ var (
 	pendingReplaceMeter   = metrics.NewRegisteredMeter("txpool/pending/replace", nil)
 	pendingRateLimitMeter = metrics.NewRegisteredMeter("txpool/pending/ratelimit", nil) // Dropped due to rate limiting
 	pendingNofundsMeter   = metrics.NewRegisteredMeter("txpool/pending/nofunds", nil)   // Dropped due to out-of-funds
+	pendingDeniedMeter    = metrics.NewRegisteredMeter("txpool/pending/denied", nil)    // Dropped due to denied addresses or logs
 
 	// Metrics for the queued pool
 	queuedDiscardMeter   = metrics.NewRegisteredMeter("txpool/queued/discard", nil)
@@ -144,6 +145,13 @@ type exTxValidator interface {
 	ValidateTx(sender common.Address, tx *types.Transaction, header *types.Header, parentState *state.StateDB) error
 }
 
+// exTxSimulator is implemented by the extra validators which also restrict what
+// transactions may do during execution, allowing the pool to screen its pending
+// transactions against those restrictions.
+type exTxSimulator interface {
+	CreateEvmExtraValidator(header *types.Header, parentState *state.StateDB) types.EvmExtraValidator
+}
+
 // TxPoolConfig are the configuration parameters of the transaction pool.
 type TxPoolConfig struct {
 	Locals    []common.Address // Addresses that should be treated by default as local
@@ -161,6 +169,8 @@ type TxPoolConfig struct {
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
 
+	ScreenGas uint64 // Maximum gas of pending transactions screened against the consensus rules on each new head (0 = disabled)
+
 	JamConfig TxJamConfig
 }
 
@@ -444,6 +454,12 @@ func (pool *TxPool) SubscribeNewTxsEvent(ch chan<- NewTxsEvent) event.Subscripti
 	return pool.scope.Track(pool.txFeed.Subscribe(ch))
 }
 
+// SubscribeJamIndexEvent registers a subscription of JamIndexEvent and starts
+// sending event to the given channel.
+func (pool *TxPool) SubscribeJamIndexEvent(ch chan<- JamIndexEvent) event.Subscription {
+	return pool.scope.Track(pool.jamIndexer.SubscribeJamIndexEvent(ch))
+}
+
 // GasPrice returns the current gas price enforced by the transaction pool.
 func (pool *TxPool) GasPrice() *big.Int {
 	pool.mu.RLock()
@@ -583,6 +599,12 @@ func (pool *TxPool) JamIndex() int {
 	return pool.jamIndexer.JamIndex()
 }
 
+// JamIndexHistory returns the recent jam index samples, oldest first, at most n
+// of them if n is positive.
+func (pool *TxPool) JamIndexHistory(n int) []*JamIndexSample {
+	return pool.jamIndexer.JamIndexHistory(n)
+}
+
 // local retrieves all currently known local transactions, grouped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -1194,10 +1216,11 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 	// If a new block appeared, validate the pool of pending transactions. This will
 	// remove any transaction that has been included in the block or was invalidated
 	// because of another transaction (e.g. higher gas price).
+	var pendingBaseFee *big.Int
 	if reset != nil {
 		pool.demoteUnexecutables()
 		if reset.newHead != nil && pool.chainconfig.IsLondon(new(big.Int).Add(reset.newHead.Number, big.NewInt(1))) {
-			pendingBaseFee := misc.CalcBaseFee(pool.chainconfig, reset.newHead)
+			pendingBaseFee = misc.CalcBaseFee(pool.chainconfig, reset.newHead)
 			pool.priced.SetBaseFee(pendingBaseFee)
 		}
 	}
@@ -1212,6 +1235,10 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 	}
 	pool.mu.Unlock()
 
+	// Screen the pending transactions against the rules of the new head
+	if reset != nil {
+		pool.screenPending(pendingBaseFee)
+	}
 	// Notify subsystems for newly added transactions
 	for _, tx := range promoted {
 		addr, _ := types.Sender(pool.signer, tx)
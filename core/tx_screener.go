@@ -0,0 +1,158 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/log"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+// deniedRecorder wraps an evm extra validator, recording whether it denied any
+// address or log. The evm only reports denials to the calling contract, which may
+// swallow them, so the outcome of a transaction doesn't tell.
+type deniedRecorder struct {
+	types.EvmExtraValidator
+	denied bool
+}
+
+func (r *deniedRecorder) IsAddressDenied(address common.Address, cType common.AddressCheckType) bool {
+	hit := r.EvmExtraValidator.IsAddressDenied(address, cType)
+	r.denied = r.denied || hit
+	return hit
+}
+
+func (r *deniedRecorder) IsLogDenied(log *types.Log) bool {
+	hit := r.EvmExtraValidator.IsLogDenied(log)
+	r.denied = r.denied || hit
+	return hit
+}
+
+// screenChainContext serves the headers needed by the BLOCKHASH opcode to the
+// screened transactions from the chain of the pool, and the consensus engine
+// deciding who may create contracts.
+type screenChainContext struct {
+	chain  blockChain
+	engine consensus.Engine
+}
+
+func (c screenChainContext) Engine() consensus.Engine { return c.engine }
+
+func (c screenChainContext) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if block := c.chain.GetBlock(hash, number); block != nil {
+		return block.Header()
+	}
+	return nil
+}
+
+// screenPending simulates the pending transactions on top of the current state,
+// in the price and nonce order of a block producer and within the configured gas
+// budget, and drops the ones which the consensus engine would deny, either directly
+// or because of their internal calls and logs. Those would otherwise be included
+// only to fail, wasting block space.
+//
+// The simulation runs on a snapshot of the pool, the caller must not hold pool.mu.
+func (pool *TxPool) screenPending(baseFee *big.Int) {
+	pool.mu.RLock()
+	simulator, ok := pool.txValidator.(exTxSimulator)
+	if !ok || pool.disableExValidate || pool.config.ScreenGas == 0 || pool.nextFakeHeader == nil {
+		pool.mu.RUnlock()
+		return
+	}
+	var (
+		header  = types.CopyHeader(pool.nextFakeHeader)
+		parent  = pool.currentState.Copy()
+		statedb = pool.currentState.Copy()
+		pending = make(map[common.Address]types.Transactions, len(pool.pending))
+	)
+	for addr, list := range pool.pending {
+		pending[addr] = list.Flatten()
+	}
+	validator, engine := pool.txValidator, consensus.Engine(nil)
+	if e, ok := pool.txValidator.(consensus.Engine); ok {
+		engine = e
+	}
+	pool.mu.RUnlock()
+
+	if pool.chainconfig.IsLondon(header.Number) {
+		// Fees don't matter to the screening, only skip the checks if unknown
+		header.BaseFee = new(big.Int)
+		if baseFee != nil {
+			header.BaseFee.Set(baseFee)
+		}
+	}
+	evmValidator := simulator.CreateEvmExtraValidator(header, statedb)
+	if evmValidator == nil {
+		return
+	}
+	var (
+		recorder = &deniedRecorder{EvmExtraValidator: evmValidator}
+		chain    = screenChainContext{chain: pool.chain, engine: engine}
+		budget   = pool.config.ScreenGas
+		txs      = types.NewTransactionsByPriceAndNonce(pool.signer, pending, baseFee)
+		denied   []*types.Transaction
+	)
+	for budget >= params.TxGas {
+		tx := txs.Peek()
+		if tx == nil {
+			break
+		}
+		// Leave the accounts whose next transaction exceeds the budget unscreened
+		if tx.Gas() > budget {
+			txs.Pop()
+			continue
+		}
+		budget -= tx.Gas()
+
+		addr, _ := types.Sender(pool.signer, tx) // already validated during insertion
+		hit := validator.ValidateTx(addr, tx, header, parent) == types.ErrAddressDenied
+		if !hit {
+			recorder.denied = false
+			gp := new(GasPool).AddGas(tx.Gas())
+			if _, err := ApplyTransaction(pool.chainconfig, chain, &header.Coinbase, gp, statedb, header, tx, new(uint64), vm.Config{NoBaseFee: true}, recorder); err != nil {
+				// Not executable on top of the others yet, leave the account be
+				log.Trace("Failed to screen pending transaction", "hash", tx.Hash(), "err", err)
+				txs.Pop()
+				continue
+			}
+			hit = recorder.denied
+		}
+		if hit {
+			denied = append(denied, tx)
+			txs.Pop()
+			continue
+		}
+		txs.Shift()
+	}
+	if len(denied) == 0 {
+		return
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	for _, tx := range denied {
+		// Later transactions of the account are demoted by the removal
+		log.Debug("Removed denied pending transaction", "hash", tx.Hash())
+		pool.removeTx(tx.Hash(), true)
+	}
+	pendingDeniedMeter.Mark(int64(len(denied)))
+}
@@ -0,0 +1,115 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+)
+
+// testScreenValidator denies every call to one address and every log of another.
+type testScreenValidator struct {
+	denied common.Address
+	logger common.Address
+}
+
+func (v *testScreenValidator) ValidateTx(sender common.Address, tx *types.Transaction, header *types.Header, parentState *state.StateDB) error {
+	if to := tx.To(); to != nil && *to == v.denied {
+		return types.ErrAddressDenied
+	}
+	return nil
+}
+
+func (v *testScreenValidator) CreateEvmExtraValidator(header *types.Header, parentState *state.StateDB) types.EvmExtraValidator {
+	return v
+}
+
+func (v *testScreenValidator) IsAddressDenied(address common.Address, cType common.AddressCheckType) bool {
+	return address == v.denied && cType == common.CheckTo
+}
+
+func (v *testScreenValidator) IsLogDenied(log *types.Log) bool {
+	return log.Address == v.logger
+}
+
+// Tests that pending transactions denied during execution are dropped from the
+// pool on a new head, along with the later transactions of their senders.
+func TestScreenPending(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	validator := &testScreenValidator{
+		denied: common.HexToAddress("0xdead"),
+		logger: common.HexToAddress("0x1002"),
+	}
+	pool.InitExTxValidator(validator)
+	pool.config.ScreenGas = 1000000
+
+	var (
+		caller = common.HexToAddress("0x1001") // Calls the denied address, ignoring the failure
+		plain  = common.HexToAddress("0x1003")
+	)
+	pool.currentState.SetCode(caller, append(append(common.FromHex("0x6000600060006000600073"), validator.denied.Bytes()...), common.FromHex("0x5af100")...))
+	pool.currentState.SetCode(validator.logger, common.FromHex("0x60006000a000"))
+
+	send := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTransaction(nonce, to, new(big.Int), 100000, big.NewInt(1), nil), types.HomesteadSigner{}, key)
+		return tx
+	}
+	other, _ := crypto.GenerateKey()
+	clean, _ := crypto.GenerateKey()
+	for _, k := range []*ecdsa.PrivateKey{key, other, clean} {
+		testAddBalance(pool, crypto.PubkeyToAddress(k.PublicKey), big.NewInt(1000000000))
+	}
+	txs := []*types.Transaction{
+		send(key, 0, plain),
+		send(key, 1, caller),
+		send(key, 2, plain),
+		send(other, 0, validator.logger),
+		send(clean, 0, plain),
+	}
+	for i, err := range pool.AddRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("tx %d: failed to add: %v", i, err)
+		}
+	}
+	<-pool.requestReset(nil, nil)
+
+	pending, queued := pool.Stats()
+	if pending != 2 {
+		t.Errorf("pending transactions mismatched: have %d, want %d", pending, 2)
+	}
+	if queued != 1 {
+		t.Errorf("queued transactions mismatched: have %d, want %d", queued, 1)
+	}
+	for i, known := range []bool{true, false, true, false, true} {
+		if pool.Has(txs[i].Hash()) != known {
+			t.Errorf("tx %d: presence mismatch: have %v, want %v", i, !known, known)
+		}
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/core/state"
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/internal/ethapi"
+	"github.com/hypnosisfoundation/go-hypnosis/miner"
 	"github.com/hypnosisfoundation/go-hypnosis/rlp"
 	"github.com/hypnosisfoundation/go-hypnosis/rpc"
 	"github.com/hypnosisfoundation/go-hypnosis/trie"
@@ -141,6 +142,21 @@ func (api *PrivateMinerAPI) SetEtherbase(etherbase common.Address) bool {
 	return true
 }
 
+// SetPriorityLane updates the policy reserving block gas for the transactions
+// calling the system contracts.
+func (api *PrivateMinerAPI) SetPriorityLane(lane miner.PriorityLaneConfig) (bool, error) {
+	if err := api.e.Miner().SetPriorityLane(lane); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PriorityLane returns the policy reserving block gas for the transactions calling
+// the system contracts.
+func (api *PrivateMinerAPI) PriorityLane() miner.PriorityLaneConfig {
+	return api.e.Miner().PriorityLane()
+}
+
 // SetRecommitInterval updates the interval for miner sealing work recommitting.
 func (api *PrivateMinerAPI) SetRecommitInterval(interval int) {
 	api.e.Miner().SetRecommitInterval(time.Duration(interval) * time.Millisecond)
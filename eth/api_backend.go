@@ -72,6 +72,18 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 	if number == rpc.LatestBlockNumber {
 		return b.eth.blockchain.CurrentBlock().Header(), nil
 	}
+	if number == rpc.FinalizedBlockNumber {
+		if header := b.eth.blockchain.CurrentFinalizedBlock(); header != nil {
+			return header, nil
+		}
+		return nil, errors.New("finalized block not found")
+	}
+	if number == rpc.SafeBlockNumber {
+		if header := b.eth.blockchain.CurrentSafeBlock(); header != nil {
+			return header, nil
+		}
+		return nil, errors.New("safe block not found")
+	}
 	return b.eth.blockchain.GetHeaderByNumber(uint64(number)), nil
 }
 
@@ -106,6 +118,13 @@ func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumbe
 	if number == rpc.LatestBlockNumber {
 		return b.eth.blockchain.CurrentBlock(), nil
 	}
+	if number == rpc.FinalizedBlockNumber || number == rpc.SafeBlockNumber {
+		header, err := b.HeaderByNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
+	}
 	return b.eth.blockchain.GetBlockByNumber(uint64(number)), nil
 }
 
@@ -288,6 +307,14 @@ func (b *EthAPIBackend) JamIndex() int {
 	return b.eth.TxPool().JamIndex()
 }
 
+func (b *EthAPIBackend) JamIndexHistory(n int) []*core.JamIndexSample {
+	return b.eth.TxPool().JamIndexHistory(n)
+}
+
+func (b *EthAPIBackend) SubscribeJamIndexEvent(ch chan<- core.JamIndexEvent) event.Subscription {
+	return b.eth.TxPool().SubscribeJamIndexEvent(ch)
+}
+
 func (b *EthAPIBackend) TxPool() *core.TxPool {
 	return b.eth.TxPool()
 }
@@ -304,7 +331,7 @@ func (b *EthAPIBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	return b.gpo.SuggestTipCap(ctx)
 }
 
-func (b *EthAPIBackend) FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (firstBlock *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, err error) {
+func (b *EthAPIBackend) FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (firstBlock *big.Int, reward [][]*big.Int, baseFee []*big.Int, gasUsedRatio []float64, burntFees []*big.Int, err error) {
 	return b.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
 
@@ -312,6 +339,14 @@ func (b *EthAPIBackend) PricePrediction(ctx context.Context) ([]uint, error) {
 	return b.gpp.CurrentPrices(), nil
 }
 
+func (b *EthAPIBackend) PricePredictionV2(ctx context.Context) (*gasprice.PredictionV2, error) {
+	pred := b.gpp.CurrentPredictionV2()
+	if pred == nil {
+		return nil, errors.New("gas price prediction not available")
+	}
+	return pred, nil
+}
+
 func (b *EthAPIBackend) ChainDb() ethdb.Database {
 	return b.eth.ChainDb()
 }
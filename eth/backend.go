@@ -42,6 +42,7 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/eth/ethconfig"
 	"github.com/hypnosisfoundation/go-hypnosis/eth/filters"
 	"github.com/hypnosisfoundation/go-hypnosis/eth/gasprice"
+	"github.com/hypnosisfoundation/go-hypnosis/eth/protocols/attest"
 	"github.com/hypnosisfoundation/go-hypnosis/eth/protocols/eth"
 	"github.com/hypnosisfoundation/go-hypnosis/eth/protocols/snap"
 	"github.com/hypnosisfoundation/go-hypnosis/ethdb"
@@ -72,6 +73,7 @@ type Ethereum struct {
 	handler            *handler
 	ethDialCandidates  enode.Iterator
 	snapDialCandidates enode.Iterator
+	attestHandler      *attest.Handler // Relay of the dpos finality attestations, nil on other engines
 
 	// DB interfaces
 	chainDb ethdb.Database // Block chain database
@@ -218,6 +220,13 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		eth.txPool.InitExTxValidator(dposEngine)
 		//
 		dposEngine.SetChain(eth.blockchain)
+		// follow validator attestations to finalize blocks, relaying them to the peers
+		dposEngine.StartFinality(eth.blockchain)
+		eth.attestHandler = attest.NewHandler(dposEngine)
+		// index the validator activity of every epoch
+		if config.DposActivityIndex {
+			dposEngine.StartActivityIndexer(eth.blockchain)
+		}
 	}
 
 	// Permit the downloader to use the trie cache allowance during fast sync
@@ -589,6 +598,9 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 	if s.config.SnapshotCache > 0 {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler), s.snapDialCandidates)...)
 	}
+	if s.attestHandler != nil {
+		protos = append(protos, s.attestHandler.MakeProtocols()...)
+	}
 	return protos
 }
 
@@ -610,6 +622,11 @@ func (s *Ethereum) Start() error {
 	}
 	// Start the networking layer and the light server if requested
 	s.handler.Start(maxPeers)
+	if s.attestHandler != nil {
+		if err := s.attestHandler.Start(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -620,6 +637,9 @@ func (s *Ethereum) Stop() error {
 	s.ethDialCandidates.Close()
 	s.snapDialCandidates.Close()
 	s.handler.Stop()
+	if s.attestHandler != nil {
+		s.attestHandler.Stop()
+	}
 
 	// Then stop everything else.
 	s.bloomIndexer.Close()
@@ -104,6 +104,10 @@ var Defaults = Config{
 		GasCeil:  8000000,
 		GasPrice: big.NewInt(params.GWei),
 		Recommit: 3 * time.Second,
+		PriorityLane: miner.PriorityLaneConfig{
+			SenderLimit:  4,
+			SenderWindow: 20,
+		},
 	},
 	TxPool:      core.DefaultTxPoolConfig,
 	RPCGasCap:   25000000,
@@ -154,6 +158,8 @@ type Config struct {
 
 	TxLookupLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 
+	DposActivityIndex bool `toml:",omitempty"` // Whether to index the validator activity of every dpos epoch
+
 	// Whitelist of required block number -> hash values to accept
 	Whitelist map[uint64]common.Hash `toml:"-"`
 
@@ -138,6 +138,14 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	}
 	head := header.Number.Uint64()
 
+	var err error
+	if f.begin, err = f.resolveFinality(ctx, f.begin); err != nil {
+		return nil, err
+	}
+	if f.end, err = f.resolveFinality(ctx, f.end); err != nil {
+		return nil, err
+	}
+
 	if f.begin == -1 {
 		f.begin = int64(head)
 	}
@@ -151,10 +159,7 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	}
 
 	// Gather all indexed logs, and finish with non indexed ones
-	var (
-		logs []*types.Log
-		err  error
-	)
+	var logs []*types.Log
 	size, sections := f.backend.BloomStatus()
 	if indexed := sections * size; indexed > uint64(f.begin) {
 		if indexed > end {
@@ -171,6 +176,22 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	return logs, err
 }
 
+// resolveFinality resolves the safe and finalized block tags of a filter range
+// limit to the numbers of the blocks they refer to, leaving other limits as is.
+func (f *Filter) resolveFinality(ctx context.Context, number int64) (int64, error) {
+	if number != rpc.SafeBlockNumber.Int64() && number != rpc.FinalizedBlockNumber.Int64() {
+		return number, nil
+	}
+	header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, errors.New("unknown block")
+	}
+	return header.Number.Int64(), nil
+}
+
 // indexedLogs returns the logs matching the filter criteria based on the bloom
 // bits indexed available locally or via the network.
 func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
@@ -18,6 +18,7 @@ package filters
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -52,6 +53,7 @@ type testBackend struct {
 	rmLogsFeed      event.Feed
 	pendingLogsFeed event.Feed
 	chainFeed       event.Feed
+	safe, finalized *types.Header
 }
 
 func (b *testBackend) ChainDb() ethdb.Database {
@@ -63,6 +65,18 @@ func (b *testBackend) HeaderByNumber(ctx context.Context, blockNr rpc.BlockNumbe
 		hash common.Hash
 		num  uint64
 	)
+	switch blockNr {
+	case rpc.SafeBlockNumber:
+		if b.safe == nil {
+			return nil, errors.New("safe block not found")
+		}
+		return b.safe, nil
+	case rpc.FinalizedBlockNumber:
+		if b.finalized == nil {
+			return nil, errors.New("finalized block not found")
+		}
+		return b.finalized, nil
+	}
 	if blockNr == rpc.LatestBlockNumber {
 		hash = rawdb.ReadHeadBlockHash(b.db)
 		number := rawdb.ReadHeaderNumber(b.db, hash)
@@ -30,6 +30,7 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/crypto"
 	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"github.com/hypnosisfoundation/go-hypnosis/rpc"
 )
 
 func makeReceipt(addr common.Address) *types.Receipt {
@@ -222,4 +223,22 @@ func TestFilters(t *testing.T) {
 	if len(logs) != 0 {
 		t.Error("expected 0 log, got", len(logs))
 	}
+
+	// The safe and finalized tags resolve to the blocks marked by the consensus engine
+	filter = NewRangeFilter(backend, rpc.FinalizedBlockNumber.Int64(), -1, nil, [][]common.Hash{{hash1, hash2, hash3, hash4}})
+	if _, err := filter.Logs(context.Background()); err == nil {
+		t.Error("expected error without a finalized block")
+	}
+	backend.safe, backend.finalized = chain[998].Header(), chain[996].Header()
+
+	filter = NewRangeFilter(backend, rpc.FinalizedBlockNumber.Int64(), -1, nil, [][]common.Hash{{hash1, hash2, hash3, hash4}})
+	logs, _ = filter.Logs(context.Background())
+	if len(logs) != 2 {
+		t.Error("expected 2 log, got", len(logs))
+	}
+	filter = NewRangeFilter(backend, 0, rpc.SafeBlockNumber.Int64(), nil, [][]common.Hash{{hash1, hash2, hash3, hash4}})
+	logs, _ = filter.Logs(context.Background())
+	if len(logs) != 3 {
+		t.Error("expected 3 log, got", len(logs))
+	}
 }
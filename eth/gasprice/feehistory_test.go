@@ -22,6 +22,11 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/ethash"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
 	"github.com/hypnosisfoundation/go-hypnosis/rpc"
 )
 
@@ -50,6 +55,8 @@ func TestFeeHistory(t *testing.T) {
 		{false, 1000, 1000, 2, rpc.PendingBlockNumber, nil, 32, 1, nil},
 		{true, 1000, 1000, 2, rpc.PendingBlockNumber, nil, 32, 2, nil},
 		{true, 1000, 1000, 2, rpc.PendingBlockNumber, []float64{0, 10}, 32, 2, nil},
+		{false, 1000, 1000, 2, rpc.FinalizedBlockNumber, []float64{0, 10}, 23, 2, nil},
+		{false, 1000, 1000, 2, rpc.SafeBlockNumber, []float64{0, 10}, 27, 2, nil},
 	}
 	for i, c := range cases {
 		config := Config{
@@ -59,7 +66,7 @@ func TestFeeHistory(t *testing.T) {
 		backend := newTestBackend(t, big.NewInt(16), c.pending)
 		oracle := NewOracle(backend, config)
 
-		first, reward, baseFee, ratio, err := oracle.FeeHistory(context.Background(), c.count, c.last, c.percent)
+		first, reward, baseFee, ratio, burnt, err := oracle.FeeHistory(context.Background(), c.count, c.last, c.percent)
 
 		expReward := c.expCount
 		if len(c.percent) == 0 {
@@ -82,8 +89,50 @@ func TestFeeHistory(t *testing.T) {
 		if len(ratio) != c.expCount {
 			t.Fatalf("Test case %d: gasUsedRatio array length mismatch, want %d, got %d", i, c.expCount, len(ratio))
 		}
+		if len(burnt) != c.expCount {
+			t.Fatalf("Test case %d: burntFees array length mismatch, want %d, got %d", i, c.expCount, len(burnt))
+		}
 		if err != c.expErr && !errors.Is(err, c.expErr) {
 			t.Fatalf("Test case %d: error mismatch, want %v, got %v", i, c.expErr, err)
 		}
 	}
 }
+
+// Tests that the fee history can end at the finalized and safe blocks.
+func TestFeeHistoryFinality(t *testing.T) {
+	// Headers are enough without reward percentiles, skip the transactions
+	var (
+		gspec  = &core.Genesis{Config: params.TestChainConfig}
+		engine = ethash.NewFaker()
+		db     = rawdb.NewMemoryDatabase()
+	)
+	genesis := gspec.MustCommit(db)
+	blocks, _ := core.GenerateChain(gspec.Config, genesis, engine, db, testHead, nil)
+	chain, err := core.NewBlockChain(db, nil, gspec.Config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create local chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	oracle := NewOracle(&testBackend{chain: chain}, Config{MaxHeaderHistory: 1000, MaxBlockHistory: 1000})
+
+	for _, c := range []struct {
+		last     rpc.BlockNumber
+		count    int
+		expFirst uint64
+		expCount int
+	}{
+		{rpc.FinalizedBlockNumber, 10, testFinalized - 9, 10},
+		{rpc.SafeBlockNumber, 10, testSafe - 9, 10},
+		{rpc.FinalizedBlockNumber, 100, 0, testFinalized + 1},
+	} {
+		first, _, _, ratio, _, err := oracle.FeeHistory(context.Background(), c.count, c.last, nil)
+		if err != nil {
+			t.Fatalf("%d blocks until %d: failed to retrieve fee history: %v", c.count, c.last, err)
+		}
+		if first.Uint64() != c.expFirst || len(ratio) != c.expCount {
+			t.Errorf("%d blocks until %d: range mismatch: have %d+%d, want %d+%d", c.count, c.last, first, len(ratio), c.expFirst, c.expCount)
+		}
+	}
+}
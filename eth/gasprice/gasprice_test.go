@@ -34,7 +34,11 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/rpc"
 )
 
-const testHead = 32
+const (
+	testHead      = 32
+	testSafe      = 28 // Block the safe tag points to
+	testFinalized = 24 // Block the finalized tag points to
+)
 
 type testBackend struct {
 	chain   *core.BlockChain
@@ -48,6 +52,12 @@ func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber
 	if number == rpc.LatestBlockNumber {
 		number = testHead
 	}
+	if number == rpc.SafeBlockNumber {
+		number = testSafe
+	}
+	if number == rpc.FinalizedBlockNumber {
+		number = testFinalized
+	}
 	if number == rpc.PendingBlockNumber {
 		if b.pending {
 			number = testHead + 1
@@ -7,7 +7,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/event"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
 	"github.com/hypnosisfoundation/go-hypnosis/rpc"
@@ -25,11 +27,21 @@ type Prediction struct {
 	chainHeadCh  chan core.ChainHeadEvent
 	chainHeadSub event.Subscription
 	pool         *core.TxPool
+	txsCh        chan core.NewTxsEvent
+	txsSub       event.Subscription
 
 	predis        []uint // gas price prediction in gwei, currently will be 3 items, from hight(fast) to low(slow)
+	predV2        *PredictionV2
 	lockPredis    sync.RWMutex
 	wg            sync.WaitGroup
 	blockGasLimit uint64
+	head          *types.Header
+
+	// Calibration of the V2 prediction, only accessed by the loop
+	tracked      map[common.Hash]*seenTx // Pending transactions tracked until their inclusion
+	observations []*observation          // Ring buffer of the recent inclusions
+	obsHead      int                     // Index of the next observation
+	heads        []headTime              // Recent heads, oldest first
 }
 
 func NewPrediction(cfg Config, backend OracleBackend, pool *core.TxPool) *Prediction {
@@ -44,6 +56,8 @@ func NewPrediction(cfg Config, backend OracleBackend, pool *core.TxPool) *Predic
 		backend:     backend,
 		chainHeadCh: make(chan core.ChainHeadEvent),
 		pool:        pool,
+		txsCh:       make(chan core.NewTxsEvent, 16),
+		tracked:     make(map[common.Hash]*seenTx),
 	}
 	price := wei2GWei(cfg.Default)
 	p.predis = []uint{price * 2, price, price}
@@ -53,6 +67,7 @@ func NewPrediction(cfg Config, backend OracleBackend, pool *core.TxPool) *Predic
 
 	//subscripts chain head events
 	p.chainHeadSub = backend.SubscribeChainHeadEvent(p.chainHeadCh)
+	p.txsSub = pool.SubscribeNewTxsEvent(p.txsCh)
 	p.wg.Add(1)
 	go p.loop()
 
@@ -68,6 +83,7 @@ func (p *Prediction) Stop() {
 		return
 	}
 	p.chainHeadSub.Unsubscribe()
+	p.txsSub.Unsubscribe()
 	p.wg.Wait()
 	log.Info("prediction quit")
 }
@@ -113,6 +129,7 @@ func (p *Prediction) initTxCnts() {
 
 	//gas limit
 	p.blockGasLimit = head.GasLimit
+	p.head = head
 }
 
 func (p *Prediction) loop() {
@@ -132,8 +149,13 @@ func (p *Prediction) loop() {
 			txcnt := len(head.Transactions())
 			p.txCnts.Add(txcnt)
 			p.blockGasLimit = head.GasLimit()
+			p.head = head.Header()
+			p.observeBlock(head)
+		case ev := <-p.txsCh:
+			p.trackTxs(ev.Txs)
 		case <-p.chainHeadSub.Err():
 			log.Warn("prediction loop quitting")
+			p.txsSub.Unsubscribe()
 			return
 		}
 	}
@@ -156,6 +178,12 @@ func (p *Prediction) update() {
 	prices := make([]uint, 3)
 
 	pendingCnt := len(byprice)
+	p.pruneTracked()
+	predV2 := p.predictV2(byprice, p.head, p.pool.JamIndex())
+	p.lockPredis.Lock()
+	p.predV2 = predV2
+	p.lockPredis.Unlock()
+
 	if pendingCnt == 0 {
 		// no pending tx, use minimum prices
 		prices = []uint{minPrice, minPrice, minPrice}
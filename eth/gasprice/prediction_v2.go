@@ -0,0 +1,254 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/common/math"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/misc"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+)
+
+const (
+	maxTrackedTxs      = 16384 // Maximum pending transactions tracked until their inclusion
+	maxObservations    = 2048  // Inclusions kept to calibrate the suggestions
+	maxRecentHeads     = 256   // Recent heads kept to turn the pending time of transactions into blocks
+	minObservations    = 20    // Inclusions needed before trusting the calibration
+	confidenceTarget   = 0.9   // Share of the observed inclusions within the target a suggestion aims at
+	jamIndexSaturation = 100   // Jam index from which the tips are doubled
+)
+
+// predictionLevels are the inclusion targets of the suggestions, in blocks.
+var predictionLevels = []struct {
+	name   string
+	blocks uint64
+}{
+	{"fast", 1},
+	{"median", 3},
+	{"low", 10},
+}
+
+// Suggestion is a suggestion of fees for an EIP-1559 transaction to be included
+// within an estimated number of blocks, with the observed confidence of it.
+type Suggestion struct {
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Blocks     uint64  // Estimated number of blocks until inclusion
+	Calibrated bool    // Whether the suggestion is calibrated from enough inclusions
+	Confidence float64 // Share of the transactions paying at least the tip included within Blocks, 0 if not calibrated
+}
+
+// PredictionV2 is the result of the calibrated prediction model.
+type PredictionV2 struct {
+	BaseFee      *big.Int // Base fee of the next block, nil before London
+	JamIndex     int
+	Observations int // Inclusions the suggestions are calibrated with
+	Suggestions  map[string]*Suggestion
+}
+
+// seenTx is a pending transaction tracked until its inclusion.
+type seenTx struct {
+	seen   time.Time
+	tipCap *big.Int
+	feeCap *big.Int
+}
+
+// observation is a transaction inclusion, with the tip it paid and how many
+// blocks it was pending for.
+type observation struct {
+	tip    *big.Int
+	blocks uint64
+}
+
+// headTime is the number and time of a recent head.
+type headTime struct {
+	number uint64
+	time   uint64
+}
+
+// trackTxs tracks the transactions entering the pool until their inclusion.
+func (p *Prediction) trackTxs(txs []*types.Transaction) {
+	for _, tx := range txs {
+		if len(p.tracked) >= maxTrackedTxs {
+			return
+		}
+		p.tracked[tx.Hash()] = &seenTx{seen: tx.LocalSeenTime(), tipCap: tx.GasTipCap(), feeCap: tx.GasFeeCap()}
+	}
+}
+
+// observeBlock records the inclusions of the tracked transactions by a new head.
+func (p *Prediction) observeBlock(block *types.Block) {
+	number, now := block.NumberU64(), block.Time()
+	p.heads = append(p.heads, headTime{number: number, time: now})
+	if len(p.heads) > maxRecentHeads {
+		p.heads = p.heads[len(p.heads)-maxRecentHeads:]
+	}
+	for _, tx := range block.Transactions() {
+		seen, ok := p.tracked[tx.Hash()]
+		if !ok {
+			continue
+		}
+		delete(p.tracked, tx.Hash())
+
+		tip := seen.tipCap
+		if baseFee := block.BaseFee(); baseFee != nil {
+			tip = math.BigMin(tip, new(big.Int).Sub(seen.feeCap, baseFee))
+		}
+		// Count the blocks produced since the transaction was seen
+		blocks := uint64(1)
+		for i := len(p.heads) - 2; i >= 0 && p.heads[i].time >= uint64(seen.seen.Unix()); i-- {
+			blocks++
+		}
+		p.observe(&observation{tip: tip, blocks: blocks})
+	}
+}
+
+// observe adds an inclusion to the observations ring.
+func (p *Prediction) observe(obs *observation) {
+	if len(p.observations) < maxObservations {
+		p.observations = append(p.observations, obs)
+	} else {
+		p.observations[p.obsHead] = obs
+	}
+	p.obsHead = (p.obsHead + 1) % maxObservations
+}
+
+// pruneTracked stops tracking the transactions pending for too long to be
+// accounted, most likely dropped from the pool.
+func (p *Prediction) pruneTracked() {
+	maxlive := time.Duration(p.cfg.MaxValidPendingSecs) * time.Second
+	for hash, seen := range p.tracked {
+		if time.Since(seen.seen) > maxlive {
+			delete(p.tracked, hash)
+		}
+	}
+}
+
+// calibrate returns the lowest observed tip such that the given share of the
+// inclusions paying at least it happened within the target blocks, along with
+// the share reached and the median blocks until their inclusion. The tip is nil
+// if there are not enough observations.
+func calibrate(observations []*observation, target uint64, confidence float64) (*big.Int, float64, uint64) {
+	if len(observations) < minObservations {
+		return nil, 0, 0
+	}
+	obs := make([]*observation, len(observations))
+	copy(obs, observations)
+	sort.Slice(obs, func(i, j int) bool {
+		return obs[i].tip.Cmp(obs[j].tip) > 0
+	})
+	var (
+		tip      *big.Int
+		reached  float64
+		included int
+		blocks   uint64
+	)
+	for i, o := range obs {
+		if o.blocks <= target {
+			included++
+		}
+		// Only settle on the lowest of equal tips, with enough inclusions above it
+		if (i+1 < len(obs) && obs[i+1].tip.Cmp(o.tip) == 0) || i+1 < minObservations {
+			continue
+		}
+		if share := float64(included) / float64(i+1); share >= confidence {
+			tip, reached = o.tip, share
+			blocks = medianBlocks(obs[:i+1])
+		}
+	}
+	if tip == nil {
+		// Not reachable, suggest the highest tip observed
+		tip = obs[0].tip
+		reached = float64(included) / float64(len(obs))
+		blocks = medianBlocks(obs)
+	}
+	return tip, reached, blocks
+}
+
+// medianBlocks returns the median blocks until inclusion of the observations.
+func medianBlocks(obs []*observation) uint64 {
+	blocks := make([]uint64, len(obs))
+	for i, o := range obs {
+		blocks[i] = o.blocks
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+	return blocks[len(blocks)/2]
+}
+
+// predictV2 computes the calibrated suggestions from the observed inclusions, the
+// pending transactions they compete with, sorted by tip, and the jam index.
+func (p *Prediction) predictV2(byprice TxByPrice, head *types.Header, jamIndex int) *PredictionV2 {
+	pred := &PredictionV2{
+		JamIndex:     jamIndex,
+		Observations: len(p.observations),
+		Suggestions:  make(map[string]*Suggestion),
+	}
+	if head != nil && p.backend.ChainConfig().IsLondon(new(big.Int).Add(head.Number, common.Big1)) {
+		pred.BaseFee = misc.CalcBaseFee(p.backend.ChainConfig(), head)
+	}
+	minTip := p.pool.GasPrice()
+
+	avgTxCnt := p.txCnts.Avg()
+	if avgTxCnt < p.cfg.MinTxCntPerBlock {
+		avgTxCnt = p.cfg.MinTxCntPerBlock
+	}
+	for _, level := range predictionLevels {
+		tip, confidence, blocks := calibrate(p.observations, level.blocks, confidenceTarget)
+		calibrated := tip != nil
+		if !calibrated {
+			// Not calibrated yet, assume the pool is drained at the average pace
+			tip, blocks = new(big.Int).Set(minTip), level.blocks
+		}
+		// Outbid the pending transactions the pool can't include before the target
+		if ahead := int(level.blocks) * avgTxCnt; ahead < len(byprice) {
+			if pending := byprice[ahead].GasTipCap(); pending.Cmp(tip) > 0 {
+				tip = new(big.Int).Set(pending)
+			}
+		}
+		if tip.Cmp(minTip) < 0 {
+			tip = new(big.Int).Set(minTip)
+		}
+		// Raise the tip with the jam, which the observations lag behind
+		if jamIndex > 0 {
+			jam := jamIndex
+			if jam > jamIndexSaturation {
+				jam = jamIndexSaturation
+			}
+			tip = new(big.Int).Div(new(big.Int).Mul(tip, big.NewInt(int64(jamIndexSaturation+jam))), big.NewInt(jamIndexSaturation))
+			confidence = confidence * jamIndexSaturation / float64(jamIndexSaturation+jam)
+		}
+		suggestion := &Suggestion{GasTipCap: tip, GasFeeCap: new(big.Int).Set(tip), Blocks: blocks, Calibrated: calibrated, Confidence: confidence}
+		if pred.BaseFee != nil {
+			// Leave room for the base fee to rise for a few full blocks
+			suggestion.GasFeeCap.Add(suggestion.GasFeeCap, new(big.Int).Mul(pred.BaseFee, common.Big2))
+		}
+		pred.Suggestions[level.name] = suggestion
+	}
+	return pred
+}
+
+// CurrentPredictionV2 returns the current calibrated prediction, nil if the
+// prediction is not running.
+func (p *Prediction) CurrentPredictionV2() *PredictionV2 {
+	p.lockPredis.RLock()
+	defer p.lockPredis.RUnlock()
+	return p.predV2
+}
@@ -0,0 +1,191 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/ethash"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/misc"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+func TestCalibrate(t *testing.T) {
+	var observations []*observation
+	add := func(tip int64, blocks uint64, n int) {
+		for i := 0; i < n; i++ {
+			observations = append(observations, &observation{tip: big.NewInt(tip), blocks: blocks})
+		}
+	}
+	if tip, _, _ := calibrate(observations, 1, confidenceTarget); tip != nil {
+		t.Fatalf("calibrated without observations: %v", tip)
+	}
+	// High tips are included in the next block, low ones wait a few
+	add(3e9, 1, 30)
+	add(2e9, 1, 10)
+	add(2e9, 2, 1)
+	add(1e9, 4, 20)
+
+	for i, tt := range []struct {
+		target     uint64
+		tip        int64
+		confidence float64
+		blocks     uint64
+	}{
+		{target: 1, tip: 2e9, confidence: 40.0 / 41, blocks: 1},
+		{target: 4, tip: 1e9, confidence: 1, blocks: 1},
+		{target: 0, tip: 3e9, confidence: 0, blocks: 1}, // Not reachable
+	} {
+		tip, confidence, blocks := calibrate(observations, tt.target, confidenceTarget)
+		if tip == nil || tip.Cmp(big.NewInt(tt.tip)) != 0 {
+			t.Errorf("test %d: tip mismatch: have %v, want %v", i, tip, tt.tip)
+		}
+		if confidence != tt.confidence {
+			t.Errorf("test %d: confidence mismatch: have %v, want %v", i, confidence, tt.confidence)
+		}
+		if blocks != tt.blocks {
+			t.Errorf("test %d: blocks mismatch: have %v, want %v", i, blocks, tt.blocks)
+		}
+	}
+}
+
+func TestObserveBlock(t *testing.T) {
+	var (
+		now     = time.Now()
+		baseFee = big.NewInt(10)
+		p       = &Prediction{tracked: make(map[common.Hash]*seenTx)}
+	)
+	newTx := func(nonce uint64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{Nonce: nonce})
+	}
+	newBlock := func(number uint64, time time.Time, txs ...*types.Transaction) *types.Block {
+		header := &types.Header{Number: new(big.Int).SetUint64(number), Time: uint64(time.Unix()), BaseFee: baseFee}
+		return types.NewBlockWithHeader(header).WithBody(txs, nil)
+	}
+	slow, fast, untracked := newTx(0), newTx(1), newTx(2)
+	p.tracked[slow.Hash()] = &seenTx{seen: now, tipCap: big.NewInt(5), feeCap: big.NewInt(12)}
+	p.tracked[fast.Hash()] = &seenTx{seen: now.Add(7 * time.Second), tipCap: big.NewInt(5), feeCap: big.NewInt(20)}
+
+	// The slow one is seen after the first head, included by the third
+	p.observeBlock(newBlock(1, now.Add(-time.Second)))
+	p.observeBlock(newBlock(2, now.Add(3*time.Second)))
+	p.observeBlock(newBlock(3, now.Add(6*time.Second), slow, untracked))
+	// The fast one is included by the next block
+	p.observeBlock(newBlock(4, now.Add(9*time.Second), fast))
+
+	if len(p.tracked) != 0 {
+		t.Errorf("included transactions still tracked: %d", len(p.tracked))
+	}
+	if len(p.heads) != 4 {
+		t.Errorf("heads mismatch: have %d, want %d", len(p.heads), 4)
+	}
+	want := []observation{
+		{tip: big.NewInt(2), blocks: 2}, // Tip capped by the fee cap over the base fee
+		{tip: big.NewInt(5), blocks: 1},
+	}
+	if len(p.observations) != len(want) {
+		t.Fatalf("observations mismatch: have %d, want %d", len(p.observations), len(want))
+	}
+	for i, obs := range p.observations {
+		if obs.tip.Cmp(want[i].tip) != 0 || obs.blocks != want[i].blocks {
+			t.Errorf("observation %d mismatch: have %v/%d, want %v/%d", i, obs.tip, obs.blocks, want[i].tip, want[i].blocks)
+		}
+	}
+}
+
+func TestPredictV2(t *testing.T) {
+	config := *params.TestChainConfig
+	config.LondonBlock = common.Big0
+
+	db := rawdb.NewMemoryDatabase()
+	(&core.Genesis{Config: &config, BaseFee: big.NewInt(params.InitialBaseFee)}).MustCommit(db)
+	chain, err := core.NewBlockChain(db, nil, &config, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	poolConfig := core.DefaultTxPoolConfig
+	poolConfig.Journal = ""
+	pool := core.NewTxPool(poolConfig, &config, chain)
+	defer pool.Stop()
+
+	var (
+		head    = chain.CurrentHeader()
+		minTip  = pool.GasPrice()
+		baseFee = misc.CalcBaseFee(&config, head)
+		p       = &Prediction{
+			cfg:     &Config{PredConfig: PredConfig{MinTxCntPerBlock: 1}},
+			backend: &testBackend{chain: chain},
+			pool:    pool,
+			txCnts:  NewStats([]int{1}),
+		}
+	)
+	check := func(pred *PredictionV2, name string, tip *big.Int, blocks uint64, calibrated bool, confidence float64) {
+		t.Helper()
+		sug := pred.Suggestions[name]
+		if sug == nil {
+			t.Fatalf("%s: missing suggestion", name)
+		}
+		if sug.GasTipCap.Cmp(tip) != 0 {
+			t.Errorf("%s: tip mismatch: have %v, want %v", name, sug.GasTipCap, tip)
+		}
+		if feeCap := new(big.Int).Add(tip, new(big.Int).Mul(baseFee, common.Big2)); sug.GasFeeCap.Cmp(feeCap) != 0 {
+			t.Errorf("%s: fee cap mismatch: have %v, want %v", name, sug.GasFeeCap, feeCap)
+		}
+		if sug.Blocks != blocks || sug.Calibrated != calibrated || sug.Confidence != confidence {
+			t.Errorf("%s: suggestion mismatch: have %d/%v/%v, want %d/%v/%v", name, sug.Blocks, sug.Calibrated, sug.Confidence, blocks, calibrated, confidence)
+		}
+	}
+	// Without observations, the minimum tip is suggested with no confidence
+	pred := p.predictV2(nil, head, 0)
+	if pred.BaseFee == nil || pred.BaseFee.Cmp(baseFee) != 0 {
+		t.Errorf("base fee mismatch: have %v, want %v", pred.BaseFee, baseFee)
+	}
+	for _, level := range predictionLevels {
+		check(pred, level.name, minTip, level.blocks, false, 0)
+	}
+	// The pending transactions ahead of the target are outbid
+	var byprice TxByPrice
+	for _, tip := range []int64{5e9, 4e9, 3e9, 2e9} {
+		byprice = append(byprice, types.NewTx(&types.DynamicFeeTx{GasTipCap: big.NewInt(tip), GasFeeCap: big.NewInt(tip)}))
+	}
+	pred = p.predictV2(byprice, head, 0)
+	check(pred, "fast", big.NewInt(4e9), 1, false, 0)
+	check(pred, "median", big.NewInt(2e9), 3, false, 0)
+	check(pred, "low", minTip, 10, false, 0)
+
+	// Once calibrated, the observed tip is suggested, raised with the jam
+	for i := 0; i < minObservations; i++ {
+		p.observe(&observation{tip: big.NewInt(3e9), blocks: 1})
+	}
+	pred = p.predictV2(nil, head, 0)
+	if pred.Observations != minObservations {
+		t.Errorf("observations mismatch: have %d, want %d", pred.Observations, minObservations)
+	}
+	check(pred, "fast", big.NewInt(3e9), 1, true, 1)
+	check(pred, "low", big.NewInt(3e9), 1, true, 1)
+
+	pred = p.predictV2(nil, head, 2*jamIndexSaturation)
+	check(pred, "fast", big.NewInt(6e9), 1, true, 0.5)
+}
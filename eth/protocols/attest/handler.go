@@ -0,0 +1,196 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package attest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
+	"github.com/hypnosisfoundation/go-hypnosis/event"
+	"github.com/hypnosisfoundation/go-hypnosis/p2p"
+	"github.com/hypnosisfoundation/go-hypnosis/p2p/enode"
+)
+
+// attestationChanSize is the size of the channel listening to the attestations
+// accepted by the finality gadget.
+const attestationChanSize = 256
+
+// Backend is the finality gadget whose attestations are exchanged with the
+// remote peers.
+type Backend interface {
+	// SubmitAttestation verifies an attestation received from a remote peer and
+	// adds it to the finality gadget.
+	SubmitAttestation(att *dpos.Attestation) error
+
+	// SubscribeAttestations subscribes to the attestations accepted by the
+	// finality gadget, local and remote ones, to relay them.
+	SubscribeAttestations(ch chan<- *dpos.Attestation) (event.Subscription, error)
+}
+
+// Handler relays the attestations of the finality gadget between the peers
+// running the `attest` protocol.
+type Handler struct {
+	backend Backend
+
+	peers map[string]*Peer
+	lock  sync.RWMutex
+
+	sub event.Subscription
+	wg  sync.WaitGroup
+}
+
+// NewHandler creates a handler relaying the attestations of the backend.
+func NewHandler(backend Backend) *Handler {
+	return &Handler{
+		backend: backend,
+		peers:   make(map[string]*Peer),
+	}
+}
+
+// MakeProtocols constructs the P2P protocol definitions for `attest`.
+func (h *Handler) MakeProtocols() []p2p.Protocol {
+	protocols := make([]p2p.Protocol, len(ProtocolVersions))
+	for i, version := range ProtocolVersions {
+		version := version // Closure
+
+		protocols[i] = p2p.Protocol{
+			Name:    ProtocolName,
+			Version: version,
+			Length:  protocolLengths[version],
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return h.runPeer(newPeer(version, p, rw))
+			},
+			NodeInfo: func() interface{} {
+				return nil
+			},
+			PeerInfo: func(id enode.ID) interface{} {
+				return nil
+			},
+		}
+	}
+	return protocols
+}
+
+// Start starts relaying the attestations accepted by the finality gadget.
+func (h *Handler) Start() error {
+	ch := make(chan *dpos.Attestation, attestationChanSize)
+	sub, err := h.backend.SubscribeAttestations(ch)
+	if err != nil {
+		return err
+	}
+	h.sub = sub
+
+	h.wg.Add(1)
+	go h.broadcastLoop(ch)
+	return nil
+}
+
+// Stop terminates the attestation relay.
+func (h *Handler) Stop() {
+	if h.sub != nil {
+		h.sub.Unsubscribe()
+	}
+	h.wg.Wait()
+}
+
+// runPeer registers the peer and handles its messages until it disconnects.
+func (h *Handler) runPeer(peer *Peer) error {
+	h.lock.Lock()
+	if _, ok := h.peers[peer.id]; ok {
+		h.lock.Unlock()
+		return p2p.DiscAlreadyConnected
+	}
+	h.peers[peer.id] = peer
+	h.lock.Unlock()
+
+	defer func() {
+		h.lock.Lock()
+		delete(h.peers, peer.id)
+		h.lock.Unlock()
+		peer.close()
+	}()
+	for {
+		if err := h.handleMessage(peer); err != nil {
+			peer.Log().Debug("Message handling failed in `attest`", "err", err)
+			return err
+		}
+	}
+}
+
+// handleMessage is invoked whenever an inbound message is received from a
+// remote peer on the `attest` protocol. The remote connection is torn down upon
+// returning any error.
+func (h *Handler) handleMessage(peer *Peer) error {
+	// Read the next message from the remote peer, and ensure it's fully consumed
+	msg, err := peer.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	if msg.Size > maxMessageSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
+	}
+	defer msg.Discard()
+
+	switch msg.Code {
+	case AttestationsMsg:
+		var atts AttestationsPacket
+		if err := msg.Decode(&atts); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		for i, att := range atts {
+			if att == nil {
+				return fmt.Errorf("%w: attestation %d is nil", errDecode, i)
+			}
+			// Attestations may target blocks not yet imported locally or come
+			// from validators of another fork, so rejects are not punished
+			peer.markAttestation(att.Hash())
+			if err := h.backend.SubmitAttestation(att); err != nil {
+				peer.Log().Trace("Rejected attestation", "target", att.TargetNumber, "err", err)
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %v", errInvalidMsgCode, msg.Code)
+	}
+}
+
+// broadcastLoop relays the attestations accepted by the finality gadget to the
+// peers not knowing them yet. The gadget only accepts an attestation newer than
+// the latest one of its validator, so relayed attestations don't loop around.
+func (h *Handler) broadcastLoop(ch chan *dpos.Attestation) {
+	defer h.wg.Done()
+
+	for {
+		select {
+		case att := <-ch:
+			hash := att.Hash()
+
+			h.lock.RLock()
+			for _, peer := range h.peers {
+				if !peer.KnownAttestation(hash) {
+					peer.AsyncSendAttestations([]*dpos.Attestation{att})
+				}
+			}
+			h.lock.RUnlock()
+
+		case <-h.sub.Err():
+			return
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package attest
+
+import (
+	"crypto/rand"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
+	"github.com/hypnosisfoundation/go-hypnosis/event"
+	"github.com/hypnosisfoundation/go-hypnosis/p2p"
+	"github.com/hypnosisfoundation/go-hypnosis/p2p/enode"
+)
+
+// testBackend is a finality gadget accepting every attestation it hasn't seen.
+type testBackend struct {
+	feed event.Feed
+	seen map[common.Hash]bool
+	lock sync.Mutex
+}
+
+func newTestBackend() *testBackend {
+	return &testBackend{seen: make(map[common.Hash]bool)}
+}
+
+func (b *testBackend) SubmitAttestation(att *dpos.Attestation) error {
+	b.lock.Lock()
+	if b.seen[att.Hash()] {
+		b.lock.Unlock()
+		return errors.New("known attestation")
+	}
+	b.seen[att.Hash()] = true
+	b.lock.Unlock()
+
+	b.feed.Send(att)
+	return nil
+}
+
+func (b *testBackend) SubscribeAttestations(ch chan<- *dpos.Attestation) (event.Subscription, error) {
+	return b.feed.Subscribe(ch), nil
+}
+
+func (b *testBackend) count() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return len(b.seen)
+}
+
+// newTestHandler creates a started handler relaying the attestations of a test
+// backend.
+func newTestHandler(t *testing.T) (*Handler, *testBackend) {
+	backend := newTestBackend()
+	handler := NewHandler(backend)
+	if err := handler.Start(); err != nil {
+		t.Fatalf("failed to start handler: %v", err)
+	}
+	return handler, backend
+}
+
+// connect links two handlers through a message pipe.
+func connect(a, b *Handler) (p2p.MsgReadWriter, p2p.MsgReadWriter, <-chan error) {
+	var idA, idB enode.ID
+	rand.Read(idA[:])
+	rand.Read(idB[:])
+
+	app, net := p2p.MsgPipe()
+	errc := make(chan error, 2)
+	go func() { errc <- a.runPeer(newPeer(attest1, p2p.NewPeer(idB, "b", nil), app)) }()
+	go func() { errc <- b.runPeer(newPeer(attest1, p2p.NewPeer(idA, "a", nil), net)) }()
+	return app, net, errc
+}
+
+func TestAttestationRelay(t *testing.T) {
+	// Chain three handlers: a - b - c
+	a, backendA := newTestHandler(t)
+	defer a.Stop()
+	b, backendB := newTestHandler(t)
+	defer b.Stop()
+	c, backendC := newTestHandler(t)
+	defer c.Stop()
+
+	ab, _, _ := connect(a, b)
+	defer ab.(*p2p.MsgPipeRW).Close()
+	bc, _, _ := connect(b, c)
+	defer bc.(*p2p.MsgPipeRW).Close()
+
+	// Wait for the peers to register before attesting
+	for deadline := time.Now().Add(time.Second); ; {
+		a.lock.RLock()
+		b.lock.RLock()
+		ready := len(a.peers) == 1 && len(b.peers) == 2
+		b.lock.RUnlock()
+		a.lock.RUnlock()
+		if ready {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("peers not registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	att := &dpos.Attestation{SourceNumber: 1, TargetNumber: 2, TargetHash: common.Hash{0x02}, Signature: make([]byte, 65)}
+	if err := backendA.SubmitAttestation(att); err != nil {
+		t.Fatalf("failed to attest: %v", err)
+	}
+	for deadline := time.Now().Add(time.Second); backendC.count() == 0; {
+		if time.Now().After(deadline) {
+			t.Fatalf("attestation not relayed: b has %d, c has %d", backendB.count(), backendC.count())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// The attestation isn't echoed back to the peers knowing it
+	time.Sleep(50 * time.Millisecond)
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	for _, peer := range b.peers {
+		if !peer.KnownAttestation(att.Hash()) {
+			t.Errorf("peer %s not marked as knowing the attestation", peer.ID())
+		}
+	}
+}
+
+func TestInvalidMessage(t *testing.T) {
+	a, _ := newTestHandler(t)
+	defer a.Stop()
+
+	var id enode.ID
+	rand.Read(id[:])
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+
+	errc := make(chan error, 1)
+	go func() { errc <- a.runPeer(newPeer(attest1, p2p.NewPeer(id, "b", nil), net)) }()
+
+	if err := p2p.Send(app, 0x01, []uint{}); err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+	select {
+	case err := <-errc:
+		if !errors.Is(err, errInvalidMsgCode) {
+			t.Errorf("error mismatch: have %v, want %v", err, errInvalidMsgCode)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("peer not dropped")
+	}
+}
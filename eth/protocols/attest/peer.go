@@ -0,0 +1,134 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package attest
+
+import (
+	mapset "github.com/deckarep/golang-set"
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
+	"github.com/hypnosisfoundation/go-hypnosis/log"
+	"github.com/hypnosisfoundation/go-hypnosis/p2p"
+)
+
+const (
+	// maxKnownAttestations is the maximum attestation hashes to keep in the known
+	// list before starting to randomly evict them.
+	maxKnownAttestations = 4096
+
+	// maxQueuedAttestations is the maximum number of attestation batches to queue
+	// up before dropping broadcasts.
+	maxQueuedAttestations = 128
+)
+
+// Peer is a collection of relevant information we have about an `attest` peer.
+type Peer struct {
+	id string // Unique ID for the peer, cached
+
+	*p2p.Peer                   // The embedded P2P package peer
+	rw        p2p.MsgReadWriter // Input/output streams for attest
+	version   uint              // Protocol version negotiated
+
+	known mapset.Set               // Set of attestation hashes known to be known by this peer
+	queue chan []*dpos.Attestation // Queue of attestations to broadcast to the peer
+	term  chan struct{}            // Termination channel to stop the broadcaster
+	log   log.Logger               // Contextual logger with the peer id injected
+}
+
+// newPeer creates a wrapper for a network connection and negotiated protocol
+// version.
+func newPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+	id := p.ID().String()
+	peer := &Peer{
+		id:      id,
+		Peer:    p,
+		rw:      rw,
+		version: version,
+		known:   mapset.NewSet(),
+		queue:   make(chan []*dpos.Attestation, maxQueuedAttestations),
+		term:    make(chan struct{}),
+		log:     log.New("peer", id[:8]),
+	}
+	go peer.broadcastAttestations()
+	return peer
+}
+
+// close signals the broadcast goroutine to terminate.
+func (p *Peer) close() {
+	close(p.term)
+}
+
+// ID retrieves the peer's unique identifier.
+func (p *Peer) ID() string {
+	return p.id
+}
+
+// Version retrieves the peer's negotiated `attest` protocol version.
+func (p *Peer) Version() uint {
+	return p.version
+}
+
+// Log overrides the P2P logger with the higher level one containing only the id.
+func (p *Peer) Log() log.Logger {
+	return p.log
+}
+
+// KnownAttestation returns whether the peer is known to already have an
+// attestation.
+func (p *Peer) KnownAttestation(hash common.Hash) bool {
+	return p.known.Contains(hash)
+}
+
+// markAttestation marks an attestation as known for the peer, ensuring that it
+// will never be propagated to this particular peer.
+func (p *Peer) markAttestation(hash common.Hash) {
+	// If we reached the memory allowance, drop a previously known attestation hash
+	for p.known.Cardinality() >= maxKnownAttestations {
+		p.known.Pop()
+	}
+	p.known.Add(hash)
+}
+
+// AsyncSendAttestations queues a batch of attestations for propagation to the
+// remote peer. If the peer's broadcast queue is full, the batch is silently
+// dropped.
+func (p *Peer) AsyncSendAttestations(atts []*dpos.Attestation) {
+	select {
+	case p.queue <- atts:
+		for _, att := range atts {
+			p.markAttestation(att.Hash())
+		}
+	default:
+		p.Log().Debug("Dropping attestation propagation", "count", len(atts))
+	}
+}
+
+// broadcastAttestations is a write loop that sends the queued attestations to
+// the remote peer. The goroutine stops when the peer is closed.
+func (p *Peer) broadcastAttestations() {
+	for {
+		select {
+		case atts := <-p.queue:
+			if err := p2p.Send(p.rw, AttestationsMsg, AttestationsPacket(atts)); err != nil {
+				return
+			}
+			p.Log().Trace("Sent attestations", "count", len(atts))
+
+		case <-p.term:
+			return
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package attest
+
+import (
+	"errors"
+
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	attest1 = 1
+)
+
+// ProtocolName is the official short name of the `attest` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "attest"
+
+// ProtocolVersions are the supported versions of the `attest` protocol (first
+// is primary).
+var ProtocolVersions = []uint{attest1}
+
+// protocolLengths are the number of implemented message corresponding to
+// different protocol versions.
+var protocolLengths = map[uint]uint64{attest1: 1}
+
+// maxMessageSize is the maximum cap on the size of a protocol message.
+const maxMessageSize = 1024 * 1024
+
+const (
+	AttestationsMsg = 0x00
+)
+
+var (
+	errMsgTooLarge    = errors.New("message too long")
+	errDecode         = errors.New("invalid message")
+	errInvalidMsgCode = errors.New("invalid message code")
+)
+
+// AttestationsPacket is the network packet for relaying the attestations of the
+// dpos finality gadget.
+type AttestationsPacket []*dpos.Attestation
@@ -977,13 +977,24 @@ func (api *API) traceResult(tracer vm.Tracer, result *core.ExecutionResult) (int
 
 // APIs return the collection of RPC services the tracer package offers.
 func APIs(backend Backend) []rpc.API {
-	// Append all the local APIs and return
-	return []rpc.API{
+	api := NewAPI(backend)
+	apis := []rpc.API{
 		{
 			Namespace: "debug",
 			Version:   "1.0",
-			Service:   NewAPI(backend),
+			Service:   api,
 			Public:    false,
 		},
 	}
+	// Governance proposals of dpos can be simulated with the same machinery
+	if engine, ok := backend.Engine().(proposalSimulator); ok {
+		apis = append(apis, rpc.API{
+			Namespace: "dpos",
+			Version:   "1.0",
+			Service:   &ProposalAPI{api: api, engine: engine},
+			Public:    false,
+		})
+	}
+	// Append all the local APIs and return
+	return apis
 }
@@ -6,7 +6,7 @@
 // evmdis_tracer.js (4.195kB)
 // noop_tracer.js (1.271kB)
 // opcount_tracer.js (1.372kB)
-// prestate_tracer.js (4.287kB)
+// prestate_tracer.js (5.191kB)
 // trigram_tracer.js (1.788kB)
 // unigram_tracer.js (1.469kB)
 
@@ -197,7 +197,7 @@ func opcount_tracerJs() (*asset, error) {
 	return a, nil
 }
 
-var _prestate_tracerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x57\xdd\x6f\xdb\x38\x12\x7f\xb6\xfe\x8a\x41\x5f\x6c\x5d\x5d\xb9\xcd\x02\x7b\x80\x73\x39\x40\x75\xdd\x36\x40\x36\x09\x6c\xe7\x72\xb9\xc5\x3e\x50\xe4\x48\xe6\x9a\x26\x05\x92\xb2\xe3\x2b\xf2\xbf\x1f\x86\xfa\xf0\x47\x93\xa6\x7b\x6f\x16\x39\xfc\xcd\xf7\x6f\xc6\xa3\x11\x4c\x4c\xb9\xb3\xb2\x58\x7a\x38\x7b\xff\xe1\xef\xb0\x58\x22\x14\xe6\x1d\xfa\x25\x5a\xac\xd6\x90\x56\x7e\x69\xac\x8b\x46\x23\x58\x2c\xa5\x83\x5c\x2a\x04\xe9\xa0\x64\xd6\x83\xc9\xc1\x9f\xc8\x2b\x99\x59\x66\x77\x49\x34\x1a\xd5\x6f\x9e\xbd\x26\x84\xdc\x22\x82\x33\xb9\xdf\x32\x8b\x63\xd8\x99\x0a\x38\xd3\x60\x51\x48\xe7\xad\xcc\x2a\x8f\x20\x3d\x30\x2d\x46\xc6\xc2\xda\x08\x99\xef\x08\x52\x7a\xa8\xb4\x40\x1b\x54\x7b\xb4\x6b\xd7\xda\xf1\xe5\xfa\x0e\xae\xd0\x39\xb4\xf0\x05\x35\x5a\xa6\xe0\xb6\xca\x94\xe4\x70\x25\x39\x6a\x87\xc0\x1c\x94\x74\xe2\x96\x28\x20\x0b\x70\xf4\xf0\x33\x99\x32\x6f\x4c\x81\xcf\xa6\xd2\x82\x79\x69\xf4\x10\x50\x92\xe5\xb0\x41\xeb\xa4\xd1\xf0\x4b\xab\xaa\x01\x1c\x82\xb1\x04\x32\x60\x9e\x1c\xb0\x60\x4a\x7a\x17\x03\xd3\x3b\x50\xcc\xef\x9f\xfe\x44\x40\xf6\x7e\x0b\x90\x3a\xa8\x59\x9a\x12\xc1\x2f\x99\x27\xaf\xb7\x52\x29\xc8\x10\x2a\x87\x79\xa5\x86\x84\x96\x55\x1e\xee\x2f\x17\x5f\x6f\xee\x16\x90\x5e\x3f\xc0\x7d\x3a\x9b\xa5\xd7\x8b\x87\x73\xd8\x4a\xbf\x34\x95\x07\xdc\x60\x0d\x25\xd7\xa5\x92\x28\x60\xcb\xac\x65\xda\xef\xc0\xe4\x84\xf0\xdb\x74\x36\xf9\x9a\x5e\x2f\xd2\x8f\x97\x57\x97\x8b\x07\x30\x16\x3e\x5f\x2e\xae\xa7\xf3\x39\x7c\xbe\x99\x41\x0a\xb7\xe9\x6c\x71\x39\xb9\xbb\x4a\x67\x70\x7b\x37\xbb\xbd\x99\x4f\x13\x98\x23\x59\x85\xf4\xfe\xf5\x98\xe7\x21\x7b\x16\x41\xa0\x67\x52\xb9\x36\x12\x0f\xa6\x02\xb7\x34\x95\x12\xb0\x64\x1b\x04\x8b\x1c\xe5\x06\x05\x30\xe0\xa6\xdc\xfd\x74\x52\x09\x8b\x29\xa3\x8b\xe0\xf3\x8b\x05\x09\x97\x39\x68\xe3\x87\xe0\x10\xe1\x1f\x4b\xef\xcb\xf1\x68\xb4\xdd\x6e\x93\x42\x57\x89\xb1\xc5\x48\xd5\x70\x6e\xf4\xcf\x24\x22\xcc\xd2\xa2\xf3\xcc\xe3\xc2\x32\x8e\x16\x4c\xe5\xcb\xca\x3b\x70\x55\x9e\x4b\x2e\x51\x7b\x90\x3a\x37\x76\x1d\x2a\x05\xbc\x01\x6e\x91\x79\x04\x06\xca\x70\xa6\x00\x1f\x91\x57\xe1\xae\x8e\x74\x28\x57\xcb\xb4\x63\x3c\x9c\xe6\xd6\xac\xc9\xd7\xca\x79\xfa\xe1\x1c\xae\x33\x85\x02\x0a\xd4\xe8\xa4\x83\x4c\x19\xbe\x4a\xa2\x6f\x51\xef\xc0\x18\xaa\x93\xe0\x61\x23\x14\x6a\x63\x8b\x7d\x8b\x90\x55\x52\x09\xa9\x8b\x24\xea\xb5\xd2\x63\xd0\x95\x52\xc3\x28\x40\x28\x63\x56\x55\x99\x72\x6e\xaa\x60\xfb\x9f\xc8\x7d\x0d\xe6\x4a\xe4\x32\xa7\xe2\x60\xdd\xad\x37\xe1\xaa\xd3\x6b\x32\x92\x4f\xa2\xde\x11\xcc\x18\xf2\x4a\x07\x77\x06\x4c\x08\x3b\x04\x91\xc5\xdf\xa2\x5e\x6f\xc3\x2c\x61\xc1\x05\x78\xf3\x15\x1f\xc3\x65\x7c\x1e\xf5\x7a\x32\x87\x81\x5f\x4a\x97\xb4\xc0\xbf\x33\xce\xff\x80\x8b\x8b\x8b\xd0\xd4\xb9\xd4\x28\x62\x20\x88\xde\x73\x62\xf5\x4d\x2f\x63\x8a\x69\x8e\x63\xe8\xbf\x7f\xec\xc3\x5b\x10\x59\x52\xa0\xff\x58\x9f\xd6\xca\x12\x6f\xe6\xde\x4a\x5d\x0c\x3e\xfc\x1a\x0f\xc3\x2b\x6d\xc2\x1b\x68\xc4\xaf\x4d\x27\x5c\xdf\x73\x23\xc2\x75\x63\x73\x2d\x35\x31\xa2\x11\x6a\xa4\x9c\x37\x96\x15\x38\x86\x6f\x4f\xf4\xfd\x44\x5e\x3d\x45\xbd\xa7\xa3\x28\xcf\x6b\xa1\x17\xa2\xdc\x40\x00\x6a\x6f\xbb\x3a\x2f\x24\x75\xea\x61\x02\x02\xde\x8f\x92\x30\x6f\x4d\x39\x49\xc2\x0a\x77\xaf\x67\x82\x2e\xa4\x78\xec\x2e\x56\xb8\x8b\xcf\xa3\x17\x53\x94\x34\x46\xff\x2e\xc5\xe3\xcf\xe6\xeb\xe4\xcd\x51\x5c\xe7\x24\xb5\xb7\x37\x8e\x4f\xe2\x68\xd1\x55\xca\x53\xb9\x4b\xbd\x31\x2b\x22\xae\x25\xc5\x47\xa9\x10\x12\x53\x52\xb6\x5c\xcd\x1c\x19\xa2\x06\xe9\xd1\x32\xa2\x4e\xb3\x41\x4b\x53\x03\x2c\xfa\xca\x6a\xd7\x85\x31\x97\x9a\xa9\x16\xb8\x89\xba\xb7\x8c\xd7\x3d\x53\x9f\x1f\xc4\x92\xfb\xc7\x10\xc5\xe0\xdd\x68\x04\xa9\x07\x72\x11\x4a\x23\xb5\x1f\xc2\x16\x41\x23\x0a\x6a\x7c\x81\xa2\xe2\x3e\xe0\xf5\x37\x4c\x55\xd8\xaf\x9b\x9b\x28\x32\x3c\x35\x15\x4d\x82\x83\xe6\x1f\x06\x03\xd7\x66\x13\x46\x5c\xc6\xf8\x0a\x9a\x86\x33\x56\x16\x52\x47\x4d\x38\x8f\x9a\x8d\x2c\x4a\x08\x38\x98\x15\x72\x45\x49\xa4\x93\x8f\x4c\xc1\x05\x64\xb2\xb8\xd4\xfe\x24\x79\x75\xd0\xdb\xa7\xf1\x1f\x49\xd3\x3c\x89\x23\xc2\x1b\x9c\xc5\x43\xf8\xf0\x6b\x57\x11\xde\x10\x14\xbc\x0e\xe6\xcd\xcb\x50\xd1\x69\x31\x3c\xff\x2c\xa8\xa1\x0e\x7e\x1b\xb4\x26\xae\xca\x28\x1d\xb5\x9f\x21\x8e\xc7\x5d\x7c\xfe\x03\xdc\x63\xdf\x5a\xdc\x26\x34\x09\x13\xe2\x10\x94\x3e\xc3\x77\xc1\xdc\x9d\x43\x01\x6f\x81\xbe\xa4\x26\x55\x4e\xf2\x2f\xcc\xc5\xf0\x37\x68\x24\x6e\xad\xe4\xdf\x59\x52\xe7\xf5\x13\x72\x8b\x6b\x1a\x05\x94\x3a\xce\x94\x42\xdb\x77\x10\x88\x66\xd8\xd4\x60\x48\x32\xae\x4b\xbf\x6b\x07\x84\x67\xb6\x40\xef\x5e\xf7\x26\xe0\xbc\x7b\xd7\xf2\x66\x88\xdf\xae\x44\xb8\xb8\x80\xfe\x64\x36\x4d\x17\xd3\x7e\xd3\x7b\xa3\x11\xdc\x63\x58\x9f\x32\x25\x33\xa1\x76\x20\x50\xa1\xc7\xda\x2e\xa3\x43\x5c\x3b\x1e\x19\xd2\x1e\x44\x1b\x0a\x3e\x4a\xe7\xa5\x2e\xa0\xa6\x97\x2d\x0d\xe3\x06\x2e\x34\x16\x67\x15\x85\xe7\x74\x72\x79\x43\x6b\x88\x45\x22\x23\x1a\x1a\xa1\x47\x99\x92\xdd\xda\x92\x4b\xeb\x3c\x94\x8a\x71\x4c\x08\xaf\x33\xe6\xe5\xa2\x68\xda\x9f\x54\xcf\x42\xdf\x06\xa0\xfd\x54\x64\x8a\xa6\x2a\xa9\x77\x30\x68\x31\xe2\xa8\xd7\xb3\xad\xf4\x01\xf6\xf9\x9e\x47\x9c\xc7\xf2\x90\x45\x68\x1b\xc1\x0d\x12\xef\x06\x0a\xa9\x27\x28\xe9\xfa\xd7\x6f\xcd\xc8\x46\x97\x44\x3d\x7a\x77\x40\x06\xca\x14\xc7\x64\x20\xea\xb0\xf0\xca\x5a\xca\x7f\xc7\xdb\x39\x11\xc3\x9f\x95\xf3\x14\x53\x4b\xe1\x69\x28\xe6\x39\x66\x0d\x3c\x4a\x23\x3a\xfe\x9e\x41\x69\xd8\x85\xe1\x42\xea\x9a\xd1\x56\xaf\x80\xa5\xf1\xa8\xbd\x64\x4a\xed\x28\x0f\x5b\x4b\xbb\x0f\x6d\x3b\x43\x70\x92\xa4\x02\x4d\x05\x51\xa9\xb9\xaa\x44\x5d\x06\xa1\xf8\x1b\x3c\x17\x6c\x3e\x5e\x9a\xd6\xe8\x1c\x2b\x30\xa1\x4a\xca\xe5\x63\xb3\x76\x6a\xe8\xd7\xcc\x38\x88\xfb\x49\x67\xe4\x31\x2f\x29\x53\x24\x6d\x91\x11\xb7\xa7\x42\x58\x74\x6e\x10\x37\x44\xd5\x65\xf6\x7e\x89\x9a\x82\x0f\x1a\xb7\xd0\xed\x33\x8c\x73\xda\xef\xc4\x10\x98\x10\xc4\x87\x27\xbb\x47\xd4\xeb\xb9\xad\xf4\x7c\x09\x41\x93\x29\xf7\xbd\x18\x37\xf5\xcf\x99\x43\x78\x33\xfd\xf7\x62\x72\xf3\x69\x3a\xb9\xb9\x7d\x78\x33\x86\xa3\xb3\xf9\xe5\x7f\xa6\xdd\xd9\xc7\xf4\x2a\xbd\x9e\x4c\xdf\x8c\xc3\x40\x7f\xc6\x21\x6f\x5a\x17\x48\xa1\xf3\x8c\xaf\x92\x12\x71\x35\x78\x7f\xcc\x03\x7b\x07\x7b\xbd\xcc\x22\x5b\x9d\xef\x8d\xa9\x1b\xb4\xd1\xd1\xf2\x34\x5c\xc0\x8b\xc1\x3a\x7f\xd9\x9a\x49\x23\x3f\x68\xd9\x7f\xbf\xbf\x04\xaa\x78\xdd\x8e\xb3\xbf\x6c\x48\xe8\x1d\xc6\x57\x63\x70\x4c\xd1\xda\x2c\xff\x4b\x7f\x77\xf2\xdc\xa1\x1f\x02\x6a\x61\xb6\xc4\x7c\x1d\x6a\x7d\xd3\xe0\x1e\x84\xec\x43\x5c\xd3\xee\x4d\x3e\x88\x3b\x61\x02\xfb\x5e\xf4\xec\x39\x51\xd4\x02\x2e\x5a\xf4\xb7\xe1\xe5\xeb\x81\x3a\x6b\x22\x75\xa2\xe0\x97\x93\xb5\x30\xdc\xaf\x71\x6d\xec\xae\x99\x61\x07\xfe\xfd\x38\xaa\xe9\xd5\x55\x57\x4f\xf4\x41\x45\xd6\x1d\x7c\x9a\x5e\x4d\xbf\xa4\x8b\xe9\x91\xd4\x7c\x91\x2e\x2e\x27\xf5\xd1\x5f\x2e\xbc\x0f\x3f\x5d\x78\xfd\xf9\x7c\x71\x33\x9b\xf6\xc7\xcd\xd7\xd5\x4d\xfa\xa9\xff\x9d\xc2\x66\x75\xfc\x51\xeb\x7a\x73\x6f\xac\xf8\x7f\x3a\xe0\x60\x8d\xcb\xd9\x73\x5b\x5c\xa0\x76\xee\xab\x93\x7f\x49\xc0\x74\xcb\xca\x79\xfd\x4f\xb1\x17\xde\x3f\xcb\xc3\x4f\xd1\x53\xf4\xbf\x00\x00\x00\xff\xff\x3a\xb7\x37\x41\xbf\x10\x00\x00")
+var _prestate_tracerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x58\x5d\x73\x1a\x3b\xd2\xbe\x1e\x7e\x45\xbf\xb9\x01\xde\x90\x21\xc9\xa9\x3a\x5b\x85\xd7\x5b\x45\x08\x4e\x5c\xc5\xb1\x5d\x40\x36\x9b\x4d\xed\x85\x46\xea\x01\x1d\x0b\x69\x4a\xd2\x80\x49\xca\xff\x7d\xab\x35\x9a\xe1\xc3\x10\xfb\xec\x9d\x91\x7a\x1e\x3d\xfd\xdd\xed\x7e\x1f\x46\xa6\xd8\x5a\xb9\x58\x7a\x78\xff\xf6\xdd\xdf\x60\xbe\x44\x58\x98\x37\xe8\x97\x68\xb1\x5c\xc1\xb0\xf4\x4b\x63\x5d\xab\xdf\x87\xf9\x52\x3a\xc8\xa5\x42\x90\x0e\x0a\x66\x3d\x98\x1c\xfc\x91\xbc\x92\x99\x65\x76\x9b\xb6\xfa\xfd\xea\x9b\x93\xd7\x84\x90\x5b\x44\x70\x26\xf7\x1b\x66\x71\x00\x5b\x53\x02\x67\x1a\x2c\x0a\xe9\xbc\x95\x59\xe9\x11\xa4\x07\xa6\x45\xdf\x58\x58\x19\x21\xf3\x2d\x41\x4a\x0f\xa5\x16\x68\xc3\xd3\x1e\xed\xca\xd5\x3c\x3e\xdd\x7c\x81\x09\x3a\x87\x16\x3e\xa1\x46\xcb\x14\xdc\x95\x99\x92\x1c\x26\x92\xa3\x76\x08\xcc\x41\x41\x27\x6e\x89\x02\xb2\x00\x47\x1f\x5e\x11\x95\x59\xa4\x02\x57\xa6\xd4\x82\x79\x69\x74\x0f\x50\x12\x73\x58\xa3\x75\xd2\x68\xf8\xad\x7e\x2a\x02\xf6\xc0\x58\x02\xe9\x30\x4f\x0a\x58\x30\x05\x7d\xd7\x05\xa6\xb7\xa0\x98\xdf\x7d\xfa\x02\x83\xec\xf4\x16\x20\x75\x50\x6f\x69\x0a\x04\xbf\x64\x9e\x2c\xb1\x91\x4a\x41\x86\x50\x3a\xcc\x4b\xd5\x23\xb4\xac\xf4\xf0\xf5\x7a\xfe\xf9\xf6\xcb\x1c\x86\x37\xdf\xe0\xeb\x70\x3a\x1d\xde\xcc\xbf\x5d\xc0\x46\xfa\xa5\x29\x3d\xe0\x1a\x2b\x28\xb9\x2a\x94\x44\x01\x1b\x66\x2d\xd3\x7e\x0b\x26\x27\x84\x3f\xc6\xd3\xd1\xe7\xe1\xcd\x7c\xf8\xe1\x7a\x72\x3d\xff\x06\xc6\xc2\xd5\xf5\xfc\x66\x3c\x9b\xc1\xd5\xed\x14\x86\x70\x37\x9c\xce\xaf\x47\x5f\x26\xc3\x29\xdc\x7d\x99\xde\xdd\xce\xc6\x29\xcc\x90\x58\x21\x7d\xff\xbc\xcd\xf3\xe0\x3d\x8b\x20\xd0\x33\xa9\x5c\x6d\x89\x6f\xa6\x04\xb7\x34\xa5\x12\xb0\x64\x6b\x04\x8b\x1c\xe5\x1a\x05\x30\xe0\xa6\xd8\xbe\xd8\xa9\x84\xc5\x94\xd1\x8b\xa0\xf3\xd9\x80\x84\xeb\x1c\xb4\xf1\x3d\x70\x88\xf0\xf7\xa5\xf7\xc5\xa0\xdf\xdf\x6c\x36\xe9\x42\x97\xa9\xb1\x8b\xbe\xaa\xe0\x5c\xff\x1f\x69\x8b\x30\x0b\x8b\xce\x33\x8f\x73\xcb\x38\x5a\x30\xa5\x2f\x4a\xef\xc0\x95\x79\x2e\xb9\x44\xed\x41\xea\xdc\xd8\x55\x88\x14\xf0\x06\xb8\x45\xe6\x11\x18\x28\xc3\x99\x02\x7c\x40\x5e\x86\xbb\xca\xd2\x44\xcc\x5b\xa6\x1d\xe3\xe1\x34\xb7\x66\x45\xba\x96\xce\xd3\x1f\xce\xe1\x2a\x53\x28\x60\x81\x1a\x9d\x74\x90\x29\xc3\xef\xd3\xd6\xcf\x56\xb2\x47\x86\x12\x87\x80\x6a\xa1\x10\x1b\x1b\x6c\x5b\x84\xac\x94\x4a\x48\xbd\x48\x5b\x49\x2d\x3d\x00\x5d\x2a\xd5\x6b\x05\x08\x65\xcc\x7d\x59\x0c\x39\x37\x65\xe0\xfe\x27\x72\x4f\x00\x08\xae\x40\x2e\x73\x0a\x0e\xd6\xdc\x7a\x13\xae\x9a\x77\x4d\x46\xf2\x69\x2b\x39\x80\x19\x40\x5e\xea\xa0\x4e\x87\x09\x61\x7b\x20\xb2\xee\xcf\x56\x92\xac\x99\x05\xc6\x39\x5c\x82\x37\x9f\xf1\x21\x5c\x76\x2f\x5a\x49\x22\x73\xe8\xf8\xa5\x74\x69\x0d\xfc\x9d\x71\xfe\x1f\xb8\xbc\xbc\x0c\x49\x9d\x4b\x8d\xa2\x0b\x04\x91\x9c\x12\xab\x6e\x92\x8c\x29\xa6\x39\x0e\xa0\xfd\xf6\xa1\x0d\xaf\x41\x64\xe9\x02\xfd\x87\xea\xb4\x7a\x2c\xf5\x66\xe6\xad\xd4\x8b\xce\xbb\xdf\xbb\xbd\xf0\x95\x36\xe1\x1b\x88\xe2\x37\xa6\x11\xae\xee\xb9\x11\xe1\x3a\x72\xae\xa4\x46\x46\x44\xa1\x28\xe5\xbc\xb1\x6c\x81\x03\xf8\xf9\x48\xbf\x1f\x49\xab\xc7\x56\xf2\x78\x60\xe5\x59\x25\x74\xc6\xca\x11\x02\x50\x7b\xdb\xc4\xf9\x42\x52\xa6\xee\x3b\x20\xe0\xfd\xca\x09\xf1\x95\x27\x4e\xb8\xc7\xed\xf3\x9e\x20\x17\x49\xf1\xd0\x5c\xdc\xe3\xb6\x7b\xd1\x3a\xeb\xa2\x34\x92\xfe\x2e\xc5\xc3\x4b\xfd\x75\xf4\x4d\x7c\xa8\xb2\xeb\x8c\x90\x77\x7c\xbb\xdd\x23\x3b\x5a\x74\xa5\xf2\x14\xee\x52\xaf\xcd\x3d\x15\xae\x25\xd9\x47\xa9\x60\x2d\x53\x90\xb7\x5c\x55\x39\x32\x44\x0d\xd2\xa3\x65\x54\x3a\xcd\x1a\x2d\x75\x0d\xb0\xe8\x4b\xab\x5d\x63\xc6\x5c\x6a\xa6\x6a\xe0\x68\x75\x6f\x19\xaf\x72\xa6\x3a\xdf\xb3\x25\xf7\x0f\xc1\x8a\x41\xbb\x7e\x1f\x86\x1e\x48\x45\x28\x8c\xd4\xbe\x07\x1b\x04\x8d\x28\x28\xf1\x05\x8a\x92\xd3\x2d\x42\x7b\xcd\x54\x89\xed\x2a\xb9\xa9\x44\x26\xf4\xba\x29\x3d\xda\xfd\xe4\xef\x05\x82\x2b\xb3\x0e\x2d\x2e\x63\xfc\x1e\x62\xc2\x19\x2b\x17\x52\xb7\xa2\x39\x0f\x92\xad\xc3\xfd\x43\x4a\xc0\x81\x56\xf0\x55\xbf\x0f\x57\x88\x0e\x98\x45\x2a\x3f\x42\x92\x01\x2a\x24\x69\xa9\xa2\xca\x82\x2a\x95\x03\x96\x13\x83\xa6\x24\xf5\xc0\x99\xfd\xeb\x58\x46\x6c\xe4\xab\x8d\x07\x6f\x4a\x1e\xba\x24\xe6\xc6\xe2\x9e\xa1\x2d\x72\x63\x05\x8a\x5d\xb9\xcd\x89\x82\xd4\x5c\x95\x02\x45\x6f\xcf\x1c\xab\x18\x67\x41\xe0\x12\x7e\x3e\xd6\x81\xb7\x60\xee\x8b\x43\x01\x97\x90\xc9\xc5\x75\x54\xad\x3e\x7c\x0d\xa4\xa8\xd4\x94\xbe\x4e\xf2\x4f\xcc\x35\xa5\x83\x2e\xbc\x2c\xa6\x35\x73\xf8\xbf\x13\x81\x48\xaf\x7d\xaf\x62\xed\x58\xbe\x4b\x61\xf8\x93\xa2\x6e\x00\xc7\x77\x3d\xc8\x11\x07\x10\x59\xa4\xab\x52\x79\x59\xa8\x6d\x4d\x6d\x2e\x8b\x6e\x9d\xec\x0d\x97\x8c\x39\xbc\x42\xfc\x35\x9f\xe3\x14\x3c\xf5\x61\xd0\x30\xc0\x06\xf6\x67\x8b\x62\xb2\x77\xbd\xaf\xc8\x31\x5e\x54\xa6\xb2\x6e\xfa\x03\xad\x09\xdc\x03\xf9\x1d\x46\x9a\x23\xc2\x25\x1c\xfc\x4e\x99\x10\x9d\x93\x46\x88\x6f\x34\xa9\x9a\x50\x67\xef\xd4\xda\x49\x1d\x70\x22\xcf\xbf\x50\xe7\x4f\x45\xfa\x8e\x51\xd3\x56\x42\xbc\x57\xc6\xcc\x98\xda\x45\xce\x89\xb2\x13\xdb\x43\xea\xa8\xa5\x77\xde\x77\x7b\xf0\xee\xf7\x40\x3a\x49\xce\x4b\xc3\x65\xe8\x26\xaf\x33\xa6\x52\x57\x66\x54\x18\xf6\x79\xe4\x88\x87\x3d\xa5\x31\xe7\x63\x1d\xe4\xd6\xac\x3e\x9c\x67\xb6\xf3\x3d\xa5\x70\xf7\x17\x2c\x09\xcc\x1b\x82\x82\xe7\xc1\xbc\x39\x0f\x55\x57\x91\x67\x3e\x03\xa8\x75\x0f\xaf\xee\xb4\x27\xfc\x50\xcf\x9e\x6a\xfe\x32\xdd\x6a\x5c\x3a\x25\x64\x0a\xad\x3d\x50\xfa\xf9\x5c\xe6\xc3\xff\x43\x94\xb8\xb3\x92\x3f\xf1\x41\x55\xaf\x3e\x22\xb7\xb8\xa2\xfc\xa3\x72\xc4\x99\x52\x68\xdb\x0e\x42\xc3\xef\xc5\x5e\x10\x8a\x2d\xae\x0a\xbf\xad\x07\x35\xcf\xec\x02\xbd\x7b\x5e\x9b\x80\xf3\xe6\xcd\x41\x11\xda\x16\x08\x97\x97\xd0\x1e\x4d\xc7\xc3\xf9\xb8\x1d\x63\xb9\xdf\x87\xaf\x44\x40\x43\xa6\x64\x26\xd4\x16\x04\x2a\xf4\x61\x5a\x06\x6e\x74\xb0\x6b\xd3\xcf\x7b\xb4\x8f\xd0\xa6\x80\x0f\xd2\x79\xa9\x17\x10\x8e\x61\x43\x43\x71\x84\x0b\x75\x97\xb3\x92\x0a\xe3\xf1\x04\xe9\x0d\xad\x03\x16\x69\x32\xa3\xe1\x8d\x8a\xf0\x9a\x29\xd9\xac\x0f\xb9\xb4\xce\x43\xa1\x18\xc7\x94\xf0\x1a\x32\xa7\xd5\xa5\x58\xaa\x73\xbb\xdf\x87\x69\xe8\x9f\x01\x68\x37\x9d\x32\x45\xd3\x2d\xf5\x30\x07\x9d\x1a\xa3\xdb\x4a\x12\x5b\x4b\xef\x61\x5f\xec\xfa\xb9\xf3\x58\xec\x77\x73\xaa\x1d\xb8\x46\x9a\x7f\x42\x2b\xaf\xb6\x1c\x7a\xeb\x9f\x7f\xc4\x3e\x85\x2e\x6d\x25\xf4\xdd\x5e\x53\x56\x66\x71\xd8\x94\x45\x65\x16\x5e\x5a\x4b\xfe\x6f\xe6\xa7\x9c\x1a\xf4\x9f\xa5\xf3\x64\x53\x1b\xda\x62\xd5\xea\xa3\x13\x0f\x78\x86\xba\x44\xa3\x72\xf7\xe9\x24\xd3\xf4\x2d\xd2\x22\x8e\x98\xd5\x2a\x56\x18\x8f\xda\x4b\xa6\xd4\x96\xfc\xb0\xb1\xb4\x83\xd0\xd6\xd1\x03\x27\x49\x8a\x70\x2a\xd1\xd8\x1c\x03\xd5\x10\xfc\x11\xcf\x05\xce\x87\xcb\xcb\x0a\x9d\x63\x0b\x4c\x29\x92\x72\xf9\x10\xd7\x3f\x0d\xed\x6a\x42\xe9\x74\xdb\x69\xeb\x74\xd5\x54\x66\x91\xd6\x41\x46\x33\xd6\x50\x08\x8b\xce\x75\xba\xb1\x80\x36\x9e\xfd\xba\x44\x4d\xc6\x07\x8d\x9b\x18\x73\xd2\xd1\x98\x48\x7b\x96\xe8\x01\x13\x82\xe6\x92\xa3\x1d\xa0\x95\x24\x6e\x23\x3d\x5f\x42\x78\xc9\x14\xbb\x5c\xec\xc6\xf8\xe7\xcc\x21\xbc\x1a\xff\x6b\x3e\xba\xfd\x38\x1e\xdd\xde\x7d\x7b\x35\x80\x83\xb3\xd9\xf5\xbf\xc7\xcd\xd9\x87\xe1\x64\x78\x33\x1a\xbf\x1a\x9c\x6b\x03\xde\xd4\x2a\xd0\x83\xce\x33\x7e\x9f\x16\x88\xf7\x9d\xb7\x87\x75\x60\xa7\x60\x92\x64\x16\xd9\xfd\xc5\x8e\x4c\x95\xa0\xf1\x8d\xba\x4e\xc3\x25\x9c\x35\xd6\xc5\x79\x36\xa3\x28\xdf\xa9\xa7\xb0\xdd\x1e\x41\x27\x2f\xe0\xf1\xfe\x2f\x13\xa1\x28\x21\xc5\x07\xe0\x98\xa2\xf5\x55\xfe\xc0\x1e\x98\x3c\x77\xe8\x7b\x80\x5a\x98\x0d\x55\xbe\x06\xb5\xba\x89\xb8\x7b\x26\x7b\xd7\x4d\x43\xe4\xdd\xe6\x9d\x6e\x23\xec\xe4\x0f\x7c\x2a\xfa\xfe\x94\x28\x6a\x1a\xd7\x22\xfa\xeb\x40\xe3\x79\x43\xbd\x8f\x96\x3a\x7a\xe0\xb7\x43\xf7\xf5\x02\xd7\x15\xae\x8c\xdd\xc6\x1e\xb6\xa7\xdf\xaf\xad\x3a\x9c\x4c\x9a\x78\x1a\x0d\x27\x13\x0a\xbc\xe6\xe0\xe3\x78\x32\xfe\x34\x9c\x8f\x0f\xa4\x66\xf3\xe1\xfc\x7a\x54\x1d\x9d\xd7\xa0\xf6\xc2\x11\xf3\x77\x2f\x0e\xbc\xf6\x6c\x36\xbf\x9d\x8e\xdb\x83\xf8\x6b\x72\x3b\xfc\xd8\x7e\xf2\x60\x5c\xe1\x7e\x95\xba\xde\x7c\x35\x56\xfc\x2f\x19\xb0\xb7\x4e\xe5\xec\xd4\x36\x45\xe5\x86\x71\x5f\x1e\xfd\xb7\x02\x98\xae\xab\x72\x5e\xfd\xc7\x26\xc9\xd9\xe1\x72\xb4\xab\xc3\x8f\xad\xc7\xd6\x7f\x07\x00\x9c\xd7\xd0\xd7\x47\x14\x00\x00")
 
 func prestate_tracerJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -213,7 +213,7 @@ func prestate_tracerJs() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "prestate_tracer.js", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd4, 0x9, 0xf9, 0x44, 0x13, 0x31, 0x89, 0xf7, 0x35, 0x9a, 0xc6, 0xf0, 0x86, 0x9d, 0xb2, 0xe3, 0x57, 0xe2, 0xc0, 0xde, 0xc9, 0x3a, 0x4c, 0x4a, 0x94, 0x90, 0xa5, 0x92, 0x2f, 0xbf, 0xc0, 0xb8}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x24, 0xef, 0x4c, 0x48, 0xc3, 0x56, 0x64, 0xcb, 0xd7, 0x64, 0x4, 0x7a, 0xc0, 0xa3, 0x41, 0x73, 0xcf, 0x8a, 0x75, 0x79, 0xcb, 0xeb, 0x10, 0x9d, 0xc8, 0x15, 0x8d, 0x71, 0x5, 0x23, 0x72, 0x79}}
 	return a, nil
 }
 
@@ -51,6 +51,28 @@
 		// outer transaction, and move it back to the origin
 		this.lookupAccount(ctx.from, db);
 
+		// Fees are credited to their recipients after execution, so recipients that were
+		// not touched before have been recorded with the fees included, deduct them
+		var fees = {};
+		var gasUsed = bigInt(ctx.gasUsed + ctx.intrinsicGas);
+		if (ctx.tipRecipient !== undefined) {
+			fees[toHex(ctx.tipRecipient)] = {addr: ctx.tipRecipient, fee: gasUsed.multiply(ctx.gasTip)};
+		}
+		if (ctx.baseFeeRecipient !== undefined) {
+			var acc = toHex(ctx.baseFeeRecipient);
+			if (fees[acc] === undefined) {
+				fees[acc] = {addr: ctx.baseFeeRecipient, fee: bigInt.zero};
+			}
+			fees[acc].fee = fees[acc].fee.add(gasUsed.multiply(ctx.baseFee));
+		}
+		for (var acc in fees) {
+			if (this.prestate[acc] === undefined) {
+				this.lookupAccount(fees[acc].addr, db);
+
+				var bal = bigInt(this.prestate[acc].balance.slice(2), 16);
+				this.prestate[acc].balance = '0x'+bal.subtract(fees[acc].fee).toString(16);
+			}
+		}
 		var fromBal = bigInt(this.prestate[toHex(ctx.from)].balance.slice(2), 16);
 		var toBal   = bigInt(this.prestate[toHex(ctx.to)].balance.slice(2), 16);
 
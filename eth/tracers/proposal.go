@@ -0,0 +1,200 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/common/hexutil"
+	"github.com/hypnosisfoundation/go-hypnosis/common/math"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/rpc"
+)
+
+// ProposalArgs selects the governance proposal to simulate: either the id of a
+// passed proposal, or the fields of a proposal yet to be voted on.
+type ProposalArgs struct {
+	Id     *big.Int       `json:"-"`
+	Action hexutil.Uint64 `json:"action"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Value  *hexutil.Big   `json:"value"`
+	Data   hexutil.Bytes  `json:"data"`
+}
+
+// UnmarshalJSON accepts a proposal id as a number or a hex/decimal string, or a
+// proposal object.
+func (args *ProposalArgs) UnmarshalJSON(input []byte) error {
+	input = bytes.TrimSpace(input)
+	if len(input) > 0 && input[0] != '{' {
+		id, ok := math.ParseBig256(string(bytes.Trim(input, `"`)))
+		if !ok {
+			return fmt.Errorf("invalid proposal id %s", input)
+		}
+		args.Id = id
+		return nil
+	}
+	type fields ProposalArgs
+	var dec fields
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*args = ProposalArgs(dec)
+	return nil
+}
+
+// ProposalAccountState is the state of an account touched by a proposal. Only
+// the storage slots and code changed by the proposal are included.
+type ProposalAccountState struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Nonce   hexutil.Uint64              `json:"nonce"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// ProposalAccountDiff is the change of an account touched by a proposal.
+type ProposalAccountDiff struct {
+	Pre  *ProposalAccountState `json:"pre"`
+	Post *ProposalAccountState `json:"post"`
+}
+
+// ProposalSimulation is the outcome of a governance proposal executed on top of
+// a block. The logs are part of the receipt.
+type ProposalSimulation struct {
+	Receipt   *types.Receipt                          `json:"receipt"`
+	StateDiff map[common.Address]*ProposalAccountDiff `json:"stateDiff"`
+	Trace     interface{}                             `json:"trace"`
+}
+
+// proposalSimulator is a consensus engine able to simulate governance proposals.
+type proposalSimulator interface {
+	SimulateProposal(chain consensus.ChainHeaderReader, parent *types.Header, state *state.StateDB, id *big.Int, action uint64, from, to common.Address, value *big.Int, data []byte) (*types.Header, *types.Transaction, *types.Receipt, error)
+}
+
+// ProposalAPI is the collection of governance proposal simulation methods of the
+// dpos namespace.
+type ProposalAPI struct {
+	api    *API
+	engine proposalSimulator
+}
+
+// SimulateProposal executes a governance proposal on a copy of the state at the
+// given block, as the producer of the next block would, and returns its receipt,
+// the state it changes and a trace of its execution.
+func (p *ProposalAPI) SimulateProposal(ctx context.Context, args ProposalArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (*ProposalSimulation, error) {
+	api := p.api
+	var (
+		err   error
+		block *types.Block
+	)
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block, err = api.blockByHash(ctx, hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		block, err = api.blockByNumber(ctx, number)
+	} else {
+		return nil, errors.New("invalid arguments; neither block nor hash specified")
+	}
+	if err != nil {
+		return nil, err
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, err := api.backend.StateAtBlock(ctx, block, reexec, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	var value *big.Int
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+	pre, traced := statedb.Copy(), statedb.Copy()
+
+	// Proposals are executed when finalizing a block, so run it in the next one
+
+	header, tx, receipt, err := p.engine.SimulateProposal(api.backend.ChainHeaderReader(), block.Header(), statedb, args.Id, uint64(args.Action), args.From, args.To, value, args.Data)
+	if err != nil {
+		return nil, err
+	}
+	// Replay the system transaction the way debug_traceTransaction does
+	vmctx := core.NewEVMBlockContext(header, api.chainContext(ctx), nil)
+	trace, err := api.tracePoSASysTx(ctx, header.Coinbase, tx, &Context{TxHash: tx.Hash()}, vmctx, traced, config)
+	if err != nil {
+		return nil, err
+	}
+	return &ProposalSimulation{
+		Receipt:   receipt,
+		StateDiff: proposalStateDiff(pre, statedb),
+		Trace:     trace,
+	}, nil
+}
+
+// proposalStateDiff returns the accounts changed from pre to post, the latter of
+// which must not have had its root computed since the changes.
+func proposalStateDiff(pre, post *state.StateDB) map[common.Address]*ProposalAccountDiff {
+	diff := make(map[common.Address]*ProposalAccountDiff)
+	for addr, slots := range post.PendingChanges() {
+		var (
+			before = &ProposalAccountState{Balance: (*hexutil.Big)(pre.GetBalance(addr)), Nonce: hexutil.Uint64(pre.GetNonce(addr))}
+			after  = &ProposalAccountState{Balance: (*hexutil.Big)(post.GetBalance(addr)), Nonce: hexutil.Uint64(post.GetNonce(addr))}
+			values = make(map[common.Hash]common.Hash)
+		)
+		changed := before.Balance.ToInt().Cmp(after.Balance.ToInt()) != 0 || before.Nonce != after.Nonce
+		if pre.GetCodeHash(addr) != post.GetCodeHash(addr) {
+			before.Code, after.Code = pre.GetCode(addr), post.GetCode(addr)
+			changed = true
+		}
+		for _, slot := range slots {
+			values[slot] = post.GetState(addr, slot)
+		}
+		// A destructed or erased account loses all of its storage, except for the
+		// slots written afterwards
+		if !post.Exist(addr) || (len(before.Code) > 0 && len(after.Code) == 0) {
+			pre.ForEachStorage(addr, func(slot, _ common.Hash) bool {
+				if _, ok := values[slot]; !ok {
+					values[slot] = common.Hash{}
+				}
+				return true
+			})
+		}
+		for slot, value := range values {
+			prev := pre.GetState(addr, slot)
+			if prev == value {
+				continue
+			}
+			if before.Storage == nil {
+				before.Storage, after.Storage = make(map[common.Hash]common.Hash), make(map[common.Hash]common.Hash)
+			}
+			before.Storage[slot], after.Storage[slot] = prev, value
+			changed = true
+		}
+		if changed {
+			diff[addr] = &ProposalAccountDiff{Pre: before, Post: after}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
+)
+
+func TestProposalArgs(t *testing.T) {
+	for _, input := range []string{`12`, `"12"`, `"0xc"`} {
+		var args ProposalArgs
+		if err := json.Unmarshal([]byte(input), &args); err != nil {
+			t.Fatalf("%s: failed to decode: %v", input, err)
+		}
+		if args.Id == nil || args.Id.Int64() != 12 {
+			t.Errorf("%s: id mismatch: have %v, want 12", input, args.Id)
+		}
+	}
+	var args ProposalArgs
+	if err := json.Unmarshal([]byte(`{"action":"0x4","to":"0x0000000000000000000000000000000000001000","value":"0x5"}`), &args); err != nil {
+		t.Fatalf("failed to decode proposal fields: %v", err)
+	}
+	if args.Id != nil || args.Action != 4 || args.To != common.HexToAddress("0x1000") || args.Value.ToInt().Int64() != 5 {
+		t.Errorf("proposal mismatch: %+v", args)
+	}
+	if err := json.Unmarshal([]byte(`"twelve"`), &args); err == nil {
+		t.Errorf("invalid proposal id accepted")
+	}
+}
+
+func TestProposalStateDiff(t *testing.T) {
+	var (
+		target   = common.HexToAddress("0x1000")
+		erased   = common.HexToAddress("0x2000")
+		coinbase = common.HexToAddress("0x01")
+		slot     = common.HexToHash("0x02")
+		other    = common.HexToHash("0x03")
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.SetBalance(target, big.NewInt(10))
+	statedb.SetState(target, slot, common.HexToHash("0x01"))
+	statedb.SetCode(erased, []byte{0x00})
+	statedb.SetBalance(erased, big.NewInt(1))
+	statedb.SetState(erased, slot, common.HexToHash("0x04"))
+	statedb.SetState(erased, other, common.HexToHash("0x05"))
+	statedb.SetBalance(coinbase, big.NewInt(1))
+	root, _ := statedb.Commit(true)
+	statedb, _ = state.New(root, statedb.Database(), nil)
+	pre := statedb.Copy()
+
+	// Mint to the target and clear its slot, erase a contract but rewrite one of
+	// its slots, and bump the nonce of the block producer
+	statedb.AddBalance(target, big.NewInt(5))
+	statedb.SetState(target, slot, common.Hash{})
+	statedb.Erase(erased)
+	statedb.SetState(erased, other, common.HexToHash("0x06"))
+	statedb.SetNonce(coinbase, 1)
+	statedb.Finalise(true)
+
+	diff := proposalStateDiff(pre, statedb)
+	if len(diff) != 3 {
+		t.Fatalf("changed account count mismatch: have %d, want 3", len(diff))
+	}
+	if d := diff[target]; d == nil || d.Pre.Balance.ToInt().Int64() != 10 || d.Post.Balance.ToInt().Int64() != 15 ||
+		d.Pre.Storage[slot] != common.HexToHash("0x01") || d.Post.Storage[slot] != (common.Hash{}) {
+		t.Errorf("target diff mismatch: %+v", d)
+	}
+	if d := diff[erased]; d == nil || len(d.Post.Code) != 0 || len(d.Post.Storage) != 2 ||
+		d.Post.Storage[slot] != (common.Hash{}) || d.Post.Storage[other] != common.HexToHash("0x06") {
+		t.Errorf("erased diff mismatch: %+v", d)
+	}
+	if d := diff[coinbase]; d == nil || d.Pre.Nonce != 0 || d.Post.Nonce != 1 || d.Post.Storage != nil {
+		t.Errorf("coinbase diff mismatch: %+v", d)
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/common/hexutil"
+	"github.com/hypnosisfoundation/go-hypnosis/common/math"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
 	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
 	"github.com/hypnosisfoundation/go-hypnosis/crypto"
@@ -590,6 +591,23 @@ func (jst *Tracer) CaptureStart(env *vm.EVM, from common.Address, to common.Addr
 		return
 	}
 	jst.ctx["intrinsicGas"] = intrinsicGas
+
+	// Expose the accounts credited with the fees after execution, so that tracers can
+	// reconstruct their balances. Only dpos chains pay them to accounts of their own.
+	if env.ChainConfig().Dpos == nil {
+		return
+	}
+	gasTip := env.TxContext.GasPrice
+	if env.Context.BaseFee != nil {
+		baseFee := math.BigMin(env.Context.BaseFee, env.TxContext.GasPrice)
+		gasTip = new(big.Int).Sub(env.TxContext.GasPrice, baseFee)
+		if recipient := core.BaseFeeRecipient(env.ChainConfig(), env.Context.BlockNumber); recipient != nil {
+			jst.ctx["baseFeeRecipient"] = *recipient
+			jst.ctx["baseFee"] = baseFee
+		}
+	}
+	jst.ctx["tipRecipient"] = core.TipRecipient(env.ChainConfig(), env.Context.Coinbase)
+	jst.ctx["gasTip"] = gasTip
 }
 
 // CaptureState implements the Tracer interface to trace a single step of VM execution.
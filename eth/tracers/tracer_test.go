@@ -236,3 +236,29 @@ func TestIsPrecompile(t *testing.T) {
 		t.Errorf("Tracer should consider blake2f as precompile in istanbul")
 	}
 }
+
+// TestFeeRecipients tests that dpos chains expose the accounts credited with the fees
+// to tracers, following the base fee policy.
+func TestFeeRecipients(t *testing.T) {
+	treasury := common.HexToAddress("0x0000000000000000000000000000000000001234")
+	chaincfg := *params.TestChainConfig
+	chaincfg.Ethash = nil
+	chaincfg.Dpos = &params.DposConfig{Period: 3, Epoch: 200, BaseFeePolicy: &params.BaseFeePolicy{Mode: params.BaseFeeTreasury, Treasury: treasury}}
+	chaincfg.BaseFeePolicyBlock = big.NewInt(0)
+
+	tracer, err := New("{step: function() {}, fault: function() {}, result: function(ctx) { return [toHex(ctx.tipRecipient), toHex(ctx.baseFeeRecipient), ctx.gasTip, ctx.baseFee]; }}", new(Context))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret, err := runTrace(tracer, &vmContext{
+		blockCtx: vm.BlockContext{BlockNumber: big.NewInt(1), BaseFee: big.NewInt(10000)},
+		txCtx:    vm.TxContext{GasPrice: big.NewInt(100000)},
+	}, &chaincfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `["0xffffffffffffffffffffffffffffffffffffffff","0x0000000000000000000000000000000000001234","90000","10000"]`
+	if string(ret) != want {
+		t.Errorf("fee recipients mismatch: have %s, want %s", ret, want)
+	}
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
 	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/eth/gasprice"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
 	"github.com/hypnosisfoundation/go-hypnosis/p2p"
 	"github.com/hypnosisfoundation/go-hypnosis/params"
@@ -88,10 +89,11 @@ type feeHistoryResult struct {
 	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
 	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
 	GasUsedRatio []float64        `json:"gasUsedRatio"`
+	BurntFees    []*hexutil.Big   `json:"burntFees,omitempty"`
 }
 
 func (s *PublicEthereumAPI) FeeHistory(ctx context.Context, blockCount rpc.DecimalOrHex, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*feeHistoryResult, error) {
-	oldest, reward, baseFee, gasUsed, err := s.b.FeeHistory(ctx, int(blockCount), lastBlock, rewardPercentiles)
+	oldest, reward, baseFee, gasUsed, burnt, err := s.b.FeeHistory(ctx, int(blockCount), lastBlock, rewardPercentiles)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +116,12 @@ func (s *PublicEthereumAPI) FeeHistory(ctx context.Context, blockCount rpc.Decim
 			results.BaseFee[i] = (*hexutil.Big)(v)
 		}
 	}
+	if burnt != nil {
+		results.BurntFees = make([]*hexutil.Big, len(burnt))
+		for i, v := range burnt {
+			results.BurntFees[i] = (*hexutil.Big)(v)
+		}
+	}
 	return results, nil
 }
 
@@ -130,6 +138,60 @@ func (s *PublicEthereumAPI) GasPricePrediction(ctx context.Context) (map[string]
 	}, nil
 }
 
+// GasPriceSuggestion is a suggestion of fees for an EIP-1559 transaction, with
+// the estimated blocks until its inclusion and the confidence of it. There's no
+// confidence until the suggestion is calibrated.
+type GasPriceSuggestion struct {
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	EstimatedBlocks      hexutil.Uint64 `json:"estimatedBlocks"`
+	Calibrated           bool           `json:"calibrated"`
+	Confidence           *float64       `json:"confidence,omitempty"`
+}
+
+// GasPricePredictionV2Result is the result of eth_gasPricePredictionV2.
+type GasPricePredictionV2Result struct {
+	BaseFee      *hexutil.Big        `json:"baseFee,omitempty"`
+	JamIndex     int                 `json:"jamIndex"`
+	Observations int                 `json:"observations"`
+	Fast         *GasPriceSuggestion `json:"fast"`
+	Median       *GasPriceSuggestion `json:"median"`
+	Low          *GasPriceSuggestion `json:"low"`
+}
+
+// GasPricePredictionV2 returns wei-precision fee suggestions of fast, median and
+// low, calibrated from the observed inclusion latency of the pool transactions.
+func (s *PublicEthereumAPI) GasPricePredictionV2(ctx context.Context) (*GasPricePredictionV2Result, error) {
+	pred, err := s.b.PricePredictionV2(ctx)
+	if err != nil {
+		return nil, err
+	}
+	format := func(sug *gasprice.Suggestion) *GasPriceSuggestion {
+		if sug == nil {
+			return nil
+		}
+		result := &GasPriceSuggestion{
+			MaxPriorityFeePerGas: (*hexutil.Big)(sug.GasTipCap),
+			MaxFeePerGas:         (*hexutil.Big)(sug.GasFeeCap),
+			EstimatedBlocks:      hexutil.Uint64(sug.Blocks),
+			Calibrated:           sug.Calibrated,
+		}
+		if sug.Calibrated {
+			confidence := sug.Confidence
+			result.Confidence = &confidence
+		}
+		return result
+	}
+	return &GasPricePredictionV2Result{
+		BaseFee:      (*hexutil.Big)(pred.BaseFee),
+		JamIndex:     pred.JamIndex,
+		Observations: pred.Observations,
+		Fast:         format(pred.Suggestions["fast"]),
+		Median:       format(pred.Suggestions["median"]),
+		Low:          format(pred.Suggestions["low"]),
+	}, nil
+}
+
 // Syncing returns false in case the node is currently not syncing with the network. It can be up to date or has not
 // yet received the latest block headers from its pears. In case it is synchronizing:
 // - startingBlock: block number this node started to synchronise from
@@ -301,6 +363,56 @@ func (s *PublicTxPoolAPI) JamIndex() int {
 	return s.b.JamIndex()
 }
 
+// JamIndexHistory returns the recent jam index samples with their components,
+// oldest first, at most count of them if given.
+func (s *PublicTxPoolAPI) JamIndexHistory(count *int) []*core.JamIndexSample {
+	var n int
+	if count != nil {
+		n = *count
+	}
+	return s.b.JamIndexHistory(n)
+}
+
+// PublicTxPoolSubscriptionAPI offers the subscriptions of the "txpool" namespace.
+type PublicTxPoolSubscriptionAPI struct {
+	b Backend
+}
+
+// NewPublicTxPoolSubscriptionAPI creates a new tx pool subscription service.
+func NewPublicTxPoolSubscriptionAPI(b Backend) *PublicTxPoolSubscriptionAPI {
+	return &PublicTxPoolSubscriptionAPI{b}
+}
+
+// JamIndex creates a subscription that fires with each jam index evaluation.
+func (s *PublicTxPoolSubscriptionAPI) JamIndex(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		samples := make(chan core.JamIndexEvent)
+		samplesSub := s.b.SubscribeJamIndexEvent(samples)
+
+		for {
+			select {
+			case ev := <-samples:
+				notifier.Notify(rpcSub.ID, ev.Sample)
+			case <-rpcSub.Err():
+				samplesSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				samplesSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // PublicAccountAPI provides an API to access accounts managed by this node.
 // It offers only methods that can retrieve accounts.
 type PublicAccountAPI struct {
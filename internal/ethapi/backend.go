@@ -30,6 +30,7 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
 	"github.com/hypnosisfoundation/go-hypnosis/eth/downloader"
+	"github.com/hypnosisfoundation/go-hypnosis/eth/gasprice"
 	"github.com/hypnosisfoundation/go-hypnosis/ethdb"
 	"github.com/hypnosisfoundation/go-hypnosis/event"
 	"github.com/hypnosisfoundation/go-hypnosis/params"
@@ -42,8 +43,9 @@ type Backend interface {
 	// General Ethereum API
 	Downloader() *downloader.Downloader
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
-	FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error)
+	FeeHistory(ctx context.Context, blockCount int, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, []*big.Int, error)
 	PricePrediction(ctx context.Context) ([]uint, error)
+	PricePredictionV2(ctx context.Context) (*gasprice.PredictionV2, error)
 	ChainDb() ethdb.Database
 	AccountManager() *accounts.Manager
 	ExtRPCEnabled() bool
@@ -81,6 +83,8 @@ type Backend interface {
 	TxPoolContentFrom(addr common.Address) (types.Transactions, types.Transactions)
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
 	JamIndex() int
+	JamIndexHistory(n int) []*core.JamIndexSample
+	SubscribeJamIndexEvent(ch chan<- core.JamIndexEvent) event.Subscription
 
 	// Filter API
 	BloomStatus() (uint64, uint64)
@@ -117,6 +121,11 @@ func GetAPIs(apiBackend Backend) []rpc.API {
 			Version:   "1.0",
 			Service:   NewPublicTxPoolAPI(apiBackend),
 			Public:    true,
+		}, {
+			Namespace: "txpool",
+			Version:   "1.0",
+			Service:   NewPublicTxPoolSubscriptionAPI(apiBackend),
+			Public:    true,
 		}, {
 			Namespace: "debug",
 			Version:   "1.0",
@@ -7,7 +7,10 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/common/hexutil"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos/systemcontract"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/log"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+	"github.com/hypnosisfoundation/go-hypnosis/rpc"
 	"math/big"
 )
 
@@ -369,6 +372,48 @@ func (pd *PublicDposTxAPI) Restore(args *TransactionArgs) (common.Hash, error) {
 	return txHash, nil
 }
 
+// Unjail signs the request releasing a validator jailed for downtime and queues it
+// into the local engine. Once the cool-down since jailing is over, a sealing validator
+// carries it into its block; the returned raw request can be handed to other
+// validators through dpos_submitUnjail.
+func (pd *PublicDposTxAPI) Unjail(args *TransactionArgs) (hexutil.Bytes, error) {
+	ctx := context.Background()
+	engine, ok := pd.b.Engine().(interface {
+		SubmitUnjail(tx *types.Transaction) error
+	})
+	if !ok {
+		return nil, errors.New("unjail is not supported by the consensus engine")
+	}
+	if err := pd.prepareAccount(args); err != nil {
+		return nil, err
+	}
+
+	pd.nonceLock.LockAddr(*args.From)
+	defer pd.nonceLock.UnlockAddr(*args.From)
+
+	log.Info("validator Unjail", "from", args.From)
+
+	state, _, err := pd.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	tx := types.NewTransaction(state.GetNonce(*args.From), systemcontract.SysUnjailToAddr, new(big.Int), params.TxGas, new(big.Int), nil)
+
+	account := accounts.Account{Address: *args.From}
+	wallet, err := pd.b.AccountManager().Find(account)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := wallet.SignTx(account, tx, pd.b.ChainConfig().ChainID)
+	if err != nil {
+		return nil, err
+	}
+	if err := engine.SubmitUnjail(signed); err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
 // ValidatorRedeem redeem function of Validators contract
 func (pd *PublicDposTxAPI) ValidatorRedeem(args *TransactionArgs) (common.Hash, error) {
 	ctx := context.Background()
@@ -389,6 +389,15 @@ web3._extend({
 			}],
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'unjail',
+			call: 'dpos_unjail',
+			inputFormatter: [function(options) {
+				options = options == undefined? {} : options
+				return web3._extend.formatters.inputCallFormatter(options)
+			}],
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'validatorRedeem',
 			call: 'dpos_validatorRedeem',
@@ -443,6 +452,86 @@ web3._extend({
 			}],
 			params: 2
 		}),
+		new web3._extend.Method({
+			name: 'submitAttestation',
+			call: 'dpos_submitAttestation',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getAttestations',
+			call: 'dpos_getAttestations',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getRewardDistribution',
+			call: 'dpos_getRewardDistribution',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getLiveness',
+			call: 'dpos_getLiveness',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getValidatorActivity',
+			call: 'dpos_getValidatorActivity',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getQueuedProposals',
+			call: 'dpos_getQueuedProposals',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'screenAddress',
+			call: 'dpos_screenAddress',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'screenTx',
+			call: 'dpos_screenTx',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'simulateProposal',
+			call: 'dpos_simulateProposal',
+			params: 3,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getFeeAccounting',
+			call: 'dpos_getFeeAccounting',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'submitUnjail',
+			call: 'dpos_submitUnjail',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'submitDoubleSignEvidence',
+			call: 'dpos_submitDoubleSignEvidence',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getDoubleSignEvidences',
+			call: 'dpos_getDoubleSignEvidences',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getDoubleSignEvidence',
+			call: 'dpos_getDoubleSignEvidence',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 	],
 });
 `
@@ -950,6 +1039,10 @@ web3._extend({
 			name: 'gasPricePrediction',
 			getter: 'eth_gasPricePrediction'
 		}),
+		new web3._extend.Property({
+			name: 'gasPricePredictionV2',
+			getter: 'eth_gasPricePredictionV2'
+		}),
 	]
 });
 `
@@ -1000,6 +1093,15 @@ web3._extend({
 			name: 'getHashrate',
 			call: 'miner_getHashrate'
 		}),
+		new web3._extend.Method({
+			name: 'setPriorityLane',
+			call: 'miner_setPriorityLane',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'priorityLane',
+			call: 'miner_priorityLane'
+		}),
 	],
 	properties: []
 });
@@ -1115,6 +1217,12 @@ web3._extend({
 			call: 'txpool_contentFrom',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'jamIndexHistory',
+			call: 'txpool_jamIndexHistory',
+			params: 1,
+			inputFormatter: [null]
+		}),
 		new web3._extend.Property({
 			name: 'jamIndex',
 			getter: 'txpool_jamIndex'
@@ -18,6 +18,7 @@
 package les
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -26,9 +27,11 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/common/hexutil"
 	"github.com/hypnosisfoundation/go-hypnosis/common/mclock"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
 	"github.com/hypnosisfoundation/go-hypnosis/core/bloombits"
 	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/state"
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/eth/downloader"
 	"github.com/hypnosisfoundation/go-hypnosis/eth/ethconfig"
@@ -49,6 +52,10 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/rpc"
 )
 
+// dposRetrievalTimeout is the time allowed to the dpos engine to retrieve a
+// header it needs on demand.
+const dposRetrievalTimeout = 10 * time.Second
+
 type LightEthereum struct {
 	lesCommons
 
@@ -142,6 +149,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*LightEthereum, error) {
 		return nil, err
 	}
 	leth.chainReader = leth.blockchain
+	if dposEngine, ok := leth.engine.(*dpos.Dpos); ok {
+		leth.setupDpos(dposEngine)
+	}
 	leth.txPool = light.NewTxPool(leth.chainConfig, leth.blockchain, leth.relay)
 
 	// Set up checkpoint oracle.
@@ -198,6 +208,20 @@ func New(stack *node.Node, config *ethconfig.Config) (*LightEthereum, error) {
 	return leth, nil
 }
 
+// setupDpos lets the dpos engine follow the light chain, retrieving the headers
+// and states missing locally on demand.
+func (s *LightEthereum) setupDpos(engine *dpos.Dpos) {
+	engine.SetChain(s.blockchain)
+	engine.SetHeaderFn(func(number uint64) (*types.Header, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), dposRetrievalTimeout)
+		defer cancel()
+		return light.GetHeaderByNumber(ctx, s.odr, number)
+	})
+	engine.SetHeaderStateFn(func(ctx context.Context, header *types.Header) (*state.StateDB, error) {
+		return light.NewState(ctx, header, s.odr), nil
+	})
+}
+
 // VfluxRequest sends a batch of requests to the given node through discv5 UDP TalkRequest and returns the responses
 func (s *LightEthereum) VfluxRequest(n *enode.Node, reqs vflux.Requests) vflux.Replies {
 	if !s.udpEnabled {
@@ -17,12 +17,15 @@
 package les
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi/bind"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
 	"github.com/hypnosisfoundation/go-hypnosis/core/types"
 	"github.com/hypnosisfoundation/go-hypnosis/crypto"
@@ -388,3 +391,105 @@ func testSyncAll(t *testing.T, protocol int) {
 		t.Error("checkpoint syncing timeout")
 	}
 }
+
+// Tests that a light client syncs a DPoS chain from a trusted checkpoint within an
+// epoch, following the validators and serving their RPCs from the retrieved state.
+func TestDposCheckpointSyncingLes3(t *testing.T) {
+	config := light.TestServerIndexerConfig
+
+	waitIndexers := func(cIndexer, bIndexer, btIndexer *core.ChainIndexer) {
+		for {
+			cs, _, _ := cIndexer.Sections()
+			bts, _, _ := btIndexer.Sections()
+			if cs >= 1 && bts >= 1 {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	netconfig := testnetConfig{
+		blocks:    int(config.ChtSize + config.ChtConfirms),
+		protocol:  lpv3,
+		indexFn:   waitIndexers,
+		nopruning: true,
+		dpos:      true,
+	}
+	server, client, tearDown := newClientServerEnv(t, netconfig)
+	defer tearDown()
+
+	// Register the CHT section head, not an epoch header, as hardcoded checkpoint
+	s, _, head := server.chtIndexer.Sections()
+	cp := &params.TrustedCheckpoint{
+		SectionIndex: 0,
+		SectionHead:  head,
+		CHTRoot:      light.GetChtRoot(server.db, s-1, head),
+		BloomRoot:    light.GetBloomTrieRoot(server.db, s-1, head),
+	}
+	epoch := server.backend.Blockchain().Config().Dpos.Epoch
+	if number := config.ChtSize - 1; number%epoch == 0 {
+		t.Fatalf("checkpoint %d is an epoch header", number)
+	}
+	client.handler.checkpoint = cp
+	client.handler.backend.blockchain.AddTrustedCheckpoint(cp)
+
+	expected := config.ChtSize + config.ChtConfirms
+	done := make(chan error)
+	client.handler.syncEnd = func(header *types.Header) {
+		if header.Number.Uint64() == expected {
+			done <- nil
+		} else {
+			done <- fmt.Errorf("blockchain length mismatch, want %d, got %d", expected, header.Number)
+		}
+	}
+	peer1, peer2, err := newTestPeerPair("peer", lpv3, server.handler, client.handler)
+	if err != nil {
+		t.Fatalf("Failed to connect testing peers %v", err)
+	}
+	defer peer1.close()
+	defer peer2.close()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal("sync failed", err)
+		}
+	case <-time.NewTimer(10 * time.Second).C:
+		t.Fatal("checkpoint syncing timeout")
+	}
+	// Only the headers back to the epoch one are retrieved before the checkpoint
+	if client.handler.backend.blockchain.GetHeaderByNumber((config.ChtSize-1)/epoch*epoch-1) != nil {
+		t.Errorf("headers before the checkpoint epoch retrieved")
+	}
+	// The light client follows the validators of the server
+	var (
+		ctx       = context.Background()
+		serverAPI = server.handler.blockchain.Engine().APIs(server.handler.blockchain)[0].Service.(*dpos.API)
+		clientAPI = client.handler.backend.engine.APIs(client.handler.backend.blockchain)[0].Service.(*dpos.API)
+	)
+	want, err := serverAPI.GetValidators(nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve the server validators: %v", err)
+	}
+	have, err := clientAPI.GetValidators(nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve the client validators: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) || len(have) != 1 || have[0] != dposValidatorAddr {
+		t.Errorf("validators mismatch: have %v, want %v", have, want)
+	}
+	// The validator RPCs read the state on demand
+	wantDeposit, err := serverAPI.GetTotalDeposit(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve the server deposit: %v", err)
+	}
+	haveDeposit, err := clientAPI.GetTotalDeposit(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve the client deposit: %v", err)
+	}
+	if haveDeposit.Sign() == 0 || haveDeposit.Cmp(wantDeposit) != 0 {
+		t.Errorf("total deposit mismatch: have %v, want %v", haveDeposit, wantDeposit)
+	}
+	if effective, err := clientAPI.IsEffictiveValidator(ctx, dposValidatorAddr, nil); err != nil || !effective {
+		t.Errorf("validator not effective: %v %v", effective, err)
+	}
+}
@@ -33,6 +33,8 @@ import (
 	"github.com/hypnosisfoundation/go-hypnosis/accounts/abi/bind/backends"
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"github.com/hypnosisfoundation/go-hypnosis/common/mclock"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/dpos"
 	"github.com/hypnosisfoundation/go-hypnosis/consensus/ethash"
 	"github.com/hypnosisfoundation/go-hypnosis/contracts/checkpointoracle/contract"
 	"github.com/hypnosisfoundation/go-hypnosis/core"
@@ -69,6 +71,10 @@ var (
 
 	testEventEmitterCode = common.Hex2Bytes("60606040523415600e57600080fd5b7f57050ab73f6b9ebdd9f76b8d4997793f48cf956e965ee070551b9ca0bb71584e60405160405180910390a160358060476000396000f3006060604052600080fd00a165627a7a723058203f727efcad8b5811f8cb1fc2620ce5e8c63570d697aef968172de296ea3994140029")
 
+	// Genesis validator of the DPoS test chains
+	dposValidatorKey, _ = crypto.GenerateKey()
+	dposValidatorAddr   = crypto.PubkeyToAddress(dposValidatorKey.PublicKey)
+
 	// Checkpoint oracle relative fields
 	oracleAddr   common.Address
 	signerKey, _ = crypto.GenerateKey()
@@ -192,8 +198,8 @@ func testIndexers(db ethdb.Database, odr light.OdrBackend, config *light.Indexer
 func newTestClientHandler(backend *backends.SimulatedBackend, odr *LesOdr, indexers []*core.ChainIndexer, db ethdb.Database, peers *serverPeerSet, ulcServers []string, ulcFraction int) (*clientHandler, func()) {
 	var (
 		evmux  = new(event.TypeMux)
-		engine = ethash.NewFaker()
-		gspec  = core.Genesis{
+		engine = consensus.Engine(ethash.NewFaker())
+		gspec  = &core.Genesis{
 			Config:   params.AllEthashProtocolChanges,
 			Alloc:    core.GenesisAlloc{bankAddr: {Balance: bankFunds}},
 			GasLimit: 100000000,
@@ -201,9 +207,14 @@ func newTestClientHandler(backend *backends.SimulatedBackend, odr *LesOdr, index
 		}
 		oracle *checkpointoracle.CheckpointOracle
 	)
+	// Follow the server chain if it's a DPoS one
+	if backend.Blockchain().Config().Dpos != nil {
+		gspec = backends.DposSimulatedGenesis(core.GenesisAlloc{bankAddr: {Balance: bankFunds}}, dposValidatorAddr)
+		engine = dpos.New(gspec.Config, db)
+	}
 	genesis := gspec.MustCommit(db)
 	chain, _ := light.NewLightChain(odr, gspec.Config, engine, nil)
-	if indexers != nil {
+	if indexers != nil && gspec.Config.Dpos == nil {
 		checkpointConfig := &params.CheckpointOracleConfig{
 			Address:   crypto.CreateAddress(bankAddr, 0),
 			Signers:   []common.Address{signerAddr},
@@ -225,7 +236,7 @@ func newTestClientHandler(backend *backends.SimulatedBackend, odr *LesOdr, index
 		lesCommons: lesCommons{
 			genesis:     genesis.Hash(),
 			config:      &ethconfig.Config{LightPeers: 100, NetworkId: NetworkId},
-			chainConfig: params.AllEthashProtocolChanges,
+			chainConfig: gspec.Config,
 			iConfig:     light.TestClientIndexerConfig,
 			chainDb:     db,
 			oracle:      oracle,
@@ -240,6 +251,9 @@ func newTestClientHandler(backend *backends.SimulatedBackend, odr *LesOdr, index
 		blockchain: chain,
 		eventMux:   evmux,
 	}
+	if engine, ok := engine.(*dpos.Dpos); ok {
+		client.setupDpos(engine)
+	}
 	client.handler = newClientHandler(ulcServers, ulcFraction, nil, client)
 
 	if client.oracle != nil {
@@ -251,7 +265,7 @@ func newTestClientHandler(backend *backends.SimulatedBackend, odr *LesOdr, index
 	}
 }
 
-func newTestServerHandler(blocks int, indexers []*core.ChainIndexer, db ethdb.Database, clock mclock.Clock) (*serverHandler, *backends.SimulatedBackend, func()) {
+func newTestServerHandler(blocks int, indexers []*core.ChainIndexer, db ethdb.Database, clock mclock.Clock, dposChain bool) (*serverHandler, *backends.SimulatedBackend, func()) {
 	var (
 		gspec = core.Genesis{
 			Config:   params.AllEthashProtocolChanges,
@@ -259,18 +273,36 @@ func newTestServerHandler(blocks int, indexers []*core.ChainIndexer, db ethdb.Da
 			GasLimit: 100000000,
 			BaseFee:  big.NewInt(params.InitialBaseFee),
 		}
-		oracle *checkpointoracle.CheckpointOracle
+		oracle     *checkpointoracle.CheckpointOracle
+		simulation *backends.SimulatedBackend
 	)
-	genesis := gspec.MustCommit(db)
+	if dposChain {
+		// create a simulated DPoS chain of empty blocks made by the dpos chain maker,
+		// without checkpoint oracle.
+		simulation = backends.NewDposSimulatedBackendWithDatabase(db, gspec.Alloc, dposValidatorKey)
+		maker := dpos.NewChainMaker(backends.DposSimulatedGenesis(gspec.Alloc, dposValidatorAddr), dposValidatorKey)
+		chain, err := maker.Generate(maker.Genesis(), blocks, nil)
+		if err != nil {
+			panic(err)
+		}
+		if _, err := simulation.Blockchain().InsertChain(chain); err != nil {
+			panic(err)
+		}
+		simulation.Rollback()
+	} else {
+		gspec.MustCommit(db)
 
-	// create a simulation backend and pre-commit several customized block to the database.
-	simulation := backends.NewSimulatedBackendWithDatabase(db, gspec.Alloc, 100000000)
-	prepare(blocks, simulation)
+		// create a simulation backend and pre-commit several customized block to the database.
+		simulation = backends.NewSimulatedBackendWithDatabase(db, gspec.Alloc, 100000000)
+		prepare(blocks, simulation)
+	}
+	genesis := simulation.Blockchain().Genesis()
+	config := simulation.Blockchain().Config()
 
 	txpoolConfig := core.DefaultTxPoolConfig
 	txpoolConfig.Journal = ""
-	txpool := core.NewTxPool(txpoolConfig, gspec.Config, simulation.Blockchain())
-	if indexers != nil {
+	txpool := core.NewTxPool(txpoolConfig, config, simulation.Blockchain())
+	if indexers != nil && !dposChain {
 		checkpointConfig := &params.CheckpointOracleConfig{
 			Address:   crypto.CreateAddress(bankAddr, 0),
 			Signers:   []common.Address{signerAddr},
@@ -292,7 +324,7 @@ func newTestServerHandler(blocks int, indexers []*core.ChainIndexer, db ethdb.Da
 		lesCommons: lesCommons{
 			genesis:     genesis.Hash(),
 			config:      &ethconfig.Config{LightPeers: 100, NetworkId: NetworkId},
-			chainConfig: params.AllEthashProtocolChanges,
+			chainConfig: config,
 			iConfig:     light.TestServerIndexerConfig,
 			chainDb:     db,
 			chainReader: simulation.Blockchain(),
@@ -582,6 +614,7 @@ type testnetConfig struct {
 	simClock    bool
 	connect     bool
 	nopruning   bool
+	dpos        bool // Whether the chain is a DPoS one, sealed by dposValidatorKey
 }
 
 func newClientServerEnv(t *testing.T, config testnetConfig) (*testServer, *testClient, func()) {
@@ -605,7 +638,7 @@ func newClientServerEnv(t *testing.T, config testnetConfig) (*testServer, *testC
 	ccIndexer, cbIndexer, cbtIndexer := cIndexers[0], cIndexers[1], cIndexers[2]
 	odr.SetIndexers(ccIndexer, cbIndexer, cbtIndexer)
 
-	server, b, serverClose := newTestServerHandler(config.blocks, sindexers, sdb, clock)
+	server, b, serverClose := newTestServerHandler(config.blocks, sindexers, sdb, clock, config.dpos)
 	client, clientClose := newTestClientHandler(b, odr, cIndexers, cdb, speers, config.ulcServers, config.ulcFraction)
 
 	scIndexer.Start(server.blockchain)
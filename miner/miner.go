@@ -51,6 +51,8 @@ type Config struct {
 	GasPrice   *big.Int       // Minimum gas price for mining a transaction
 	Recommit   time.Duration  // The time interval for miner to re-create mining work.
 	Noverify   bool           // Disable remote mining solution verification(only useful in ethash).
+
+	PriorityLane PriorityLaneConfig // Gas reserved for the system contract transactions
 }
 
 // Miner creates blocks and searches for proof-of-work values.
@@ -175,6 +177,22 @@ func (miner *Miner) SetExtra(extra []byte) error {
 	return nil
 }
 
+// SetPriorityLane updates the policy reserving block gas for the system contract
+// transactions.
+func (miner *Miner) SetPriorityLane(lane PriorityLaneConfig) error {
+	if err := lane.sanitize(); err != nil {
+		return err
+	}
+	miner.worker.setPriorityLane(lane)
+	return nil
+}
+
+// PriorityLane returns the policy reserving block gas for the system contract
+// transactions.
+func (miner *Miner) PriorityLane() PriorityLaneConfig {
+	return miner.worker.priorityLane()
+}
+
 // SetRecommitInterval sets the interval for sealing work resubmitting.
 func (miner *Miner) SetRecommitInterval(interval time.Duration) {
 	miner.worker.setRecommitInterval(interval)
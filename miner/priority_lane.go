@@ -0,0 +1,157 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+)
+
+// PriorityLaneConfig is the policy reserving a slice of the block gas for the
+// transactions calling the system contracts, e.g. staking actions, so they don't
+// compete with the rest of the pool during congestion. The reserved gas the lane
+// leaves unused is not given to the other transactions. It only affects the blocks
+// built locally and is consensus neutral.
+type PriorityLaneConfig struct {
+	GasPercent   uint64 `json:"gasPercent"`   // Percentage of the block gas reserved for the lane, 0 disables it
+	SenderLimit  uint64 `json:"senderLimit"`  // Maximum transactions of a sender in the lane within SenderWindow blocks, 0 for no limit
+	SenderWindow uint64 `json:"senderWindow"` // Number of blocks the sender limit applies to
+}
+
+// sanitize checks the policy is consistent.
+func (c *PriorityLaneConfig) sanitize() error {
+	if c.GasPercent > 100 {
+		return errors.New("priority lane gas percentage above 100")
+	}
+	if c.SenderLimit > 0 && c.SenderWindow == 0 {
+		return errors.New("priority lane sender limit without window")
+	}
+	return nil
+}
+
+// systemContractPrefix is the common prefix of the system contract addresses,
+// 0x...fff001 to 0x...fff00f.
+var systemContractPrefix = common.HexToAddress("0xfff000")
+
+// isPriorityTarget returns whether the transaction calls a system contract.
+func isPriorityTarget(tx *types.Transaction) bool {
+	to := tx.To()
+	if to == nil {
+		return false
+	}
+	last := to[common.AddressLength-1]
+	return bytes.Equal(to[:common.AddressLength-1], systemContractPrefix[:common.AddressLength-1]) && last > 0 && last <= 0x0f
+}
+
+// laneTransactions returns the pending transactions eligible to the lane at the
+// given height: the leading system contract calls of each sender, within their
+// remaining allowance.
+func (w *worker) laneTransactions(pending map[common.Address]types.Transactions, number uint64, lane PriorityLaneConfig) map[common.Address]types.Transactions {
+	w.laneMu.Lock()
+	defer w.laneMu.Unlock()
+
+	txs := make(map[common.Address]types.Transactions)
+	for from, list := range pending {
+		n := 0
+		for n < len(list) && isPriorityTarget(list[n]) {
+			n++
+		}
+		if lane.SenderLimit > 0 {
+			var used uint64
+			for height, usage := range w.laneUsage {
+				if height < number && height+lane.SenderWindow >= number {
+					used += usage[from]
+				}
+			}
+			if used >= lane.SenderLimit {
+				continue
+			}
+			if remaining := lane.SenderLimit - used; uint64(n) > remaining {
+				n = int(remaining)
+			}
+		}
+		if n > 0 {
+			txs[from] = list[:n]
+		}
+	}
+	return txs
+}
+
+// recordLaneUsage records the transactions included through the lane at the given
+// height, replacing the ones of any previous work at the same height. The usage is
+// that of the blocks built locally, whether sealed or not.
+func (w *worker) recordLaneUsage(number uint64, txs []*types.Transaction, window uint64) {
+	w.laneMu.Lock()
+	defer w.laneMu.Unlock()
+
+	usage := make(map[common.Address]uint64)
+	for _, tx := range txs {
+		from, _ := types.Sender(w.current.signer, tx)
+		usage[from]++
+	}
+	w.laneUsage[number] = usage
+	for height := range w.laneUsage {
+		if height+window < number {
+			delete(w.laneUsage, height)
+		}
+	}
+}
+
+// commitPriorityLane commits the lane transactions within the reserved gas, and
+// removes the committed ones from the pending transactions. The other transactions
+// are then left the unreserved gas only, even if the lane is empty. It returns true
+// if the work is interrupted by a new head.
+func (w *worker) commitPriorityLane(pending map[common.Address]types.Transactions, lane PriorityLaneConfig, interrupt *int32) bool {
+	header := w.current.header
+	reserved := header.GasLimit * lane.GasPercent / 100
+
+	laneTxs := w.laneTransactions(pending, header.Number.Uint64(), lane)
+	if len(laneTxs) == 0 {
+		w.recordLaneUsage(header.Number.Uint64(), nil, lane.SenderWindow)
+		w.current.gasPool = new(core.GasPool).AddGas(header.GasLimit - reserved)
+		return false
+	}
+	w.current.gasPool = new(core.GasPool).AddGas(reserved)
+
+	tcount := len(w.current.txs)
+	txs := types.NewTransactionsByPriceAndNonce(w.current.signer, laneTxs, header.BaseFee)
+	if w.commitTransactions(txs, w.coinbase, interrupt) {
+		return true
+	}
+	// Hold back the reserved gas left by the lane
+	w.current.gasPool = new(core.GasPool).AddGas(header.GasLimit - reserved)
+	w.recordLaneUsage(header.Number.Uint64(), w.current.txs[tcount:], lane.SenderWindow)
+
+	// The transactions not committed remain for the normal ordering
+	for from := range laneTxs {
+		nonce := w.current.state.GetNonce(from)
+		list := pending[from]
+		for len(list) > 0 && list[0].Nonce() < nonce {
+			list = list[1:]
+		}
+		if len(list) == 0 {
+			delete(pending, from)
+		} else {
+			pending[from] = list
+		}
+	}
+	return false
+}
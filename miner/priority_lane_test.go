@@ -0,0 +1,229 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
+	"github.com/hypnosisfoundation/go-hypnosis/consensus/ethash"
+	"github.com/hypnosisfoundation/go-hypnosis/core"
+	"github.com/hypnosisfoundation/go-hypnosis/core/rawdb"
+	"github.com/hypnosisfoundation/go-hypnosis/core/types"
+	"github.com/hypnosisfoundation/go-hypnosis/core/vm"
+	"github.com/hypnosisfoundation/go-hypnosis/crypto"
+	"github.com/hypnosisfoundation/go-hypnosis/event"
+	"github.com/hypnosisfoundation/go-hypnosis/params"
+)
+
+func TestIsPriorityTarget(t *testing.T) {
+	for i, tt := range []struct {
+		to   *common.Address
+		want bool
+	}{
+		{to: nil},
+		{to: &common.Address{}},
+		{to: addrPtr("0x0000000000000000000000000000000000fff000")},
+		{to: addrPtr("0x0000000000000000000000000000000000fff001"), want: true},
+		{to: addrPtr("0x0000000000000000000000000000000000fff00f"), want: true},
+		{to: addrPtr("0x0000000000000000000000000000000000fff010")},
+		{to: addrPtr("0x0000000000000000000000000000000001fff001")},
+	} {
+		tx := types.NewTx(&types.LegacyTx{To: tt.to})
+		if have := isPriorityTarget(tx); have != tt.want {
+			t.Errorf("test %d: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+func addrPtr(hex string) *common.Address {
+	addr := common.HexToAddress(hex)
+	return &addr
+}
+
+// newPriorityTestWorker creates a worker, not started, with the spammer and the
+// staker funded.
+func newPriorityTestWorker(t *testing.T, lane PriorityLaneConfig, keys ...*ecdsa.PrivateKey) (*worker, *testWorkerBackend) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		alloc = core.GenesisAlloc{}
+	)
+	for _, key := range keys {
+		alloc[crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{Balance: testBankFunds}
+	}
+	gspec := core.Genesis{Config: params.TestChainConfig, Alloc: alloc}
+	gspec.MustCommit(db)
+
+	engine := ethash.NewFaker()
+	chain, _ := core.NewBlockChain(db, &core.CacheConfig{TrieDirtyDisabled: true}, gspec.Config, engine, vm.Config{}, nil, nil)
+	backend := &testWorkerBackend{db: db, chain: chain, txPool: core.NewTxPool(testTxPoolConfig, gspec.Config, chain), genesis: &gspec}
+
+	config := *testConfig
+	config.PriorityLane = lane
+	w := newWorker(&config, gspec.Config, engine, backend, new(event.TypeMux), nil, false)
+	w.setEtherbase(testBankAddress)
+	return w, backend
+}
+
+func TestPriorityLane(t *testing.T) {
+	var (
+		spammer, _ = crypto.GenerateKey()
+		staker, _  = crypto.GenerateKey()
+		stakerAddr = crypto.PubkeyToAddress(staker.PublicKey)
+		validators = common.HexToAddress("0x0000000000000000000000000000000000fff001")
+		signer     = types.LatestSigner(params.TestChainConfig)
+	)
+	send := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, price int64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &to,
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(price * params.InitialBaseFee),
+		})
+	}
+	// The staker pays less than the spammer, and calls a system contract
+	var txs []*types.Transaction
+	for i := uint64(0); i < 10; i++ {
+		txs = append(txs, send(spammer, i, testUserAddress, 10))
+	}
+	for i := uint64(0); i < 4; i++ {
+		txs = append(txs, send(staker, i, validators, 2))
+	}
+	// Returns the senders of the transactions in the work
+	work := func(w *worker) []common.Address {
+		w.commitNewWork(nil, true, time.Now().Unix())
+		var froms []common.Address
+		for _, tx := range w.current.txs {
+			from, _ := types.Sender(signer, tx)
+			froms = append(froms, from)
+		}
+		return froms
+	}
+	for i, tt := range []struct {
+		lane   PriorityLaneConfig
+		staker []int // Positions of the staker transactions
+	}{
+		// Without the lane the spam goes first
+		{lane: PriorityLaneConfig{}, staker: []int{10, 11, 12, 13}},
+		// The lane holds the reserved gas only, 2 transfers
+		{lane: PriorityLaneConfig{GasPercent: 1}, staker: []int{0, 1, 12, 13}},
+		// The sender is limited within the lane
+		{lane: PriorityLaneConfig{GasPercent: 50, SenderLimit: 1, SenderWindow: 10}, staker: []int{0, 11, 12, 13}},
+	} {
+		w, b := newPriorityTestWorker(t, tt.lane, spammer, staker)
+		for j, err := range b.txPool.AddRemotesSync(txs) {
+			if err != nil {
+				t.Fatalf("test %d: tx %d: failed to add: %v", i, j, err)
+			}
+		}
+		froms := work(w)
+		if len(froms) != len(txs) {
+			t.Fatalf("test %d: transaction count mismatch: have %d, want %d", i, len(froms), len(txs))
+		}
+		var positions []int
+		for j, from := range froms {
+			if from == stakerAddr {
+				positions = append(positions, j)
+			}
+		}
+		if len(positions) != len(tt.staker) {
+			t.Fatalf("test %d: staker transactions mismatch: have %v, want %v", i, positions, tt.staker)
+		}
+		for j := range positions {
+			if positions[j] != tt.staker[j] {
+				t.Errorf("test %d: staker positions mismatch: have %v, want %v", i, positions, tt.staker)
+				break
+			}
+		}
+		w.close()
+	}
+}
+
+// Tests that the reserved gas is held back from the other transactions, even if
+// there are no lane transactions.
+func TestPriorityLaneReservation(t *testing.T) {
+	spammer, _ := crypto.GenerateKey()
+	signer := types.LatestSigner(params.TestChainConfig)
+
+	var txs []*types.Transaction
+	for i := uint64(0); i < 10; i++ {
+		txs = append(txs, types.MustSignNewTx(spammer, signer, &types.LegacyTx{
+			Nonce:    i,
+			To:       &testUserAddress,
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(10 * params.InitialBaseFee),
+		}))
+	}
+	for i, tt := range []struct {
+		lane PriorityLaneConfig
+		want int
+	}{
+		{lane: PriorityLaneConfig{}, want: 10},
+		// The unreserved gas fits 2 transfers only
+		{lane: PriorityLaneConfig{GasPercent: 99}, want: 2},
+		{lane: PriorityLaneConfig{GasPercent: 100}, want: 0},
+	} {
+		w, b := newPriorityTestWorker(t, tt.lane, spammer)
+		for j, err := range b.txPool.AddRemotesSync(txs) {
+			if err != nil {
+				t.Fatalf("test %d: tx %d: failed to add: %v", i, j, err)
+			}
+		}
+		w.commitNewWork(nil, true, time.Now().Unix())
+		if have := len(w.current.txs); have != tt.want {
+			t.Errorf("test %d: transaction count mismatch: have %d, want %d", i, have, tt.want)
+		}
+		w.close()
+	}
+}
+
+func TestPriorityLaneSenderWindow(t *testing.T) {
+	var (
+		staker, _  = crypto.GenerateKey()
+		stakerAddr = crypto.PubkeyToAddress(staker.PublicKey)
+		validators = common.HexToAddress("0x0000000000000000000000000000000000fff001")
+		lane       = PriorityLaneConfig{GasPercent: 10, SenderLimit: 2, SenderWindow: 3}
+	)
+	w, _ := newPriorityTestWorker(t, lane, staker)
+	defer w.close()
+
+	var list types.Transactions
+	for i := uint64(0); i < 3; i++ {
+		list = append(list, types.NewTx(&types.LegacyTx{Nonce: i, To: &validators}))
+	}
+	pending := map[common.Address]types.Transactions{stakerAddr: list}
+	allowed := func(number uint64) int {
+		return len(w.laneTransactions(pending, number, lane)[stakerAddr])
+	}
+	if n := allowed(10); n != 2 {
+		t.Fatalf("allowance mismatch: have %d, want %d", n, 2)
+	}
+	// One transaction at height 10 is accounted until height 13
+	w.laneUsage[10] = map[common.Address]uint64{stakerAddr: 1}
+	for number, want := range map[uint64]int{10: 2, 11: 1, 13: 1, 14: 2} {
+		if n := allowed(number); n != want {
+			t.Errorf("height %d: allowance mismatch: have %d, want %d", number, n, want)
+		}
+	}
+	w.laneUsage[12] = map[common.Address]uint64{stakerAddr: 1}
+	if n := allowed(13); n != 0 {
+		t.Errorf("exhausted allowance mismatch: have %d, want %d", n, 0)
+	}
+}
@@ -169,6 +169,9 @@ type worker struct {
 	pendingMu    sync.RWMutex
 	pendingTasks map[common.Hash]*task
 
+	laneMu    sync.Mutex                           // The lock used to protect the lane usage
+	laneUsage map[uint64]map[common.Address]uint64 // Priority lane transactions of the recent heights by sender
+
 	snapshotMu       sync.RWMutex // The lock used to protect the snapshots below
 	snapshotBlock    *types.Block
 	snapshotReceipts types.Receipts
@@ -211,6 +214,7 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		remoteUncles:       make(map[common.Hash]*types.Block),
 		unconfirmed:        newUnconfirmedBlocks(eth.BlockChain(), miningLogAtDepth),
 		pendingTasks:       make(map[common.Hash]*task),
+		laneUsage:          make(map[uint64]map[common.Address]uint64),
 		txsCh:              make(chan core.NewTxsEvent, txChanSize),
 		chainHeadCh:        make(chan core.ChainHeadEvent, chainHeadChanSize),
 		chainSideCh:        make(chan core.ChainSideEvent, chainSideChanSize),
@@ -234,6 +238,10 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		log.Warn("Sanitizing miner recommit interval", "provided", recommit, "updated", minRecommitInterval)
 		recommit = minRecommitInterval
 	}
+	if err := worker.config.PriorityLane.sanitize(); err != nil {
+		log.Warn("Disabling miner priority lane", "err", err)
+		worker.config.PriorityLane = PriorityLaneConfig{}
+	}
 
 	go worker.mainLoop()
 	go worker.newWorkLoop(recommit)
@@ -254,6 +262,20 @@ func (w *worker) setEtherbase(addr common.Address) {
 	w.coinbase = addr
 }
 
+// setPriorityLane updates the priority lane policy.
+func (w *worker) setPriorityLane(lane PriorityLaneConfig) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.config.PriorityLane = lane
+}
+
+// priorityLane returns the priority lane policy.
+func (w *worker) priorityLane() PriorityLaneConfig {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.config.PriorityLane
+}
+
 func (w *worker) setGasCeil(ceil uint64) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -325,6 +347,15 @@ func (w *worker) isRunning() bool {
 	return atomic.LoadInt32(&w.running) == 1
 }
 
+// onDemandSealing returns whether the engine only seals blocks with transactions,
+// as 0 period clique and dpos chains do.
+func (w *worker) onDemandSealing() bool {
+	if w.chainConfig.Clique != nil {
+		return w.chainConfig.Clique.Period == 0
+	}
+	return w.chainConfig.Dpos != nil && w.chainConfig.Dpos.Period == 0
+}
+
 // close terminates all background threads maintained by the worker.
 // Note the worker does not support being closed multiple times.
 func (w *worker) close() {
@@ -409,7 +440,7 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 		case <-timer.C:
 			// If mining is running resubmit a new work cycle periodically to pull in
 			// higher priced transactions. Disable this overhead for pending blocks.
-			if w.isRunning() && (w.chainConfig.Clique == nil || w.chainConfig.Clique.Period > 0) {
+			if w.isRunning() && !w.onDemandSealing() {
 				// Short circuit if no new transaction arrives.
 				if atomic.LoadInt32(&w.newTxs) == 0 {
 					timer.Reset(recommit)
@@ -533,10 +564,11 @@ func (w *worker) mainLoop() {
 					w.updateSnapshot()
 				}
 			} else {
-				// Special case, if the consensus engine is 0 period clique(dev mode),
-				// submit mining work here since all empty submission will be rejected
-				// by clique. Of course the advance sealing(empty submission) is disabled.
-				if w.chainConfig.Clique != nil && w.chainConfig.Clique.Period == 0 {
+				// Special case, if the consensus engine is 0 period clique or dpos (dev
+				// mode), submit mining work here since all empty submission will be
+				// rejected by them. Of course the advance sealing(empty submission) is
+				// disabled.
+				if w.onDemandSealing() {
 					w.commitNewWork(nil, true, time.Now().Unix())
 				}
 			}
@@ -1010,6 +1042,12 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 		w.updateSnapshot()
 		return
 	}
+	// Commit the system contract transactions into the reserved gas first
+	if lane := w.config.PriorityLane; lane.GasPercent > 0 {
+		if w.commitPriorityLane(pending, lane, interrupt) {
+			return
+		}
+	}
 	// Split the pending transactions into locals and remotes
 	localTxs, remoteTxs := make(map[common.Address]types.Transactions), pending
 	for _, account := range w.eth.TxPool().Locals() {
@@ -18,8 +18,10 @@ package params
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/hypnosisfoundation/go-hypnosis/common"
 	"golang.org/x/crypto/sha3"
@@ -117,16 +119,16 @@ var (
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, new(EthashConfig), nil, nil}
+	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, new(EthashConfig), nil, nil}
 
 	// AllCliqueProtocolChanges contains every protocol change (EIPs) introduced
 	// and accepted by the Ethereum core developers into the Clique consensus.
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}, nil}
+	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}, nil}
 
-	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, new(EthashConfig), nil, nil}
+	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, new(EthashConfig), nil, nil}
 	TestRules       = TestChainConfig.Rules(new(big.Int))
 )
 
@@ -211,6 +213,16 @@ type ChainConfig struct {
 	RedCoastBlock *big.Int `json:"redCoastBlock,omitempty"` // RedCoast switch block (nil = no fork, 0 = already activated)
 	SophonBlock   *big.Int `json:"sophonBlock,omitempty"`
 
+	DoubleSignSlashBlock *big.Int `json:"doubleSignSlashBlock,omitempty"` // Double-sign slashing switch block (nil = no fork, 0 = already activated)
+	StakeScheduleBlock   *big.Int `json:"stakeScheduleBlock,omitempty"`   // Stake-weighted proposer schedule switch block (nil = no fork, 0 = already activated)
+	BaseFeePolicyBlock   *big.Int `json:"baseFeePolicyBlock,omitempty"`   // Dpos base fee policy switch block (nil = no fork, 0 = already activated)
+	ContractUpgradeBlock *big.Int `json:"contractUpgradeBlock,omitempty"` // System contract code upgrade block (nil = no fork)
+	JailBlock            *big.Int `json:"jailBlock,omitempty"`            // Downtime jailing switch block (nil = no fork, 0 = already activated)
+
+	GovernanceActionsBlock  *big.Int `json:"governanceActionsBlock,omitempty"`  // Extended governance actions switch block (nil = no fork, 0 = already activated)
+	GovernanceTimelockBlock *big.Int `json:"governanceTimelockBlock,omitempty"` // Timelocked and batched governance proposals switch block (nil = no fork, 0 = already activated)
+	EventDataRulesBlock     *big.Int `json:"eventDataRulesBlock,omitempty"`     // Event check rules on log data switch block (nil = no fork, 0 = already activated)
+
 	// Various consensus engines
 	Ethash *EthashConfig `json:"ethash,omitempty"`
 	Clique *CliqueConfig `json:"clique,omitempty"`
@@ -242,6 +254,11 @@ type DposConfig struct {
 	Epoch  uint64 `json:"epoch"`  // Epoch length to reset votes and checkpoint
 
 	EnableDevVerification bool `json:"enableDevVerification"` // Enable developer address verification
+
+	RewardSchedule []*RewardDistribution `json:"rewardSchedule,omitempty"` // Block reward splits in ascending order of their fork blocks
+	BaseFeePolicy  *BaseFeePolicy        `json:"baseFeePolicy,omitempty"`  // Destination of the base fee after the BaseFeePolicy fork
+	Jail           *JailConfig           `json:"jail,omitempty"`           // Downtime jailing parameters after the Jail fork
+	ProposalDelay  uint64                `json:"proposalDelay,omitempty"`  // Blocks a passed governance proposal stays queued after the GovernanceTimelock fork, positive
 }
 
 // String implements the stringer interface, returning the consensus engine details.
@@ -249,6 +266,161 @@ func (d *DposConfig) String() string {
 	return "dpos"
 }
 
+// MaxRewardShare is the total share of a block reward, in basis points.
+const MaxRewardShare = 10000
+
+// RewardRecipient is an address receiving a share of every block reward.
+type RewardRecipient struct {
+	Address common.Address `json:"address"`
+	Share   uint64         `json:"share"` // Share of the block reward in basis points
+}
+
+// RewardDistribution is a split of the block reward taking effect at a fork block.
+// The share neither given to the recipients nor burnt is distributed to validators.
+type RewardDistribution struct {
+	Block      *big.Int          `json:"block"`          // Fork block the split takes effect at
+	Recipients []RewardRecipient `json:"recipients"`     // Addresses receiving a fixed share of the reward
+	Burn       uint64            `json:"burn,omitempty"` // Share of the block reward burnt, in basis points
+}
+
+// ValidatorShare returns the share of the block reward left to validators, in basis points.
+func (r *RewardDistribution) ValidatorShare() uint64 {
+	share := uint64(MaxRewardShare) - r.Burn
+	for _, recipient := range r.Recipients {
+		share -= recipient.Share
+	}
+	return share
+}
+
+// equal returns whether two reward distributions split rewards the same way.
+func (r *RewardDistribution) equal(other *RewardDistribution) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if r.Burn != other.Burn || len(r.Recipients) != len(other.Recipients) {
+		return false
+	}
+	for i, recipient := range r.Recipients {
+		if recipient != other.Recipients[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RewardDistributionAt returns the reward distribution in force at the given block,
+// or nil if no scheduled distribution has taken effect yet.
+func (d *DposConfig) RewardDistributionAt(num *big.Int) *RewardDistribution {
+	var current *RewardDistribution
+	for _, dist := range d.RewardSchedule {
+		if !isForked(dist.Block, num) {
+			break
+		}
+		current = dist
+	}
+	return current
+}
+
+// CheckRewardSchedule checks that the reward distributions are ordered by fork block
+// and that none of them gives away more than the whole block reward.
+func (d *DposConfig) CheckRewardSchedule() error {
+	for i, dist := range d.RewardSchedule {
+		if dist.Block == nil {
+			return fmt.Errorf("reward distribution %d has no fork block", i)
+		}
+		if i > 0 && d.RewardSchedule[i-1].Block.Cmp(dist.Block) >= 0 {
+			return fmt.Errorf("unsupported reward schedule ordering: distribution at %v follows distribution at %v", dist.Block, d.RewardSchedule[i-1].Block)
+		}
+		total := dist.Burn
+		for _, recipient := range dist.Recipients {
+			total += recipient.Share
+		}
+		if total > MaxRewardShare {
+			return fmt.Errorf("reward distribution at %v shares %d basis points, more than %d", dist.Block, total, MaxRewardShare)
+		}
+	}
+	return nil
+}
+
+// Base fee policy modes, deciding where the base fee of transactions goes.
+const (
+	BaseFeeBurn       = "burn"       // Base fee is burnt, as in EIP-1559
+	BaseFeeValidators = "validators" // Base fee joins the tips in the validators' reward pool
+	BaseFeeTreasury   = "treasury"   // Base fee is sent to a treasury address
+)
+
+// BaseFeePolicy decides what happens to the base fee paid by transactions.
+type BaseFeePolicy struct {
+	Mode     string         `json:"mode"`               // One of burn, validators or treasury
+	Treasury common.Address `json:"treasury,omitempty"` // Recipient of the base fee in treasury mode
+}
+
+// check validates the mode of the policy and its treasury address.
+func (p *BaseFeePolicy) check() error {
+	switch p.Mode {
+	case BaseFeeBurn, BaseFeeValidators:
+		return nil
+	case BaseFeeTreasury:
+		if p.Treasury == (common.Address{}) {
+			return errors.New("base fee policy sends fees to the zero treasury address")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown base fee policy mode %q", p.Mode)
+}
+
+// equal returns whether two policies send the base fee to the same place.
+func (p *BaseFeePolicy) equal(other *BaseFeePolicy) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+// JailConfig decides when validators missing their in-turn slots are jailed.
+type JailConfig struct {
+	Window    uint64 `json:"window"`    // Number of recent blocks in which missed slots are counted
+	Threshold uint64 `json:"threshold"` // Missed slots within the window to get jailed
+	Cooldown  uint64 `json:"cooldown"`  // Number of blocks after jailing before unjailing is allowed
+}
+
+// check validates that the threshold can be reached within the window.
+func (j *JailConfig) check() error {
+	if j.Window == 0 || j.Threshold == 0 {
+		return errors.New("jail window and threshold must be positive")
+	}
+	if j.Threshold > j.Window {
+		return fmt.Errorf("jail threshold %d exceeds the window of %d blocks", j.Threshold, j.Window)
+	}
+	return nil
+}
+
+// equal returns whether two jail configs jail and release validators the same way.
+func (j *JailConfig) equal(other *JailConfig) bool {
+	if j == nil || other == nil {
+		return j == other
+	}
+	return *j == *other
+}
+
+// rewardScheduleConflict returns the lowest block up to head at which the two
+// schedules distribute rewards differently, or nil if there is none.
+func rewardScheduleConflict(stored, newcfg *DposConfig, head *big.Int) *big.Int {
+	var blocks []*big.Int
+	for _, dist := range append(append([]*RewardDistribution{}, stored.RewardSchedule...), newcfg.RewardSchedule...) {
+		if isForked(dist.Block, head) {
+			blocks = append(blocks, dist.Block)
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Cmp(blocks[j]) < 0 })
+	for _, block := range blocks {
+		if !stored.RewardDistributionAt(block).equal(newcfg.RewardDistributionAt(block)) {
+			return block
+		}
+	}
+	return nil
+}
+
 // String implements the fmt.Stringer interface.
 func (c *ChainConfig) String() string {
 	var engine interface{}
@@ -359,6 +531,58 @@ func (c *ChainConfig) IsSophon(num *big.Int) bool {
 	return isForked(c.SophonBlock, num)
 }
 
+// IsDoubleSignSlash returns whether num represents a block number after the double-sign slashing fork
+func (c *ChainConfig) IsDoubleSignSlash(num *big.Int) bool {
+	return isForked(c.DoubleSignSlashBlock, num)
+}
+
+// IsStakeSchedule returns whether num represents a block number after the stake-weighted proposer schedule fork
+func (c *ChainConfig) IsStakeSchedule(num *big.Int) bool {
+	return isForked(c.StakeScheduleBlock, num)
+}
+
+// IsBaseFeePolicy returns whether num represents a block number after the base fee policy fork
+func (c *ChainConfig) IsBaseFeePolicy(num *big.Int) bool {
+	return isForked(c.BaseFeePolicyBlock, num)
+}
+
+// IsContractUpgrade returns whether num represents a block number after the system contract upgrade fork
+func (c *ChainConfig) IsContractUpgrade(num *big.Int) bool {
+	return isForked(c.ContractUpgradeBlock, num)
+}
+
+// IsJail returns whether num represents a block number after the downtime jailing fork
+func (c *ChainConfig) IsJail(num *big.Int) bool {
+	return isForked(c.JailBlock, num)
+}
+
+// IsGovernanceActions returns whether num represents a block number after the extended
+// governance actions fork
+func (c *ChainConfig) IsGovernanceActions(num *big.Int) bool {
+	return isForked(c.GovernanceActionsBlock, num)
+}
+
+// IsGovernanceTimelock returns whether num represents a block number after the timelocked
+// and batched governance proposals fork
+func (c *ChainConfig) IsGovernanceTimelock(num *big.Int) bool {
+	return isForked(c.GovernanceTimelockBlock, num)
+}
+
+// IsEventDataRules returns whether num represents a block number after the fork of the
+// event check rules inspecting log data
+func (c *ChainConfig) IsEventDataRules(num *big.Int) bool {
+	return isForked(c.EventDataRulesBlock, num)
+}
+
+// BaseFeePolicyAt returns the base fee policy in force at the given block, which
+// is burning the base fee on chains without a policy or before the fork.
+func (c *ChainConfig) BaseFeePolicyAt(num *big.Int) *BaseFeePolicy {
+	if c.Dpos == nil || c.Dpos.BaseFeePolicy == nil || !c.IsBaseFeePolicy(num) {
+		return &BaseFeePolicy{Mode: BaseFeeBurn}
+	}
+	return c.Dpos.BaseFeePolicy
+}
+
 // CheckCompatible checks whether scheduled fork transitions have been imported
 // with a mismatching chain configuration.
 func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64) *ConfigCompatError {
@@ -420,6 +644,48 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
+	// The optional dpos forks are independent features, enabled in any order
+	for _, cur := range []fork{
+		{name: "doubleSignSlashBlock", block: c.DoubleSignSlashBlock},
+		{name: "stakeScheduleBlock", block: c.StakeScheduleBlock},
+		{name: "baseFeePolicyBlock", block: c.BaseFeePolicyBlock},
+		{name: "contractUpgradeBlock", block: c.ContractUpgradeBlock},
+		{name: "jailBlock", block: c.JailBlock},
+		{name: "governanceActionsBlock", block: c.GovernanceActionsBlock},
+		{name: "governanceTimelockBlock", block: c.GovernanceTimelockBlock},
+		{name: "eventDataRulesBlock", block: c.EventDataRulesBlock},
+	} {
+		if cur.block != nil && cur.block.Sign() < 0 {
+			return fmt.Errorf("invalid fork block: %v enabled at %v, before genesis", cur.name, cur.block)
+		}
+	}
+	if c.BaseFeePolicyBlock != nil {
+		if c.Dpos == nil || c.Dpos.BaseFeePolicy == nil {
+			return errors.New("base fee policy fork enabled without a dpos base fee policy")
+		}
+		if err := c.Dpos.BaseFeePolicy.check(); err != nil {
+			return err
+		}
+	}
+	if c.JailBlock != nil {
+		if c.Dpos == nil || c.Dpos.Jail == nil {
+			return errors.New("jail fork enabled without a dpos jail config")
+		}
+		// Jailed validators are kicked out through the slash entry point
+		if c.DoubleSignSlashBlock == nil || c.JailBlock.Cmp(c.DoubleSignSlashBlock) < 0 {
+			return errors.New("jail fork enabled before the double-sign slashing fork")
+		}
+		if err := c.Dpos.Jail.check(); err != nil {
+			return err
+		}
+	}
+	// A zero delay would execute the proposals in the block queueing them
+	if c.GovernanceTimelockBlock != nil && (c.Dpos == nil || c.Dpos.ProposalDelay == 0) {
+		return errors.New("governance timelock fork enabled without a dpos proposal delay")
+	}
+	if c.Dpos != nil {
+		return c.Dpos.CheckRewardSchedule()
+	}
 	return nil
 }
 
@@ -473,6 +739,44 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.RedCoastBlock, newcfg.RedCoastBlock, head) {
 		return newCompatError("RedCoast fork block", c.RedCoastBlock, newcfg.RedCoastBlock)
 	}
+	if isForkIncompatible(c.DoubleSignSlashBlock, newcfg.DoubleSignSlashBlock, head) {
+		return newCompatError("DoubleSignSlash fork block", c.DoubleSignSlashBlock, newcfg.DoubleSignSlashBlock)
+	}
+	if isForkIncompatible(c.StakeScheduleBlock, newcfg.StakeScheduleBlock, head) {
+		return newCompatError("StakeSchedule fork block", c.StakeScheduleBlock, newcfg.StakeScheduleBlock)
+	}
+	if isForkIncompatible(c.BaseFeePolicyBlock, newcfg.BaseFeePolicyBlock, head) {
+		return newCompatError("BaseFeePolicy fork block", c.BaseFeePolicyBlock, newcfg.BaseFeePolicyBlock)
+	}
+	if isForkIncompatible(c.ContractUpgradeBlock, newcfg.ContractUpgradeBlock, head) {
+		return newCompatError("ContractUpgrade fork block", c.ContractUpgradeBlock, newcfg.ContractUpgradeBlock)
+	}
+	if isForkIncompatible(c.JailBlock, newcfg.JailBlock, head) {
+		return newCompatError("Jail fork block", c.JailBlock, newcfg.JailBlock)
+	}
+	if isForkIncompatible(c.GovernanceActionsBlock, newcfg.GovernanceActionsBlock, head) {
+		return newCompatError("GovernanceActions fork block", c.GovernanceActionsBlock, newcfg.GovernanceActionsBlock)
+	}
+	if isForkIncompatible(c.GovernanceTimelockBlock, newcfg.GovernanceTimelockBlock, head) {
+		return newCompatError("GovernanceTimelock fork block", c.GovernanceTimelockBlock, newcfg.GovernanceTimelockBlock)
+	}
+	if isForkIncompatible(c.EventDataRulesBlock, newcfg.EventDataRulesBlock, head) {
+		return newCompatError("EventDataRules fork block", c.EventDataRulesBlock, newcfg.EventDataRulesBlock)
+	}
+	if c.IsBaseFeePolicy(head) && !c.BaseFeePolicyAt(head).equal(newcfg.BaseFeePolicyAt(head)) {
+		return newCompatError("Dpos base fee policy", c.BaseFeePolicyBlock, newcfg.BaseFeePolicyBlock)
+	}
+	if c.IsJail(head) && c.Dpos != nil && newcfg.Dpos != nil && !c.Dpos.Jail.equal(newcfg.Dpos.Jail) {
+		return newCompatError("Dpos jail config", c.JailBlock, newcfg.JailBlock)
+	}
+	if c.IsGovernanceTimelock(head) && c.Dpos != nil && newcfg.Dpos != nil && c.Dpos.ProposalDelay != newcfg.Dpos.ProposalDelay {
+		return newCompatError("Dpos proposal delay", c.GovernanceTimelockBlock, newcfg.GovernanceTimelockBlock)
+	}
+	if c.Dpos != nil && newcfg.Dpos != nil {
+		if block := rewardScheduleConflict(c.Dpos, newcfg.Dpos, head); block != nil {
+			return newCompatError("Dpos reward distribution", block, block)
+		}
+	}
 	return nil
 }
 
@@ -20,6 +20,8 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+
+	"github.com/hypnosisfoundation/go-hypnosis/common"
 )
 
 func TestCheckCompatible(t *testing.T) {
@@ -96,3 +98,189 @@ func TestCheckCompatible(t *testing.T) {
 		}
 	}
 }
+
+func TestRewardSchedule(t *testing.T) {
+	foundation := RewardRecipient{Address: common.HexToAddress("0x01"), Share: 500}
+	treasury := RewardRecipient{Address: common.HexToAddress("0x02"), Share: 1000}
+	config := &DposConfig{RewardSchedule: []*RewardDistribution{
+		{Block: big.NewInt(10), Recipients: []RewardRecipient{foundation}},
+		{Block: big.NewInt(20), Recipients: []RewardRecipient{foundation, treasury}, Burn: 2000},
+	}}
+	if err := config.CheckRewardSchedule(); err != nil {
+		t.Fatalf("valid schedule rejected: %v", err)
+	}
+	tests := []struct {
+		number uint64
+		want   *RewardDistribution
+	}{
+		{0, nil}, {9, nil}, {10, config.RewardSchedule[0]}, {19, config.RewardSchedule[0]}, {20, config.RewardSchedule[1]}, {100, config.RewardSchedule[1]},
+	}
+	for _, tt := range tests {
+		if have := config.RewardDistributionAt(new(big.Int).SetUint64(tt.number)); have != tt.want {
+			t.Errorf("block %d: distribution mismatch: have %v, want %v", tt.number, have, tt.want)
+		}
+	}
+	if share := config.RewardSchedule[1].ValidatorShare(); share != 6500 {
+		t.Errorf("validator share mismatch: have %d, want 6500", share)
+	}
+
+	invalid := []*DposConfig{
+		{RewardSchedule: []*RewardDistribution{{Recipients: []RewardRecipient{foundation}}}},
+		{RewardSchedule: []*RewardDistribution{{Block: big.NewInt(20)}, {Block: big.NewInt(10)}}},
+		{RewardSchedule: []*RewardDistribution{{Block: big.NewInt(0), Recipients: []RewardRecipient{foundation}, Burn: 9501}}},
+	}
+	for i, config := range invalid {
+		if err := config.CheckRewardSchedule(); err == nil {
+			t.Errorf("invalid schedule %d accepted", i)
+		}
+	}
+}
+
+func TestRewardScheduleCompatible(t *testing.T) {
+	foundation := RewardRecipient{Address: common.HexToAddress("0x01"), Share: 500}
+	stored := &ChainConfig{Dpos: &DposConfig{RewardSchedule: []*RewardDistribution{
+		{Block: big.NewInt(10), Recipients: []RewardRecipient{foundation}},
+	}}}
+	// Scheduling a new distribution in the future is fine
+	future := &ChainConfig{Dpos: &DposConfig{RewardSchedule: []*RewardDistribution{
+		{Block: big.NewInt(10), Recipients: []RewardRecipient{foundation}},
+		{Block: big.NewInt(30), Burn: 100},
+	}}}
+	if err := stored.CheckCompatible(future, 20); err != nil {
+		t.Errorf("future distribution rejected: %v", err)
+	}
+	// Changing a distribution already in force is not
+	changed := &ChainConfig{Dpos: &DposConfig{RewardSchedule: []*RewardDistribution{
+		{Block: big.NewInt(10), Recipients: []RewardRecipient{foundation}, Burn: 100},
+	}}}
+	want := &ConfigCompatError{What: "Dpos reward distribution", StoredConfig: big.NewInt(10), NewConfig: big.NewInt(10), RewindTo: 9}
+	if err := stored.CheckCompatible(changed, 20); !reflect.DeepEqual(err, want) {
+		t.Errorf("error mismatch: have %v, want %v", err, want)
+	}
+}
+
+func TestBaseFeePolicy(t *testing.T) {
+	treasury := common.HexToAddress("0x01")
+	tests := []struct {
+		policy *BaseFeePolicy
+		valid  bool
+	}{
+		{nil, false},
+		{&BaseFeePolicy{Mode: BaseFeeBurn}, true},
+		{&BaseFeePolicy{Mode: BaseFeeValidators}, true},
+		{&BaseFeePolicy{Mode: BaseFeeTreasury, Treasury: treasury}, true},
+		{&BaseFeePolicy{Mode: BaseFeeTreasury}, false},
+		{&BaseFeePolicy{Mode: "mint"}, false},
+	}
+	for i, tt := range tests {
+		config := *TestChainConfig
+		config.Ethash = nil
+		config.Dpos = &DposConfig{BaseFeePolicy: tt.policy}
+		config.SophonBlock = big.NewInt(0)
+		config.BaseFeePolicyBlock = big.NewInt(10)
+		if err := config.CheckConfigForkOrder(); (err == nil) != tt.valid {
+			t.Errorf("test %d: validity mismatch: have %v, want valid %v", i, err, tt.valid)
+		}
+	}
+	config := &ChainConfig{
+		BaseFeePolicyBlock: big.NewInt(10),
+		Dpos:               &DposConfig{BaseFeePolicy: &BaseFeePolicy{Mode: BaseFeeTreasury, Treasury: treasury}},
+	}
+	if mode := config.BaseFeePolicyAt(big.NewInt(9)).Mode; mode != BaseFeeBurn {
+		t.Errorf("policy before the fork mismatch: have %s, want %s", mode, BaseFeeBurn)
+	}
+	if policy := config.BaseFeePolicyAt(big.NewInt(10)); policy.Mode != BaseFeeTreasury || policy.Treasury != treasury {
+		t.Errorf("policy after the fork mismatch: have %v", policy)
+	}
+	// Changing the policy after the fork is incompatible
+	changed := &ChainConfig{
+		BaseFeePolicyBlock: big.NewInt(10),
+		Dpos:               &DposConfig{BaseFeePolicy: &BaseFeePolicy{Mode: BaseFeeValidators}},
+	}
+	if err := config.CheckCompatible(changed, 9); err != nil {
+		t.Errorf("policy change before the fork rejected: %v", err)
+	}
+	if err := config.CheckCompatible(changed, 10); err == nil {
+		t.Errorf("policy change after the fork accepted")
+	}
+}
+
+func TestJailConfig(t *testing.T) {
+	tests := []struct {
+		jail  *JailConfig
+		valid bool
+	}{
+		{nil, false},
+		{&JailConfig{Window: 100, Threshold: 50, Cooldown: 10}, true},
+		{&JailConfig{Window: 100, Threshold: 100}, true},
+		{&JailConfig{Window: 100, Threshold: 101}, false},
+		{&JailConfig{Window: 100}, false},
+		{&JailConfig{Threshold: 1}, false},
+	}
+	for i, tt := range tests {
+		config := *TestChainConfig
+		config.Ethash = nil
+		config.Dpos = &DposConfig{Jail: tt.jail}
+		config.SophonBlock = big.NewInt(0)
+		config.DoubleSignSlashBlock = big.NewInt(10)
+		config.JailBlock = big.NewInt(10)
+		if err := config.CheckConfigForkOrder(); (err == nil) != tt.valid {
+			t.Errorf("test %d: validity mismatch: have %v, want valid %v", i, err, tt.valid)
+		}
+	}
+	// Jailed validators are kicked out through the slash entry point
+	ordered := *TestChainConfig
+	ordered.Ethash = nil
+	ordered.Dpos = &DposConfig{Jail: tests[1].jail}
+	ordered.SophonBlock = big.NewInt(0)
+	ordered.JailBlock = big.NewInt(10)
+	if err := ordered.CheckConfigForkOrder(); err == nil {
+		t.Errorf("jail fork accepted without double-sign slashing")
+	}
+	ordered.DoubleSignSlashBlock = big.NewInt(11)
+	if err := ordered.CheckConfigForkOrder(); err == nil {
+		t.Errorf("jail fork accepted before double-sign slashing")
+	}
+	// Changing the jail config after the fork is incompatible
+	config := &ChainConfig{
+		JailBlock: big.NewInt(10),
+		Dpos:      &DposConfig{Jail: &JailConfig{Window: 100, Threshold: 50}},
+	}
+	changed := &ChainConfig{
+		JailBlock: big.NewInt(10),
+		Dpos:      &DposConfig{Jail: &JailConfig{Window: 100, Threshold: 20}},
+	}
+	if err := config.CheckCompatible(changed, 9); err != nil {
+		t.Errorf("jail change before the fork rejected: %v", err)
+	}
+	if err := config.CheckCompatible(changed, 10); err == nil {
+		t.Errorf("jail change after the fork accepted")
+	}
+}
+
+func TestDposForkIndependence(t *testing.T) {
+	// The dpos forks may be enabled in any order, and without sophon
+	config := *TestChainConfig
+	config.Ethash = nil
+	config.Dpos = &DposConfig{BaseFeePolicy: &BaseFeePolicy{Mode: BaseFeeBurn}}
+	config.DoubleSignSlashBlock = big.NewInt(2000)
+	config.BaseFeePolicyBlock = big.NewInt(1000)
+	config.EventDataRulesBlock = big.NewInt(0)
+	if err := config.CheckConfigForkOrder(); err != nil {
+		t.Fatalf("independent forks rejected: %v", err)
+	}
+	// The governance timelock needs a proposal delay
+	config.GovernanceTimelockBlock = big.NewInt(0)
+	if err := config.CheckConfigForkOrder(); err == nil {
+		t.Fatalf("timelock without proposal delay accepted")
+	}
+	config.Dpos.ProposalDelay = 10
+	if err := config.CheckConfigForkOrder(); err != nil {
+		t.Fatalf("timelock with proposal delay rejected: %v", err)
+	}
+	// But none of them before genesis
+	config.StakeScheduleBlock = big.NewInt(-1)
+	if err := config.CheckConfigForkOrder(); err == nil {
+		t.Fatalf("fork before genesis accepted")
+	}
+}